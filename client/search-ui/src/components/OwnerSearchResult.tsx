@@ -0,0 +1,58 @@
+import React from 'react'
+
+import classNames from 'classnames'
+
+import { getOwnerMatchLabel, getOwnerMatchUrl, OwnerMatch } from '@sourcegraph/shared/src/search/stream'
+import { Link } from '@sourcegraph/wildcard'
+
+import { ResultContainer } from './ResultContainer'
+
+import styles from './SearchResult.module.scss'
+
+export interface OwnerSearchResultProps {
+    result: OwnerMatch
+    onSelect: () => void
+    containerClassName?: string
+    as?: React.ElementType
+    index: number
+}
+
+export const OwnerSearchResult: React.FunctionComponent<OwnerSearchResultProps> = ({
+    result,
+    onSelect,
+    containerClassName,
+    as,
+    index,
+}) => {
+    const title = (
+        <div className={styles.title}>
+            <span className={classNames('test-search-result-label', styles.titleInner)}>
+                <Link to={getOwnerMatchUrl(result)} data-selectable-search-result="true">
+                    {getOwnerMatchLabel(result)}
+                </Link>
+            </span>
+        </div>
+    )
+
+    return (
+        <ResultContainer
+            index={index}
+            title={title}
+            resultType={result.type}
+            onResultClicked={onSelect}
+            repoName={result.repository}
+            className={containerClassName}
+            as={as}
+        >
+            <div data-testid="search-owner-result">
+                <div className={classNames(styles.searchResultMatch, 'p-2 flex-column')}>
+                    <div className="d-flex align-items-center flex-row">
+                        <div className={styles.matchType}>
+                            <small>Owner match</small>
+                        </div>
+                    </div>
+                </div>
+            </div>
+        </ResultContainer>
+    )
+}
@@ -31,6 +31,7 @@ const accessibleResultType: Record<SearchMatch['type'], string> = {
     repo: 'repository',
     path: 'file path',
     commit: 'commit',
+    owner: 'owner',
 }
 
 /**
@@ -4,6 +4,7 @@ export * from './CommitSearchResult'
 export * from './CommitSearchResultMatch'
 export * from './CopyPathAction'
 export * from './FileContentSearchResult'
+export * from './OwnerSearchResult'
 export * from './LastSyncedIcon'
 export * from './RepoFileLink'
 export * from './RepoSearchResult'
@@ -24,6 +24,7 @@ import { ThemeProps } from '@sourcegraph/shared/src/theme'
 import { CommitSearchResult } from '../components/CommitSearchResult'
 import { FileContentSearchResult } from '../components/FileContentSearchResult'
 import { FilePathSearchResult } from '../components/FilePathSearchResult'
+import { OwnerSearchResult } from '../components/OwnerSearchResult'
 import { RepoSearchResult } from '../components/RepoSearchResult'
 import { SymbolSearchResult } from '../components/SymbolSearchResult'
 import { smartSearchClickedEvent } from '../util/events'
@@ -219,6 +220,16 @@ export const StreamingSearchResultsList: React.FunctionComponent<
                                 as="li"
                             />
                         )
+                    case 'owner':
+                        return (
+                            <OwnerSearchResult
+                                index={index}
+                                result={result}
+                                onSelect={() => logSearchResultClicked(index, 'owner')}
+                                containerClassName={resultClassName}
+                                as="li"
+                            />
+                        )
                 }
             }
 
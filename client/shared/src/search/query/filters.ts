@@ -17,6 +17,7 @@ export enum FilterType {
     content = 'content',
     context = 'context',
     count = 'count',
+    'diff.function' = 'diff.function',
     file = 'file',
     fork = 'fork',
     lang = 'lang',
@@ -76,6 +77,7 @@ export enum NegatedFilters {
     author = '-author',
     committer = '-committer',
     content = '-content',
+    'diff.function' = '-diff.function',
     f = '-f',
     file = '-file',
     path = '-path',
@@ -98,6 +100,7 @@ export type NegatableFilter =
     | FilterType.committer
     | FilterType.author
     | FilterType.message
+    | FilterType['diff.function']
 
 export const isNegatableFilter = (filter: FilterType): filter is NegatableFilter =>
     Object.keys(NegatedFilters).includes(filter)
@@ -112,6 +115,7 @@ const negatedFilterToNegatableFilter: { [key: string]: NegatableFilter } = {
     '-author': FilterType.author,
     '-committer': FilterType.committer,
     '-content': FilterType.content,
+    '-diff.function': FilterType['diff.function'],
     '-f': FilterType.file,
     '-file': FilterType.file,
     '-path': FilterType.file,
@@ -231,6 +235,12 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         placeholder: 'number',
         singular: true,
     },
+    [FilterType['diff.function']]: {
+        negatable: true,
+        description: negated =>
+            `${negated ? 'Exclude' : 'Include only'} diff hunks inside functions or classes matching the given pattern.`,
+        placeholder: 'regex',
+    },
     [FilterType.file]: {
         alias: 'f',
         negatable: true,
@@ -40,8 +40,14 @@ export const PREDICATES: Access[] = [
                     { name: 'description' },
                     { name: 'tag' },
                     { name: 'key' },
+                    { name: 'codeowners' },
+                    { name: 'language' },
                 ],
             },
+            {
+                name: 'depends',
+                fields: [{ name: 'on' }],
+            },
         ],
     },
     {
@@ -53,7 +59,16 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'owner' }],
+            },
+        ],
+    },
+    {
+        name: 'rev',
+        fields: [
+            {
+                name: 'at',
+                fields: [{ name: 'time' }],
             },
         ],
     },
@@ -214,6 +229,29 @@ export const predicateCompletion = (field: string): Completion[] => {
                 insertText: 'has.key(${1})',
                 asSnippet: true,
             },
+            {
+                label: 'has.codeowners()',
+                insertText: 'has.codeowners()',
+            },
+            {
+                label: 'has.language(...)',
+                insertText: 'has.language(${1:java}, ${2:60})',
+                asSnippet: true,
+            },
+            {
+                label: 'depends.on(...)',
+                insertText: 'depends.on(${1:npm}:${2:package}@${3:<1.0.0})',
+                asSnippet: true,
+            },
+        ]
+    }
+    if (field === 'rev') {
+        return [
+            {
+                label: 'at.time(...)',
+                insertText: 'at.time(${1:2023-01-01}, ${2:main})',
+                asSnippet: true,
+            },
         ]
     }
     return []
@@ -29,7 +29,7 @@ export type SearchEvent =
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
-export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch
+export type SearchMatch = ContentMatch | RepositoryMatch | CommitMatch | SymbolMatch | PathMatch | OwnerMatch
 
 export interface PathMatch {
     type: 'path'
@@ -40,6 +40,7 @@ export interface PathMatch {
     repoLastFetched?: string
     branches?: string[]
     commit?: string
+    language?: string
     debug?: string
 }
 
@@ -55,6 +56,7 @@ export interface ContentMatch {
     lineMatches?: LineMatch[]
     chunkMatches?: ChunkMatch[]
     hunks?: DecoratedHunk[]
+    language?: string
     debug?: string
 }
 
@@ -155,6 +157,19 @@ export interface RepositoryMatch {
     descriptionMatches?: Range[]
 }
 
+/**
+ * An owner of matched files, as defined by the CODEOWNERS file of the repository
+ * the files were found in. Owner matches are returned for `select:file.owners`.
+ * Either handle or email is set.
+ */
+export interface OwnerMatch {
+    type: 'owner'
+    handle?: string
+    email?: string
+    // The repository in which the owner was first found.
+    repository: string
+}
+
 /**
  * An aggregate type representing a progress update.
  * Should be replaced when a new ones come in.
@@ -569,6 +584,14 @@ export function getCommitMatchUrl(commitMatch: CommitMatch): string {
     return '/' + encodeURI(commitMatch.repository) + '/-/commit/' + commitMatch.oid
 }
 
+export function getOwnerMatchLabel(ownerMatch: OwnerMatch): string {
+    return ownerMatch.handle ? `@${ownerMatch.handle}` : ownerMatch.email ?? ''
+}
+
+export function getOwnerMatchUrl(ownerMatch: OwnerMatch): string {
+    return '/search?q=' + encodeURIComponent(`file:has.owner(${getOwnerMatchLabel(ownerMatch)})`)
+}
+
 export function getMatchUrl(match: SearchMatch): string {
     switch (match.type) {
         case 'path':
@@ -579,6 +602,8 @@ export function getMatchUrl(match: SearchMatch): string {
             return getCommitMatchUrl(match)
         case 'repo':
             return getRepoMatchUrl(match)
+        case 'owner':
+            return getOwnerMatchUrl(match)
     }
 }
 
@@ -17,9 +17,11 @@ import { PerforceIcon, PhabricatorIcon } from '@sourcegraph/shared/src/component
 import { Link, Code, Text } from '@sourcegraph/wildcard'
 
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
@@ -1257,6 +1259,71 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const AZUREDEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com",
+  "username": "<admin username>",
+  "token": "<admin token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to https://dev.azure.com for Azure DevOps
+                    Services, or to the collection URL of your Azure DevOps Server instance.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>token</Field> to a username and a personal access token
+                    with the <strong>Code (Read)</strong> and <strong>Project and Team (Read)</strong> scopes.
+                </li>
+                <li>
+                    Set <Field>orgs</Field> to the organizations whose repositories should be mirrored, or set{' '}
+                    <Field>projects</Field> to a list of <Code>"org/project"</Code> strings.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Set <Field>token</Field> to an access token with the <Code>read:repository</Code>,{' '}
+                    <Code>read:organization</Code> and <Code>read:user</Code> scopes.
+                </li>
+                <li>
+                    Select repositories with <Field>orgs</Field>, <Field>users</Field> or{' '}
+                    <Field>repositoryQuery</Field>. Use <Code>["all"]</Code> to mirror all repositories visible to
+                    the token.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const GERRIT: AddExternalServiceOptions = {
     kind: ExternalServiceKind.GERRIT,
     title: 'Gerrit',
@@ -1458,6 +1525,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
     ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
+    ...(window.context?.experimentalFeatures?.azureDevOps === 'enabled' ? { azureDevOps: AZUREDEVOPS } : {}),
+    ...(window.context?.experimentalFeatures?.gitea === 'enabled' ? { gitea: GITEA } : {}),
 }
 
 export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptions> = {
@@ -1481,6 +1550,8 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.GERRIT]: GERRIT,
     [ExternalServiceKind.PAGURE]: PAGURE,
+    [ExternalServiceKind.AZUREDEVOPS]: AZUREDEVOPS,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
     [ExternalServiceKind.NPMPACKAGES]: NPM_PACKAGES,
@@ -52,9 +52,27 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
             <Code>pipeline:read</Code> permissions.
         </span>
     ),
+    [ExternalServiceKind.GERRIT]: (
+        <span>
+            with the <Code>Push</Code>, <Code>Create Change</Code>, <Code>Abandon</Code>, and <Code>Submit</Code>{' '}
+            access rights on the projects.
+        </span>
+    ),
+    [ExternalServiceKind.AZUREDEVOPS]: (
+        <span>
+            with the <Code>Code (Read &amp; write)</Code>, <Code>Code (Status)</Code>, and{' '}
+            <Code>User Profile (Read)</Code> scopes.
+        </span>
+    ),
+    [ExternalServiceKind.PAGURE]: (
+        <span>
+            with the <Code>Create a new PR</Code>, <Code>Merge a pull-request</Code>,{' '}
+            <Code>Comment on a pull-request</Code>, <Code>Update a pull-request</Code>, and{' '}
+            <Code>Fork a project</Code> ACLs.
+        </span>
+    ),
 
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.GERRIT]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
@@ -65,7 +83,7 @@ const scopeRequirements: Record<ExternalServiceKind, JSX.Element> = {
     [ExternalServiceKind.PERFORCE]: <span>Unsupported</span>,
     [ExternalServiceKind.PHABRICATOR]: <span>Unsupported</span>,
     [ExternalServiceKind.AWSCODECOMMIT]: <span>Unsupported</span>,
-    [ExternalServiceKind.PAGURE]: <span>Unsupported</span>,
+    [ExternalServiceKind.GITEA]: <span>Unsupported</span>,
     [ExternalServiceKind.OTHER]: <span>Unsupported</span>,
 }
 
@@ -136,7 +154,11 @@ export const AddCredentialModal: React.FunctionComponent<React.PropsWithChildren
     )
 
     const patLabel =
-        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD ? 'App password' : 'Personal access token'
+        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+            ? 'App password'
+            : externalServiceKind === ExternalServiceKind.GERRIT
+            ? 'HTTP password'
+            : 'Personal access token'
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={labelId}>
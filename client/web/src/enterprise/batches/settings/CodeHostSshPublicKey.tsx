@@ -16,14 +16,16 @@ const configInstructionLinks: Record<ExternalServiceKind, string> = {
         'https://confluence.atlassian.com/bitbucketserver/ssh-user-keys-for-personal-use-776639793.html',
     [ExternalServiceKind.AWSCODECOMMIT]: 'unsupported',
     [ExternalServiceKind.BITBUCKETCLOUD]: 'unsupported',
-    [ExternalServiceKind.GERRIT]: 'unsupported',
+    [ExternalServiceKind.GERRIT]: 'https://gerrit-review.googlesource.com/Documentation/user-upload.html#ssh',
     [ExternalServiceKind.GITOLITE]: 'unsupported',
     [ExternalServiceKind.GOMODULES]: 'unsupported',
     [ExternalServiceKind.JVMPACKAGES]: 'unsupported',
     [ExternalServiceKind.NPMPACKAGES]: 'unsupported',
     [ExternalServiceKind.OTHER]: 'unsupported',
     [ExternalServiceKind.PERFORCE]: 'unsupported',
-    [ExternalServiceKind.PAGURE]: 'unsupported',
+    [ExternalServiceKind.PAGURE]: 'https://docs.pagure.org/pagure/usage/first_steps.html#upload-your-ssh-key',
+    [ExternalServiceKind.AZUREDEVOPS]: 'https://learn.microsoft.com/en-us/azure/devops/repos/git/use-ssh-keys-to-authenticate',
+    [ExternalServiceKind.GITEA]: 'unsupported',
     [ExternalServiceKind.PHABRICATOR]: 'unsupported',
     [ExternalServiceKind.PYTHONPACKAGES]: 'unsupported',
     [ExternalServiceKind.RUSTPACKAGES]: 'unsupported',
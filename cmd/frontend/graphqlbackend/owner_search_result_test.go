@@ -0,0 +1,34 @@
+package graphqlbackend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMatchesToResolvers_Owners(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	resolvers := matchesToResolvers(database.NewMockDB(), []result.Match{
+		&result.OwnerMatch{Handle: "alice", Repo: repo},
+		&result.OwnerMatch{Email: "bob@example.com", Repo: repo},
+	})
+	require.Len(t, resolvers, 2)
+
+	alice, ok := resolvers[0].ToOwnerSearchResult()
+	require.True(t, ok)
+	require.Equal(t, "alice", *alice.Handle())
+	require.Nil(t, alice.Email())
+	require.Equal(t, "github.com/sourcegraph/sourcegraph", alice.Repository().Name())
+
+	bob, ok := resolvers[1].ToOwnerSearchResult()
+	require.True(t, ok)
+	require.Nil(t, bob.Handle())
+	require.Equal(t, "bob@example.com", *bob.Email())
+
+	_, ok = resolvers[0].ToFileMatch()
+	require.False(t, ok)
+}
@@ -20,6 +20,7 @@ const (
 	// cf. SearchQueryOutputPhase in GQL definitions.
 	ParseTree = "PARSE_TREE"
 	JobTree   = "JOB_TREE"
+	Explain   = "EXPLAIN"
 
 	// cf. SearchQueryOutputFormat in GQL definitions.
 	Json    = "JSON"
@@ -56,7 +57,7 @@ func (r *schemaResolver) ParseSearchQuery(ctx context.Context, args *args) (stri
 	switch args.OutputPhase {
 	case ParseTree:
 		return outputParseTree(searchType, args)
-	case JobTree:
+	case JobTree, Explain:
 		return outputJobTree(ctx, searchType, args, r.db, r.logger)
 	}
 	return "", nil
@@ -107,6 +108,16 @@ func outputJobTree(
 		return "", err
 	}
 
+	var d job.Describer = j
+	if args.OutputPhase == Explain {
+		clients := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()).JobClients()
+		explanation, err := jobutil.Explain(ctx, clients, plan, j)
+		if err != nil {
+			return "", err
+		}
+		d = explanation.Job
+	}
+
 	var verbosity job.Verbosity
 	switch args.OutputVerbosity {
 	case Minimal:
@@ -119,13 +130,13 @@ func outputJobTree(
 
 	switch args.OutputFormat {
 	case Json:
-		jsonString := printer.JSONVerbose(j, verbosity)
+		jsonString := printer.JSONVerbose(d, verbosity)
 		return jsonString, nil
 	case Sexp:
-		sexpString := printer.SexpVerbose(j, verbosity, true)
+		sexpString := printer.SexpVerbose(d, verbosity, true)
 		return sexpString, nil
 	case Mermaid:
-		mermaidString := printer.MermaidVerbose(j, verbosity)
+		mermaidString := printer.MermaidVerbose(d, verbosity)
 		return mermaidString, nil
 	}
 	return "", nil
@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+func (r *RepositoryResolver) OwnershipCoverage(ctx context.Context) (*repositoryOwnershipCoverageResolver, error) {
+	coverage, err := r.db.RepoOwnershipCoverage().Get(ctx, r.IDInt32())
+	if err != nil || coverage == nil {
+		return nil, err
+	}
+	return &repositoryOwnershipCoverageResolver{coverage: coverage}, nil
+}
+
+type repositoryOwnershipCoverageResolver struct {
+	coverage *database.RepoOwnershipCoverage
+}
+
+func (r *repositoryOwnershipCoverageResolver) CommitOID() GitObjectID {
+	return GitObjectID(r.coverage.CommitID)
+}
+
+func (r *repositoryOwnershipCoverageResolver) CodeownersPath() *string {
+	if r.coverage.CodeownersPath == "" {
+		return nil
+	}
+	return &r.coverage.CodeownersPath
+}
+
+func (r *repositoryOwnershipCoverageResolver) TotalFiles() int32 {
+	return int32(r.coverage.TotalFiles)
+}
+
+func (r *repositoryOwnershipCoverageResolver) OwnedFiles() int32 {
+	return int32(r.coverage.OwnedFiles)
+}
+
+func (r *repositoryOwnershipCoverageResolver) Directories() []*directoryOwnershipCoverageResolver {
+	resolvers := make([]*directoryOwnershipCoverageResolver, 0, len(r.coverage.Directories))
+	for _, d := range r.coverage.Directories {
+		resolvers = append(resolvers, &directoryOwnershipCoverageResolver{coverage: d})
+	}
+	return resolvers
+}
+
+func (r *repositoryOwnershipCoverageResolver) UpdatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.coverage.UpdatedAt}
+}
+
+type directoryOwnershipCoverageResolver struct {
+	coverage database.DirectoryOwnershipCoverage
+}
+
+func (r *directoryOwnershipCoverageResolver) Directory() string {
+	return r.coverage.Directory
+}
+
+func (r *directoryOwnershipCoverageResolver) TotalFiles() int32 {
+	return int32(r.coverage.TotalFiles)
+}
+
+func (r *directoryOwnershipCoverageResolver) OwnedFiles() int32 {
+	return int32(r.coverage.OwnedFiles)
+}
@@ -0,0 +1,107 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepository_OwnershipCoverage(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultHook(func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: 2, Name: "github.com/gorilla/mux"}, nil
+	})
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == "github.com/gorilla/mux" {
+			return &types.Repo{ID: 2, Name: name}, nil
+		}
+		return &types.Repo{ID: 3, Name: name}, nil
+	})
+
+	coverage := database.NewMockRepoOwnershipCoverageStore()
+	coverage.GetFunc.SetDefaultHook(func(_ context.Context, repoID api.RepoID) (*database.RepoOwnershipCoverage, error) {
+		if repoID != 2 {
+			return nil, nil
+		}
+		return &database.RepoOwnershipCoverage{
+			RepoID:         2,
+			CommitID:       exampleCommitSHA1,
+			CodeownersPath: ".github/CODEOWNERS",
+			TotalFiles:     3,
+			OwnedFiles:     2,
+			Directories: []database.DirectoryOwnershipCoverage{
+				{Directory: "", TotalFiles: 1, OwnedFiles: 0},
+				{Directory: "mux", TotalFiles: 2, OwnedFiles: 2},
+			},
+			UpdatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		}, nil
+	})
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoOwnershipCoverageFunc.SetDefaultReturn(coverage)
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						ownershipCoverage {
+							commitOID
+							codeownersPath
+							totalFiles
+							ownedFiles
+							directories {
+								directory
+								totalFiles
+								ownedFiles
+							}
+							updatedAt
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"ownershipCoverage": {
+							"commitOID": "` + exampleCommitSHA1 + `",
+							"codeownersPath": ".github/CODEOWNERS",
+							"totalFiles": 3,
+							"ownedFiles": 2,
+							"directories": [
+								{"directory": "", "totalFiles": 1, "ownedFiles": 0},
+								{"directory": "mux", "totalFiles": 2, "ownedFiles": 2}
+							],
+							"updatedAt": "2023-01-02T03:04:05Z"
+						}
+					}
+				}
+			`,
+		},
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/other") {
+						ownershipCoverage {
+							totalFiles
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"ownershipCoverage": null
+					}
+				}
+			`,
+		},
+	})
+}
@@ -163,6 +163,11 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.OwnerMatch:
+			resolvers = append(resolvers, &OwnerSearchResultResolver{
+				OwnerMatch:   *v,
+				RepoResolver: getRepoResolver(v.Repo, ""),
+			})
 		}
 	}
 	return resolvers
@@ -302,6 +307,9 @@ loop:
 		case *result.RepoMatch:
 			// We don't care about repo results here.
 			continue
+		case *result.OwnerMatch:
+			// Owners have no date to place them in the sparkline.
+			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
 			addPoint(m.Commit.Author.Date)
@@ -561,10 +569,12 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 //   - *RepositoryResolver         // repo name match
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
+//   - *OwnerSearchResultResolver  // owner of matched files (select:file.owners)
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToOwnerSearchResult() (*OwnerSearchResultResolver, bool)
 }
@@ -145,11 +145,18 @@ func NewHandler(
 
 	m.Get(apirouter.SearchStream).Handler(trace.Route(frontendsearch.StreamHandler(db)))
 
+	searchExports := newSearchExportHandlers(logger, db)
+	m.Get(apirouter.SearchExports).Handler(trace.Route(http.HandlerFunc(searchExports.serveList)))
+	m.Get(apirouter.SearchExportsCreate).Handler(trace.Route(http.HandlerFunc(searchExports.serveCreate)))
+	m.Get(apirouter.SearchExport).Handler(trace.Route(http.HandlerFunc(searchExports.serveGet)))
+	m.Get(apirouter.SearchExportDownload).Handler(trace.Route(http.HandlerFunc(searchExports.serveDownload)))
+
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCli).Handler(trace.Route(newSrcCliVersionHandler(logger)))
 
 	gsClient := gitserver.NewClient(db)
 	m.Get(apirouter.GitBlameStream).Handler(trace.Route(handleStreamBlame(logger, db, gsClient)))
+	m.Get(apirouter.RepoSBOM).Handler(trace.Route(handleRepoSBOM(logger, db, gsClient)))
 
 	// Set up the src-cli version cache handler (this will effectively be a
 	// no-op anywhere other than dot-com).
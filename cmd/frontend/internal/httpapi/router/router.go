@@ -14,9 +14,13 @@ const (
 	SCIPUpload       = "scip.upload"
 	SCIPUploadExists = "scip.upload.exists"
 
-	SearchStream   = "search.stream"
-	ComputeStream  = "compute.stream"
-	GitBlameStream = "git.blame.stream"
+	SearchStream         = "search.stream"
+	SearchExports        = "search.exports"
+	SearchExportsCreate  = "search.exports.create"
+	SearchExport         = "search.export"
+	SearchExportDownload = "search.export.download"
+	ComputeStream        = "compute.stream"
+	GitBlameStream       = "git.blame.stream"
 
 	SrcCli             = "src-cli"
 	SrcCliVersionCache = "src-cli.version-cache"
@@ -25,6 +29,7 @@ const (
 
 	RepoShield  = "repo.shield"
 	RepoRefresh = "repo.refresh"
+	RepoSBOM    = "repo.sbom"
 	Telemetry   = "telemetry"
 
 	Webhooks                = "webhooks"
@@ -74,8 +79,13 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/exports").Methods("GET").Name(SearchExports)
+	base.Path("/search/exports").Methods("POST").Name(SearchExportsCreate)
+	base.Path("/search/exports/{id:[0-9]+}").Methods("GET").Name(SearchExport)
+	base.Path("/search/exports/{id:[0-9]+}/download").Methods("GET").Name(SearchExportDownload)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
+	base.Path("/sbom/" + routevar.Repo + routevar.RepoRevSuffix).Methods("GET").Name(RepoSBOM)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 
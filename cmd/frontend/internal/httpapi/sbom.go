@@ -0,0 +1,89 @@
+package httpapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/sbom"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// handleRepoSBOM returns a HTTP handler that responds with the software bill
+// of materials of a repository at a revision. The format is selected with the
+// "format" query parameter, either "cyclonedx" (the default) or "spdx".
+func handleRepoSBOM(logger log.Logger, db database.DB, gitserverClient gitserver.Client) http.HandlerFunc {
+	svc := sbom.NewService(observation.NewContext(logger), db, gitserverClient)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		tr, ctx := trace.New(r.Context(), "sbom.Generate", "")
+		defer tr.Finish()
+		r = r.WithContext(ctx)
+
+		format, err := sbom.ParseFormat(r.URL.Query().Get("format"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		repo, commitID, err := handlerutil.GetRepoAndRev(r.Context(), logger, db, mux.Vars(r))
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errors.HasType(err, &gitserver.RepoNotCloneableErr{}) {
+			if errcode.IsNotFound(err) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if errcode.IsNotFound(err) || errcode.IsBlocked(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if errcode.IsUnauthorized(err) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		doc, err := svc.Generate(r.Context(), authz.DefaultSubRepoPermsChecker, repo.ID, repo.Name, commitID)
+		if err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Encode the document before writing the headers so that an error
+		// can still be reported with the appropriate status code.
+		var buf bytes.Buffer
+		if err := sbom.Encode(&buf, doc, format); err != nil {
+			tr.SetError(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("%s-%s%s", path.Base(string(repo.Name)), commitID, format.FileExtension())
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		_, _ = w.Write(buf.Bytes())
+	}
+}
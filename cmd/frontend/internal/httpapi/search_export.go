@@ -0,0 +1,276 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var searchExportUploadStoreConfig = &export.UploadStoreConfig{}
+
+func init() {
+	searchExportUploadStoreConfig.Load()
+}
+
+// searchExportJob is the JSON representation of a search export job.
+type searchExportJob struct {
+	ID             int           `json:"id"`
+	Query          string        `json:"query"`
+	PatternType    string        `json:"patternType,omitempty"`
+	Format         export.Format `json:"format"`
+	State          string        `json:"state"`
+	FailureMessage *string       `json:"failureMessage,omitempty"`
+	PagesSearched  int           `json:"pagesSearched"`
+	MatchesWritten int64         `json:"matchesWritten"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	StartedAt      *time.Time    `json:"startedAt,omitempty"`
+	FinishedAt     *time.Time    `json:"finishedAt,omitempty"`
+	Complete       bool          `json:"complete"`
+}
+
+func newSearchExportJob(j *export.Job) searchExportJob {
+	return searchExportJob{
+		ID:             j.ID,
+		Query:          j.Query,
+		PatternType:    j.PatternType,
+		Format:         j.Format,
+		State:          j.State,
+		FailureMessage: j.FailureMessage,
+		PagesSearched:  j.PagesSearched,
+		MatchesWritten: j.MatchesWritten,
+		CreatedAt:      j.CreatedAt,
+		StartedAt:      j.StartedAt,
+		FinishedAt:     j.FinishedAt,
+		Complete:       j.State == "completed" && j.ObjectKey != nil,
+	}
+}
+
+type searchExportHandlers struct {
+	logger       log.Logger
+	db           database.DB
+	store        export.Store
+	searchClient client.SearchClient
+
+	// uploadStore is nil if the upload store is misconfigured, in which
+	// case exports can be created and listed, but not downloaded.
+	uploadStore uploadstore.Store
+}
+
+func newSearchExportHandlers(logger log.Logger, db database.DB) *searchExportHandlers {
+	logger = logger.Scoped("searchExport", "search export job handlers")
+
+	h := &searchExportHandlers{
+		logger:       logger,
+		db:           db,
+		store:        export.NewStore(db),
+		searchClient: client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+	}
+
+	if err := searchExportUploadStoreConfig.Validate(); err != nil {
+		logger.Error("invalid search export upload store configuration", log.Error(err))
+		return h
+	}
+	uploadStore, err := export.NewUploadStore(context.Background(), observation.NewContext(logger), searchExportUploadStoreConfig)
+	if err != nil {
+		logger.Error("failed to create search export upload store", log.Error(err))
+		return h
+	}
+	h.uploadStore = uploadStore
+	return h
+}
+
+type createSearchExportRequest struct {
+	Query       string `json:"query"`
+	PatternType string `json:"patternType"`
+	Format      string `json:"format"`
+}
+
+// serveCreate enqueues a new search export job for the current user. The
+// query is validated before the job is created, so that a query that cannot
+// be exported is rejected immediately.
+func (h *searchExportHandlers) serveCreate(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "searchExport.Create", "")
+	defer tr.Finish()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	var req createSearchExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %s", err), http.StatusBadRequest)
+		return
+	}
+	format, err := export.ParseFormat(req.Format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var patternType *string
+	if req.PatternType != "" {
+		patternType = &req.PatternType
+	}
+	inputs, err := h.searchClient.Plan(ctx, "V3", patternType, req.Query, search.Precise, search.Streaming, settings, envvar.SourcegraphDotComMode())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := jobutil.NewExhaustiveJob(inputs, nil, nil); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.store.CreateJob(ctx, export.CreateJobOpts{
+		InitiatorID: a.UID,
+		Query:       req.Query,
+		PatternType: req.PatternType,
+		Format:      format,
+		Settings:    settings,
+	})
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	_ = writeJSON(w, newSearchExportJob(job))
+}
+
+// serveList lists the search export jobs of the current user.
+func (h *searchExportHandlers) serveList(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "searchExport.List", "")
+	defer tr.Finish()
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		http.Error(w, "not authenticated", http.StatusUnauthorized)
+		return
+	}
+
+	jobs, err := h.store.ListJobs(ctx, a.UID)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]searchExportJob, 0, len(jobs))
+	for _, j := range jobs {
+		res = append(res, newSearchExportJob(j))
+	}
+	_ = writeJSON(w, res)
+}
+
+// serveGet returns the status of a search export job.
+func (h *searchExportHandlers) serveGet(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "searchExport.Get", "")
+	defer tr.Finish()
+
+	job, status, err := h.getJob(ctx, r)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	_ = writeJSON(w, newSearchExportJob(job))
+}
+
+// serveDownload streams the results of a complete search export job.
+func (h *searchExportHandlers) serveDownload(w http.ResponseWriter, r *http.Request) {
+	tr, ctx := trace.New(r.Context(), "searchExport.Download", "")
+	defer tr.Finish()
+
+	job, status, err := h.getJob(ctx, r)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if job.State != "completed" || job.ObjectKey == nil {
+		http.Error(w, fmt.Sprintf("search export %d is not complete", job.ID), http.StatusConflict)
+		return
+	}
+	if h.uploadStore == nil {
+		http.Error(w, "search export upload store is not configured", http.StatusInternalServerError)
+		return
+	}
+
+	rc, err := h.uploadStore.Get(ctx, *job.ObjectKey)
+	if err != nil {
+		tr.SetError(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	filename := fmt.Sprintf("search-export-%d%s", job.ID, job.Format.FileExtension())
+	w.Header().Set("Content-Type", job.Format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if _, err := io.Copy(w, rc); err != nil {
+		h.logger.Warn("failed to stream search export", log.Int("id", job.ID), log.Error(err))
+	}
+}
+
+// getJob returns the job of the request. Jobs are only visible to the user
+// who created them and to site admins. On error, the HTTP status code to
+// respond with is returned.
+func (h *searchExportHandlers) getJob(ctx context.Context, r *http.Request) (*export.Job, int, error) {
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return nil, http.StatusUnauthorized, errors.New("not authenticated")
+	}
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.Newf("invalid search export ID %q", mux.Vars(r)["id"])
+	}
+
+	job, err := h.store.GetJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, export.ErrJobNotFound) {
+			return nil, http.StatusNotFound, err
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	// 🚨 SECURITY: Respond with a 404 rather than a 403 so that the
+	// existence of other users' exports is not revealed.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, h.db, job.InitiatorID); err != nil {
+		return nil, http.StatusNotFound, export.ErrJobNotFound
+	}
+
+	return job, http.StatusOK, nil
+}
@@ -294,6 +294,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -316,6 +318,7 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		Repository:   string(fm.Repo.Name),
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
+		Language:     fm.Language,
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -399,6 +402,7 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		Commit:       string(fm.CommitID),
 		LineMatches:  eventLineMatches,
 		ChunkMatches: eventChunkMatches,
+		Language:     fm.Language,
 	}
 
 	if fm.InputRev != nil {
@@ -484,6 +488,15 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	return repoEvent
 }
 
+func fromOwner(owner *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:       streamhttp.OwnerMatchType,
+		Handle:     owner.Handle,
+		Email:      owner.Email,
+		Repository: string(owner.Repo.Name),
+	}
+}
+
 func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
 	hls := commit.Body().ToHighlightedString()
 	ranges := make([][3]int32, len(hls.Highlights))
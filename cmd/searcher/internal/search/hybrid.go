@@ -203,6 +203,7 @@ func zoektSearchIgnorePaths(ctx context.Context, client zoekt.Streamer, p *proto
 			sender.Send(protocol.FileMatch{
 				Path:         fm.FileName,
 				ChunkMatches: zoektChunkMatches(fm.ChunkMatches),
+				Language:     fm.Language,
 			})
 		}
 	}))
@@ -247,14 +248,17 @@ func zoektSearchIgnorePaths(ctx context.Context, client zoekt.Streamer, p *proto
 // This function should support the same features as the "compile" function,
 // but return a zoektquery instead of a readerGrep.
 //
-// Note: This is used by hybrid search and not structural search.
+// Note: This is used by hybrid search and indexed NEAR/n search, and not
+// structural search.
 func zoektCompile(p *protocol.PatternInfo) (zoektquery.Q, error) {
 	var parts []zoektquery.Q
 	// we are redoing work here, but ensures we generate the same regex and it
 	// feels nicer than passing in a readerGrep since handle path directly.
-	if rg, err := compile(p); err != nil {
+	rg, err := compile(p)
+	if err != nil {
 		return nil, err
-	} else if rg.re == nil { // we are just matching paths
+	}
+	if rg.re == nil { // we are just matching paths
 		parts = append(parts, &zoektquery.Const{Value: true})
 	} else {
 		re, err := syntax.Parse(rg.re.String(), syntax.Perl)
@@ -285,6 +289,20 @@ func zoektCompile(p *protocol.PatternInfo) (zoektquery.Q, error) {
 		}
 	}
 
+	if rg.near != nil {
+		// Zoekt cannot check the distance between matches, so we only
+		// require both patterns to match the content.
+		re, err := syntax.Parse(rg.near.re.String(), syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, &zoektquery.Regexp{
+			Regexp:        zoektquery.OptimizeRegexp(re, syntax.Perl),
+			Content:       true,
+			CaseSensitive: !rg.ignoreCase,
+		})
+	}
+
 	for _, pat := range p.IncludePatterns {
 		re, err := syntax.Parse(pat, syntax.Perl)
 		if err != nil {
@@ -309,6 +327,15 @@ func zoektCompile(p *protocol.PatternInfo) (zoektquery.Q, error) {
 		}})
 	}
 
+	if rg.matchLang != nil {
+		for _, lang := range rg.matchLang.Include {
+			parts = append(parts, &zoektquery.Language{Language: lang})
+		}
+		for _, lang := range rg.matchLang.Exclude {
+			parts = append(parts, &zoektquery.Not{Child: &zoektquery.Language{Language: lang}})
+		}
+	}
+
 	return zoektquery.Simplify(zoektquery.NewAnd(parts...)), nil
 }
 
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+)
+
+// langMatcher matches the language of a file, detected from its path and
+// content, against the lang filters of a query.
+type langMatcher struct {
+	Include []string
+	Exclude []string
+}
+
+func (lm *langMatcher) MatchLang(lang string) bool {
+	for _, l := range lm.Include {
+		if l != lang {
+			return false
+		}
+	}
+	for _, l := range lm.Exclude {
+		if l == lang {
+			return false
+		}
+	}
+	return true
+}
+
+func (lm *langMatcher) String() string {
+	parts := append([]string{}, lm.Include...)
+	for _, l := range lm.Exclude {
+		parts = append(parts, "!"+l)
+	}
+	return strings.Join(parts, " ")
+}
+
+// compileLangFilters returns a langMatcher that matches a language iff:
+//
+// * it is all of the includeLanguages; AND
+// * it is none of the excludeLanguages.
+//
+// The languages are aliases as accepted by the lang filter, e.g. "c++".
+func compileLangFilters(includeLanguages, excludeLanguages []string) *langMatcher {
+	canonical := func(aliases []string) []string {
+		langs := make([]string, 0, len(aliases))
+		for _, alias := range aliases {
+			lang, _ := enry.GetLanguageByAlias(alias) // Invariant: the lang filter is validated.
+			langs = append(langs, lang)
+		}
+		return langs
+	}
+	return &langMatcher{
+		Include: canonical(includeLanguages),
+		Exclude: canonical(excludeLanguages),
+	}
+}
+
+// fileLanguage returns the language of f detected from its path and content.
+func fileLanguage(zf *zipFile, f *srcFile) string {
+	return inventory.GetLanguage(f.Name, zf.DataFor(f))
+}
@@ -0,0 +1,28 @@
+package search
+
+import "testing"
+
+func TestCompileLangFilters(t *testing.T) {
+	match := compileLangFilters([]string{"cpp"}, []string{"c"})
+
+	want := map[string]bool{
+		"C++":         true,
+		"C":           false,
+		"Objective-C": false,
+		"":            false,
+	}
+	for lang, want := range want {
+		got := match.MatchLang(lang)
+		if got != want {
+			t.Errorf("lang %q: got %v, want %v", lang, got, want)
+			continue
+		}
+	}
+
+	match = compileLangFilters(nil, []string{"c", "objective-c"})
+	for lang, want := range map[string]bool{"C++": true, "C": false, "Objective-C": false, "": true} {
+		if got := match.MatchLang(lang); got != want {
+			t.Errorf("exclude lang %q: got %v, want %v", lang, got, want)
+		}
+	}
+}
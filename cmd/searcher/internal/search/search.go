@@ -214,6 +214,12 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		}
 	}
 
+	if p.NearPattern != "" && p.Indexed {
+		// Zoekt finds the candidate files and we confirm the distance
+		// between matches.
+		return nearSearchWithZoekt(ctx, s.Indexed, p, rg, sender)
+	}
+
 	if p.FetchTimeout == "" {
 		p.FetchTimeout = "500ms"
 	}
@@ -233,7 +239,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 		return path, zf, err
 	}
 
-	hybrid := !p.IsStructuralPat && p.FeatHybrid
+	// Hybrid search streams the matches of Zoekt as is, so it cannot
+	// check the distance between the matches of a NEAR/n query.
+	hybrid := !p.IsStructuralPat && p.NearPattern == "" && p.FeatHybrid
 	if hybrid {
 		logger := logWithTrace(ctx, s.Log).Scoped("hybrid", "hybrid indexed and unindexed search").With(
 			log.String("repo", string(p.Repo)),
@@ -299,8 +307,11 @@ func validateParams(p *protocol.Request) error {
 	if len(p.Commit) != 40 {
 		return errors.Errorf("Commit must be resolved (Commit=%q)", p.Commit)
 	}
-	if p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 {
-		return errors.New("At least one of pattern and include/exclude pattners must be non-empty")
+	// Content based lang filters are applied by searcher, so a query with
+	// only lang filters (e.g. "lang:go") is a valid request.
+	hasLangFilters := p.ContentBasedLangFilters && len(p.Languages)+len(p.ExcludeLanguages) > 0
+	if p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 && !hasLangFilters {
+		return errors.New("At least one of pattern, include/exclude patterns and lang filters must be non-empty")
 	}
 	if p.IsNegated && p.IsStructuralPat {
 		return errors.New("Negated patterns are not supported for structural searches")
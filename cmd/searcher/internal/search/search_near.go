@@ -0,0 +1,132 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	zoektutil "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// nearSearchWithZoekt searches an indexed revision for a NEAR/n query. Zoekt
+// finds the files in which both patterns match and returns their content, on
+// which rg checks the distance between the matches.
+func nearSearchWithZoekt(ctx context.Context, client zoekt.Streamer, p *protocol.Request, rg *readerGrep, sender matchSender) error {
+	qText, err := zoektCompile(&p.PatternInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to compile query for zoekt")
+	}
+
+	branch := p.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	q := zoektquery.Simplify(zoektquery.NewAnd(
+		zoektquery.NewSingleBranchesRepos(branch, uint32(p.RepoID)),
+		qText,
+	))
+
+	opts := (&zoektutil.Options{
+		NumRepos:       1,
+		FileMatchLimit: int32(p.Limit),
+	}).ToSearch(ctx)
+	opts.Whole = true
+
+	// rg is not safe for concurrent use.
+	var mu sync.Mutex
+	return client.StreamSearch(ctx, q, opts, senderFunc(func(res *zoekt.SearchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, file := range res.Files {
+			if cms := rg.find(file.Content, sender.Remaining()); len(cms) > 0 {
+				sender.Send(protocol.FileMatch{
+					Path:         file.FileName,
+					ChunkMatches: cms,
+					Language:     file.Language,
+				})
+			}
+		}
+	}))
+}
+
+// findNear returns up to limit locations of the matches of rg and rg.near in
+// buf which are within rg.nearDistance lines of a match of the other pattern.
+// Overlapping matches are merged, so the locations are sorted and do not
+// overlap.
+func (rg *readerGrep) findNear(buf []byte, limit int) [][]int {
+	if !bytes.Contains(buf, rg.near.literalSubstring) {
+		return nil
+	}
+	left := rg.re.FindAllIndex(buf, -1)
+	if len(left) == 0 {
+		return nil
+	}
+	right := rg.near.re.FindAllIndex(buf, -1)
+	if len(right) == 0 {
+		return nil
+	}
+
+	leftLines, rightLines := locsToLines(buf, left), locsToLines(buf, right)
+	left = filterNear(left, leftLines, rightLines, rg.nearDistance)
+	right = filterNear(right, rightLines, leftLines, rg.nearDistance)
+
+	var locs [][]int
+	for len(left) > 0 || len(right) > 0 {
+		var next []int
+		if len(right) == 0 || (len(left) > 0 && left[0][0] <= right[0][0]) {
+			next, left = left[0], left[1:]
+		} else {
+			next, right = right[0], right[1:]
+		}
+		if n := len(locs); n > 0 && next[0] < locs[n-1][1] {
+			if next[1] > locs[n-1][1] {
+				locs[n-1] = []int{locs[n-1][0], next[1]}
+			}
+			continue
+		}
+		if len(locs) == limit {
+			break
+		}
+		locs = append(locs, next)
+	}
+	return locs
+}
+
+// locsToLines returns the first and last line of each of the locations locs
+// in buf. locs must be sorted and must not overlap.
+func locsToLines(buf []byte, locs [][]int) [][2]int {
+	lines := make([][2]int, 0, len(locs))
+	prevEnd, prevEndLine := 0, 0
+	for _, loc := range locs {
+		start, end := loc[0], loc[1]
+		startLine := prevEndLine + bytes.Count(buf[prevEnd:start], []byte{'\n'})
+		endLine := startLine + bytes.Count(buf[start:end], []byte{'\n'})
+		lines = append(lines, [2]int{startLine, endLine})
+		prevEnd, prevEndLine = end, endLine
+	}
+	return lines
+}
+
+// filterNear returns the locations locs whose lines are within distance lines
+// of the lines of a location of the other pattern. Both lines and other are
+// sorted.
+func filterNear(locs [][]int, lines, other [][2]int, distance int) [][]int {
+	var kept [][]int
+	for i, loc := range locs {
+		// The first location of other that does not end more than
+		// distance lines before loc starts.
+		j := sort.Search(len(other), func(j int) bool {
+			return other[j][1] >= lines[i][0]-distance
+		})
+		if j < len(other) && other[j][0] <= lines[i][1]+distance {
+			kept = append(kept, loc)
+		}
+	}
+	return kept
+}
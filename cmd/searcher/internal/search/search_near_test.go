@@ -0,0 +1,93 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+func TestFindNear(t *testing.T) {
+	content := []byte(`func main() {
+	conn := open()
+	defer conn.Close()
+}
+
+func other() {
+	conn := open()
+	log.Println("hello")
+	log.Println("world")
+	conn.Close()
+}
+`)
+
+	matchedLines := func(cms []protocol.ChunkMatch) []int32 {
+		var lines []int32
+		for _, cm := range cms {
+			for _, r := range cm.Ranges {
+				lines = append(lines, r.Start.Line)
+			}
+		}
+		return lines
+	}
+
+	cases := []struct {
+		name    string
+		pattern protocol.PatternInfo
+		want    []int32
+	}{{
+		name:    "adjacent lines",
+		pattern: protocol.PatternInfo{Pattern: "open", NearPattern: "Close", NearDistance: 1},
+		want:    []int32{1, 2},
+	}, {
+		name:    "larger distance",
+		pattern: protocol.PatternInfo{Pattern: "open", NearPattern: "Close", NearDistance: 3},
+		want:    []int32{1, 2, 6, 9},
+	}, {
+		name:    "same line",
+		pattern: protocol.PatternInfo{Pattern: "log", NearPattern: "world", NearDistance: 0},
+		want:    []int32{8, 8},
+	}, {
+		name:    "case insensitive",
+		pattern: protocol.PatternInfo{Pattern: "OPEN", NearPattern: "close", NearDistance: 1},
+		want:    []int32{1, 2},
+	}, {
+		name:    "case sensitive",
+		pattern: protocol.PatternInfo{Pattern: "OPEN", NearPattern: "close", NearDistance: 1, IsCaseSensitive: true},
+		want:    nil,
+	}, {
+		name:    "overlapping matches",
+		pattern: protocol.PatternInfo{Pattern: `conn\.Close`, NearPattern: `Close\(\)`, NearDistance: 0, IsRegExp: true},
+		want:    []int32{2, 9},
+	}, {
+		name:    "too far apart",
+		pattern: protocol.PatternInfo{Pattern: "main", NearPattern: "hello", NearDistance: 5},
+		want:    nil,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rg, err := compile(&tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := matchedLines(rg.find(content, 100))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected matched lines (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		rg, err := compile(&protocol.PatternInfo{Pattern: "conn", NearPattern: "open", NearDistance: 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Like the regular search, find returns limit+1 matches so callers
+		// know whether they hit the limit.
+		if got := matchedLines(rg.find(content, 1)); len(got) != 2 {
+			t.Errorf("expected 2 matches, got %v", got)
+		}
+	})
+}
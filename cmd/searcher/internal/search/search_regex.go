@@ -55,11 +55,22 @@ type readerGrep struct {
 	// whether a file path matches (and should be searched).
 	matchPath *pathMatcher
 
+	// matchLang, if non-nil, is compiled from content based lang filters and
+	// reports whether the language of a file matches (and it should be
+	// searched).
+	matchLang *langMatcher
+
 	// literalSubstring is used to test if a file is worth considering for
 	// matches. literalSubstring is guaranteed to appear in any match found by
 	// re. It is the output of the longestLiteral function. It is only set if
 	// the regex has an empty LiteralPrefix.
 	literalSubstring []byte
+
+	// near, if non-nil, matches the second pattern of a NEAR/n query. Only
+	// matches of re and near within nearDistance lines of each other are
+	// returned.
+	near         *readerGrep
+	nearDistance int
 }
 
 // compile returns a readerGrep for matching p.
@@ -126,11 +137,29 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 		return nil, err
 	}
 
+	var matchLang *langMatcher
+	if p.ContentBasedLangFilters {
+		matchLang = compileLangFilters(p.Languages, p.ExcludeLanguages)
+	}
+
+	var near *readerGrep
+	if p.NearPattern != "" {
+		np := *p
+		np.Pattern, np.NearPattern = p.NearPattern, ""
+		near, err = compile(&np)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &readerGrep{
 		re:               re,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
+		matchLang:        matchLang,
 		literalSubstring: literalSubstring,
+		near:             near,
+		nearDistance:     p.NearDistance,
 	}, nil
 }
 
@@ -141,7 +170,10 @@ func (rg *readerGrep) Copy() *readerGrep {
 		re:               rg.re,
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
+		matchLang:        rg.matchLang,
 		literalSubstring: rg.literalSubstring,
+		near:             rg.near,
+		nearDistance:     rg.nearDistance,
 	}
 }
 
@@ -161,9 +193,16 @@ func (rg *readerGrep) matchString(s string) bool {
 // LimitHit is true if some matches may not have been included in the result.
 // NOTE: This is not safe to use concurrently.
 func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protocol.ChunkMatch, err error) {
+	if rg.ignoreCase && rg.transformBuf == nil {
+		rg.transformBuf = make([]byte, zf.MaxLen)
+	}
+	return rg.find(zf.DataFor(f), limit), nil
+}
+
+// find is Find for the content fileBuf of a file.
+func (rg *readerGrep) find(fileBuf []byte, limit int) []protocol.ChunkMatch {
 	// fileMatchBuf is what we run match on, fileBuf is the original
 	// data (for Preview).
-	fileBuf := zf.DataFor(f)
 	fileMatchBuf := fileBuf
 
 	// If we are ignoring case, we transform the input instead of
@@ -172,8 +211,8 @@ func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protoc
 	// trade some correctness for perf by using a non-utf8 aware
 	// lowercase function.
 	if rg.ignoreCase {
-		if rg.transformBuf == nil {
-			rg.transformBuf = make([]byte, zf.MaxLen)
+		if len(rg.transformBuf) < len(fileBuf) {
+			rg.transformBuf = make([]byte, len(fileBuf))
 		}
 		fileMatchBuf = rg.transformBuf[:len(fileBuf)]
 		casetransform.BytesToLowerASCII(fileMatchBuf, fileBuf)
@@ -187,17 +226,22 @@ func (rg *readerGrep) Find(zf *zipFile, f *srcFile, limit int) (matches []protoc
 	// per-line. Additionally if we have a non-empty literalSubstring, we use
 	// that to prune out files since doing bytes.Index is very fast.
 	if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
-		return nil, nil
+		return nil
 	}
 
 	// find limit+1 matches so we know whether we hit the limit
-	locs := rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	var locs [][]int
+	if rg.near != nil {
+		locs = rg.findNear(fileMatchBuf, limit+1)
+	} else {
+		locs = rg.re.FindAllIndex(fileMatchBuf, limit+1)
+	}
 	if len(locs) == 0 {
-		return nil, nil // short-circuit if we have no matches
+		return nil // short-circuit if we have no matches
 	}
 	ranges := locsToRanges(fileBuf, locs)
 	chunks := chunkRanges(ranges, 0)
-	return chunksToMatches(fileBuf, chunks), nil
+	return chunksToMatches(fileBuf, chunks)
 }
 
 // locs must be sorted, non-overlapping, and must be valid slices of buf.
@@ -269,6 +313,9 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 		span.SetTag("re", rg.re.String())
 	}
 	span.SetTag("path", rg.matchPath.String())
+	if rg.matchLang != nil {
+		span.SetTag("lang", rg.matchLang.String())
+	}
 	defer func() {
 		if err != nil {
 			ext.Error.Set(span, true)
@@ -300,12 +347,19 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
-		for _, f := range files {
-			if match := rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name); match == !isPatternNegated {
+		for i := range files {
+			f := &files[i]
+			match := rg.matchPath.MatchPath(f.Name) && rg.matchString(f.Name)
+			var lang string
+			if match && rg.matchLang != nil {
+				lang = fileLanguage(zf, f)
+				match = rg.matchLang.MatchLang(lang)
+			}
+			if match == !isPatternNegated {
 				if ctx.Err() != nil {
 					return ctx.Err()
 				}
-				fm := protocol.FileMatch{Path: f.Name}
+				fm := protocol.FileMatch{Path: f.Name, Language: lang}
 				sender.Send(fm)
 			}
 		}
@@ -346,6 +400,14 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 					filesSkipped.Inc()
 					continue
 				}
+				var lang string
+				if rg.matchLang != nil {
+					lang = fileLanguage(zf, f)
+					if !rg.matchLang.MatchLang(lang) {
+						filesSkipped.Inc()
+						continue
+					}
+				}
 				filesSearched.Inc()
 
 				// process
@@ -362,6 +424,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 					}
 				}
 				if match == !isPatternNegated {
+					fm.Language = lang
 					sender.Send(fm)
 				}
 			}
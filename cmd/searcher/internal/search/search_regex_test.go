@@ -422,6 +422,71 @@ func TestPathMatches(t *testing.T) {
 	}
 }
 
+func TestLangMatches(t *testing.T) {
+	zipData, err := createZip(map[string]string{
+		"main.go":      "package main\n\nfunc main() {}\n",
+		"deploy":       "#!/usr/bin/env python3\nmain()\n",
+		"build":        "#!/bin/sh\nmain\n",
+		"include/a.h":  "#include <string>\nnamespace a {\nclass A {\n public:\n  std::string main;\n};\n}\n",
+		"README":       "main\n",
+		"script.py":    "main()\n",
+		"vendor/x.txt": "main\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := mockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name             string
+		languages        []string
+		excludeLanguages []string
+		want             map[string]string
+	}{{
+		name:      "shebang",
+		languages: []string{"python"},
+		want:      map[string]string{"deploy": "Python", "script.py": "Python"},
+	}, {
+		name:      "ambiguous extension",
+		languages: []string{"c++"},
+		want:      map[string]string{"include/a.h": "C++"},
+	}, {
+		name:             "exclude",
+		excludeLanguages: []string{"python", "go"},
+		want:             map[string]string{"build": "Shell", "include/a.h": "C++", "README": "", "vendor/x.txt": "Text"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rg, err := compile(&protocol.PatternInfo{
+				Pattern:                 "main",
+				Languages:               tc.languages,
+				ExcludeLanguages:        tc.excludeLanguages,
+				ContentBasedLangFilters: true,
+				PatternMatchesContent:   true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			fileMatches, _, err := regexSearchBatch(context.Background(), rg, zf, 100, true, false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string, len(fileMatches))
+			for _, fm := range fileMatches {
+				got[fm.Path] = fm.Language
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got file matches %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 // githubStore fetches from github and caches across test runs.
 var githubStore = &Store{
 	FetchTar:       fetchTarFromGithub,
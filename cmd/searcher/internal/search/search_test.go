@@ -284,6 +284,9 @@ abc.txt
 `},
 		{protocol.PatternInfo{Pattern: "abc", PatternMatchesPath: true, PatternMatchesContent: false}, `
 abc.txt
+`},
+		{protocol.PatternInfo{Languages: []string{"go"}, ContentBasedLangFilters: true, PatternMatchesPath: true, PatternMatchesContent: true}, `
+main.go
 `},
 	}
 
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -79,6 +81,18 @@ type Store struct {
 	// MaxCacheSizeBytes.
 	MaxCacheSizeBytes int64
 
+	// HighWaterMarkBytes when non-zero is the cache size above which fetching
+	// an archive synchronously evicts archives until the cache is smaller
+	// than MaxCacheSizeBytes.
+	HighWaterMarkBytes int64
+
+	// EvictionPolicy decides which archives are evicted first. If nil,
+	// the least recently used archives are evicted first.
+	EvictionPolicy diskcache.EvictionPolicy
+
+	// PinnedRepos are repositories whose archives are never evicted.
+	PinnedRepos []api.RepoName
+
 	// Log is the Logger to use.
 	Log log.Logger
 
@@ -112,11 +126,19 @@ type FilterFunc func(hdr *tar.Header) bool
 func (s *Store) Start() {
 	s.once.Do(func() {
 		s.fetchLimiter = mutablelimiter.New(15)
-		s.cache = diskcache.NewStore(s.Path, "store",
-			diskcache.WithBackgroundTimeout(10*time.Minute),
+		opts := []diskcache.StoreOpt{
+			diskcache.WithBackgroundTimeout(10 * time.Minute),
 			diskcache.WithBeforeEvict(s.zipCache.delete),
 			diskcache.WithobservationCtx(s.ObservationCtx),
-		)
+			diskcache.WithPinned(s.pinnedKeys),
+		}
+		if s.EvictionPolicy != nil {
+			opts = append(opts, diskcache.WithEvictionPolicy(s.EvictionPolicy))
+		}
+		if s.HighWaterMarkBytes > 0 {
+			opts = append(opts, diskcache.WithHighWaterMark(s.HighWaterMarkBytes, s.MaxCacheSizeBytes))
+		}
+		s.cache = diskcache.NewStore(s.Path, "store", opts...)
 		_ = os.MkdirAll(s.Path, 0o700)
 		metrics.MustRegisterDiskMonitor(s.Path)
 
@@ -191,7 +213,7 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 		// since we're just going to close it again immediately.
 		cacheHit := true
 		bgctx := opentracing.ContextWithSpan(context.Background(), opentracing.SpanFromContext(ctx))
-		f, err := s.cache.Open(bgctx, []string{key}, func(ctx context.Context) (io.ReadCloser, error) {
+		f, err := s.cache.Open(bgctx, []string{string(repo), key}, func(ctx context.Context) (io.ReadCloser, error) {
 			cacheHit = false
 			return s.fetch(ctx, repo, commit, filter, paths)
 		})
@@ -412,6 +434,16 @@ func (s *Store) String() string {
 	return "Store(" + s.Path + ")"
 }
 
+// pinnedKeys returns the diskcache key prefixes of the archives of
+// PinnedRepos.
+func (s *Store) pinnedKeys() [][]string {
+	keys := make([][]string, 0, len(s.PinnedRepos))
+	for _, repo := range s.PinnedRepos {
+		keys = append(keys, []string{string(repo)})
+	}
+	return keys
+}
+
 // watchAndEvict is a loop which periodically checks the size of the cache and
 // evicts/deletes items if the store gets too large.
 func (s *Store) watchAndEvict() {
@@ -431,6 +463,43 @@ func (s *Store) watchAndEvict() {
 		}
 		metricCacheSizeBytes.Set(float64(stats.CacheSize))
 		metricEvictions.Add(float64(stats.Evicted))
+
+		s.removeStaleEntries(time.Now())
+	}
+}
+
+// staleDirAge is how long a per-repository directory has to be empty before
+// it is removed. This gives fetches which just created the directory time to
+// write their archive into it.
+const staleDirAge = time.Minute
+
+// removeStaleEntries removes the archives which older versions of searcher
+// stored directly in the cache directory, before archives were grouped by
+// repository, as well as the per-repository directories which eviction left
+// empty.
+func (s *Store) removeStaleEntries(now time.Time) {
+	entries, err := os.ReadDir(s.Path)
+	if err != nil {
+		s.Log.Error("failed to list cache directory", log.Error(err))
+		return
+	}
+	for _, entry := range entries {
+		path := filepath.Join(s.Path, entry.Name())
+		if !entry.IsDir() {
+			if strings.HasSuffix(entry.Name(), ".zip") {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					s.Log.Warn("failed to remove archive in old cache layout", log.String("path", path), log.Error(err))
+				}
+			}
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < staleDirAge {
+			continue
+		}
+		// Removing a directory which isn't empty fails, which is what we want.
+		_ = os.Remove(path)
 	}
 }
 
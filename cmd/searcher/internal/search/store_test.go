@@ -296,6 +296,53 @@ func tarArchive(dir string) (*tar.Reader, error) {
 	return tar.NewReader(&b), nil
 }
 
+func TestRemoveStaleEntries(t *testing.T) {
+	s := tmpStore(t)
+
+	write := func(path string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir := func(path string, modTime time.Time) {
+		t.Helper()
+		if err := os.MkdirAll(path, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	legacy := filepath.Join(s.Path, "legacy.zip")
+	archive := filepath.Join(s.Path, "repo", "current.zip")
+	staleDir := filepath.Join(s.Path, "stale")
+	freshDir := filepath.Join(s.Path, "fresh")
+	write(legacy)
+	write(archive)
+	mkdir(staleDir, now.Add(-2*staleDirAge))
+	mkdir(freshDir, now)
+
+	s.removeStaleEntries(now)
+
+	for path, wantExists := range map[string]bool{
+		legacy:   false,
+		archive:  true,
+		staleDir: false,
+		freshDir: true,
+	} {
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("%s: want exists=%v, got err=%v", path, wantExists, err)
+		}
+	}
+}
+
 func tmpStore(t *testing.T) *Store {
 	d := t.TempDir()
 	return &Store{
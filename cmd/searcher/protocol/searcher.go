@@ -112,6 +112,16 @@ type PatternInfo struct {
 	// Languages is the languages passed via the lang filters (e.g., "lang:c")
 	Languages []string
 
+	// ExcludeLanguages is the languages passed via negated lang filters
+	// (e.g., "-lang:c").
+	ExcludeLanguages []string `json:",omitempty"`
+
+	// ContentBasedLangFilters is true if the lang filters are not part of
+	// IncludePatterns and ExcludePattern. Instead, searcher detects the
+	// language of each file from its path and content, and only searches
+	// files in all of Languages and none of ExcludeLanguages.
+	ContentBasedLangFilters bool `json:",omitempty"`
+
 	// CombyRule is a rule that constrains matching for structural search.
 	// It only applies when IsStructuralPat is true.
 	// As a temporary measure, the expression `where "backcompat" == "backcompat"` acts as
@@ -123,6 +133,13 @@ type PatternInfo struct {
 	// use it since selection is done after the query completes, but exposing it can enable
 	// optimizations.
 	Select string
+
+	// NearPattern, if non-empty, is a second pattern interpreted like
+	// Pattern. A file only matches if Pattern and NearPattern match within
+	// NearDistance lines of each other, and only those matches are
+	// returned. It implements the NEAR/n query operator.
+	NearPattern  string `json:",omitempty"`
+	NearDistance int    `json:",omitempty"`
 }
 
 func (p *PatternInfo) String() string {
@@ -155,9 +172,18 @@ func (p *PatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLanguages {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
+	if p.ContentBasedLangFilters {
+		args = append(args, "langfromcontent")
+	}
 	if p.Select != "" {
 		args = append(args, fmt.Sprintf("select:%s", p.Select))
 	}
+	if p.NearPattern != "" {
+		args = append(args, fmt.Sprintf("near/%d:%q", p.NearDistance, p.NearPattern))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
@@ -192,6 +218,11 @@ type FileMatch struct {
 
 	// LimitHit is true if LineMatches may not include all LineMatches.
 	LimitHit bool
+
+	// Language is the language of the file detected from its path and
+	// content. It is only set when content based lang filters were applied,
+	// and is empty if unknown.
+	Language string `json:",omitempty"`
 }
 
 func (fm FileMatch) MatchCount() int {
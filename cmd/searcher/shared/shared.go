@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	connections "github.com/sourcegraph/sourcegraph/internal/database/connections/live"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -49,6 +51,10 @@ var (
 	cacheDir    = env.Get("CACHE_DIR", "/tmp", "directory to store cached archives.")
 	cacheSizeMB = env.Get("SEARCHER_CACHE_SIZE_MB", "100000", "maximum size of the on disk cache in megabytes")
 
+	cacheHighWaterMarkMB = env.Get("SEARCHER_CACHE_HIGH_WATER_MARK_MB", "0", "size of the on disk cache in megabytes above which fetches evict archives immediately. 0 disables the check")
+	cacheEvictionPolicy  = env.Get("SEARCHER_CACHE_EVICTION_POLICY", "lru", "order in which archives are evicted from the on disk cache: lru, size-weighted-lru or lfu")
+	cachePinnedRepos     = env.Get("SEARCHER_CACHE_PINNED_REPOS", "", "comma-separated list of repositories whose archives are never evicted from the on disk cache")
+
 	maxTotalPathsLengthRaw = env.Get("MAX_TOTAL_PATHS_LENGTH", "100000", "maximum sum of lengths of all paths in a single call to git archive")
 )
 
@@ -129,6 +135,25 @@ func run(logger log.Logger) error {
 		cacheSizeBytes = i * 1000 * 1000
 	}
 
+	var cacheHighWaterMarkBytes int64
+	if i, err := strconv.ParseInt(cacheHighWaterMarkMB, 10, 64); err != nil {
+		return errors.Wrapf(err, "invalid int %q for SEARCHER_CACHE_HIGH_WATER_MARK_MB", cacheHighWaterMarkMB)
+	} else {
+		cacheHighWaterMarkBytes = i * 1000 * 1000
+	}
+
+	evictionPolicy, err := diskcache.ParseEvictionPolicy(cacheEvictionPolicy)
+	if err != nil {
+		return errors.Wrap(err, "invalid SEARCHER_CACHE_EVICTION_POLICY")
+	}
+
+	var pinnedRepos []api.RepoName
+	for _, repo := range strings.Split(cachePinnedRepos, ",") {
+		if repo = strings.TrimSpace(repo); repo != "" {
+			pinnedRepos = append(pinnedRepos, api.RepoName(repo))
+		}
+	}
+
 	maxTotalPathsLength, err := strconv.Atoi(maxTotalPathsLengthRaw)
 	if err != nil {
 		return errors.Wrapf(err, "invalid int %q for MAX_TOTAL_PATHS_LENGTH", maxTotalPathsLengthRaw)
@@ -172,12 +197,15 @@ func run(logger log.Logger) error {
 					Pathspecs: pathspecs,
 				})
 			},
-			FilterTar:         search.NewFilter,
-			Path:              filepath.Join(cacheDir, "searcher-archives"),
-			MaxCacheSizeBytes: cacheSizeBytes,
-			Log:               storeObservationCtx.Logger,
-			ObservationCtx:    storeObservationCtx,
-			DB:                db,
+			FilterTar:          search.NewFilter,
+			Path:               filepath.Join(cacheDir, "searcher-archives"),
+			MaxCacheSizeBytes:  cacheSizeBytes,
+			HighWaterMarkBytes: cacheHighWaterMarkBytes,
+			EvictionPolicy:     evictionPolicy,
+			PinnedRepos:        pinnedRepos,
+			Log:                storeObservationCtx.Logger,
+			ObservationCtx:     storeObservationCtx,
+			DB:                 db,
 		},
 
 		Indexed: sharedsearch.Indexed(),
@@ -59,6 +59,16 @@ func repoCommitKey(repo api.RepoName, commitID api.CommitID) []string {
 	}
 }
 
+// PinnedRepoKeys returns the diskcache key prefixes of the databases of the
+// given repos, to be used with diskcache.WithPinned.
+func PinnedRepoKeys(repos []string) [][]string {
+	keys := make([][]string, 0, len(repos))
+	for _, repo := range repos {
+		keys = append(keys, repoKey(api.RepoName(repo)))
+	}
+	return keys
+}
+
 // repoKey returns the diskcache key for a repo (points to a directory).
 func repoKey(repo api.RepoName) []string {
 	return []string{
@@ -44,10 +44,23 @@ func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverC
 		logger.Fatal("failed to create parser pool", log.Error(err))
 	}
 
-	cache := diskcache.NewStore(config.CacheDir, "symbols",
+	evictionPolicy, err := diskcache.ParseEvictionPolicy(config.CacheEvictionPolicy)
+	if err != nil {
+		logger.Fatal("invalid SYMBOLS_CACHE_EVICTION_POLICY", log.Error(err))
+	}
+	pinnedKeys := writer.PinnedRepoKeys(config.CachePinnedRepos)
+
+	cacheSizeBytes := int64(config.CacheSizeMB) * 1000 * 1000
+	cacheOpts := []diskcache.StoreOpt{
 		diskcache.WithBackgroundTimeout(config.ProcessingTimeout),
 		diskcache.WithobservationCtx(observationCtx),
-	)
+		diskcache.WithEvictionPolicy(evictionPolicy),
+		diskcache.WithPinned(func() [][]string { return pinnedKeys }),
+	}
+	if config.CacheHighWaterMarkMB > 0 {
+		cacheOpts = append(cacheOpts, diskcache.WithHighWaterMark(int64(config.CacheHighWaterMarkMB)*1000*1000, cacheSizeBytes))
+	}
+	cache := diskcache.NewStore(config.CacheDir, "symbols", cacheOpts...)
 
 	parser := parser.NewParser(observationCtx, parserPool, repositoryFetcher, config.RequestBufferSize, config.NumCtagsProcesses)
 	databaseWriter := writer.NewDatabaseWriter(observationCtx, config.CacheDir, gitserverClient, parser, semaphore.NewWeighted(int64(config.MaxConcurrentlyIndexing)))
@@ -55,7 +68,6 @@ func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverC
 	searchFunc := api.MakeSqliteSearchFunc(observationCtx, cachedDatabaseWriter, db)
 
 	evictionInterval := time.Second * 10
-	cacheSizeBytes := int64(config.CacheSizeMB) * 1000 * 1000
 	cacheEvicter := janitor.NewCacheEvicter(evictionInterval, cache, cacheSizeBytes, janitor.NewMetrics(observationCtx))
 
 	return searchFunc, nil, []goroutine.BackgroundRoutine{cacheEvicter}, config.Ctags.Command, nil
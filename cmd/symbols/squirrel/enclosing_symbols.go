@@ -0,0 +1,96 @@
+package squirrel
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// enclosingSymbolNodeTypes matches the tree-sitter node types of the functions and
+// classes that can enclose a range of lines across languages, e.g.
+// function_declaration (Go, JavaScript), method_declaration (Java, C#),
+// class_definition (Python), function_item (Rust), or method (Ruby).
+var enclosingSymbolNodeTypes = regexp.MustCompile(`^(((abstract_)?class|(generator_)?function|method|constructor|interface|enum|struct|record|namespace|module)_(declaration|definition)|(function|struct|enum|trait|mod)_item|type_spec|class|method|singleton_method|module)$`)
+
+// enclosingSymbol is a function or class and the lines it spans.
+type enclosingSymbol struct {
+	name       string
+	start, end int
+}
+
+// enclosingSymbols finds the innermost function or class enclosing each of the
+// given line ranges.
+func (squirrel *SquirrelService) enclosingSymbols(ctx context.Context, args types.RepoCommitPathLines) (*types.EnclosingSymbolsPayload, error) {
+	root, err := squirrel.parse(ctx, args.RepoCommitPath)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := collectEnclosingSymbols(root.Node, root.Contents, "", nil)
+
+	payload := &types.EnclosingSymbolsPayload{Symbols: make([]string, len(args.Lines))}
+	for i, lines := range args.Lines {
+		// Symbols are collected in pre-order, so the last symbol that
+		// encloses the lines is the innermost one.
+		for _, symbol := range symbols {
+			if symbol.start <= lines.Start && lines.End <= symbol.end {
+				payload.Symbols[i] = symbol.name
+			}
+		}
+	}
+	return payload, nil
+}
+
+// collectEnclosingSymbols appends the functions and classes in the tree rooted at
+// node to symbols in pre-order. Names are qualified by the names of their
+// enclosing symbols, e.g. "Server.Handle".
+func collectEnclosingSymbols(node *sitter.Node, contents []byte, parent string, symbols []enclosingSymbol) []enclosingSymbol {
+	if node == nil {
+		return symbols
+	}
+
+	if enclosingSymbolNodeTypes.MatchString(node.Type()) {
+		if name := enclosingSymbolName(node, contents); name != "" {
+			if parent != "" {
+				name = parent + "." + name
+			}
+			parent = name
+			symbols = append(symbols, enclosingSymbol{
+				name:  name,
+				start: int(node.StartPoint().Row),
+				end:   int(node.EndPoint().Row),
+			})
+		}
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		symbols = collectEnclosingSymbols(node.NamedChild(i), contents, parent, symbols)
+	}
+	return symbols
+}
+
+// enclosingSymbolName returns the name of a function or class node, or the empty
+// string for anonymous functions and classes.
+func enclosingSymbolName(node *sitter.Node, contents []byte) string {
+	if name := node.ChildByFieldName("name"); name != nil {
+		return strings.TrimSpace(name.Content(contents))
+	}
+
+	// C and C++ functions are named by their (nested) declarator, e.g.
+	// function_definition > function_declarator > identifier.
+	declarator := node.ChildByFieldName("declarator")
+	if declarator == nil {
+		return ""
+	}
+	for {
+		next := declarator.ChildByFieldName("declarator")
+		if next == nil {
+			return strings.TrimSpace(declarator.Content(contents))
+		}
+		declarator = next
+	}
+}
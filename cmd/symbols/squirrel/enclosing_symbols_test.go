@@ -0,0 +1,88 @@
+package squirrel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestEnclosingSymbols(t *testing.T) {
+	tests := []struct {
+		path     string
+		contents string
+		lines    []types.LineRange
+		want     []string
+	}{{
+		path: "test.go",
+		contents: `package auth
+
+func ValidateToken(token string) error {
+	if token == "" {
+		return errEmpty
+	}
+	return nil
+}
+
+type Server struct {
+	name string
+}
+
+func (s *Server) Handle() {
+	s.name = "x"
+}
+`,
+		lines: []types.LineRange{
+			{Start: 3, End: 5},   // inside ValidateToken
+			{Start: 2, End: 2},   // ValidateToken signature
+			{Start: 10, End: 10}, // Server field
+			{Start: 14, End: 14}, // inside Handle
+			{Start: 0, End: 0},   // package clause
+			{Start: 6, End: 13},  // spans multiple functions
+		},
+		want: []string{"ValidateToken", "ValidateToken", "Server", "Handle", "", ""},
+	}, {
+		path: "test.java",
+		contents: `class Auth {
+    void validateToken(String token) {
+        check(token);
+    }
+}
+`,
+		lines: []types.LineRange{{Start: 2, End: 2}, {Start: 0, End: 4}},
+		want:  []string{"Auth.validateToken", "Auth"},
+	}, {
+		path: "test.py",
+		contents: `class Auth:
+    def validate_token(self, token):
+        return token
+
+x = 1
+`,
+		lines: []types.LineRange{{Start: 2, End: 2}, {Start: 4, End: 4}},
+		want:  []string{"Auth.validate_token", ""},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
+				return []byte(test.contents), nil
+			}
+
+			squirrel := New(readFile, nil)
+			defer squirrel.Close()
+
+			payload, err := squirrel.enclosingSymbols(context.Background(), types.RepoCommitPathLines{
+				RepoCommitPath: types.RepoCommitPath{Repo: "foo", Commit: "bar", Path: test.path},
+				Lines:          test.lines,
+			})
+			fatalIfError(t, err)
+
+			if diff := cmp.Diff(test.want, payload.Symbols); diff != "" {
+				t.Fatalf("unexpected enclosing symbols (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -79,6 +79,44 @@ func LocalCodeIntelHandler(readFile ReadFileFunc) func(w http.ResponseWriter, r
 	}
 }
 
+// Responds to /enclosingSymbols
+func EnclosingSymbolsHandler(readFile ReadFileFunc) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Read the args from the request body.
+		var args types.RepoCommitPathLines
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			log15.Error("failed to decode request body", "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		squirrel := New(readFile, nil)
+		defer squirrel.Close()
+
+		// Find the enclosing symbols.
+		payload, err := squirrel.enclosingSymbols(r.Context(), args)
+		if err != nil {
+			_ = json.NewEncoder(w).Encode(nil)
+
+			// Log the error if it's not an unrecognized file extension or unsupported language error.
+			if !errors.Is(err, unrecognizedFileExtensionError) && !errors.Is(err, unsupportedLanguageError) {
+				log15.Error("failed to find enclosing symbols", "err", err)
+			}
+
+			return
+		}
+
+		// Write the response.
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(payload)
+		if err != nil {
+			log15.Error("failed to write response: %s", "error", err)
+			http.Error(w, fmt.Sprintf("failed to find enclosing symbols: %s", err), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
 // Responds to /symbolInfo
 func NewSymbolInfoHandler(symbolSearch symbolsTypes.SearchFunc, readFile ReadFileFunc) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,835 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The maximum number of symbols to inspect when searching for a type in a namespace.
+const maxSymbolSearchResultsCSharp = 100
+
+func (squirrel *SquirrelService) getDefCSharp(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefCSharp: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "compilation_unit":
+				for _, child := range children(cur) {
+					name := getTypeDeclarationNameCSharp(child)
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				return squirrel.getDefInNamespacesCSharp(ctx, node, ident)
+
+			case "namespace_declaration":
+				name := cur.ChildByFieldName("name")
+				if name != nil && nodeId(name) == nodeId(prev) {
+					// Namespaces don't have a single definition
+					return nil, nil
+				}
+				for _, child := range children(cur.ChildByFieldName("body")) {
+					name := getTypeDeclarationNameCSharp(child)
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			// Only the types in using directives have definitions, and those are found by the
+			// qualified_name case below
+			case "using_directive":
+				return nil, nil
+
+			// Check for member access
+			case "qualified_name":
+				left := cur.NamedChild(0)
+				if left == nil || nodeId(left) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldCSharp(ctx, swapNode(node, left), ident)
+
+			case "member_access_expression":
+				expression := cur.ChildByFieldName("expression")
+				if expression == nil || nodeId(expression) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldCSharp(ctx, swapNode(node, expression), ident)
+
+			case "member_binding_expression":
+				// x?.y
+				access := cur.Parent()
+				if access == nil || access.Type() != "conditional_access_expression" {
+					return nil, nil
+				}
+				condition := access.ChildByFieldName("condition")
+				if condition == nil {
+					return nil, nil
+				}
+				return squirrel.getFieldCSharp(ctx, swapNode(node, condition), ident)
+
+			// Check nodes that might have bindings:
+			case "block":
+				for _, child := range children(cur) {
+					switch child.Type() {
+					case "local_function_statement":
+						// Local functions can be called before they're declared
+						name := child.ChildByFieldName("name")
+						if name != nil && name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					case "local_declaration_statement":
+						if child.StartByte() >= prev.StartByte() {
+							continue
+						}
+						for _, declaration := range children(child) {
+							for _, binding := range getVariableBindingsCSharp(declaration) {
+								if binding.Content(node.Contents) == ident {
+									return swapNodePtr(node, binding), nil
+								}
+							}
+						}
+					}
+				}
+				continue
+
+			case "method_declaration":
+				fallthrough
+			case "constructor_declaration":
+				fallthrough
+			case "local_function_statement":
+				for _, binding := range getTypeParameterBindingsCSharp(cur) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				for _, binding := range getParameterBindingsCSharp(cur.ChildByFieldName("parameters")) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "lambda_expression":
+				for _, child := range children(cur) {
+					switch child.Type() {
+					case "identifier":
+						// x => ...
+						if child.Content(node.Contents) == ident {
+							return swapNodePtr(node, child), nil
+						}
+					case "parameter_list":
+						for _, binding := range getParameterBindingsCSharp(child) {
+							if binding.Content(node.Contents) == ident {
+								return swapNodePtr(node, binding), nil
+							}
+						}
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, binding := range getVariableBindingsCSharp(cur.ChildByFieldName("initializer")) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "for_each_statement":
+				left := cur.ChildByFieldName("left")
+				if left != nil && left.Content(node.Contents) == ident {
+					return swapNodePtr(node, left), nil
+				}
+				continue
+
+			case "using_statement":
+				for _, child := range children(cur) {
+					for _, binding := range getVariableBindingsCSharp(child) {
+						if binding.Content(node.Contents) == ident {
+							return swapNodePtr(node, binding), nil
+						}
+					}
+				}
+				continue
+
+			case "catch_clause":
+				for _, child := range children(cur) {
+					if child.Type() != "catch_declaration" {
+						continue
+					}
+					name := child.ChildByFieldName("name")
+					if name != nil && name.Content(node.Contents) == ident {
+						return swapNodePtr(node, name), nil
+					}
+				}
+				continue
+
+			case "class_declaration":
+				fallthrough
+			case "struct_declaration":
+				fallthrough
+			case "interface_declaration":
+				fallthrough
+			case "record_declaration":
+				fallthrough
+			case "enum_declaration":
+				name := cur.ChildByFieldName("name")
+				if name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				for _, binding := range getTypeParameterBindingsCSharp(cur) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				bases := cur.ChildByFieldName("bases")
+				if bases != nil && nodeId(bases) == nodeId(prev) {
+					// Base types are resolved outside of the class
+					continue
+				}
+				found, err := squirrel.lookupFieldCSharp(ctx, ClassTypeCSharp{def: swapNode(node, cur)}, ident)
+				if err != nil {
+					return nil, err
+				}
+				if found != nil {
+					return found, nil
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "this_expression":
+		class := getEnclosingTypeDeclarationCSharp(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		name := class.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, name), nil
+
+	case "base_expression":
+		class := getEnclosingTypeDeclarationCSharp(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		bases := getBasesCSharp(swapNode(node, class))
+		if len(bases) == 0 {
+			return nil, nil
+		}
+		name := getTypeNameCSharp(bases[0].Node)
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getDefCSharp(ctx, swapNode(node, name))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInNamespacesCSharp looks for a type named ident in the using aliases, the enclosing namespaces,
+// the namespaces imported by using directives, and the types imported by using static directives.
+func (squirrel *SquirrelService) getDefInNamespacesCSharp(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(ident), lazyNodeStringer(&ret))()
+
+	// Collect the using directives in scope
+	namespaces := []string{}
+	statics := []Node{}
+	for _, using := range getUsingDirectivesCSharp(node.Node) {
+		target := using.NamedChild(int(using.NamedChildCount()) - 1)
+		if target == nil {
+			continue
+		}
+		alias := using.NamedChild(0)
+		if alias != nil && alias.Type() == "name_equals" {
+			// using M = Acme.Models;
+			name := alias.NamedChild(0)
+			if name != nil && name.Content(node.Contents) == ident {
+				return swapNodePtr(node, name), nil
+			}
+			continue
+		}
+		if hasChildOfType(using, "static") {
+			// using static Acme.Util.Strings;
+			statics = append(statics, swapNode(node, target))
+			continue
+		}
+		namespaces = append(namespaces, getQualifiedNameCSharp(swapNode(node, target)))
+	}
+
+	// The enclosing namespaces take precedence over the imported ones, innermost first
+	enclosing := []string{}
+	components := strings.Split(getNamespaceCSharp(node), ".")
+	for i := len(components); i > 0; i-- {
+		enclosing = append(enclosing, strings.Join(components[:i], "."))
+	}
+	namespaces = append(enclosing, namespaces...)
+	if enclosing[len(enclosing)-1] != "" {
+		// The global namespace
+		namespaces = append(namespaces, "")
+	}
+
+	results, err := squirrel.symbolSearchCSharp(ctx, node, ident)
+	if err != nil {
+		return nil, err
+	}
+	for _, namespace := range namespaces {
+		for _, result := range results {
+			if getNamespaceCSharp(result) == namespace {
+				return &result, nil
+			}
+		}
+	}
+
+	for _, static := range statics {
+		found, err := squirrel.getFieldCSharp(ctx, static, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findInNamespaceCSharp searches for a type named ident declared directly in the given namespace.
+func (squirrel *SquirrelService) findInNamespaceCSharp(ctx context.Context, node Node, namespace string, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(namespace), String(ident)}, lazyNodeStringer(&ret))()
+
+	results, err := squirrel.symbolSearchCSharp(ctx, node, ident)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if getNamespaceCSharp(result) == namespace {
+			return &result, nil
+		}
+	}
+	return nil, nil
+}
+
+// symbolSearchCSharp returns the top-level types named ident in the C# files of the repository.
+func (squirrel *SquirrelService) symbolSearchCSharp(ctx context.Context, node Node, ident string) ([]Node, error) {
+	results, err := squirrel.symbolSearchAll(ctx, node.RepoCommitPath.Repo, node.RepoCommitPath.Commit, []string{`\.cs$`}, ident, maxSymbolSearchResultsCSharp)
+	if err != nil {
+		return nil, err
+	}
+	csharpResults := []Node{}
+	for _, result := range results {
+		if result.LangSpec.name == "csharp" {
+			csharpResults = append(csharpResults, result)
+		}
+	}
+	return csharpResults, nil
+}
+
+func (squirrel *SquirrelService) getFieldCSharp(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefCSharp(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldCSharp(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldCSharp(ctx context.Context, ty TypeCSharp, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ClassTypeCSharp:
+		// record Point(int X, int Y);
+		for _, binding := range getParameterBindingsCSharp(ty2.def.ChildByFieldName("parameters")) {
+			if binding.Content(ty2.def.Contents) == field {
+				return swapNodePtr(ty2.def, binding), nil
+			}
+		}
+		for _, child := range children(ty2.def.ChildByFieldName("body")) {
+			switch child.Type() {
+			case "field_declaration":
+				fallthrough
+			case "event_field_declaration":
+				for _, declaration := range children(child) {
+					for _, binding := range getVariableBindingsCSharp(declaration) {
+						if binding.Content(ty2.def.Contents) == field {
+							return swapNodePtr(ty2.def, binding), nil
+						}
+					}
+				}
+			case "method_declaration":
+				fallthrough
+			case "property_declaration":
+				fallthrough
+			case "event_declaration":
+				fallthrough
+			case "delegate_declaration":
+				fallthrough
+			case "enum_member_declaration":
+				name := child.ChildByFieldName("name")
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			default:
+				name := getTypeDeclarationNameCSharp(child)
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, base := range getBasesCSharp(ty2.def) {
+			found, err := squirrel.getFieldCSharp(ctx, base, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case NamespaceTypeCSharp:
+		return squirrel.findInNamespaceCSharp(ctx, ty2.noad, ty2.name, field)
+	case FnTypeCSharp:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldCSharp: unexpected object type %s", ty.variant()))
+		return nil, nil
+	case PrimTypeCSharp:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldCSharp: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldCSharp: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getTypeDefCSharp(ctx context.Context, node Node) (ret TypeCSharp, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeCSharpStringer(&ret))()
+
+	// getMember returns the type of the given member of the object, or the nested namespace if the
+	// object is a namespace without such a type.
+	getMember := func(object *sitter.Node, member *sitter.Node) (TypeCSharp, error) {
+		if object == nil || member == nil {
+			return nil, nil
+		}
+		name := getTypeNameCSharp(member)
+		if name == nil {
+			return nil, nil
+		}
+		objectType, err := squirrel.getTypeDefCSharp(ctx, swapNode(node, object))
+		if err != nil {
+			return nil, err
+		}
+		if objectType == nil {
+			return nil, nil
+		}
+		found, err := squirrel.lookupFieldCSharp(ctx, objectType, name.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			if namespace, ok := objectType.(NamespaceTypeCSharp); ok {
+				return (TypeCSharp)(NamespaceTypeCSharp{
+					name: namespace.name + "." + name.Content(node.Contents),
+					noad: node,
+				}), nil
+			}
+			return nil, nil
+		}
+		return squirrel.defToTypeCSharp(ctx, *found)
+	}
+
+	switch node.Type() {
+	case "identifier":
+		found, err := squirrel.getDefCSharp(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			// Identifiers without a definition might be namespaces, as in System.Console
+			return (TypeCSharp)(NamespaceTypeCSharp{
+				name: node.Content(node.Contents),
+				noad: node,
+			}), nil
+		}
+		return squirrel.defToTypeCSharp(ctx, *found)
+	case "this_expression":
+		fallthrough
+	case "base_expression":
+		found, err := squirrel.getDefCSharp(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeCSharp(ctx, *found)
+	case "generic_name":
+		fallthrough
+	case "nullable_type":
+		fallthrough
+	case "parenthesized_expression":
+		child := node.NamedChild(0)
+		if child == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCSharp(ctx, swapNode(node, child))
+	case "qualified_name":
+		return getMember(node.NamedChild(0), node.NamedChild(int(node.NamedChildCount())-1))
+	case "member_access_expression":
+		return getMember(node.ChildByFieldName("expression"), node.ChildByFieldName("name"))
+	case "conditional_access_expression":
+		// x?.y
+		binding := node.NamedChild(int(node.NamedChildCount()) - 1)
+		if binding == nil || binding.Type() != "member_binding_expression" {
+			return nil, nil
+		}
+		return getMember(node.ChildByFieldName("condition"), binding.ChildByFieldName("name"))
+	case "object_creation_expression":
+		fallthrough
+	case "cast_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCSharp(ctx, swapNode(node, ty))
+	case "invocation_expression":
+		function := node.ChildByFieldName("function")
+		if function == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefCSharp(ctx, swapNode(node, function))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeCSharp:
+			return ty2.ret, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefCSharp: expected method, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "predefined_type":
+		return PrimTypeCSharp{noad: node, varient: node.Content(node.Contents)}, nil
+	case "void_keyword":
+		return PrimTypeCSharp{noad: node, varient: "void"}, nil
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefCSharp: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) defToTypeCSharp(ctx context.Context, def Node) (TypeCSharp, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+	switch parent.Type() {
+	case "class_declaration":
+		fallthrough
+	case "struct_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "record_declaration":
+		fallthrough
+	case "enum_declaration":
+		return (TypeCSharp)(ClassTypeCSharp{def: swapNode(def, parent)}), nil
+	case "method_declaration":
+		fallthrough
+	case "local_function_statement":
+		retTyNode := parent.ChildByFieldName("type")
+		if retTyNode == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToType: could not find return type")
+			return (TypeCSharp)(FnTypeCSharp{
+				ret:  nil,
+				noad: swapNode(def, parent),
+			}), nil
+		}
+		retTy, err := squirrel.getTypeDefCSharp(ctx, swapNode(def, retTyNode))
+		if err != nil {
+			return nil, err
+		}
+		return (TypeCSharp)(FnTypeCSharp{
+			ret:  retTy,
+			noad: swapNode(def, parent),
+		}), nil
+	case "property_declaration":
+		fallthrough
+	case "parameter":
+		fallthrough
+	case "catch_declaration":
+		fallthrough
+	case "for_each_statement":
+		tyNode := parent.ChildByFieldName("type")
+		if tyNode == nil || tyNode.Type() == "implicit_type" {
+			squirrel.breadcrumb(swapNode(def, parent), "defToType: could not find type")
+			return nil, nil
+		}
+		return squirrel.getTypeDefCSharp(ctx, swapNode(def, tyNode))
+	case "variable_declarator":
+		declaration := parent.Parent()
+		if declaration == nil {
+			return nil, nil
+		}
+		tyNode := declaration.ChildByFieldName("type")
+		if tyNode == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToType: could not find type")
+			return nil, nil
+		}
+		if tyNode.Type() != "implicit_type" {
+			return squirrel.getTypeDefCSharp(ctx, swapNode(def, tyNode))
+		}
+		// var x = ...
+		for _, child := range children(parent) {
+			if child.Type() == "equals_value_clause" && child.NamedChildCount() > 0 {
+				return squirrel.getTypeDefCSharp(ctx, swapNode(def, child.NamedChild(0)))
+			}
+		}
+		return nil, nil
+	case "name_equals":
+		// using M = Acme.Models;
+		using := parent.Parent()
+		if using == nil {
+			return nil, nil
+		}
+		target := using.NamedChild(int(using.NamedChildCount()) - 1)
+		if target == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefCSharp(ctx, swapNode(def, target))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getTypeDeclarationNameCSharp returns the name of the given type declaration, or nil if it isn't one.
+func getTypeDeclarationNameCSharp(node *sitter.Node) *sitter.Node {
+	switch node.Type() {
+	case "class_declaration":
+		fallthrough
+	case "struct_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "record_declaration":
+		fallthrough
+	case "enum_declaration":
+		return node.ChildByFieldName("name")
+	default:
+		return nil
+	}
+}
+
+func getEnclosingTypeDeclarationCSharp(node *sitter.Node) *sitter.Node {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		if getTypeDeclarationNameCSharp(cur) != nil {
+			return cur
+		}
+	}
+	return nil
+}
+
+// getVariableBindingsCSharp returns the names declared by the given variable_declaration.
+func getVariableBindingsCSharp(declaration *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	if declaration == nil || declaration.Type() != "variable_declaration" {
+		return bindings
+	}
+	for _, declarator := range children(declaration) {
+		if declarator.Type() != "variable_declarator" {
+			continue
+		}
+		name := declarator.NamedChild(0)
+		if name != nil && name.Type() == "identifier" {
+			bindings = append(bindings, name)
+		}
+	}
+	return bindings
+}
+
+// getParameterBindingsCSharp returns the names declared by the given parameter_list.
+func getParameterBindingsCSharp(parameters *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	for _, parameter := range children(parameters) {
+		if parameter.Type() != "parameter" {
+			continue
+		}
+		name := parameter.ChildByFieldName("name")
+		if name != nil {
+			bindings = append(bindings, name)
+		}
+	}
+	return bindings
+}
+
+func getTypeParameterBindingsCSharp(node *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	for _, typeParameter := range children(node.ChildByFieldName("type_parameters")) {
+		name := typeParameter.NamedChild(int(typeParameter.NamedChildCount()) - 1)
+		if name != nil && name.Type() == "identifier" {
+			bindings = append(bindings, name)
+		}
+	}
+	return bindings
+}
+
+func getBasesCSharp(declaration Node) []Node {
+	bases := []Node{}
+	for _, base := range children(declaration.ChildByFieldName("bases")) {
+		bases = append(bases, swapNode(declaration, base))
+	}
+	return bases
+}
+
+// getTypeNameCSharp returns the identifier that names the given type, as in List<T> or Acme.Models.User.
+func getTypeNameCSharp(ty *sitter.Node) *sitter.Node {
+	switch ty.Type() {
+	case "identifier":
+		return ty
+	case "generic_name":
+		return ty.NamedChild(0)
+	case "qualified_name":
+		return getTypeNameCSharp(ty.NamedChild(int(ty.NamedChildCount()) - 1))
+	default:
+		return nil
+	}
+}
+
+// getUsingDirectivesCSharp returns the using directives in scope at the given node, innermost first.
+func getUsingDirectivesCSharp(node *sitter.Node) []*sitter.Node {
+	usings := []*sitter.Node{}
+	for cur := node; cur != nil; cur = cur.Parent() {
+		scope := cur
+		if cur.Type() == "namespace_declaration" {
+			scope = cur.ChildByFieldName("body")
+		} else if cur.Type() != "compilation_unit" {
+			continue
+		}
+		for _, child := range children(scope) {
+			if child.Type() == "using_directive" {
+				usings = append(usings, child)
+			}
+		}
+	}
+	return usings
+}
+
+// getNamespaceCSharp returns the fully qualified name of the namespace that encloses the given node, or
+// the empty string for the global namespace.
+func getNamespaceCSharp(node Node) string {
+	components := []string{}
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		if cur.Type() != "namespace_declaration" {
+			continue
+		}
+		name := cur.ChildByFieldName("name")
+		if name == nil {
+			continue
+		}
+		components = append([]string{getQualifiedNameCSharp(swapNode(node, name))}, components...)
+	}
+	return strings.Join(components, ".")
+}
+
+// getQualifiedNameCSharp returns the given name without any whitespace, as in Acme.Models.
+func getQualifiedNameCSharp(name Node) string {
+	return strings.Join(strings.Fields(name.Content(name.Contents)), "")
+}
+
+type TypeCSharp interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeCSharp struct {
+	ret  TypeCSharp
+	noad Node
+}
+
+func (t FnTypeCSharp) variant() string {
+	return "fn"
+}
+
+func (t FnTypeCSharp) node() Node {
+	return t.noad
+}
+
+type ClassTypeCSharp struct {
+	def Node
+}
+
+func (t ClassTypeCSharp) variant() string {
+	return "class"
+}
+
+func (t ClassTypeCSharp) node() Node {
+	return t.def
+}
+
+type NamespaceTypeCSharp struct {
+	name string
+	noad Node
+}
+
+func (t NamespaceTypeCSharp) variant() string {
+	return fmt.Sprintf("namespace:%s", t.name)
+}
+
+func (t NamespaceTypeCSharp) node() Node {
+	return t.noad
+}
+
+type PrimTypeCSharp struct {
+	noad    Node
+	varient string
+}
+
+func (t PrimTypeCSharp) variant() string {
+	return fmt.Sprintf("prim:%s", t.varient)
+}
+
+func (t PrimTypeCSharp) node() Node {
+	return t.noad
+}
+
+func lazyTypeCSharpStringer(ty *TypeCSharp) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -0,0 +1,897 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// The maximum number of symbols to inspect when searching for a package-level declaration or a method.
+const maxSymbolSearchResultsGo = 100
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "package_identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefGo: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "source_file":
+				return squirrel.getDefInFileOrPackageGo(ctx, swapNode(node, cur), ident)
+
+			// Check for package-qualified types
+			case "qualified_type":
+				pkg := cur.ChildByFieldName("package")
+				if pkg == nil || nodeId(pkg) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldGo(ctx, swapNode(node, pkg), ident)
+
+			// Check nodes that might have bindings:
+			case "block":
+				fallthrough
+			case "expression_case":
+				fallthrough
+			case "default_case":
+				fallthrough
+			case "type_case":
+				fallthrough
+			case "communication_case":
+				for sibling := prev.PrevNamedSibling(); sibling != nil; sibling = sibling.PrevNamedSibling() {
+					for _, name := range declaredNamesGo(sibling, node.Contents) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "function_declaration":
+				fallthrough
+			case "method_declaration":
+				fallthrough
+			case "func_literal":
+				for _, field := range []string{"receiver", "parameters", "result"} {
+					params := cur.ChildByFieldName(field)
+					if params == nil {
+						continue
+					}
+					for _, name := range declaredNamesGo(params, node.Contents) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "for_statement":
+				for _, child := range children(cur) {
+					var decl *sitter.Node
+					switch child.Type() {
+					case "for_clause":
+						decl = child.ChildByFieldName("initializer")
+					case "range_clause":
+						decl = child
+					}
+					if decl == nil {
+						continue
+					}
+					for _, name := range declaredNamesGo(decl, node.Contents) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			case "if_statement":
+				fallthrough
+			case "expression_switch_statement":
+				fallthrough
+			case "type_switch_statement":
+				for _, field := range []string{"initializer", "alias"} {
+					decl := cur.ChildByFieldName(field)
+					if decl == nil {
+						continue
+					}
+					for _, name := range declaredNamesGo(decl, node.Contents) {
+						if name.Content(node.Contents) == ident {
+							return swapNodePtr(node, name), nil
+						}
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "field_identifier":
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		switch parent.Type() {
+		case "selector_expression":
+			operand := parent.ChildByFieldName("operand")
+			if operand == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldGo(ctx, swapNode(node, operand), node.Content(node.Contents))
+
+		case "keyed_element":
+			// Check for the keys of struct literals like T{Field: ...}
+			if nodeId(parent.NamedChild(0)) != nodeId(node.Node) {
+				return nil, nil
+			}
+			literalValue := parent.Parent()
+			if literalValue == nil || literalValue.Type() != "literal_value" {
+				return nil, nil
+			}
+			compositeLiteral := literalValue.Parent()
+			if compositeLiteral == nil || compositeLiteral.Type() != "composite_literal" {
+				return nil, nil
+			}
+			ty := compositeLiteral.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			return squirrel.getFieldGo(ctx, swapNode(node, ty), node.Content(node.Contents))
+
+		default:
+			return nil, nil
+		}
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getDefInFileOrPackageGo(ctx context.Context, file Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(file, &Tuple{String(file.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	// Check declarations in the current file (faster) before running symbol searches (slower)
+	for _, child := range children(file.Node) {
+		for _, name := range declaredNamesGo(child, file.Contents) {
+			if name.Content(file.Contents) == ident {
+				return swapNodePtr(file, name), nil
+			}
+		}
+	}
+
+	// Check imports
+	dotImports := []Node{}
+	for _, spec := range getImportSpecsGo(file) {
+		name := getImportNameGo(spec)
+		if name == "." {
+			dotImports = append(dotImports, spec)
+			continue
+		}
+		if name == ident {
+			nameNode := spec.ChildByFieldName("name")
+			if nameNode != nil {
+				return swapNodePtr(spec, nameNode), nil
+			}
+			return swapNodePtr(spec, spec.ChildByFieldName("path")), nil
+		}
+	}
+
+	// Search in the current package
+	found, err := squirrel.findInPackageGo(ctx, file, []string{getPackagePatternGo(filepath.Dir(file.RepoCommitPath.Path))}, ident, "")
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Search in packages imported with a dot
+	for _, spec := range dotImports {
+		found, err := squirrel.findInImportGo(ctx, spec, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findInImportGo finds the package-level declaration of ident in the package imported by the given
+// import_spec.
+func (squirrel *SquirrelService) findInImportGo(ctx context.Context, spec Node, ident string) (*Node, error) {
+	// Import paths don't say where the package lives in the repository, so try the longest suffix of
+	// the import path that names a directory containing the declaration.
+	components := strings.Split(getImportPathGo(spec), "/")
+	for i := range components {
+		suffix := strings.Join(components[i:], "/")
+		pattern := fmt.Sprintf("^(.*/)?%s/[^/]+\\.go$", regexp.QuoteMeta(suffix))
+		found, err := squirrel.findInPackageGo(ctx, spec, []string{pattern}, ident, "")
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+	return nil, nil
+}
+
+// findInPackageGo searches the files matching the given include patterns for a package-level
+// declaration of ident, or for a method named ident on the given receiver type if it's not empty.
+func (squirrel *SquirrelService) findInPackageGo(ctx context.Context, node Node, include []string, ident string, receiver string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(ident), String(receiver)}, lazyNodeStringer(&ret))()
+
+	results, err := squirrel.symbolSearchAll(ctx, node.RepoCommitPath.Repo, node.RepoCommitPath.Commit, include, ident, maxSymbolSearchResultsGo)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.LangSpec.name != "go" {
+			continue
+		}
+		parent := result.Parent()
+		if parent == nil {
+			continue
+		}
+		isMethod := parent.Type() == "method_declaration"
+		if receiver == "" && !isMethod {
+			return &result, nil
+		}
+		if receiver != "" && isMethod && getReceiverTypeNameGo(swapNode(result, parent)) == receiver {
+			return &result, nil
+		}
+	}
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldGo(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefGo(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldGo(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldGo(ctx context.Context, ty TypeGo, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case PkgTypeGo:
+		return squirrel.findInImportGo(ctx, ty2.spec, field)
+	case NamedTypeGo:
+		name := ty2.def.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		typeName := name.Content(ty2.def.Contents)
+
+		// Check fields and interface methods
+		underlying := ty2.def.ChildByFieldName("type")
+		if underlying != nil {
+			found, err := squirrel.lookupMemberGo(ctx, swapNode(ty2.def, underlying), field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		// Check methods in the file that declares the type (faster) before searching the package (slower)
+		file := swapNode(ty2.def, getRoot(ty2.def.Node))
+		for _, child := range children(file.Node) {
+			if child.Type() != "method_declaration" {
+				continue
+			}
+			methodName := child.ChildByFieldName("name")
+			if methodName == nil || methodName.Content(file.Contents) != field {
+				continue
+			}
+			if getReceiverTypeNameGo(swapNode(file, child)) == typeName {
+				return swapNodePtr(file, methodName), nil
+			}
+		}
+		return squirrel.findInPackageGo(ctx, file, []string{getPackagePatternGo(filepath.Dir(file.RepoCommitPath.Path))}, field, typeName)
+	case FnTypeGo:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldGo: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+// lookupMemberGo finds the field or interface method named field in the given type expression, including
+// the members of embedded types.
+func (squirrel *SquirrelService) lookupMemberGo(ctx context.Context, ty Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty, &Tuple{String(ty.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty.Type() {
+	case "struct_type":
+		embedded := []Node{}
+		for _, list := range children(ty.Node) {
+			if list.Type() != "field_declaration_list" {
+				continue
+			}
+			for _, decl := range children(list) {
+				if decl.Type() != "field_declaration" {
+					continue
+				}
+				named := false
+				for _, child := range children(decl) {
+					if child.Type() != "field_identifier" {
+						continue
+					}
+					named = true
+					if child.Content(ty.Contents) == field {
+						return swapNodePtr(ty, child), nil
+					}
+				}
+				if !named {
+					embeddedType := decl.ChildByFieldName("type")
+					if embeddedType == nil {
+						continue
+					}
+					if getTypeNameGo(swapNode(ty, embeddedType)) == field {
+						return swapNodePtr(ty, embeddedType), nil
+					}
+					embedded = append(embedded, swapNode(ty, embeddedType))
+				}
+			}
+		}
+		for _, embeddedType := range embedded {
+			found, err := squirrel.getFieldGo(ctx, embeddedType, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case "interface_type":
+		embedded := []Node{}
+		for _, list := range children(ty.Node) {
+			if list.Type() != "method_spec_list" {
+				continue
+			}
+			for _, spec := range children(list) {
+				switch spec.Type() {
+				case "method_spec":
+					name := spec.ChildByFieldName("name")
+					if name != nil && name.Content(ty.Contents) == field {
+						return swapNodePtr(ty, name), nil
+					}
+				case "type_identifier":
+					fallthrough
+				case "qualified_type":
+					embedded = append(embedded, swapNode(ty, spec))
+				}
+			}
+		}
+		for _, embeddedType := range embedded {
+			found, err := squirrel.getFieldGo(ctx, embeddedType, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case "type_identifier":
+		fallthrough
+	case "qualified_type":
+		// A type defined in terms of another type has the fields (but not the methods) of that type
+		underlying, err := squirrel.getTypeDefGo(ctx, ty)
+		if err != nil {
+			return nil, err
+		}
+		named, ok := underlying.(NamedTypeGo)
+		if !ok {
+			return nil, nil
+		}
+		underlyingType := named.def.ChildByFieldName("type")
+		if underlyingType == nil {
+			return nil, nil
+		}
+		return squirrel.lookupMemberGo(ctx, swapNode(named.def, underlyingType), field)
+	default:
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getTypeDefGo(ctx context.Context, node Node) (ret TypeGo, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeGoStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "field_identifier":
+		fallthrough
+	case "package_identifier":
+		found, err := squirrel.getDefGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "pointer_type":
+		fallthrough
+	case "parenthesized_type":
+		fallthrough
+	case "parenthesized_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefGo(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "unary_expression":
+		operator := node.Child(0)
+		if operator == nil || (operator.Type() != "&" && operator.Type() != "*") {
+			return nil, nil
+		}
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, operand))
+	case "qualified_type":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, name))
+	case "generic_type":
+		fallthrough
+	case "composite_literal":
+		fallthrough
+	case "type_assertion_expression":
+		ty := node.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(node, ty))
+	case "selector_expression":
+		operand := node.ChildByFieldName("operand")
+		if operand == nil {
+			return nil, nil
+		}
+		field := node.ChildByFieldName("field")
+		if field == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldGo(ctx, swapNode(node, operand), field.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeGo(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefGo(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeGo:
+			return squirrel.getResultTypeGo(ctx, ty2, 0)
+		case NamedTypeGo:
+			// Conversions like T(x)
+			return ty2, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefGo: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefGo: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+// getResultTypeGo returns the type of the i-th result of the given function.
+func (squirrel *SquirrelService) getResultTypeGo(ctx context.Context, fn FnTypeGo, i int) (TypeGo, error) {
+	result := fn.def.ChildByFieldName("result")
+	if result == nil {
+		return nil, nil
+	}
+	if result.Type() != "parameter_list" {
+		if i != 0 {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(fn.def, result))
+	}
+
+	// Each result declaration like (a, b int) declares one result per name, or one if it's unnamed
+	for _, decl := range children(result) {
+		count := len(declaredNamesGo(decl, fn.def.Contents))
+		if count == 0 {
+			count = 1
+		}
+		if i < count {
+			ty := decl.ChildByFieldName("type")
+			if ty == nil {
+				return nil, nil
+			}
+			return squirrel.getTypeDefGo(ctx, swapNode(fn.def, ty))
+		}
+		i -= count
+	}
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) defToTypeGo(ctx context.Context, def Node) (TypeGo, error) {
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "import_spec":
+		return (TypeGo)(PkgTypeGo{spec: swapNode(def, parent)}), nil
+	case "type_spec":
+		return (TypeGo)(NamedTypeGo{def: swapNode(def, parent)}), nil
+	case "type_alias":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "function_declaration":
+		fallthrough
+	case "method_declaration":
+		fallthrough
+	case "method_spec":
+		return (TypeGo)(FnTypeGo{def: swapNode(def, parent)}), nil
+	case "parameter_declaration":
+		fallthrough
+	case "field_declaration":
+		ty := parent.ChildByFieldName("type")
+		if ty == nil {
+			squirrel.breadcrumb(swapNode(def, parent), "defToTypeGo: could not find type")
+			return nil, nil
+		}
+		if nodeId(ty) == nodeId(def.Node) {
+			// An embedded field, which is named after its type
+			return squirrel.getTypeDefGo(ctx, def)
+		}
+		return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefGo(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getAssignedTypeGo(ctx, swapNode(def, value), indexOfNameGo(parent, def.Node, def.Contents))
+	case "expression_list":
+		decl := parent.Parent()
+		if decl == nil || decl.Type() != "short_var_declaration" {
+			return nil, nil
+		}
+		right := decl.ChildByFieldName("right")
+		if right == nil {
+			return nil, nil
+		}
+		return squirrel.getAssignedTypeGo(ctx, swapNode(def, right), indexOfNameGo(parent, def.Node, def.Contents))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getAssignedTypeGo returns the type of the i-th value assigned by the given expression list, which
+// either has one expression per name or a single call that returns multiple results.
+func (squirrel *SquirrelService) getAssignedTypeGo(ctx context.Context, values Node, i int) (TypeGo, error) {
+	if i < 0 {
+		return nil, nil
+	}
+	exprs := children(values.Node)
+	if i < len(exprs) && (len(exprs) > 1 || i == 0) {
+		return squirrel.getTypeDefGo(ctx, swapNode(values, exprs[i]))
+	}
+	if len(exprs) != 1 || exprs[0].Type() != "call_expression" {
+		return nil, nil
+	}
+	fn := exprs[0].ChildByFieldName("function")
+	if fn == nil {
+		return nil, nil
+	}
+	ty, err := squirrel.getTypeDefGo(ctx, swapNode(values, fn))
+	if err != nil {
+		return nil, err
+	}
+	fnTy, ok := ty.(FnTypeGo)
+	if !ok {
+		return nil, nil
+	}
+	return squirrel.getResultTypeGo(ctx, fnTy, i)
+}
+
+// declaredNamesGo returns the identifiers declared by the given statement, declaration, or parameter
+// list.
+func declaredNamesGo(node *sitter.Node, contents []byte) []*sitter.Node {
+	names := []*sitter.Node{}
+	switch node.Type() {
+	case "short_var_declaration":
+		left := node.ChildByFieldName("left")
+		if left == nil {
+			return names
+		}
+		for _, child := range children(left) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	case "range_clause":
+		fallthrough
+	case "receive_statement":
+		// Only `for x := range ...` and `case x := <-ch:` declare variables
+		if !hasChildOfType(node, ":=") {
+			return names
+		}
+		left := node.ChildByFieldName("left")
+		if left == nil {
+			return names
+		}
+		for _, child := range children(left) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	case "expression_list":
+		// The alias of a type switch like `switch x := y.(type)`
+		for _, child := range children(node) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	case "var_declaration":
+		fallthrough
+	case "const_declaration":
+		fallthrough
+	case "var_spec_list":
+		for _, child := range children(node) {
+			names = append(names, declaredNamesGo(child, contents)...)
+		}
+	case "var_spec":
+		fallthrough
+	case "const_spec":
+		fallthrough
+	case "parameter_declaration":
+		fallthrough
+	case "variadic_parameter_declaration":
+		for _, child := range children(node) {
+			if child.Type() == "identifier" {
+				names = append(names, child)
+			}
+		}
+	case "parameter_list":
+		for _, child := range children(node) {
+			names = append(names, declaredNamesGo(child, contents)...)
+		}
+	case "type_declaration":
+		for _, spec := range children(node) {
+			name := spec.ChildByFieldName("name")
+			if name != nil {
+				names = append(names, name)
+			}
+		}
+	case "function_declaration":
+		name := node.ChildByFieldName("name")
+		if name != nil {
+			names = append(names, name)
+		}
+	case "labeled_statement":
+		for _, child := range children(node) {
+			if child.Type() != "label_name" {
+				names = append(names, declaredNamesGo(child, contents)...)
+			}
+		}
+	}
+	return names
+}
+
+// indexOfNameGo returns the position of the given name among the names declared by the given node.
+func indexOfNameGo(decl *sitter.Node, name *sitter.Node, contents []byte) int {
+	for i, other := range declaredNamesGo(decl, contents) {
+		if nodeId(other) == nodeId(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func getImportSpecsGo(file Node) []Node {
+	specs := []Node{}
+	for _, decl := range children(file.Node) {
+		if decl.Type() != "import_declaration" {
+			continue
+		}
+		for _, child := range children(decl) {
+			switch child.Type() {
+			case "import_spec":
+				specs = append(specs, swapNode(file, child))
+			case "import_spec_list":
+				for _, spec := range children(child) {
+					if spec.Type() == "import_spec" {
+						specs = append(specs, swapNode(file, spec))
+					}
+				}
+			}
+		}
+	}
+	return specs
+}
+
+func getImportPathGo(spec Node) string {
+	path := spec.ChildByFieldName("path")
+	if path == nil {
+		return ""
+	}
+	return strings.Trim(path.Content(spec.Contents), "\"`")
+}
+
+var majorVersionRegexGo = regexp.MustCompile(`^v[0-9]+$`)
+
+// getImportNameGo returns the name that the given import_spec binds, which is the explicit name if
+// there is one or the conventional package name otherwise.
+func getImportNameGo(spec Node) string {
+	name := spec.ChildByFieldName("name")
+	if name != nil {
+		return name.Content(spec.Contents)
+	}
+	components := strings.Split(getImportPathGo(spec), "/")
+	last := components[len(components)-1]
+	if majorVersionRegexGo.MatchString(last) && len(components) > 1 {
+		last = components[len(components)-2]
+	}
+	return last
+}
+
+// getReceiverTypeNameGo returns the name of the receiver type of the given method_declaration.
+func getReceiverTypeNameGo(method Node) string {
+	receiver := method.ChildByFieldName("receiver")
+	if receiver == nil {
+		return ""
+	}
+	for _, param := range children(receiver) {
+		ty := param.ChildByFieldName("type")
+		if ty == nil {
+			continue
+		}
+		return getTypeNameGo(swapNode(method, ty))
+	}
+	return ""
+}
+
+// getTypeNameGo returns the unqualified name of the given type expression, ignoring pointers and type
+// arguments.
+func getTypeNameGo(ty Node) string {
+	switch ty.Type() {
+	case "type_identifier":
+		return ty.Content(ty.Contents)
+	case "pointer_type":
+		for _, child := range children(ty.Node) {
+			return getTypeNameGo(swapNode(ty, child))
+		}
+	case "qualified_type":
+		fallthrough
+	case "generic_type":
+		field := "name"
+		if ty.Type() == "generic_type" {
+			field = "type"
+		}
+		child := ty.ChildByFieldName(field)
+		if child != nil {
+			return getTypeNameGo(swapNode(ty, child))
+		}
+	}
+	return ""
+}
+
+// getPackagePatternGo returns an include pattern matching the Go files in the given directory.
+func getPackagePatternGo(dir string) string {
+	if dir == "." {
+		return "^[^/]+\\.go$"
+	}
+	return fmt.Sprintf("^%s/[^/]+\\.go$", regexp.QuoteMeta(dir))
+}
+
+type TypeGo interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeGo struct {
+	def Node
+}
+
+func (t FnTypeGo) variant() string {
+	return "fn"
+}
+
+func (t FnTypeGo) node() Node {
+	return t.def
+}
+
+type NamedTypeGo struct {
+	def Node
+}
+
+func (t NamedTypeGo) variant() string {
+	return "named"
+}
+
+func (t NamedTypeGo) node() Node {
+	return t.def
+}
+
+type PkgTypeGo struct {
+	spec Node
+}
+
+func (t PkgTypeGo) variant() string {
+	return "pkg"
+}
+
+func (t PkgTypeGo) node() Node {
+	return t.spec
+}
+
+func lazyTypeGoStringer(ty *TypeGo) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -0,0 +1,934 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		ident := node.Content(node.Contents)
+
+		cur := node.Node
+
+		for {
+			prev := cur
+			cur = cur.Parent()
+			if cur == nil {
+				squirrel.breadcrumb(node, "getDefTypeScript: ran out of parents")
+				return nil, nil
+			}
+
+			switch cur.Type() {
+
+			case "program":
+				found := findNodeInScopeTypeScript(swapNode(node, cur), ident)
+				if found == nil {
+					return nil, nil
+				}
+				return squirrel.followImportTypeScript(ctx, *found)
+
+			// Check for the names in import { foo as bar } and export { foo as bar }
+			case "import_specifier":
+				return squirrel.followImportTypeScript(ctx, node)
+
+			case "export_specifier":
+				name := cur.ChildByFieldName("name")
+				if name == nil || nodeId(name) != nodeId(node.Node) {
+					return nil, nil
+				}
+				statement := cur.Parent()
+				if statement != nil {
+					statement = statement.Parent()
+				}
+				if statement == nil || getSourceTypeScript(statement) == nil {
+					continue
+				}
+				module := squirrel.resolveModuleTypeScript(ctx, swapNode(node, statement))
+				if module == nil {
+					return nil, nil
+				}
+				return squirrel.findExportTypeScript(ctx, *module, ident)
+
+			// Check for namespace-qualified types
+			case "nested_type_identifier":
+				module := cur.ChildByFieldName("module")
+				if module == nil || nodeId(module) == nodeId(prev) {
+					continue
+				}
+				return squirrel.getFieldTypeScript(ctx, swapNode(node, module), ident)
+
+			// Check nodes that might have bindings:
+			case "statement_block":
+				found := findNodeInScopeTypeScript(swapNode(node, cur), ident)
+				if found != nil {
+					return found, nil
+				}
+				continue
+
+			case "function_declaration":
+				fallthrough
+			case "generator_function_declaration":
+				fallthrough
+			case "function":
+				fallthrough
+			case "generator_function":
+				fallthrough
+			case "arrow_function":
+				fallthrough
+			case "method_definition":
+				name := cur.ChildByFieldName("name")
+				if name != nil && cur.Type() != "method_definition" && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				for _, binding := range getParameterBindingsTypeScript(cur) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "class_declaration":
+				fallthrough
+			case "abstract_class_declaration":
+				fallthrough
+			case "class":
+				fallthrough
+			case "interface_declaration":
+				fallthrough
+			case "type_alias_declaration":
+				name := cur.ChildByFieldName("name")
+				if name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+				for _, binding := range getTypeParameterBindingsTypeScript(cur) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "for_statement":
+				initializer := cur.ChildByFieldName("initializer")
+				if initializer == nil {
+					continue
+				}
+				for _, binding := range getBindingsTypeScript(initializer) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "for_in_statement":
+				left := cur.ChildByFieldName("left")
+				if left == nil {
+					continue
+				}
+				for _, binding := range getPatternBindingsTypeScript(left) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			case "catch_clause":
+				parameter := cur.ChildByFieldName("parameter")
+				if parameter == nil {
+					continue
+				}
+				for _, binding := range getPatternBindingsTypeScript(parameter) {
+					if binding.Content(node.Contents) == ident {
+						return swapNodePtr(node, binding), nil
+					}
+				}
+				continue
+
+			// Skip all other nodes
+			default:
+				continue
+			}
+		}
+
+	case "property_identifier":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "member_expression" {
+			return nil, nil
+		}
+		object := parent.ChildByFieldName("object")
+		if object == nil {
+			return nil, nil
+		}
+		return squirrel.getFieldTypeScript(ctx, swapNode(node, object), node.Content(node.Contents))
+
+	case "this":
+		class := getEnclosingClassTypeScript(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		name := class.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return swapNodePtr(node, name), nil
+
+	case "super":
+		class := getEnclosingClassTypeScript(node.Node)
+		if class == nil {
+			return nil, nil
+		}
+		super := getSuperclassTypeScript(swapNode(node, class))
+		if super == nil {
+			return nil, nil
+		}
+		return squirrel.getDefTypeScript(ctx, *super)
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+// findNodeInScopeTypeScript finds the binding named ident among the declarations and imports directly
+// inside the given program or statement block. Declarations are hoisted, so they don't need to precede
+// the usage.
+func findNodeInScopeTypeScript(block Node, ident string) *Node {
+	for _, child := range children(block.Node) {
+		for _, binding := range getBindingsTypeScript(child) {
+			if binding.Content(block.Contents) == ident {
+				return swapNodePtr(block, binding)
+			}
+		}
+	}
+	return nil
+}
+
+// followImportTypeScript returns the definition that the given binding refers to if it's bound by an
+// import statement, or the binding itself otherwise.
+func (squirrel *SquirrelService) followImportTypeScript(ctx context.Context, binding Node) (ret *Node, err error) {
+	defer squirrel.onCall(binding, String(binding.Type()), lazyNodeStringer(&ret))()
+
+	var importStatement *sitter.Node
+	for cur := binding.Parent(); cur != nil; cur = cur.Parent() {
+		if cur.Type() == "import_statement" {
+			importStatement = cur
+			break
+		}
+	}
+	if importStatement == nil {
+		return &binding, nil
+	}
+
+	module := squirrel.resolveModuleTypeScript(ctx, swapNode(binding, importStatement))
+	if module == nil {
+		// The module is not in this repository (e.g. it's in node_modules)
+		return &binding, nil
+	}
+
+	parent := binding.Parent()
+	switch parent.Type() {
+	case "namespace_import":
+		// import * as foo from "./foo"
+		return module, nil
+	case "import_clause":
+		// import foo from "./foo"
+		found, err := squirrel.findExportTypeScript(ctx, *module, "default")
+		if err != nil || found == nil {
+			return &binding, err
+		}
+		return found, nil
+	case "import_specifier":
+		// import { foo, bar as baz } from "./foo"
+		name := parent.ChildByFieldName("name")
+		if name == nil {
+			return &binding, nil
+		}
+		found, err := squirrel.findExportTypeScript(ctx, *module, name.Content(binding.Contents))
+		if err != nil || found == nil {
+			return &binding, err
+		}
+		return found, nil
+	default:
+		return &binding, nil
+	}
+}
+
+// resolveModuleTypeScript parses the module that the given import or export statement refers to, or
+// returns nil if it's not a relative path to a file in this repository.
+func (squirrel *SquirrelService) resolveModuleTypeScript(ctx context.Context, statement Node) *Node {
+	source := getSourceTypeScript(statement.Node)
+	if source == nil {
+		return nil
+	}
+	specifier := strings.Trim(source.Content(statement.Contents), "\"'`")
+	if !strings.HasPrefix(specifier, "./") && !strings.HasPrefix(specifier, "../") {
+		return nil
+	}
+
+	path := filepath.Join(filepath.Dir(statement.RepoCommitPath.Path), specifier)
+	candidates := []string{path}
+	switch filepath.Ext(path) {
+	case ".ts", ".tsx":
+	case ".js", ".jsx":
+		// TypeScript allows importing foo.ts as foo.js
+		withoutExt := strings.TrimSuffix(path, filepath.Ext(path))
+		candidates = append(candidates, withoutExt+".ts", withoutExt+".tsx")
+	default:
+		candidates = append(candidates, path+".ts", path+".tsx", path+".d.ts", path+"/index.ts", path+"/index.tsx")
+	}
+
+	for _, candidate := range candidates {
+		module, _ := squirrel.parse(ctx, types.RepoCommitPath{
+			Repo:   statement.RepoCommitPath.Repo,
+			Commit: statement.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if module != nil {
+			return module
+		}
+	}
+	return nil
+}
+
+// findExportTypeScript finds the definition that the given module exports under the given name.
+func (squirrel *SquirrelService) findExportTypeScript(ctx context.Context, module Node, name string) (ret *Node, err error) {
+	defer squirrel.onCall(module, &Tuple{String(module.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	reexports := []Node{}
+	for _, statement := range children(module.Node) {
+		if statement.Type() != "export_statement" {
+			continue
+		}
+
+		if isDefaultExportTypeScript(statement) {
+			if name != "default" {
+				continue
+			}
+			declaration := statement.ChildByFieldName("declaration")
+			if declaration == nil {
+				declaration = statement.ChildByFieldName("value")
+			}
+			if declaration == nil {
+				return nil, nil
+			}
+			if declarationName := declaration.ChildByFieldName("name"); declarationName != nil {
+				return swapNodePtr(module, declarationName), nil
+			}
+			if declaration.Type() == "identifier" {
+				return squirrel.getDefTypeScript(ctx, swapNode(module, declaration))
+			}
+			return swapNodePtr(module, declaration), nil
+		}
+
+		// export const foo = ...
+		declaration := statement.ChildByFieldName("declaration")
+		if declaration != nil {
+			for _, binding := range getBindingsTypeScript(declaration) {
+				if binding.Content(module.Contents) == name {
+					return swapNodePtr(module, binding), nil
+				}
+			}
+			continue
+		}
+
+		source := getSourceTypeScript(statement)
+		clause := (*sitter.Node)(nil)
+		for _, child := range children(statement) {
+			if child.Type() == "export_clause" {
+				clause = child
+			}
+		}
+		if clause == nil {
+			// export * from "./foo"
+			if source != nil {
+				reexports = append(reexports, swapNode(module, statement))
+			}
+			continue
+		}
+
+		// export { foo, bar as baz } (from "./foo")
+		for _, specifier := range children(clause) {
+			if specifier.Type() != "export_specifier" {
+				continue
+			}
+			local := specifier.ChildByFieldName("name")
+			if local == nil {
+				continue
+			}
+			exported := local
+			if alias := specifier.ChildByFieldName("alias"); alias != nil {
+				exported = alias
+			}
+			if exported.Content(module.Contents) != name {
+				continue
+			}
+			if source != nil {
+				other := squirrel.resolveModuleTypeScript(ctx, swapNode(module, statement))
+				if other == nil {
+					return nil, nil
+				}
+				return squirrel.findExportTypeScript(ctx, *other, local.Content(module.Contents))
+			}
+			found := findNodeInScopeTypeScript(module, local.Content(module.Contents))
+			if found == nil {
+				return nil, nil
+			}
+			return squirrel.followImportTypeScript(ctx, *found)
+		}
+	}
+
+	for _, reexport := range reexports {
+		other := squirrel.resolveModuleTypeScript(ctx, reexport)
+		if other == nil {
+			continue
+		}
+		found, err := squirrel.findExportTypeScript(ctx, *other, name)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (squirrel *SquirrelService) getFieldTypeScript(ctx context.Context, object Node, field string) (ret *Node, err error) {
+	defer squirrel.onCall(object, &Tuple{String(object.Type()), String(field)}, lazyNodeStringer(&ret))()
+
+	ty, err := squirrel.getTypeDefTypeScript(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	if ty == nil {
+		return nil, nil
+	}
+	return squirrel.lookupFieldTypeScript(ctx, ty, field)
+}
+
+func (squirrel *SquirrelService) lookupFieldTypeScript(ctx context.Context, ty TypeTypeScript, field string) (ret *Node, err error) {
+	defer squirrel.onCall(ty.node(), &Tuple{String(ty.variant()), String(field)}, lazyNodeStringer(&ret))()
+
+	switch ty2 := ty.(type) {
+	case ModuleTypeTypeScript:
+		return squirrel.findExportTypeScript(ctx, ty2.module, field)
+	case ClassTypeTypeScript:
+		body := ty2.def.ChildByFieldName("body")
+		if body == nil {
+			return nil, nil
+		}
+		for _, child := range children(body) {
+			switch child.Type() {
+			case "method_definition":
+				name := child.ChildByFieldName("name")
+				if name == nil {
+					continue
+				}
+				if name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+				if name.Content(ty2.def.Contents) == "constructor" {
+					// Parameter properties like constructor(private foo: Foo) are fields
+					parameters := child.ChildByFieldName("parameters")
+					for _, parameter := range children(parameters) {
+						if !hasChildOfType(parameter, "accessibility_modifier") && !hasChildOfType(parameter, "readonly") {
+							continue
+						}
+						for _, binding := range getPatternBindingsTypeScript(parameter) {
+							if binding.Content(ty2.def.Contents) == field {
+								return swapNodePtr(ty2.def, binding), nil
+							}
+						}
+					}
+				}
+			case "public_field_definition":
+				fallthrough
+			case "abstract_method_signature":
+				fallthrough
+			case "method_signature":
+				fallthrough
+			case "property_signature":
+				name := child.ChildByFieldName("name")
+				if name != nil && name.Content(ty2.def.Contents) == field {
+					return swapNodePtr(ty2.def, name), nil
+				}
+			}
+		}
+		for _, super := range getSupertypesTypeScript(ty2.def) {
+			found, err := squirrel.getFieldTypeScript(ctx, super, field)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+		return nil, nil
+	case FnTypeTypeScript:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unexpected object type %s", ty.variant()))
+		return nil, nil
+	default:
+		squirrel.breadcrumb(ty.node(), fmt.Sprintf("lookupFieldTypeScript: unrecognized type variant %q", ty.variant()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getTypeDefTypeScript(ctx context.Context, node Node) (ret TypeTypeScript, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyTypeTypeScriptStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		fallthrough
+	case "type_identifier":
+		fallthrough
+	case "property_identifier":
+		fallthrough
+	case "this":
+		fallthrough
+	case "super":
+		found, err := squirrel.getDefTypeScript(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypeScript(ctx, *found)
+	case "type_annotation":
+		fallthrough
+	case "parenthesized_expression":
+		fallthrough
+	case "non_null_expression":
+		for _, child := range children(node.Node) {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(node, child))
+		}
+		return nil, nil
+	case "generic_type":
+		for _, child := range children(node.Node) {
+			if child.Type() == "type_identifier" || child.Type() == "nested_type_identifier" {
+				return squirrel.getTypeDefTypeScript(ctx, swapNode(node, child))
+			}
+		}
+		return nil, nil
+	case "nested_type_identifier":
+		name := node.ChildByFieldName("name")
+		if name == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, name))
+	case "as_expression":
+		if node.NamedChildCount() < 2 {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, node.NamedChild(1)))
+	case "member_expression":
+		object := node.ChildByFieldName("object")
+		if object == nil {
+			return nil, nil
+		}
+		property := node.ChildByFieldName("property")
+		if property == nil {
+			return nil, nil
+		}
+		found, err := squirrel.getFieldTypeScript(ctx, swapNode(node, object), property.Content(node.Contents))
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, nil
+		}
+		return squirrel.defToTypeTypeScript(ctx, *found)
+	case "call_expression":
+		fn := node.ChildByFieldName("function")
+		if fn == nil {
+			return nil, nil
+		}
+		ty, err := squirrel.getTypeDefTypeScript(ctx, swapNode(node, fn))
+		if err != nil {
+			return nil, err
+		}
+		if ty == nil {
+			return nil, nil
+		}
+		switch ty2 := ty.(type) {
+		case FnTypeTypeScript:
+			return ty2.ret, nil
+		default:
+			squirrel.breadcrumb(ty.node(), fmt.Sprintf("getTypeDefTypeScript: expected function, got %q", ty.variant()))
+			return nil, nil
+		}
+	case "new_expression":
+		constructor := node.ChildByFieldName("constructor")
+		if constructor == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(node, constructor))
+	case "function":
+		fallthrough
+	case "arrow_function":
+		return squirrel.getFnTypeTypeScript(ctx, node)
+	default:
+		squirrel.breadcrumb(node, fmt.Sprintf("getTypeDefTypeScript: unrecognized node type %q", node.Type()))
+		return nil, nil
+	}
+}
+
+func (squirrel *SquirrelService) getFnTypeTypeScript(ctx context.Context, fn Node) (TypeTypeScript, error) {
+	retTyNode := fn.ChildByFieldName("return_type")
+	if retTyNode == nil {
+		return (TypeTypeScript)(FnTypeTypeScript{
+			ret:  nil,
+			noad: fn,
+		}), nil
+	}
+	retTy, err := squirrel.getTypeDefTypeScript(ctx, swapNode(fn, retTyNode))
+	if err != nil {
+		return nil, err
+	}
+	return (TypeTypeScript)(FnTypeTypeScript{
+		ret:  retTy,
+		noad: fn,
+	}), nil
+}
+
+func (squirrel *SquirrelService) defToTypeTypeScript(ctx context.Context, def Node) (TypeTypeScript, error) {
+	if def.Node.Type() == "program" {
+		return (TypeTypeScript)(ModuleTypeTypeScript{module: def}), nil
+	}
+
+	parent := def.Node.Parent()
+	if parent == nil {
+		return nil, nil
+	}
+
+	switch parent.Type() {
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "class":
+		fallthrough
+	case "interface_declaration":
+		return (TypeTypeScript)(ClassTypeTypeScript{def: swapNode(def, parent)}), nil
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "method_definition":
+		fallthrough
+	case "method_signature":
+		fallthrough
+	case "abstract_method_signature":
+		return squirrel.getFnTypeTypeScript(ctx, swapNode(def, parent))
+	case "type_alias_declaration":
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		for _, child := range children(parent) {
+			if child.Type() == "type_annotation" {
+				return squirrel.getTypeDefTypeScript(ctx, swapNode(def, child))
+			}
+		}
+		return nil, nil
+	case "variable_declarator":
+		fallthrough
+	case "public_field_definition":
+		fallthrough
+	case "property_signature":
+		ty := parent.ChildByFieldName("type")
+		if ty != nil {
+			return squirrel.getTypeDefTypeScript(ctx, swapNode(def, ty))
+		}
+		value := parent.ChildByFieldName("value")
+		if value == nil {
+			return nil, nil
+		}
+		return squirrel.getTypeDefTypeScript(ctx, swapNode(def, value))
+	default:
+		squirrel.breadcrumb(swapNode(def, parent), fmt.Sprintf("unrecognized def parent %q", parent.Type()))
+		return nil, nil
+	}
+}
+
+// getBindingsTypeScript returns the identifiers bound by the given statement.
+func getBindingsTypeScript(statement *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	switch statement.Type() {
+	case "export_statement":
+		declaration := statement.ChildByFieldName("declaration")
+		if declaration != nil {
+			bindings = append(bindings, getBindingsTypeScript(declaration)...)
+		}
+	case "lexical_declaration":
+		fallthrough
+	case "variable_declaration":
+		for _, declarator := range children(statement) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			name := declarator.ChildByFieldName("name")
+			if name != nil {
+				bindings = append(bindings, getPatternBindingsTypeScript(name)...)
+			}
+		}
+	case "function_declaration":
+		fallthrough
+	case "generator_function_declaration":
+		fallthrough
+	case "class_declaration":
+		fallthrough
+	case "abstract_class_declaration":
+		fallthrough
+	case "interface_declaration":
+		fallthrough
+	case "type_alias_declaration":
+		fallthrough
+	case "enum_declaration":
+		name := statement.ChildByFieldName("name")
+		if name != nil {
+			bindings = append(bindings, name)
+		}
+	case "import_statement":
+		for _, clause := range children(statement) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+			for _, child := range children(clause) {
+				switch child.Type() {
+				case "identifier":
+					bindings = append(bindings, child)
+				case "namespace_import":
+					for _, ident := range children(child) {
+						bindings = append(bindings, ident)
+					}
+				case "named_imports":
+					for _, specifier := range children(child) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						local := specifier.ChildByFieldName("alias")
+						if local == nil {
+							local = specifier.ChildByFieldName("name")
+						}
+						if local != nil {
+							bindings = append(bindings, local)
+						}
+					}
+				}
+			}
+		}
+	}
+	return bindings
+}
+
+// getPatternBindingsTypeScript returns the identifiers bound by the given destructuring pattern.
+func getPatternBindingsTypeScript(pattern *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	switch pattern.Type() {
+	case "identifier":
+		fallthrough
+	case "shorthand_property_identifier_pattern":
+		bindings = append(bindings, pattern)
+	case "pair_pattern":
+		value := pattern.ChildByFieldName("value")
+		if value != nil {
+			bindings = append(bindings, getPatternBindingsTypeScript(value)...)
+		}
+	case "assignment_pattern":
+		fallthrough
+	case "object_assignment_pattern":
+		left := pattern.ChildByFieldName("left")
+		if left != nil {
+			bindings = append(bindings, getPatternBindingsTypeScript(left)...)
+		}
+	case "required_parameter":
+		fallthrough
+	case "optional_parameter":
+		// Only the first child is the pattern, the rest are the type and the default value
+		for _, child := range children(pattern) {
+			if child.Type() == "accessibility_modifier" {
+				continue
+			}
+			bindings = append(bindings, getPatternBindingsTypeScript(child)...)
+			break
+		}
+	case "object_pattern":
+		fallthrough
+	case "array_pattern":
+		fallthrough
+	case "rest_pattern":
+		for _, child := range children(pattern) {
+			bindings = append(bindings, getPatternBindingsTypeScript(child)...)
+		}
+	}
+	return bindings
+}
+
+// getParameterBindingsTypeScript returns the identifiers bound by the parameters and type parameters of
+// the given function.
+func getParameterBindingsTypeScript(fn *sitter.Node) []*sitter.Node {
+	bindings := getTypeParameterBindingsTypeScript(fn)
+	if parameter := fn.ChildByFieldName("parameter"); parameter != nil {
+		// x => ...
+		bindings = append(bindings, getPatternBindingsTypeScript(parameter)...)
+	}
+	if parameters := fn.ChildByFieldName("parameters"); parameters != nil {
+		for _, parameter := range children(parameters) {
+			bindings = append(bindings, getPatternBindingsTypeScript(parameter)...)
+		}
+	}
+	return bindings
+}
+
+func getTypeParameterBindingsTypeScript(node *sitter.Node) []*sitter.Node {
+	bindings := []*sitter.Node{}
+	typeParameters := node.ChildByFieldName("type_parameters")
+	for _, typeParameter := range children(typeParameters) {
+		name := typeParameter.ChildByFieldName("name")
+		if name == nil && typeParameter.NamedChildCount() > 0 {
+			name = typeParameter.NamedChild(0)
+		}
+		if name != nil {
+			bindings = append(bindings, name)
+		}
+	}
+	return bindings
+}
+
+// getSourceTypeScript returns the module specifier string of the given import or export statement.
+// ChildByFieldName("source") doesn't find it, so look it up by type instead.
+func getSourceTypeScript(statement *sitter.Node) *sitter.Node {
+	for _, child := range children(statement) {
+		if child.Type() == "string" {
+			return child
+		}
+	}
+	return nil
+}
+
+func isDefaultExportTypeScript(statement *sitter.Node) bool {
+	for i := 0; i < int(statement.ChildCount()); i++ {
+		if statement.Child(i).Type() == "default" {
+			return true
+		}
+	}
+	return false
+}
+
+func getEnclosingClassTypeScript(node *sitter.Node) *sitter.Node {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		switch cur.Type() {
+		case "class_declaration":
+			fallthrough
+		case "abstract_class_declaration":
+			fallthrough
+		case "class":
+			return cur
+		}
+	}
+	return nil
+}
+
+func getSuperclassTypeScript(class Node) *Node {
+	supers := getSupertypesTypeScript(class)
+	if len(supers) == 0 {
+		return nil
+	}
+	return &supers[0]
+}
+
+// getSupertypesTypeScript returns the superclass of the given class, or the interfaces that the given
+// interface extends.
+func getSupertypesTypeScript(declaration Node) []Node {
+	supers := []Node{}
+	for _, child := range children(declaration.Node) {
+		switch child.Type() {
+		case "class_heritage":
+			for _, clause := range children(child) {
+				if clause.Type() != "extends_clause" {
+					continue
+				}
+				for _, super := range children(clause) {
+					supers = append(supers, swapNode(declaration, super))
+					break
+				}
+			}
+		case "extends_clause":
+			fallthrough
+		case "extends_type_clause":
+			for _, super := range children(child) {
+				supers = append(supers, swapNode(declaration, super))
+			}
+		}
+	}
+	return supers
+}
+
+type TypeTypeScript interface {
+	variant() string
+	node() Node
+}
+
+type FnTypeTypeScript struct {
+	ret  TypeTypeScript
+	noad Node
+}
+
+func (t FnTypeTypeScript) variant() string {
+	return "fn"
+}
+
+func (t FnTypeTypeScript) node() Node {
+	return t.noad
+}
+
+type ClassTypeTypeScript struct {
+	def Node
+}
+
+func (t ClassTypeTypeScript) variant() string {
+	return "class"
+}
+
+func (t ClassTypeTypeScript) node() Node {
+	return t.def
+}
+
+type ModuleTypeTypeScript struct {
+	module Node
+}
+
+func (t ModuleTypeTypeScript) variant() string {
+	return "module"
+}
+
+func (t ModuleTypeTypeScript) node() Node {
+	return t.module
+}
+
+func lazyTypeTypeScriptStringer(ty *TypeTypeScript) func() fmt.Stringer {
+	return func() fmt.Stringer {
+		if ty != nil && *ty != nil {
+			return String((*ty).variant())
+		} else {
+			return String("<nil>")
+		}
+	}
+}
@@ -0,0 +1,19 @@
+using Acme.Data;
+
+namespace Acme.App
+{
+    //                    vvvvvvvvvvv cs.BaseProgram def
+    public abstract class BaseProgram
+    {
+        //        vvvvvvvvv cs.UserStore ref
+        //                  vvvvv cs.BaseProgram.store def
+        //                              vvvvvvvvv cs.UserStore ref
+        protected UserStore store = new UserStore();
+
+        //             vvv cs.BaseProgram.Log def
+        //                        vvvvvvv cs.BaseProgram.Log.message def
+        protected void Log(string message)
+        {
+        }
+    }
+}
@@ -0,0 +1,20 @@
+namespace Acme.App
+{
+    //           vvvvvv cs.Helper def
+    static class Helper
+    {
+        //                 vvvvv cs.Helper.Check def
+        //                                   vvvv cs.User ref
+        //                                        vvvv cs.Helper.Check.user def
+        public static void Check(Acme.Models.User user)
+        {
+            //  vvvvv cs.Helper.Check.Twice def
+            //            v cs.Helper.Check.Twice.x def
+            //                  v cs.Helper.Check.Twice.x ref
+            int Twice(int x) => x * 2;
+            //    vvvv cs.Helper.Check.user ref
+            //         vv cs.Entity.Id ref
+            Twice(user.Id); // < "Twice" cs.Helper.Check.Twice ref
+        }
+    }
+}
@@ -0,0 +1,88 @@
+using System;
+using Acme.Models;
+//    v cs.M def
+using M = Acme.Models;
+using static Acme.Util.Strings;
+
+namespace Acme.App
+{
+    //           vvvvvvv cs.Program def
+    //                     vvvvvvvvvvv cs.BaseProgram ref
+    public class Program : BaseProgram
+    {
+        //      v cs.M ref
+        //        vvvv cs.User ref
+        //             vvvvvvv cs.Program.current def
+        private M.User current;
+
+        //             vvvv cs.User ref
+        //                  vvvv cs.Program.ctor.user def
+        public Program(User user)
+        {
+            //   vvvvvvv cs.Program.current ref
+            //             vvvv cs.Program.ctor.user ref
+            this.current = user;
+        }
+
+        //          vvv cs.Program.Run def
+        public void Run()
+        {
+            //       v cs.Program.Run.i def
+            //              v cs.Program.Run.i ref
+            //                  vvvvvv cs.Config ref
+            //                         vvvvv cs.Config.Limit ref
+            //                                v cs.Program.Run.i ref
+            for (int i = 0; i < Config.Limit; i++)
+            {
+                //    vvv cs.UserStore.Add ref
+                //        vvvv cs.User ref
+                //             vvvvvv cs.User.Create ref
+                //                    vvvvv cs.Strings.Shout ref
+                //                          vvvvvvv cs.Program.current ref
+                //                                  vvvv cs.User.Name ref
+                store.Add(User.Create(Shout(current.Name))); // < "store" cs.BaseProgram.store ref
+            }
+            //  vvvvv cs.Program.Run.found def
+            //               vvvvv cs.BaseProgram.store ref
+            //                     vvvv cs.UserStore.Find ref
+            var found = base.store.Find(1);
+            //  vvvvv cs.Program.Run.found ref
+            //         vvvv cs.User.Home ref
+            //              vvvv cs.Address.City ref
+            Log(found?.Home.City); // < "Log" cs.BaseProgram.Log ref
+            //              vvvv cs.Role ref
+            //                   vvvvv cs.Role.Admin ref
+            Log(Acme.Models.Role.Admin.ToString()); // < "Log" cs.BaseProgram.Log ref
+            //   vvvv cs.User ref
+            //                 vvvvvvvv cs.Program.Run.describe def
+            //                             vvvv cs.User ref
+            //                                  v cs.Program.Run.u def
+            //                                        v cs.Program.Run.u ref
+            //                                          vvvv cs.User.Home ref
+            //                                               vvvvvv cs.Address.Street ref
+            Func<User, string> describe = (User u) => u.Home.Street;
+            try
+            {
+                //     vvvvv cs.Helper.Check ref
+                //           vvvvvvv cs.Program.current ref
+                Helper.Check(current); // < "Helper" cs.Helper ref
+            }
+            //               v cs.Program.Run.e def
+            catch (Exception e)
+            {
+                //  v cs.Program.Run.e ref
+                Log(e.Message); // < "Log" cs.BaseProgram.Log ref
+            }
+        }
+
+        //                 vvvv cs.Program.Main def
+        //                               vvvv cs.Program.Main.args def
+        public static void Main(string[] args)
+        {
+            //  vvvvvvv cs.Program ref
+            //              vvvv cs.User ref
+            //                      vvv cs.Program.Run ref
+            new Program(new User()).Run();
+        }
+    }
+}
@@ -0,0 +1,9 @@
+namespace Acme
+{
+    //           vvvvvv cs.Config def
+    public class Config
+    {
+        //                vvvvv cs.Config.Limit def
+        public static int Limit = 10;
+    }
+}
@@ -0,0 +1,56 @@
+using System.Collections.Generic;
+using Acme.Models;
+
+namespace Acme.Data
+{
+    //               vvvvvv cs.IStore def
+    //                      v cs.IStore.T def
+    public interface IStore<T>
+    {
+        //         vv cs.IStore.Find.id def
+        T Find(int id); // < "T" cs.IStore.T ref < "Find" cs.IStore.Find def
+    }
+
+    //           vvvvvvvvv cs.UserStore def
+    //                       vvvvvv cs.IStore ref
+    //                              vvvv cs.User ref
+    public class UserStore : IStore<User>
+    {
+        //                    vvvv cs.User ref
+        //                          vvvvv cs.UserStore.users def
+        //                                           vvvv cs.User ref
+        private readonly List<User> users = new List<User>();
+
+        //     vvvv cs.User ref
+        //          vvvv cs.UserStore.Find def
+        //                   vv cs.UserStore.Find.id def
+        public User Find(int id)
+        {
+            //       vvvv cs.User ref
+            //            vvvv cs.UserStore.Find.user def
+            //                    vvvvv cs.UserStore.users ref
+            foreach (User user in users)
+            {
+                //  vvvv cs.UserStore.Find.user ref
+                //       vv cs.Entity.Id ref
+                //             vv cs.UserStore.Find.id ref
+                if (user.Id == id)
+                {
+                    //     vvvv cs.UserStore.Find.user ref
+                    return user;
+                }
+            }
+            return null;
+        }
+
+        //          vvv cs.UserStore.Add def
+        //              vvvv cs.User ref
+        //                   vvvv cs.UserStore.Add.user def
+        public void Add(User user)
+        {
+            //   vvvvv cs.UserStore.users ref
+            //             vvvv cs.UserStore.Add.user ref
+            this.users.Add(user);
+        }
+    }
+}
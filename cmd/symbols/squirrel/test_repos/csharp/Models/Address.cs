@@ -0,0 +1,14 @@
+namespace Acme.Models
+{
+    //            vvvvvvv cs.Address def
+    //                           vvvvvv cs.Address.Street def
+    //                                          vvvv cs.Address.City def
+    public record Address(string Street, string City);
+
+    //          vvvv cs.Role def
+    public enum Role
+    {
+        Admin, // < "Admin" cs.Role.Admin def
+        Guest, // < "Guest" cs.Role.Guest def
+    }
+}
@@ -0,0 +1,40 @@
+namespace Acme.Models
+{
+    //           vvvvvv cs.Entity def
+    public class Entity
+    {
+        //         vv cs.Entity.Id def
+        public int Id;
+    }
+
+    //           vvvv cs.User def
+    //                  vvvvvv cs.Entity ref
+    public class User : Entity
+    {
+        //            vvvv cs.User.Name def
+        public string Name { get; set; }
+        //     vvvvvvv cs.Address ref
+        //             vvvv cs.User.Home def
+        public Address Home;
+        //     vvvv cs.Role ref
+        //          vvvvvv cs.User.Access def
+        //                   vvvv cs.Role ref
+        //                        vvvvv cs.Role.Guest ref
+        public Role Access = Role.Guest;
+
+        //            vvvv cs.User ref
+        //                 vvvvvv cs.User.Create def
+        //                               vvvv cs.User.Create.name def
+        public static User Create(string name)
+        {
+            //  vvvv cs.User.Create.user def
+            //             vvvv cs.User ref
+            var user = new User();
+            //   vvvv cs.User.Name ref
+            //          vvvv cs.User.Create.name ref
+            user.Name = name; // < "user" cs.User.Create.user ref
+            //     vvvv cs.User.Create.user ref
+            return user;
+        }
+    }
+}
@@ -0,0 +1,14 @@
+namespace Acme.Util
+{
+    //                  vvvvvvv cs.Strings def
+    public static class Strings
+    {
+        //                   vvvvv cs.Strings.Shout def
+        //                                v cs.Strings.Shout.s def
+        public static string Shout(string s)
+        {
+            //     v cs.Strings.Shout.s ref
+            return s.ToUpper();
+        }
+    }
+}
@@ -0,0 +1,29 @@
+//go:build ignore
+
+package lib
+
+import "example.com/gorepo/lib/util"
+
+//   vvvvv go.lib.Store def
+type Store struct {
+	Backend string // < "Backend" go.lib.Store.Backend def
+	values  map[int]string
+}
+
+//   vvvvvvvv go.lib.Embedded def
+type Embedded struct{}
+
+//              vvvvvv go.lib.Embedded.Helper def
+func (Embedded) Helper() string {
+	return ""
+}
+
+//   vvvvvvvvv go.lib.OpenStore def
+func OpenStore(backend string) (*Store, error) {
+	if err := util.Validate(backend); err != nil {
+		return nil, err
+	}
+	//      vvvvv go.lib.Store ref
+	//            vvvvvvv go.lib.Store.Backend ref
+	return &Store{Backend: backend}, nil
+}
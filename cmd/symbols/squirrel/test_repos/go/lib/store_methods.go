@@ -0,0 +1,18 @@
+//go:build ignore
+
+package lib
+
+//              vvv go.lib.Store.Get def
+func (s *Store) Get(key int) (string, bool) {
+	value, ok := s.values[key]
+	return value, ok
+}
+
+//              vvvv go.lib.Store.Keys def
+func (s *Store) Keys() []int {
+	keys := []int{}
+	for key := range s.values {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,13 @@
+//go:build ignore
+
+package util
+
+import "errors"
+
+//   vvvvvvvv go.util.Validate def
+func Validate(backend string) error {
+	if backend == "" {
+		return errors.New("empty backend")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+//go:build ignore
+
+package main
+
+//     vvvvv go.fmt def
+import "fmt"
+
+import (
+	"example.com/gorepo/lib"
+	u "example.com/gorepo/lib/util" // < "u" go.util def
+)
+
+//   vvvvvv go.Server def
+type Server struct {
+	store *lib.Store // < "store" go.Server.store def
+	name  string // < "name" go.Server.name def
+	lib.Embedded
+}
+
+//    v go.Server.Run.s def
+//               vvv go.Server.Run def
+//                   v go.Server.Run.n def
+func (s *Server) Run(n int) (string, error) {
+	//       v go.Server.Run.s ref
+	//         vvvvv go.Server.store ref
+	//               vvv go.lib.Store.Get ref
+	//                   v go.Server.Run.n ref
+	v, ok := s.store.Get(n) // < "v" go.Server.Run.v def
+	if !ok {
+		//     vvv go.fmt ref
+		return fmt.Sprint(n), nil
+	}
+	//       vvvv go.Server.name ref
+	//              v go.Server.Run.v ref
+	//                    vvvvvvvv go.Server.Describe ref
+	//                                   vvvvvv go.lib.Embedded.Helper ref
+	return s.name + v + s.Describe() + s.Helper(), nil
+}
+
+//   vvvvvvvvv go.newServer def
+//             vvvvvvv go.newServer.backend def
+func newServer(backend string) *Server {
+	// vvv go.newServer.err1 def
+	//        v go.util ref
+	//          vvvvvvvv go.util.Validate ref
+	//                   vvvvvvv go.newServer.backend ref
+	if err := u.Validate(backend); err != nil {
+		//    vvv go.newServer.err1 ref
+		panic(err)
+	}
+
+	//  vvv go.newServer.err2 def
+	//             vvvvvvvvv go.lib.OpenStore ref
+	st, err := lib.OpenStore(backend) // < "st" go.newServer.st def
+	// vvv go.newServer.err2 ref
+	if err != nil {
+		panic(err)
+	}
+
+	//      vvvvvv go.Server ref
+	//             vvvvv go.Server.store ref
+	//                    vv go.newServer.st ref
+	//                        vvvv go.Server.name ref
+	//                              vvvvvvvvvvv go.defaultName ref
+	return &Server{store: st, name: defaultName}
+}
+
+func main() {
+	//        vvvvvvvvv go.newServer ref
+	server := newServer("memory") // < "server" go.main.server def
+	//     vvv go.Server.Run ref
+	server.Run(1) // < "server" go.main.server ref
+
+	//  v go.main.i def
+	//     vvv go.main.key def
+	//                               vvvv go.lib.Store.Keys ref
+	for i, key := range server.store.Keys() {
+		//          v go.main.i ref
+		//             vvv go.main.key ref
+		fmt.Println(i, key)
+	}
+
+	//     v go.main.b def
+	//          vvvvvv go.helper ref
+	//                    v go.main.b ref
+	switch b := helper(); b {
+	case true:
+		x := 1 // < "x" go.main.x def
+		//  v go.main.x ref
+		_ = x
+	}
+}
@@ -0,0 +1,22 @@
+//go:build ignore
+
+package main
+
+import "strings"
+
+//    vvvvvvvvvvv go.defaultName def
+const defaultName = "server"
+
+//               vvvvvvvv go.Server.Describe def
+func (s *Server) Describe() string {
+	//                       vvvv go.Server.name ref
+	//                                 vvvvv go.Server.store ref
+	//                                       vvvvvvv go.lib.Store.Backend ref
+	return strings.ToUpper(s.name) + s.store.Backend
+}
+
+//   vvvvvv go.helper def
+func helper() bool {
+	//     vvvvvvvvvvv go.defaultName ref
+	return defaultName != ""
+}
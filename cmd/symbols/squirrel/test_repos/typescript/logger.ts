@@ -0,0 +1,36 @@
+//          vvvvv ts.Level def
+export enum Level {
+    Info,
+    Warn,
+}
+
+//           vvvvvvvvvv ts.BaseLogger def
+export class BaseLogger {
+    //                 vvvvv ts.BaseLogger.level def
+    //                        vvvvv ts.Level ref
+    constructor(public level: Level) {}
+
+    warn(message: unknown): void { // < "warn" ts.BaseLogger.warn def
+        //   vvvvv ts.BaseLogger.write ref
+        this.write(message)
+    }
+
+    //        vvvvv ts.BaseLogger.write def
+    protected write(message: unknown): void {
+        console.log(message)
+    }
+}
+
+//                   vvvvvv ts.Logger def
+//                                  vvvvvvvvvv ts.BaseLogger ref
+export default class Logger extends BaseLogger {
+    //   vvvvvvv ts.Logger.info.message def
+    info(message: string): void { // < "info" ts.Logger.info def
+        //       vvvvv ts.BaseLogger.level ref
+        if (this.level === Level.Info) {
+            //    vvvvv ts.BaseLogger.write ref
+            //          vvvvvvv ts.Logger.info.message ref
+            super.write(message)
+        }
+    }
+}
@@ -0,0 +1,66 @@
+//     vvvvvv ts.Logger ref
+//               vvvvv ts.Level ref
+import Logger, { Level } from './logger'
+//       vvvvv ts.Store ref
+import { Store as MemoryStore } from './store'
+import * as models from './models'
+//       vvvv ts.User ref
+import { User } from './models'
+
+//    vvvvvv ts.logger def
+//                 vvvvvv ts.Logger ref
+//                        vvvvv ts.Level ref
+const logger = new Logger(Level.Info)
+
+//       vvvv ts.main def
+//            vvvv ts.main.args def
+function main(args: string[]): void {
+    //    vvvvv ts.main.store def
+    //                vvvvvvvvvvv ts.Store ref
+    const store = new MemoryStore()
+    //         vvv ts.main.arg def
+    //                vvvv ts.main.args ref
+    for (const arg of args) {
+        //    vvvv ts.main.user def
+        //                 vvvv ts.User ref
+        //                               vvvvvvvvv ts.parseUser ref
+        //                                         vvv ts.main.arg ref
+        const user: models.User = models.parseUser(arg)
+        //    vvv ts.Store.add ref
+        //        vvvv ts.main.user ref
+        store.add(user) // < "store" ts.main.store ref
+        //     vvvv ts.Logger.info ref
+        //               vvvv ts.User.name ref
+        logger.info(user.name) // < "logger" ts.logger ref
+    }
+
+    try {
+        //    vvvv ts.Store.find ref
+        //                  vvvvv ts.User.greet ref
+        store.find('admin').greet()
+    //       vvvvv ts.main.error def
+    } catch (error) {
+        //     vvvv ts.BaseLogger.warn ref
+        //          vvvvv ts.main.error ref
+        logger.warn(error)
+    }
+
+    //    vvvvv ts.main.names def
+    //                  vvvvv ts.Store.users ref
+    //                             v ts.main.u def
+    //                                vvvv ts.User ref
+    //                                         v ts.main.u ref
+    //                                           vvvv ts.User.name ref
+    const names = store.users.map((u: User) => u.name)
+    //     vvvvv ts.BaseLogger.level ref
+    //             vvvvv ts.main.names ref
+    logger.level = names.length
+}
+
+main([]) // < "main" ts.main ref
+
+//              vvvvv ts.admin def
+//                       vvvv ts.User ref
+export function admin(): User {
+    return new User('admin')
+}
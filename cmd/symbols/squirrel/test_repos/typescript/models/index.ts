@@ -0,0 +1,2 @@
+export * from './user'
+export { parse as parseUser } from './parse'
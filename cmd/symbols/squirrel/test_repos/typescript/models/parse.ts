@@ -0,0 +1,8 @@
+//       vvvv ts.User ref
+import { User } from './user'
+
+//              vvvvv ts.parseUser def
+export function parse(line: string): User {
+    //         vvvv ts.User ref
+    return new User(line.trim())
+}
@@ -0,0 +1,11 @@
+//           vvvv ts.User def
+export class User {
+    //                          vvvv ts.User.name def
+    constructor(public readonly name: string) {}
+
+    //     vvvvv ts.User.greet def
+    public greet(): string {
+        //                    vvvv ts.User.name ref
+        return `Hello, ${this.name}`
+    }
+}
@@ -0,0 +1,26 @@
+import { User } from './models/user'
+
+//           vvvvv ts.Store def
+export class Store {
+    //     vvvvv ts.Store.users def
+    public users: User[] = []
+
+    //     vvv ts.Store.add def
+    //         vvvv ts.Store.add.user def
+    public add(user: User): void {
+        //   vvvvv ts.Store.users ref
+        //              vvvv ts.Store.add.user ref
+        this.users.push(user)
+    }
+
+    //     vvvv ts.Store.find def
+    public find(name: string): User {
+        //          vvvvvv ts.Store.byName ref
+        return this.byName(name) ?? new User(name)
+    }
+
+    //      vvvvvv ts.Store.byName def
+    private byName(name: string): User | undefined {
+        return this.users.find(user => user.name === name)
+    }
+}
@@ -356,6 +356,16 @@ func children(node *sitter.Node) []*sitter.Node {
 	return children
 }
 
+// hasChildOfType returns true if any child of the node (named or not) has the given type.
+func hasChildOfType(node *sitter.Node, ty string) bool {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.Child(i).Type() == ty {
+			return true
+		}
+	}
+	return false
+}
+
 func snippet(node *Node) string {
 	contextChars := 5
 	start := int(node.StartByte()) - contextChars
@@ -403,6 +413,19 @@ func lazyNodeStringer(node **Node) func() fmt.Stringer {
 }
 
 func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, commit string, include []string, ident string) (*Node, error) {
+	nodes, err := s.symbolSearchAll(ctx, repo, commit, include, ident, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	return &nodes[0], nil
+}
+
+// symbolSearchAll returns the nodes of up to first symbols named ident, skipping symbols in files that
+// can't be parsed.
+func (s *SquirrelService) symbolSearchAll(ctx context.Context, repo string, commit string, include []string, ident string, first int) ([]Node, error) {
 	symbols, err := s.symbolSearch(ctx, search.SymbolsParameters{
 		Repo:            api.RepoName(repo),
 		CommitID:        api.CommitID(commit),
@@ -411,34 +434,33 @@ func (s *SquirrelService) symbolSearchOne(ctx context.Context, repo string, comm
 		IsCaseSensitive: true,
 		IncludePatterns: include,
 		ExcludePattern:  "",
-		First:           1,
-	})
-	if err != nil {
-		return nil, err
-	}
-	if len(symbols) == 0 {
-		return nil, nil
-	}
-	symbol := symbols[0]
-	file, err := s.parse(ctx, types.RepoCommitPath{
-		Repo:   repo,
-		Commit: commit,
-		Path:   symbol.Path,
+		First:           first,
 	})
-	if errors.Is(err, unsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
-		return nil, nil
-	}
 	if err != nil {
 		return nil, err
 	}
-	point := sitter.Point{
-		Row:    uint32(symbol.Line),
-		Column: uint32(symbol.Character),
-	}
-	symbolNode := file.NamedDescendantForPointRange(point, point)
-	if symbolNode == nil {
-		return nil, nil
+	nodes := []Node{}
+	for _, symbol := range symbols {
+		file, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   repo,
+			Commit: commit,
+			Path:   symbol.Path,
+		})
+		if errors.Is(err, unsupportedLanguageError) || errors.Is(err, unrecognizedFileExtensionError) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		point := sitter.Point{
+			Row:    uint32(symbol.Line),
+			Column: uint32(symbol.Character),
+		}
+		symbolNode := file.NamedDescendantForPointRange(point, point)
+		if symbolNode == nil {
+			continue
+		}
+		nodes = append(nodes, swapNode(*file, symbolNode))
 	}
-	ret := swapNode(*file, symbolNode)
-	return &ret, nil
+	return nodes, nil
 }
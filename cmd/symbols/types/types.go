@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/search"
@@ -16,6 +17,9 @@ import (
 type SqliteConfig struct {
 	CacheDir                string
 	CacheSizeMB             int
+	CacheHighWaterMarkMB    int
+	CacheEvictionPolicy     string
+	CachePinnedRepos        []string
 	NumCtagsProcesses       int
 	RequestBufferSize       int
 	ProcessingTimeout       time.Duration
@@ -30,6 +34,9 @@ func LoadSqliteConfig(baseConfig env.BaseConfig, ctags CtagsConfig, repositoryFe
 		RepositoryFetcher:       repositoryFetcher,
 		CacheDir:                baseConfig.Get("CACHE_DIR", "/tmp/symbols-cache", "directory in which to store cached symbols"),
 		CacheSizeMB:             baseConfig.GetInt("SYMBOLS_CACHE_SIZE_MB", "100000", "maximum size of the disk cache (in megabytes)"),
+		CacheHighWaterMarkMB:    baseConfig.GetInt("SYMBOLS_CACHE_HIGH_WATER_MARK_MB", "0", "size of the disk cache (in megabytes) above which indexing a repository evicts databases immediately, 0 disables the check"),
+		CacheEvictionPolicy:     baseConfig.Get("SYMBOLS_CACHE_EVICTION_POLICY", "lru", "order in which databases are evicted from the disk cache: lru, size-weighted-lru or lfu"),
+		CachePinnedRepos:        splitList(baseConfig.GetOptional("SYMBOLS_CACHE_PINNED_REPOS", "comma-separated list of repositories whose databases are never evicted from the disk cache")),
 		NumCtagsProcesses:       baseConfig.GetInt("CTAGS_PROCESSES", strconv.Itoa(runtime.GOMAXPROCS(0)), "number of concurrent parser processes to run"),
 		RequestBufferSize:       baseConfig.GetInt("REQUEST_BUFFER_SIZE", "8192", "maximum size of buffered parser request channel"),
 		ProcessingTimeout:       baseConfig.GetInterval("PROCESSING_TIMEOUT", "2h", "maximum time to spend processing a repository"),
@@ -37,6 +44,17 @@ func LoadSqliteConfig(baseConfig env.BaseConfig, ctags CtagsConfig, repositoryFe
 	}
 }
 
+// splitList splits a comma-separated list, ignoring empty elements.
+func splitList(s string) []string {
+	var elems []string
+	for _, elem := range strings.Split(s, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
+
 type CtagsConfig struct {
 	Command            string
 	PatternLengthLimit int
@@ -0,0 +1,62 @@
+package codeintel
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type lockfileIndexerConfig struct {
+	env.BaseConfig
+
+	Interval  time.Duration
+	BatchSize int
+}
+
+var lockfileIndexerConfigInst = &lockfileIndexerConfig{}
+
+func (c *lockfileIndexerConfig) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_LOCKFILE_INDEXER_INTERVAL", "1m", "How frequently to index the lockfiles of repositories that changed.")
+	c.BatchSize = c.GetInt("CODEINTEL_LOCKFILE_INDEXER_BATCH_SIZE", "100", "The maximum number of repositories to index the lockfiles of at a time.")
+}
+
+type lockfileIndexerJob struct{}
+
+func NewLockfileIndexerJob() job.Job {
+	return &lockfileIndexerJob{}
+}
+
+func (j *lockfileIndexerJob) Description() string {
+	return "lockfile dependency graph indexer"
+}
+
+func (j *lockfileIndexerJob) Config() []env.Config {
+	return []env.Config{
+		lockfileIndexerConfigInst,
+	}
+}
+
+func (j *lockfileIndexerJob) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	gitserverClient := gitserver.NewClient(db)
+	dependenciesService := dependencies.NewService(observationCtx, db)
+
+	return dependencies.LockfileIndexerJob(
+		observationCtx,
+		dependenciesService,
+		gitserverClient,
+		lockfileIndexerConfigInst.Interval,
+		lockfileIndexerConfigInst.BatchSize,
+	), nil
+}
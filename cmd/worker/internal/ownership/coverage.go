@@ -0,0 +1,141 @@
+package ownership
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/perrepo"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type coverageComputer struct{}
+
+var _ job.Job = &coverageComputer{}
+
+func NewCoverageComputer() job.Job {
+	return &coverageComputer{}
+}
+
+func (j *coverageComputer) Description() string {
+	return "ownership.CoverageComputer periodically computes the share of files owned according to the CODEOWNERS file of each repository."
+}
+
+func (j *coverageComputer) Config() []env.Config {
+	return nil
+}
+
+func (j *coverageComputer) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	gitserverClient := gitserver.NewClient(db)
+	updater := &coverageUpdater{
+		db:              db,
+		gitserverClient: gitserverClient,
+		ownService:      own.NewService(gitserverClient),
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "own.coverage-computer", "computes the ownership coverage of repositories",
+			1*time.Hour, perrepo.NewHandler(db, gitserverClient, observationCtx.Logger, updater, perrepo.Options{
+				Name:        "ownership coverage",
+				Concurrency: 8,
+			}),
+		),
+	}, nil
+}
+
+// coverageUpdater computes the ownership coverage of a repository from its
+// CODEOWNERS file and the list of its files.
+type coverageUpdater struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+	ownService      own.Service
+}
+
+var _ perrepo.Updater = &coverageUpdater{}
+
+func (u *coverageUpdater) LastCommit(ctx context.Context, repo types.MinimalRepo) (api.CommitID, error) {
+	previous, err := u.db.RepoOwnershipCoverage().Get(ctx, repo.ID)
+	if err != nil || previous == nil {
+		return "", err
+	}
+	return previous.CommitID, nil
+}
+
+func (u *coverageUpdater) Update(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID) error {
+	coverage, err := u.computeCoverage(ctx, repo, commitID)
+	if err != nil {
+		return err
+	}
+	return u.db.RepoOwnershipCoverage().Upsert(ctx, coverage)
+}
+
+func (u *coverageUpdater) computeCoverage(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID) (*database.RepoOwnershipCoverage, error) {
+	path, rs, err := u.ownService.FindOwnersFile(ctx, repo.Name, commitID)
+	if err != nil {
+		var parseErr *own.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		// An invalid CODEOWNERS file owns nothing.
+		path, rs = "", nil
+	}
+
+	files, err := u.gitserverClient.LsFiles(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, commitID)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing files")
+	}
+
+	coverage := computeCoverage(files, rs)
+	coverage.RepoID = repo.ID
+	coverage.CommitID = commitID
+	coverage.CodeownersPath = path
+	return coverage, nil
+}
+
+// computeCoverage counts the files which have at least one owner according to
+// rs, overall and per top-level directory. Directories are returned in the
+// order they first appear in files. A nil rs owns no files.
+func computeCoverage(files []string, rs *codeowners.Ruleset) *database.RepoOwnershipCoverage {
+	var (
+		coverage database.RepoOwnershipCoverage
+		dirs     = map[string]int{}
+	)
+	for _, file := range files {
+		dir, _, ok := strings.Cut(file, "/")
+		if !ok {
+			dir = ""
+		}
+
+		i, ok := dirs[dir]
+		if !ok {
+			i = len(coverage.Directories)
+			dirs[dir] = i
+			coverage.Directories = append(coverage.Directories, database.DirectoryOwnershipCoverage{Directory: dir})
+		}
+
+		coverage.TotalFiles++
+		coverage.Directories[i].TotalFiles++
+		if rs != nil && len(rs.FindOwners(file)) > 0 {
+			coverage.OwnedFiles++
+			coverage.Directories[i].OwnedFiles++
+		}
+	}
+	return &coverage
+}
@@ -0,0 +1,100 @@
+package ownership
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestComputeCoverage(t *testing.T) {
+	file, err := codeowners.Parse(bytes.NewBufferString("/client/ @frontend\n*.go @backend\n"))
+	require.NoError(t, err)
+
+	files := []string{
+		"README.md",
+		"main.go",
+		"client/web/index.ts",
+		"client/README.md",
+		"docs/index.md",
+		"docs/gen.go",
+	}
+
+	assert.Equal(t, &database.RepoOwnershipCoverage{
+		TotalFiles: 6,
+		OwnedFiles: 4,
+		Directories: []database.DirectoryOwnershipCoverage{
+			{Directory: "", TotalFiles: 2, OwnedFiles: 1},
+			{Directory: "client", TotalFiles: 2, OwnedFiles: 2},
+			{Directory: "docs", TotalFiles: 2, OwnedFiles: 1},
+		},
+	}, computeCoverage(files, codeowners.NewRuleset(file)))
+
+	assert.Equal(t, &database.RepoOwnershipCoverage{
+		TotalFiles: 1,
+		Directories: []database.DirectoryOwnershipCoverage{
+			{Directory: "", TotalFiles: 1},
+		},
+	}, computeCoverage([]string{"README.md"}, nil))
+}
+
+func TestCoverageUpdater(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	update := func(t *testing.T, codeownersContent string) *database.RepoOwnershipCoverage {
+		t.Helper()
+
+		coverage := database.NewMockRepoOwnershipCoverageStore()
+		db := database.NewMockDB()
+		db.RepoOwnershipCoverageFunc.SetDefaultReturn(coverage)
+
+		git := gitserver.NewMockClient()
+		git.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string) ([]byte, error) {
+			if path != "CODEOWNERS" || codeownersContent == "" {
+				return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+			}
+			return []byte(codeownersContent), nil
+		})
+		git.LsFilesFunc.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, ...gitdomain.Pathspec) ([]string, error) {
+			return []string{"main.go", "README.md"}, nil
+		})
+
+		u := &coverageUpdater{db: db, gitserverClient: git, ownService: own.NewService(git)}
+		require.NoError(t, u.Update(context.Background(), repo, "deadbeef"))
+		mockassert.CalledOnce(t, coverage.UpsertFunc)
+		return coverage.UpsertFunc.History()[0].Arg1
+	}
+
+	t.Run("computes coverage", func(t *testing.T) {
+		assert.Equal(t, &database.RepoOwnershipCoverage{
+			RepoID:         1,
+			CommitID:       "deadbeef",
+			CodeownersPath: "CODEOWNERS",
+			TotalFiles:     2,
+			OwnedFiles:     1,
+			Directories: []database.DirectoryOwnershipCoverage{
+				{Directory: "", TotalFiles: 2, OwnedFiles: 1},
+			},
+		}, update(t, "*.go @backend"))
+	})
+
+	t.Run("no codeowners file", func(t *testing.T) {
+		got := update(t, "")
+		assert.Equal(t, "", got.CodeownersPath)
+		assert.Equal(t, 2, got.TotalFiles)
+		assert.Equal(t, 0, got.OwnedFiles)
+	})
+}
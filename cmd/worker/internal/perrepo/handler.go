@@ -0,0 +1,172 @@
+// Package perrepo implements a periodic handler which keeps data derived from
+// the default branch of each cloned repository up to date.
+package perrepo
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Updater computes and stores the data derived from a commit of a repository.
+type Updater interface {
+	// LastCommit returns the commit the data of repo was last computed at, or
+	// the empty string if it was never computed.
+	LastCommit(ctx context.Context, repo types.MinimalRepo) (api.CommitID, error)
+
+	// Update computes and stores the data of repo at commitID.
+	Update(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID) error
+}
+
+// Options configure a handler returned by NewHandler.
+type Options struct {
+	// Name describes the computed data in log messages, e.g. "ownership
+	// coverage".
+	Name string
+
+	// Concurrency is the number of repositories updated at once. It
+	// defaults to 1.
+	Concurrency int
+
+	// RepoTimeout, if non-zero, bounds the time spent updating a single
+	// repository.
+	RepoTimeout time.Duration
+}
+
+// reposPageSize is the number of repositories loaded from the database at
+// once.
+const reposPageSize = 500
+
+// NewHandler returns a handler which calls updater for each cloned repository
+// whose default branch moved since its data was last computed.
+//
+// Repositories are visited in the order of their IDs. The handler records the
+// first repository of the page it is working on, so that a pass which is
+// interrupted by an error resumes from there instead of starting over.
+func NewHandler(db database.DB, gitserverClient gitserver.Client, logger log.Logger, updater Updater, opts Options) goroutine.Handler {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	return &handler{
+		db:              db,
+		gitserverClient: gitserverClient,
+		logger:          logger,
+		updater:         updater,
+		opts:            opts,
+	}
+}
+
+type handler struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+	logger          log.Logger
+	updater         Updater
+	opts            Options
+
+	// cursor is the ID of the first repository of the page the current pass
+	// is working on.
+	cursor api.RepoID
+}
+
+var (
+	_ goroutine.Handler      = &handler{}
+	_ goroutine.ErrorHandler = &handler{}
+)
+
+func (h *handler) Handle(ctx context.Context) error {
+	// Repository contents are read on behalf of the instance.
+	ctx = actor.WithInternalActor(ctx)
+
+	for {
+		repos, err := h.db.Repos().ListMinimalRepos(ctx, database.ReposListOptions{
+			OnlyCloned: true,
+			OrderBy:    database.RepoListOrderBy{{Field: database.RepoListID}},
+			Cursors: types.MultiCursor{{
+				Column:    string(database.RepoListID),
+				Value:     strconv.Itoa(int(h.cursor)),
+				Direction: "next",
+			}},
+			LimitOffset: &database.LimitOffset{Limit: reposPageSize},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := h.updateRepos(ctx, repos); err != nil {
+			return err
+		}
+
+		if len(repos) < reposPageSize {
+			// The pass is complete, the next one starts over.
+			h.cursor = 0
+			return nil
+		}
+		h.cursor = repos[len(repos)-1].ID + 1
+	}
+}
+
+func (h *handler) HandleError(err error) {
+	h.logger.Error("error computing "+h.opts.Name, log.Error(err))
+}
+
+// updateRepos updates repos with bounded concurrency. Errors of individual
+// repositories are logged, so that a single broken repository does not
+// prevent the others from being updated.
+func (h *handler) updateRepos(ctx context.Context, repos []types.MinimalRepo) error {
+	var (
+		sema = semaphore.NewWeighted(int64(h.opts.Concurrency))
+		wg   sync.WaitGroup
+	)
+	for _, repo := range repos {
+		if err := sema.Acquire(ctx, 1); err != nil {
+			break
+		}
+		wg.Add(1)
+		go func(repo types.MinimalRepo) {
+			defer wg.Done()
+			defer sema.Release(1)
+			if err := h.updateRepo(ctx, repo); err != nil && ctx.Err() == nil {
+				h.logger.Warn("error computing "+h.opts.Name, log.String("repo", string(repo.Name)), log.Error(err))
+			}
+		}(repo)
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// updateRepo updates the data of the default branch of repo, unless it was
+// already computed for its current commit.
+func (h *handler) updateRepo(ctx context.Context, repo types.MinimalRepo) error {
+	commitID, err := h.gitserverClient.ResolveRevision(ctx, repo.Name, "HEAD", gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return errors.Wrap(err, "resolving HEAD")
+	}
+
+	previous, err := h.updater.LastCommit(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if previous == commitID {
+		return nil
+	}
+
+	if h.opts.RepoTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.opts.RepoTimeout)
+		defer cancel()
+	}
+	return h.updater.Update(ctx, repo, commitID)
+}
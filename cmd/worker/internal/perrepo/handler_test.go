@@ -0,0 +1,136 @@
+package perrepo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeUpdater struct {
+	mu      sync.Mutex
+	last    map[api.RepoID]api.CommitID
+	updated []api.RepoID
+	fail    map[api.RepoID]bool
+
+	running, maxRunning int
+	block               chan struct{}
+}
+
+func (u *fakeUpdater) LastCommit(_ context.Context, repo types.MinimalRepo) (api.CommitID, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.last[repo.ID], nil
+}
+
+func (u *fakeUpdater) Update(_ context.Context, repo types.MinimalRepo, commitID api.CommitID) error {
+	u.mu.Lock()
+	u.running++
+	if u.running > u.maxRunning {
+		u.maxRunning = u.running
+	}
+	u.mu.Unlock()
+
+	if u.block != nil {
+		<-u.block
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.running--
+	if u.fail[repo.ID] {
+		return errors.New("broken repository")
+	}
+	u.updated = append(u.updated, repo.ID)
+	return nil
+}
+
+func newTestHandler(t *testing.T, repos *database.MockRepoStore, updater Updater, concurrency int) *handler {
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	git := gitserver.NewMockClient()
+	git.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+
+	return NewHandler(db, git, logtest.Scoped(t), updater, Options{Name: "test data", Concurrency: concurrency}).(*handler)
+}
+
+func TestHandler(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{
+		{ID: 1, Name: "github.com/sourcegraph/a"},
+		{ID: 2, Name: "github.com/sourcegraph/b"},
+		{ID: 3, Name: "github.com/sourcegraph/c"},
+	}, nil)
+
+	updater := &fakeUpdater{
+		// Repository 2 did not change since the last pass.
+		last: map[api.RepoID]api.CommitID{2: "deadbeef", 3: "cafebabe"},
+		// A broken repository does not prevent the others from being updated.
+		fail: map[api.RepoID]bool{1: true},
+	}
+	h := newTestHandler(t, repos, updater, 1)
+	require.NoError(t, h.Handle(context.Background()))
+
+	assert.Equal(t, []api.RepoID{3}, updater.updated)
+	assert.Equal(t, api.RepoID(0), h.cursor)
+}
+
+func TestHandlerConcurrency(t *testing.T) {
+	var page []types.MinimalRepo
+	for i := 1; i <= 10; i++ {
+		page = append(page, types.MinimalRepo{ID: api.RepoID(i)})
+	}
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultReturn(page, nil)
+
+	updater := &fakeUpdater{block: make(chan struct{})}
+	h := newTestHandler(t, repos, updater, 3)
+
+	done := make(chan error)
+	go func() { done <- h.Handle(context.Background()) }()
+	for i := 0; i < len(page); i++ {
+		updater.block <- struct{}{}
+	}
+	require.NoError(t, <-done)
+
+	assert.Len(t, updater.updated, 10)
+	assert.LessOrEqual(t, updater.maxRunning, 3)
+}
+
+func TestHandlerResumes(t *testing.T) {
+	firstPage := make([]types.MinimalRepo, 0, reposPageSize)
+	for i := 1; i <= reposPageSize; i++ {
+		firstPage = append(firstPage, types.MinimalRepo{ID: api.RepoID(i)})
+	}
+
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.PushReturn(firstPage, nil)
+	repos.ListMinimalReposFunc.PushReturn(nil, errors.New("database is down"))
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{{ID: reposPageSize + 1}}, nil)
+
+	updater := &fakeUpdater{}
+	h := newTestHandler(t, repos, updater, 4)
+
+	require.Error(t, h.Handle(context.Background()))
+	assert.Len(t, updater.updated, reposPageSize)
+
+	// The next pass continues after the first page.
+	require.NoError(t, h.Handle(context.Background()))
+	history := repos.ListMinimalReposFunc.History()
+	require.Len(t, history, 3)
+	assert.Equal(t, "0", history[0].Arg1.Cursors[0].Value)
+	assert.Equal(t, "501", history[1].Arg1.Cursors[0].Value)
+	assert.Equal(t, "501", history[2].Arg1.Cursors[0].Value)
+	assert.Len(t, updater.updated, reposPageSize+1)
+	assert.Equal(t, api.RepoID(0), h.cursor)
+}
@@ -0,0 +1,134 @@
+package repoinventory
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/perrepo"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type computer struct{}
+
+var _ job.Job = &computer{}
+
+func NewComputer() job.Job {
+	return &computer{}
+}
+
+func (j *computer) Description() string {
+	return "repoinventory.Computer periodically computes the language breakdown of the default branch of each repository."
+}
+
+func (j *computer) Config() []env.Config {
+	return nil
+}
+
+func (j *computer) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	gitserverClient := gitserver.NewClient(db)
+	updater := &inventoryUpdater{
+		db:              db,
+		gitserverClient: gitserverClient,
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(context.Background(), "repo-inventory-computer", "computes the language breakdown of repositories",
+			1*time.Hour, perrepo.NewHandler(db, gitserverClient, observationCtx.Logger, updater, perrepo.Options{
+				Name:        "repository inventory",
+				Concurrency: inventoryConcurrency,
+				RepoTimeout: repoTimeout,
+			}),
+		),
+	}, nil
+}
+
+const (
+	// inventoryConcurrency is the number of repositories whose inventory is
+	// computed at once. Computing an inventory reads every file of the
+	// repository, so it is kept low to bound the load on gitserver.
+	inventoryConcurrency = 4
+
+	// repoTimeout bounds the time spent computing the inventory of a single
+	// repository.
+	repoTimeout = 3 * time.Minute
+)
+
+// inventoryUpdater computes the language breakdown of a repository.
+type inventoryUpdater struct {
+	db              database.DB
+	gitserverClient gitserver.Client
+}
+
+var _ perrepo.Updater = &inventoryUpdater{}
+
+func (u *inventoryUpdater) LastCommit(ctx context.Context, repo types.MinimalRepo) (api.CommitID, error) {
+	previous, err := u.db.RepoInventory().Get(ctx, repo.ID)
+	if err != nil || previous == nil {
+		return "", err
+	}
+	return previous.CommitID, nil
+}
+
+func (u *inventoryUpdater) Update(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID) error {
+	inv, err := u.computeInventory(ctx, repo.Name, commitID)
+	if err != nil {
+		return err
+	}
+	return u.db.RepoInventory().Upsert(ctx, toRepoInventory(repo.ID, commitID, inv))
+}
+
+func (u *inventoryUpdater) computeInventory(ctx context.Context, repo api.RepoName, commitID api.CommitID) (inventory.Inventory, error) {
+	invCtx := inventory.Context{
+		ReadTree: func(ctx context.Context, path string) ([]fs.FileInfo, error) {
+			return u.gitserverClient.ReadDir(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, path, false)
+		},
+		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			return u.gitserverClient.NewFileReader(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, path)
+		},
+	}
+
+	root, err := u.gitserverClient.Stat(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, "")
+	if err != nil {
+		return inventory.Inventory{}, errors.Wrap(err, "reading root tree")
+	}
+	return invCtx.Entries(ctx, root)
+}
+
+// toRepoInventory converts inv to the representation stored in the database,
+// with languages ordered by name.
+func toRepoInventory(repoID api.RepoID, commitID api.CommitID, inv inventory.Inventory) *database.RepoInventory {
+	languages := make([]database.RepoLanguage, 0, len(inv.Languages))
+	for _, l := range inv.Languages {
+		languages = append(languages, database.RepoLanguage{
+			Language:   l.Name,
+			TotalBytes: int64(l.TotalBytes),
+			TotalLines: int64(l.TotalLines),
+		})
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Language < languages[j].Language })
+
+	return &database.RepoInventory{
+		RepoID:    repoID,
+		CommitID:  commitID,
+		Languages: languages,
+	}
+}
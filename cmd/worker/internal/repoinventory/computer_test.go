@@ -0,0 +1,77 @@
+package repoinventory
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestInventoryUpdater(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	files := map[string]string{
+		"Main.java":       "class Main {\n}\n",
+		"src/Util.java":   "class Util {\n}\n",
+		"src/Build.kt":    "fun main() {}\n",
+		"vendor/Lib.java": "class Lib {}\n",
+	}
+
+	inventories := database.NewMockRepoInventoryStore()
+	db := database.NewMockDB()
+	db.RepoInventoryFunc.SetDefaultReturn(inventories)
+
+	git := gitserver.NewMockClient()
+	git.StatFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string) (fs.FileInfo, error) {
+		return &fileutil.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+	})
+	git.ReadDirFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string, _ bool) ([]fs.FileInfo, error) {
+		switch path {
+		case "":
+			return []fs.FileInfo{
+				&fileutil.FileInfo{Name_: "Main.java", Size_: int64(len(files["Main.java"]))},
+				&fileutil.FileInfo{Name_: "src", Mode_: os.ModeDir},
+				&fileutil.FileInfo{Name_: "vendor", Mode_: os.ModeDir},
+			}, nil
+		case "src":
+			return []fs.FileInfo{
+				&fileutil.FileInfo{Name_: "src/Util.java", Size_: int64(len(files["src/Util.java"]))},
+				&fileutil.FileInfo{Name_: "src/Build.kt", Size_: int64(len(files["src/Build.kt"]))},
+			}, nil
+		case "vendor":
+			return []fs.FileInfo{
+				&fileutil.FileInfo{Name_: "vendor/Lib.java", Size_: int64(len(files["vendor/Lib.java"]))},
+			}, nil
+		}
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	})
+	git.NewFileReaderFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(files[path])), nil
+	})
+
+	u := &inventoryUpdater{db: db, gitserverClient: git}
+	require.NoError(t, u.Update(context.Background(), repo, "deadbeef"))
+
+	mockassert.CalledOnce(t, inventories.UpsertFunc)
+	assert.Equal(t, &database.RepoInventory{
+		RepoID:   1,
+		CommitID: "deadbeef",
+		Languages: []database.RepoLanguage{
+			{Language: "Java", TotalBytes: 30, TotalLines: 4},
+			{Language: "Kotlin", TotalBytes: 14, TotalLines: 1},
+		},
+	}, inventories.UpsertFunc.History()[0].Arg1)
+}
@@ -0,0 +1,81 @@
+package searchexport
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type workerConfig struct {
+	env.BaseConfig
+
+	NumHandlers int
+
+	UploadStoreConfig *export.UploadStoreConfig
+}
+
+var workerConfigInst = &workerConfig{}
+
+func (c *workerConfig) Load() {
+	c.NumHandlers = c.GetInt("SEARCH_EXPORT_WORKER_CONCURRENCY", "1", "The number of search export jobs to run at once.")
+
+	c.UploadStoreConfig = &export.UploadStoreConfig{}
+	c.UploadStoreConfig.Load()
+}
+
+func (c *workerConfig) Validate() error {
+	var errs error
+	errs = errors.Append(errs, c.BaseConfig.Validate())
+	errs = errors.Append(errs, c.UploadStoreConfig.Validate())
+	return errs
+}
+
+type workerJob struct{}
+
+var _ job.Job = &workerJob{}
+
+// NewWorkerJob returns the job running search export jobs.
+func NewWorkerJob() job.Job {
+	return &workerJob{}
+}
+
+func (j *workerJob) Description() string {
+	return "Runs search export jobs, which write every match of a search to the upload store."
+}
+
+func (j *workerJob) Config() []env.Config {
+	return []env.Config{
+		workerConfigInst,
+	}
+}
+
+func (j *workerJob) Routines(startupCtx context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := export.NewUploadStore(startupCtx, observationCtx, workerConfigInst.UploadStoreConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	searchClient := client.NewSearchClient(observationCtx.Logger, db, search.Indexed(), search.SearcherURLs())
+
+	// The handler runs each job as its initiator.
+	rootContext := actor.WithInternalActor(context.Background())
+
+	return []goroutine.BackgroundRoutine{
+		export.NewWorker(rootContext, observationCtx, db, uploadStore, searchClient, workerConfigInst.NumHandlers),
+		export.NewResetter(observationCtx, db),
+	}, nil
+}
@@ -16,7 +16,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/gitserver"
 	workermigrations "github.com/sourcegraph/sourcegraph/cmd/worker/internal/migrations"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/ownership"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repoinventory"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/repostatistics"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/sbom"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/searchexport"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/webhooks"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/internal/zoektrepos"
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
@@ -46,13 +50,18 @@ func Start(observationCtx *observation.Context, additionalJobs map[string]job.Jo
 	registerMigrators := oobmigration.ComposeRegisterMigratorsFuncs(migrations.RegisterOSSMigrators, registerEnterpriseMigrators)
 
 	builtins := map[string]job.Job{
-		"webhook-log-janitor":       webhooks.NewJanitor(),
-		"out-of-band-migrations":    workermigrations.NewMigrator(registerMigrators),
-		"codeintel-crates-syncer":   codeintel.NewCratesSyncerJob(),
-		"gitserver-metrics":         gitserver.NewMetricsJob(),
-		"record-encrypter":          encryption.NewRecordEncrypterJob(),
-		"repo-statistics-compactor": repostatistics.NewCompactor(),
-		"zoekt-repos-updater":       zoektrepos.NewUpdater(),
+		"webhook-log-janitor":        webhooks.NewJanitor(),
+		"out-of-band-migrations":     workermigrations.NewMigrator(registerMigrators),
+		"codeintel-crates-syncer":    codeintel.NewCratesSyncerJob(),
+		"codeintel-lockfile-indexer": codeintel.NewLockfileIndexerJob(),
+		"gitserver-metrics":          gitserver.NewMetricsJob(),
+		"record-encrypter":           encryption.NewRecordEncrypterJob(),
+		"repo-statistics-compactor":  repostatistics.NewCompactor(),
+		"zoekt-repos-updater":        zoektrepos.NewUpdater(),
+		"own-coverage-computer":      ownership.NewCoverageComputer(),
+		"sbom-exporter":              sbom.NewExporterJob(),
+		"search-export-worker":       searchexport.NewWorkerJob(),
+		"repo-inventory-computer":    repoinventory.NewComputer(),
 	}
 
 	jobs := map[string]job.Job{}
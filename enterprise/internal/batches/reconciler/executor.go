@@ -189,6 +189,28 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	}
 	opts := buildCommitOpts(e.targetRepo, e.spec, pushConf)
 
+	if dcss, ok := css.(sources.CommitDecoratingChangesetSource); ok {
+		// The commit may carry the title and body of the changeset, so they
+		// need to be decorated the same way as when publishing it.
+		body, err := e.decorateChangesetBody(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "decorating body for changeset %d", e.ch.ID)
+		}
+
+		cs := &sources.Changeset{
+			Title:      e.spec.Title,
+			Body:       body,
+			BaseRef:    e.spec.BaseRef,
+			HeadRef:    e.spec.HeadRef,
+			RemoteRepo: remoteRepo,
+			TargetRepo: e.targetRepo,
+			Changeset:  e.ch,
+		}
+		if err := dcss.DecorateCommit(cs, &opts); err != nil {
+			return errors.Wrap(err, "decorating commit")
+		}
+	}
+
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
 	if errors.As(err, &pce) {
@@ -0,0 +1,299 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOpsSource struct {
+	client *azuredevops.Client
+}
+
+var (
+	_ DraftChangesetSource = AzureDevOpsSource{}
+)
+
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := azuredevops.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Azure DevOps client")
+	}
+
+	return &AzureDevOpsSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AzureDevOpsSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AzureDevOpsSource", a)
+	}
+
+	return &AzureDevOpsSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s AzureDevOpsSource) ValidateAuthenticator(ctx context.Context) error {
+	// Personal access tokens are scoped to organizations, so they are
+	// validated against one of the organizations of the code host connection.
+	var org string
+	if len(s.client.Config.Orgs) > 0 {
+		org = s.client.Config.Orgs[0]
+	} else if len(s.client.Config.Projects) > 0 {
+		org, _, _ = strings.Cut(s.client.Config.Projects[0], "/")
+	}
+	if org == "" {
+		return errors.New("no organization to validate the credential against")
+	}
+
+	_, err := s.client.GetConnectionData(ctx, org)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AzureDevOpsSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	id, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, repo, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, false)
+}
+
+// CreateDraftChangeset creates the given changeset on the code host in draft
+// mode.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	return s.createChangeset(ctx, cs, true)
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, cs *Changeset, draft bool) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+
+	exists := false
+	pr, err := s.client.CreatePullRequest(ctx, repo, azuredevops.PullRequestInput{
+		SourceRefName: cs.HeadRef,
+		TargetRefName: cs.BaseRef,
+		Title:         cs.Title,
+		Description:   cs.Body,
+		IsDraft:       draft,
+	})
+	if err != nil {
+		// Azure DevOps responds with a conflict if there's already an active
+		// pull request for the branch.
+		if !errcode.IsHTTPErrorCode(err, http.StatusConflict) {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+
+		prs, err := s.client.ListPullRequests(ctx, repo, azuredevops.PullRequestSearchCriteria{
+			SourceRefName: cs.HeadRef,
+			TargetRefName: cs.BaseRef,
+			Status:        azuredevops.PullRequestStatusActive,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "listing pull requests")
+		}
+		if len(prs) == 0 {
+			return false, errors.New("pull request already exists, but could not be found")
+		}
+
+		pr = prs[0]
+		exists = true
+	}
+
+	if err := s.setChangesetMetadata(ctx, repo, pr, cs); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	return s.setStatus(ctx, cs, azuredevops.PullRequestStatusAbandoned, "abandoning pull request")
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	return s.setStatus(ctx, cs, azuredevops.PullRequestStatusActive, "reactivating pull request")
+}
+
+func (s AzureDevOpsSource) setStatus(ctx context.Context, cs *Changeset, status azuredevops.PullRequestStatus, action string) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	if pr.Status == status {
+		return nil
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, azuredevops.PullRequestUpdateInput{
+		Status: &status,
+	})
+	if err != nil {
+		return errors.Wrap(err, action)
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	input := azuredevops.PullRequestUpdateInput{
+		Title:       &cs.Title,
+		Description: &cs.Body,
+	}
+	// Azure DevOps rejects updates that set the target branch to the one it
+	// already has.
+	if pr.TargetRefName != cs.BaseRef {
+		input.TargetRefName = &cs.BaseRef
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, input)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// UndraftChangeset will update the Changeset on the source to be not in draft
+// mode anymore.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	draft := false
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, azuredevops.PullRequestUpdateInput{
+		IsDraft: &draft,
+	})
+	if err != nil {
+		return errors.Wrap(err, "undrafting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AzureDevOpsSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	return s.client.CreatePullRequestComment(ctx, repo, pr.ID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	repo := cs.TargetRepo.Metadata.(*azuredevops.Repository)
+	pr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+
+	strategy := azuredevops.MergeStrategyNoFastForward
+	if squash {
+		strategy = azuredevops.MergeStrategySquash
+	}
+
+	updated, err := s.client.CompletePullRequest(ctx, repo, pr.PullRequest, strategy)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "completing pull request")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.setChangesetMetadata(ctx, repo, updated, cs)
+}
+
+func (s AzureDevOpsSource) annotatePullRequest(ctx context.Context, repo *azuredevops.Repository, pr *azuredevops.PullRequest) (*adobatches.AnnotatedPullRequest, error) {
+	policies, err := s.client.GetPullRequestPolicyEvaluations(ctx, repo, pr)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting pull request policy evaluations")
+	}
+
+	return &adobatches.AnnotatedPullRequest{
+		PullRequest:      pr,
+		Policies:         policies,
+		RepositoryWebURL: repo.WebURL,
+	}, nil
+}
+
+func (s AzureDevOpsSource) setChangesetMetadata(ctx context.Context, repo *azuredevops.Repository, pr *azuredevops.PullRequest, cs *Changeset) error {
+	apr, err := s.annotatePullRequest(ctx, repo, pr)
+	if err != nil {
+		return errors.Wrap(err, "annotating pull request")
+	}
+
+	if err := cs.SetMetadata(apr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package azuredevops
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the Azure DevOps API. This type is used as the
+// primary metadata type for Azure DevOps changesets.
+type AnnotatedPullRequest struct {
+	*azuredevops.PullRequest
+	Policies []*azuredevops.PolicyEvaluation `json:"policies"`
+
+	// RepositoryWebURL is the web URL of the target repository, which the
+	// pull request API doesn't return.
+	RepositoryWebURL string `json:"repository_web_url"`
+}
+
+// URL returns the URL of the pull request in the Azure DevOps web UI.
+func (pr *AnnotatedPullRequest) URL() string {
+	return strings.TrimSuffix(pr.RepositoryWebURL, "/") + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+// BuildPolicies returns the evaluations of the enabled build validation
+// policies of the pull request.
+func (pr *AnnotatedPullRequest) BuildPolicies() []*azuredevops.PolicyEvaluation {
+	var builds []*azuredevops.PolicyEvaluation
+	for _, p := range pr.Policies {
+		if p.Configuration.Type.ID == azuredevops.PolicyTypeBuild && p.Configuration.IsEnabled {
+			builds = append(builds, p)
+		}
+	}
+	return builds
+}
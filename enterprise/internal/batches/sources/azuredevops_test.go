@@ -0,0 +1,240 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_LoadChangeset(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_LoadChangeset_found")
+		defer save(t)
+
+		cs.ExternalID = "7"
+		require.NoError(t, s.LoadChangeset(context.Background(), cs))
+		assertAzureDevOpsGolden(t, "AzureDevOpsSource_LoadChangeset_found", cs)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_LoadChangeset_not-found")
+		defer save(t)
+
+		cs.ExternalID = "999"
+		err := s.LoadChangeset(context.Background(), cs)
+		assert.True(t, errors.HasType(err, ChangesetNotFoundError{}))
+	})
+}
+
+func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_CreateChangeset_success")
+		defer save(t)
+
+		cs.HeadRef = "refs/heads/batch-changes/create"
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, "8", cs.ExternalID)
+	})
+
+	t.Run("already-exists", func(t *testing.T) {
+		s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_CreateChangeset_already-exists")
+		defer save(t)
+
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "7", cs.ExternalID)
+	})
+}
+
+func TestAzureDevOpsSource_Drafts(t *testing.T) {
+	ctx := context.Background()
+
+	s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_CreateDraftChangeset_success")
+	defer save(t)
+
+	cs.HeadRef = "refs/heads/batch-changes/draft"
+	exists, err := s.CreateDraftChangeset(ctx, cs)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.True(t, cs.Metadata.(*adobatches.AnnotatedPullRequest).IsDraft)
+
+	s, _, save = setupAzureDevOpsTest(t, "AzureDevOpsSource_UndraftChangeset_success")
+	defer save(t)
+
+	require.NoError(t, s.UndraftChangeset(ctx, cs))
+	assert.False(t, cs.Metadata.(*adobatches.AnnotatedPullRequest).IsDraft)
+}
+
+func TestAzureDevOpsSource_ChangesetActions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status azuredevops.PullRequestStatus
+		action func(AzureDevOpsSource, context.Context, *Changeset) error
+		want   azuredevops.PullRequestStatus
+	}{
+		"CloseChangeset_success": {
+			status: azuredevops.PullRequestStatusActive,
+			action: AzureDevOpsSource.CloseChangeset,
+			want:   azuredevops.PullRequestStatusAbandoned,
+		},
+		"ReopenChangeset_success": {
+			status: azuredevops.PullRequestStatusAbandoned,
+			action: AzureDevOpsSource.ReopenChangeset,
+			want:   azuredevops.PullRequestStatusActive,
+		},
+		"UpdateChangeset_success": {
+			status: azuredevops.PullRequestStatusActive,
+			action: AzureDevOpsSource.UpdateChangeset,
+			want:   azuredevops.PullRequestStatusActive,
+		},
+		"MergeChangeset_success": {
+			status: azuredevops.PullRequestStatusActive,
+			action: func(s AzureDevOpsSource, ctx context.Context, cs *Changeset) error {
+				return s.MergeChangeset(ctx, cs, true)
+			},
+			want: azuredevops.PullRequestStatusCompleted,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			name := "AzureDevOpsSource_" + name
+			s, cs, save := setupAzureDevOpsTest(t, name)
+			defer save(t)
+
+			setAzureDevOpsMetadata(t, cs, tc.status)
+			require.NoError(t, tc.action(*s, context.Background(), cs))
+
+			assert.Equal(t, tc.want, cs.Metadata.(*adobatches.AnnotatedPullRequest).Status)
+			assertAzureDevOpsGolden(t, name, cs)
+		})
+	}
+}
+
+func TestAzureDevOpsSource_MergeChangeset_conflict(t *testing.T) {
+	s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_MergeChangeset_conflict")
+	defer save(t)
+
+	setAzureDevOpsMetadata(t, cs, azuredevops.PullRequestStatusActive)
+	err := s.MergeChangeset(context.Background(), cs, false)
+	assert.True(t, errors.HasType(err, ChangesetNotMergeableError{}))
+}
+
+func TestAzureDevOpsSource_CreateComment(t *testing.T) {
+	s, cs, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_CreateComment_success")
+	defer save(t)
+
+	setAzureDevOpsMetadata(t, cs, azuredevops.PullRequestStatusActive)
+	require.NoError(t, s.CreateComment(context.Background(), cs, "Hello from Sourcegraph"))
+}
+
+func TestAzureDevOpsSource_ValidateAuthenticator(t *testing.T) {
+	s, _, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_ValidateAuthenticator_success")
+	defer save(t)
+
+	require.NoError(t, s.ValidateAuthenticator(context.Background()))
+}
+
+func TestAzureDevOpsSource_WithAuthenticator(t *testing.T) {
+	s, _, save := setupAzureDevOpsTest(t, "AzureDevOpsSource_WithAuthenticator")
+	defer save(t)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, a := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				src, err := s.WithAuthenticator(a)
+				require.NoError(t, err)
+				assert.Same(t, a, src.(*AzureDevOpsSource).client.Authenticator())
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := s.WithAuthenticator(&auth.OAuthBearerToken{})
+		assert.True(t, errors.HasType(err, UnsupportedAuthenticatorError{}))
+	})
+}
+
+func setupAzureDevOpsTest(t *testing.T, name string) (*AzureDevOpsSource, *Changeset, func(testing.TB)) {
+	t.Helper()
+
+	// The test fixtures and golden files were recorded against the
+	// sgtestazure organization on dev.azure.com.
+	cf, save := newClientFactory(t, name)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AzureDevOpsConnection{
+			Url:      "https://dev.azure.com",
+			Username: os.Getenv("AZURE_DEVOPS_USERNAME"),
+			Token:    os.Getenv("AZURE_DEVOPS_TOKEN"),
+			Orgs:     []string{"sgtestazure"},
+		})),
+	}
+
+	s, err := NewAzureDevOpsSource(context.Background(), svc, cf)
+	require.NoError(t, err)
+
+	repo := &types.Repo{
+		Metadata: &azuredevops.Repository{
+			ID:     "c4d186ef-18a6-4de4-a610-aa9ebd4e1faa",
+			Name:   "src-cli",
+			WebURL: "https://dev.azure.com/sgtestazure/sgtestazure/_git/src-cli",
+			Project: azuredevops.Project{
+				ID:   "5ee9a6a3-4c4a-4f6a-9b57-6a0a1a3e8a11",
+				Name: "sgtestazure",
+			},
+		},
+	}
+
+	cs := &Changeset{
+		Title:      "Update README again",
+		Body:       "This changes the README, again.",
+		HeadRef:    "refs/heads/batch-changes/test",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+
+	return s, cs, save
+}
+
+func setAzureDevOpsMetadata(t *testing.T, cs *Changeset, status azuredevops.PullRequestStatus) {
+	t.Helper()
+
+	require.NoError(t, cs.SetMetadata(&adobatches.AnnotatedPullRequest{
+		PullRequest: &azuredevops.PullRequest{
+			ID:            7,
+			CodeReviewID:  7,
+			Status:        status,
+			SourceRefName: cs.HeadRef,
+			TargetRefName: cs.BaseRef,
+			LastMergeSourceCommit: &azuredevops.CommitRef{
+				CommitID: "3f7b2c8e9d1a4b6c5e0f8a7d2c1b9e4f6a3d5c7b",
+			},
+		},
+	}))
+}
+
+func assertAzureDevOpsGolden(t *testing.T, name string, cs *Changeset) {
+	t.Helper()
+
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Metadata.(*adobatches.AnnotatedPullRequest))
+}
@@ -48,6 +48,18 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A CommitDecoratingChangesetSource needs the commit pushed for a changeset to
+// differ from what the changeset spec describes, for example because the code
+// host identifies changes by a trailer in the commit message, or because
+// changes are created by pushing to a ref other than the changeset branch.
+type CommitDecoratingChangesetSource interface {
+	ChangesetSource
+
+	// DecorateCommit modifies the request used to create and push the commit
+	// of the given Changeset before it is sent to gitserver.
+	DecorateCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -61,6 +73,18 @@ type ForkableChangesetSource interface {
 	GetUserFork(ctx context.Context, targetRepo *types.Repo) (*types.Repo, error)
 }
 
+// A PushPermissionChangesetSource can tell whether the authenticated user is
+// allowed to push branches to a repository. When publishing a changeset to a
+// repository the user cannot push to, the branch is pushed to a fork in the
+// user's namespace instead.
+type PushPermissionChangesetSource interface {
+	ForkableChangesetSource
+
+	// CanPush returns true if the currently authenticated user can push
+	// branches to the given repo.
+	CanPush(ctx context.Context, repo *types.Repo) (bool, error)
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// GitserverPushConfig returns an authenticated push config used for pushing
@@ -0,0 +1,270 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is the ChangesetSource for Gerrit. Gerrit has no pull requests:
+// a change is a single commit, created by pushing to refs/for/<branch> and
+// identified across patch sets by the Change-Id trailer of its commit
+// message. The commit is pushed by gitserver as for every other code host,
+// and DecorateCommit makes sure it ends up as the right change.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var (
+	_ ChangesetSource                 = GerritSource{}
+	_ CommitDecoratingChangesetSource = GerritSource{}
+)
+
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUser(ctx)
+	return err
+}
+
+// DecorateCommit makes the commit of the changeset a Gerrit change: the commit
+// message is built from the title and body of the changeset, since Gerrit
+// has no other place for them, and ends with a Change-Id trailer that stays
+// the same every time the changeset is pushed, so that pushes add patch sets
+// to the existing change. The commit is pushed to refs/for/<base branch>,
+// with the topic of the change set to the changeset branch.
+func (s GerritSource) DecorateCommit(cs *Changeset, opts *protocol.CreateCommitFromPatchRequest) error {
+	if opts.Push == nil {
+		return errors.New("no push config")
+	}
+
+	opts.CommitInfo.Message = commitMessage(cs, changeIDFor(cs))
+
+	push := *opts.Push
+	push.PushRef = "refs/for/" + gitdomain.AbbreviateRef(cs.BaseRef) + "%topic=" + gitdomain.AbbreviateRef(cs.HeadRef)
+	opts.Push = &push
+
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	// The change was created when the commit was pushed, so all that is left
+	// is to look it up.
+	project, err := projectName(cs.TargetRepo)
+	if err != nil {
+		return false, err
+	}
+
+	change, err := s.client.GetChange(ctx, gerrit.ChangeIdentifier(project, cs.BaseRef, changeIDFor(cs)))
+	if err != nil {
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// If the current patch set isn't the first one, the change existed before
+	// the commit was pushed.
+	rev, ok := change.Revisions[change.CurrentRevision]
+	return ok && rev.Number > 1, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if change.Status == gerrit.ChangeStatusNew {
+		if err := s.client.AbandonChange(ctx, change.ChangeNumber()); err != nil {
+			return errors.Wrap(err, "abandoning change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets. The title and body of a change are
+// its commit message, so updating them creates a new patch set.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if change.Subject != strings.TrimSpace(cs.Title) || change.Body() != strings.TrimSpace(cs.Body) {
+		if err := s.client.SetCommitMessage(ctx, change.ChangeNumber(), commitMessage(cs, change.ChangeID)); err != nil {
+			return errors.Wrap(err, "updating commit message")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if err := s.client.RestoreChange(ctx, change.ChangeNumber()); err != nil {
+			return errors.Wrap(err, "restoring change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	return s.client.SetReview(ctx, change.ChangeNumber(), gerrit.ReviewInput{Message: comment})
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes consist of a single commit and are submitted according to the
+// submit type of the project, so squash has no effect.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if err := s.client.SubmitChange(ctx, change.ChangeNumber()); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// commitMessage returns the commit message of the change for the given
+// changeset.
+func commitMessage(cs *Changeset, changeID string) string {
+	message := strings.TrimSpace(cs.Title)
+	if body := strings.TrimSpace(cs.Body); body != "" {
+		message += "\n\n" + body
+	}
+	return message + "\n\nChange-Id: " + changeID + "\n"
+}
+
+// changeIDFor returns the Change-Id of the given changeset. Changesets that
+// don't have a change yet get one derived from the repository, the changeset
+// and its branch, so that it's the same every time the changeset is pushed.
+func changeIDFor(cs *Changeset) string {
+	if change, ok := cs.Metadata.(*gerritbatches.AnnotatedChange); ok && change.ChangeID != "" {
+		return change.ChangeID
+	}
+
+	h := sha1.New()
+	h.Write([]byte(cs.TargetRepo.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(cs.ID, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(cs.HeadRef))
+	return "I" + hex.EncodeToString(h.Sum(nil))
+}
+
+// projectName returns the name of the Gerrit project of the given repo.
+func projectName(repo *types.Repo) (string, error) {
+	project, ok := repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("unexpected repo metadata type %T", repo.Metadata)
+	}
+	// Project IDs are URL-encoded project names.
+	name, err := url.PathUnescape(project.ID)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding project ID")
+	}
+	return name, nil
+}
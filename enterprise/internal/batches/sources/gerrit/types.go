@@ -0,0 +1,61 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API. This type is used as the primary metadata
+// type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	CodeHostURL string `json:"code_host_url"`
+}
+
+// URL returns the URL of the change in the Gerrit web UI.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// Body returns the commit message of the current patch set without its
+// subject and Change-Id trailer. Gerrit changes have no description separate
+// from the commit message.
+func (c *AnnotatedChange) Body() string {
+	commit, ok := c.CurrentCommit()
+	if !ok {
+		return ""
+	}
+	_, body := SplitCommitMessage(commit.Message)
+	return body
+}
+
+// HeadRef returns the ref of the changeset branch. Changes created by batch
+// changes have their topic set to the name of the branch; other changes only
+// have the ref of their current patch set.
+func (c *AnnotatedChange) HeadRef() string {
+	if c.Topic != "" {
+		return "refs/heads/" + c.Topic
+	}
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return rev.Ref
+	}
+	return ""
+}
+
+// SplitCommitMessage splits a commit message into its subject and body,
+// dropping the Change-Id trailer Gerrit requires.
+func SplitCommitMessage(message string) (subject, body string) {
+	subject, body, _ = strings.Cut(strings.TrimSpace(message), "\n")
+
+	lines := strings.Split(body, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "Change-Id: ") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.TrimSpace(subject), strings.TrimSpace(strings.Join(kept, "\n"))
+}
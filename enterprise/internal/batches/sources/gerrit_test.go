@@ -0,0 +1,328 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"username": ["not a string"]}`,
+			"bad URN":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGerritSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGerritSource(ctx, &types.ExternalService{Config: extsvc.NewUnencryptedConfig(`{"url": "https://gerrit.example.com/"}`)}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s, _ := newGerritTestSource(t, nil)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, tc := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				src, err := s.WithAuthenticator(tc)
+				require.NoError(t, err)
+				assert.Same(t, tc, src.(*GerritSource).client.Authenticator())
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := s.WithAuthenticator(&auth.OAuthBearerToken{})
+		assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+	})
+}
+
+func TestGerritSource_DecorateCommit(t *testing.T) {
+	s, _ := newGerritTestSource(t, nil)
+	cs := newGerritTestChangeset()
+
+	opts := protocol.CreateCommitFromPatchRequest{
+		TargetRef:  "batch-changes/test",
+		CommitInfo: protocol.PatchCommitInfo{Message: "the commit message of the spec"},
+		Push:       &protocol.PushConfig{RemoteURL: "https://gerrit.example.com/sourcegraph/sourcegraph"},
+	}
+	require.NoError(t, s.DecorateCommit(cs, &opts))
+
+	changeID := changeIDFor(cs)
+	assert.Regexp(t, "^I[0-9a-f]{40}$", changeID)
+	assert.Equal(t, "Update README\n\nThis updates the README.\n\nChange-Id: "+changeID+"\n", opts.CommitInfo.Message)
+	assert.Equal(t, "refs/for/main%topic=batch-changes/test", opts.Push.PushRef)
+	assert.Equal(t, "batch-changes/test", opts.TargetRef)
+
+	// The Change-Id is the same every time the changeset is pushed, so that
+	// the push adds a patch set to the existing change.
+	assert.Equal(t, changeID, changeIDFor(newGerritTestChangeset()))
+
+	// Once the change exists, its Change-Id is used.
+	require.NoError(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: &gerrit.Change{ChangeID: "I123", Number: 1}}))
+	assert.Equal(t, "I123", changeIDFor(cs))
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	cs := newGerritTestChangeset()
+
+	var requested string
+	s, srv := newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.EscapedPath()
+		writeGerritChange(t, w, testGerritChange(1))
+	})
+
+	exists, err := s.CreateChangeset(context.Background(), cs)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.Equal(t, "/a/changes/sourcegraph%2Fsourcegraph~main~"+changeIDFor(newGerritTestChangeset()), requested)
+	assert.Equal(t, "4247", cs.ExternalID)
+	assert.Equal(t, extsvc.TypeGerrit, cs.ExternalServiceType)
+	assert.Equal(t, "refs/heads/batch-changes/test", cs.ExternalBranch)
+
+	url, err := cs.Changeset.URL()
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/c/sourcegraph/sourcegraph/+/4247", url)
+
+	outdated, err := cs.IsOutdated()
+	require.NoError(t, err)
+	assert.False(t, outdated)
+
+	// A second patch set means the change already existed.
+	s, _ = newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		writeGerritChange(t, w, testGerritChange(2))
+	})
+	exists, err = s.CreateChangeset(context.Background(), newGerritTestChangeset())
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		s, _ := newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Not found: 4247", http.StatusNotFound)
+		})
+		cs := newGerritTestChangeset()
+		cs.ExternalID = "4247"
+
+		err := s.LoadChangeset(context.Background(), cs)
+		assert.Equal(t, ChangesetNotFoundError{Changeset: cs}, err)
+	})
+
+	t.Run("found", func(t *testing.T) {
+		s, _ := newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/a/changes/4247", r.URL.Path)
+			writeGerritChange(t, w, testGerritChange(1))
+		})
+		cs := newGerritTestChangeset()
+		cs.ExternalID = "4247"
+
+		require.NoError(t, s.LoadChangeset(context.Background(), cs))
+		assert.Equal(t, "I8473b95934b5732ac55d26311a706c9c2bde9940", cs.Metadata.(*gerritbatches.AnnotatedChange).ChangeID)
+	})
+}
+
+func TestGerritSource_ChangeActions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status gerrit.ChangeStatus
+		title  string
+		call   func(GerritSource, context.Context, *Changeset) error
+		want   []string
+	}{
+		"close open change": {
+			status: gerrit.ChangeStatusNew,
+			call:   GerritSource.CloseChangeset,
+			want:   []string{"POST /a/changes/4247/abandon", "GET /a/changes/4247"},
+		},
+		"close abandoned change": {
+			status: gerrit.ChangeStatusAbandoned,
+			call:   GerritSource.CloseChangeset,
+			want:   []string{"GET /a/changes/4247"},
+		},
+		"reopen abandoned change": {
+			status: gerrit.ChangeStatusAbandoned,
+			call:   GerritSource.ReopenChangeset,
+			want:   []string{"POST /a/changes/4247/restore", "GET /a/changes/4247"},
+		},
+		"reopen open change": {
+			status: gerrit.ChangeStatusNew,
+			call:   GerritSource.ReopenChangeset,
+			want:   []string{"GET /a/changes/4247"},
+		},
+		"update unchanged change": {
+			status: gerrit.ChangeStatusNew,
+			call:   GerritSource.UpdateChangeset,
+			want:   []string{"GET /a/changes/4247"},
+		},
+		"update title": {
+			status: gerrit.ChangeStatusNew,
+			title:  "Update the README",
+			call:   GerritSource.UpdateChangeset,
+			want:   []string{"PUT /a/changes/4247/message", "GET /a/changes/4247"},
+		},
+		"merge": {
+			status: gerrit.ChangeStatusNew,
+			call: func(s GerritSource, ctx context.Context, cs *Changeset) error {
+				return s.MergeChangeset(ctx, cs, true)
+			},
+			want: []string{"POST /a/changes/4247/submit", "GET /a/changes/4247"},
+		},
+		"comment": {
+			status: gerrit.ChangeStatusNew,
+			call: func(s GerritSource, ctx context.Context, cs *Changeset) error {
+				return s.CreateComment(ctx, cs, "hello")
+			},
+			want: []string{"POST /a/changes/4247/revisions/current/review"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			s, _ := newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch r.Method {
+				case "PUT":
+					var input struct{ Message string }
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+					assert.Equal(t, "Update the README\n\nThis updates the README.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n", input.Message)
+					w.WriteHeader(http.StatusNoContent)
+				case "POST":
+					io.WriteString(w, ")]}'\n{}")
+				default:
+					writeGerritChange(t, w, testGerritChange(1))
+				}
+			})
+
+			change := testGerritChange(1)
+			change.Status = tc.status
+			cs := newGerritTestChangeset()
+			require.NoError(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: change}))
+			if tc.title != "" {
+				cs.Title = tc.title
+			}
+
+			require.NoError(t, tc.call(s, context.Background(), cs))
+			assert.Equal(t, tc.want, requests)
+		})
+	}
+}
+
+func TestGerritSource_MergeChangeset_NotMergeable(t *testing.T) {
+	s, _ := newGerritTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "change is new", http.StatusConflict)
+	})
+
+	cs := newGerritTestChangeset()
+	require.NoError(t, cs.SetMetadata(&gerritbatches.AnnotatedChange{Change: testGerritChange(1)}))
+
+	err := s.MergeChangeset(context.Background(), cs, false)
+	assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+}
+
+func newGerritTestSource(t *testing.T, handler http.HandlerFunc) (GerritSource, *httptest.Server) {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s, err := NewGerritSource(context.Background(), &types.ExternalService{
+		Kind:   extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(fmt.Sprintf(`{"url": %q, "username": "admin", "password": "secret"}`, srv.URL+"/")),
+	}, nil)
+	require.NoError(t, err)
+	return *s, srv
+}
+
+func newGerritTestChangeset() *Changeset {
+	repo := &types.Repo{
+		Name: "gerrit.example.com/sourcegraph/sourcegraph",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "sourcegraph%2Fsourcegraph",
+			ServiceType: extsvc.TypeGerrit,
+		},
+		Metadata: &gerrit.Project{ID: "sourcegraph%2Fsourcegraph"},
+	}
+	return &Changeset{
+		Title:      "Update README",
+		Body:       "This updates the README.",
+		HeadRef:    "refs/heads/batch-changes/test",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{ID: 42, RepoID: 1},
+	}
+}
+
+func testGerritChange(patchSet int) *gerrit.Change {
+	revision := "184ebe53805e102605d11f6b143486d15c23a09c"
+	return &gerrit.Change{
+		ID:       "sourcegraph%2Fsourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+		Project:  "sourcegraph/sourcegraph",
+		Branch:   "main",
+		Topic:    "batch-changes/test",
+		ChangeID: "I8473b95934b5732ac55d26311a706c9c2bde9940",
+		Subject:  "Update README",
+		Status:   gerrit.ChangeStatusNew,
+		Number:   4247,
+		Owner:    gerrit.Account{Username: "admin"},
+
+		CurrentRevision: revision,
+		Revisions: map[string]gerrit.Revision{
+			revision: {
+				Number: patchSet,
+				Ref:    fmt.Sprintf("refs/changes/47/4247/%d", patchSet),
+				Commit: gerrit.Commit{
+					Parents: []gerrit.CommitParent{{Commit: "1eee2c9d8f352483781e772f35dc586a69ff5646"}},
+					Subject: "Update README",
+					Message: "Update README\n\nThis updates the README.\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n",
+				},
+			},
+		},
+	}
+}
+
+func writeGerritChange(t *testing.T, w http.ResponseWriter, change *gerrit.Change) {
+	t.Helper()
+
+	data, err := json.Marshal(change)
+	require.NoError(t, err)
+	io.WriteString(w, ")]}'\n")
+	w.Write(data)
+}
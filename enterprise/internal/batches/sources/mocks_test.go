@@ -2832,6 +2832,1681 @@ func (c ForkableChangesetSourceWithAuthenticatorFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// MockPushPermissionChangesetSource is a mock implementation of the
+// PushPermissionChangesetSource interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources)
+// used for unit testing.
+type MockPushPermissionChangesetSource struct {
+	// CanPushFunc is an instance of a mock function object controlling the
+	// behavior of the method CanPush.
+	CanPushFunc *PushPermissionChangesetSourceCanPushFunc
+	// CloseChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method CloseChangeset.
+	CloseChangesetFunc *PushPermissionChangesetSourceCloseChangesetFunc
+	// CreateChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateChangeset.
+	CreateChangesetFunc *PushPermissionChangesetSourceCreateChangesetFunc
+	// CreateCommentFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateComment.
+	CreateCommentFunc *PushPermissionChangesetSourceCreateCommentFunc
+	// GetNamespaceForkFunc is an instance of a mock function object
+	// controlling the behavior of the method GetNamespaceFork.
+	GetNamespaceForkFunc *PushPermissionChangesetSourceGetNamespaceForkFunc
+	// GetUserForkFunc is an instance of a mock function object controlling
+	// the behavior of the method GetUserFork.
+	GetUserForkFunc *PushPermissionChangesetSourceGetUserForkFunc
+	// GitserverPushConfigFunc is an instance of a mock function object
+	// controlling the behavior of the method GitserverPushConfig.
+	GitserverPushConfigFunc *PushPermissionChangesetSourceGitserverPushConfigFunc
+	// LoadChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method LoadChangeset.
+	LoadChangesetFunc *PushPermissionChangesetSourceLoadChangesetFunc
+	// MergeChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method MergeChangeset.
+	MergeChangesetFunc *PushPermissionChangesetSourceMergeChangesetFunc
+	// ReopenChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method ReopenChangeset.
+	ReopenChangesetFunc *PushPermissionChangesetSourceReopenChangesetFunc
+	// UpdateChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateChangeset.
+	UpdateChangesetFunc *PushPermissionChangesetSourceUpdateChangesetFunc
+	// ValidateAuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method ValidateAuthenticator.
+	ValidateAuthenticatorFunc *PushPermissionChangesetSourceValidateAuthenticatorFunc
+	// WithAuthenticatorFunc is an instance of a mock function object
+	// controlling the behavior of the method WithAuthenticator.
+	WithAuthenticatorFunc *PushPermissionChangesetSourceWithAuthenticatorFunc
+}
+
+// NewMockPushPermissionChangesetSource creates a new mock of the
+// PushPermissionChangesetSource interface. All methods return zero values
+// for all results, unless overwritten.
+func NewMockPushPermissionChangesetSource() *MockPushPermissionChangesetSource {
+	return &MockPushPermissionChangesetSource{
+		CanPushFunc: &PushPermissionChangesetSourceCanPushFunc{
+			defaultHook: func(context.Context, *types.Repo) (r0 bool, r1 error) {
+				return
+			},
+		},
+		CloseChangesetFunc: &PushPermissionChangesetSourceCloseChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (r0 error) {
+				return
+			},
+		},
+		CreateChangesetFunc: &PushPermissionChangesetSourceCreateChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (r0 bool, r1 error) {
+				return
+			},
+		},
+		CreateCommentFunc: &PushPermissionChangesetSourceCreateCommentFunc{
+			defaultHook: func(context.Context, *Changeset, string) (r0 error) {
+				return
+			},
+		},
+		GetNamespaceForkFunc: &PushPermissionChangesetSourceGetNamespaceForkFunc{
+			defaultHook: func(context.Context, *types.Repo, string) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		GetUserForkFunc: &PushPermissionChangesetSourceGetUserForkFunc{
+			defaultHook: func(context.Context, *types.Repo) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
+		GitserverPushConfigFunc: &PushPermissionChangesetSourceGitserverPushConfigFunc{
+			defaultHook: func(*types.Repo) (r0 *protocol.PushConfig, r1 error) {
+				return
+			},
+		},
+		LoadChangesetFunc: &PushPermissionChangesetSourceLoadChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (r0 error) {
+				return
+			},
+		},
+		MergeChangesetFunc: &PushPermissionChangesetSourceMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) (r0 error) {
+				return
+			},
+		},
+		ReopenChangesetFunc: &PushPermissionChangesetSourceReopenChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (r0 error) {
+				return
+			},
+		},
+		UpdateChangesetFunc: &PushPermissionChangesetSourceUpdateChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (r0 error) {
+				return
+			},
+		},
+		ValidateAuthenticatorFunc: &PushPermissionChangesetSourceValidateAuthenticatorFunc{
+			defaultHook: func(context.Context) (r0 error) {
+				return
+			},
+		},
+		WithAuthenticatorFunc: &PushPermissionChangesetSourceWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) (r0 ChangesetSource, r1 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockPushPermissionChangesetSource creates a new mock of the
+// PushPermissionChangesetSource interface. All methods panic on invocation,
+// unless overwritten.
+func NewStrictMockPushPermissionChangesetSource() *MockPushPermissionChangesetSource {
+	return &MockPushPermissionChangesetSource{
+		CanPushFunc: &PushPermissionChangesetSourceCanPushFunc{
+			defaultHook: func(context.Context, *types.Repo) (bool, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.CanPush")
+			},
+		},
+		CloseChangesetFunc: &PushPermissionChangesetSourceCloseChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.CloseChangeset")
+			},
+		},
+		CreateChangesetFunc: &PushPermissionChangesetSourceCreateChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) (bool, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.CreateChangeset")
+			},
+		},
+		CreateCommentFunc: &PushPermissionChangesetSourceCreateCommentFunc{
+			defaultHook: func(context.Context, *Changeset, string) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.CreateComment")
+			},
+		},
+		GetNamespaceForkFunc: &PushPermissionChangesetSourceGetNamespaceForkFunc{
+			defaultHook: func(context.Context, *types.Repo, string) (*types.Repo, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.GetNamespaceFork")
+			},
+		},
+		GetUserForkFunc: &PushPermissionChangesetSourceGetUserForkFunc{
+			defaultHook: func(context.Context, *types.Repo) (*types.Repo, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.GetUserFork")
+			},
+		},
+		GitserverPushConfigFunc: &PushPermissionChangesetSourceGitserverPushConfigFunc{
+			defaultHook: func(*types.Repo) (*protocol.PushConfig, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.GitserverPushConfig")
+			},
+		},
+		LoadChangesetFunc: &PushPermissionChangesetSourceLoadChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.LoadChangeset")
+			},
+		},
+		MergeChangesetFunc: &PushPermissionChangesetSourceMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.MergeChangeset")
+			},
+		},
+		ReopenChangesetFunc: &PushPermissionChangesetSourceReopenChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.ReopenChangeset")
+			},
+		},
+		UpdateChangesetFunc: &PushPermissionChangesetSourceUpdateChangesetFunc{
+			defaultHook: func(context.Context, *Changeset) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.UpdateChangeset")
+			},
+		},
+		ValidateAuthenticatorFunc: &PushPermissionChangesetSourceValidateAuthenticatorFunc{
+			defaultHook: func(context.Context) error {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.ValidateAuthenticator")
+			},
+		},
+		WithAuthenticatorFunc: &PushPermissionChangesetSourceWithAuthenticatorFunc{
+			defaultHook: func(auth.Authenticator) (ChangesetSource, error) {
+				panic("unexpected invocation of MockPushPermissionChangesetSource.WithAuthenticator")
+			},
+		},
+	}
+}
+
+// NewMockPushPermissionChangesetSourceFrom creates a new mock of the
+// MockPushPermissionChangesetSource interface. All methods delegate to the
+// given implementation, unless overwritten.
+func NewMockPushPermissionChangesetSourceFrom(i PushPermissionChangesetSource) *MockPushPermissionChangesetSource {
+	return &MockPushPermissionChangesetSource{
+		CanPushFunc: &PushPermissionChangesetSourceCanPushFunc{
+			defaultHook: i.CanPush,
+		},
+		CloseChangesetFunc: &PushPermissionChangesetSourceCloseChangesetFunc{
+			defaultHook: i.CloseChangeset,
+		},
+		CreateChangesetFunc: &PushPermissionChangesetSourceCreateChangesetFunc{
+			defaultHook: i.CreateChangeset,
+		},
+		CreateCommentFunc: &PushPermissionChangesetSourceCreateCommentFunc{
+			defaultHook: i.CreateComment,
+		},
+		GetNamespaceForkFunc: &PushPermissionChangesetSourceGetNamespaceForkFunc{
+			defaultHook: i.GetNamespaceFork,
+		},
+		GetUserForkFunc: &PushPermissionChangesetSourceGetUserForkFunc{
+			defaultHook: i.GetUserFork,
+		},
+		GitserverPushConfigFunc: &PushPermissionChangesetSourceGitserverPushConfigFunc{
+			defaultHook: i.GitserverPushConfig,
+		},
+		LoadChangesetFunc: &PushPermissionChangesetSourceLoadChangesetFunc{
+			defaultHook: i.LoadChangeset,
+		},
+		MergeChangesetFunc: &PushPermissionChangesetSourceMergeChangesetFunc{
+			defaultHook: i.MergeChangeset,
+		},
+		ReopenChangesetFunc: &PushPermissionChangesetSourceReopenChangesetFunc{
+			defaultHook: i.ReopenChangeset,
+		},
+		UpdateChangesetFunc: &PushPermissionChangesetSourceUpdateChangesetFunc{
+			defaultHook: i.UpdateChangeset,
+		},
+		ValidateAuthenticatorFunc: &PushPermissionChangesetSourceValidateAuthenticatorFunc{
+			defaultHook: i.ValidateAuthenticator,
+		},
+		WithAuthenticatorFunc: &PushPermissionChangesetSourceWithAuthenticatorFunc{
+			defaultHook: i.WithAuthenticator,
+		},
+	}
+}
+
+// PushPermissionChangesetSourceCanPushFunc describes the behavior when the
+// CanPush method of the parent MockPushPermissionChangesetSource instance
+// is invoked.
+type PushPermissionChangesetSourceCanPushFunc struct {
+	defaultHook func(context.Context, *types.Repo) (bool, error)
+	hooks       []func(context.Context, *types.Repo) (bool, error)
+	history     []PushPermissionChangesetSourceCanPushFuncCall
+	mutex       sync.Mutex
+}
+
+// CanPush delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) CanPush(v0 context.Context, v1 *types.Repo) (bool, error) {
+	r0, r1 := m.CanPushFunc.nextHook()(v0, v1)
+	m.CanPushFunc.appendCall(PushPermissionChangesetSourceCanPushFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CanPush method of
+// the parent MockPushPermissionChangesetSource instance is invoked and the
+// hook queue is empty.
+func (f *PushPermissionChangesetSourceCanPushFunc) SetDefaultHook(hook func(context.Context, *types.Repo) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CanPush method of the parent MockPushPermissionChangesetSource instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *PushPermissionChangesetSourceCanPushFunc) PushHook(hook func(context.Context, *types.Repo) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceCanPushFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.Repo) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceCanPushFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, *types.Repo) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceCanPushFunc) nextHook() func(context.Context, *types.Repo) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceCanPushFunc) appendCall(r0 PushPermissionChangesetSourceCanPushFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceCanPushFuncCall objects describing the
+// invocations of this function.
+func (f *PushPermissionChangesetSourceCanPushFunc) History() []PushPermissionChangesetSourceCanPushFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceCanPushFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceCanPushFuncCall is an object that describes
+// an invocation of method CanPush on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceCanPushFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.Repo
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceCanPushFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceCanPushFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PushPermissionChangesetSourceCloseChangesetFunc describes the behavior
+// when the CloseChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceCloseChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset) error
+	hooks       []func(context.Context, *Changeset) error
+	history     []PushPermissionChangesetSourceCloseChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// CloseChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) CloseChangeset(v0 context.Context, v1 *Changeset) error {
+	r0 := m.CloseChangesetFunc.nextHook()(v0, v1)
+	m.CloseChangesetFunc.appendCall(PushPermissionChangesetSourceCloseChangesetFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CloseChangeset
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CloseChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) PushHook(hook func(context.Context, *Changeset) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) nextHook() func(context.Context, *Changeset) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) appendCall(r0 PushPermissionChangesetSourceCloseChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceCloseChangesetFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceCloseChangesetFunc) History() []PushPermissionChangesetSourceCloseChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceCloseChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceCloseChangesetFuncCall is an object that
+// describes an invocation of method CloseChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceCloseChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceCloseChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceCloseChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceCreateChangesetFunc describes the behavior
+// when the CreateChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceCreateChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset) (bool, error)
+	hooks       []func(context.Context, *Changeset) (bool, error)
+	history     []PushPermissionChangesetSourceCreateChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) CreateChangeset(v0 context.Context, v1 *Changeset) (bool, error) {
+	r0, r1 := m.CreateChangesetFunc.nextHook()(v0, v1)
+	m.CreateChangesetFunc.appendCall(PushPermissionChangesetSourceCreateChangesetFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateChangeset
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) PushHook(hook func(context.Context, *Changeset) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, *Changeset) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) nextHook() func(context.Context, *Changeset) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) appendCall(r0 PushPermissionChangesetSourceCreateChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceCreateChangesetFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceCreateChangesetFunc) History() []PushPermissionChangesetSourceCreateChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceCreateChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceCreateChangesetFuncCall is an object that
+// describes an invocation of method CreateChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceCreateChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceCreateChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceCreateChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PushPermissionChangesetSourceCreateCommentFunc describes the behavior
+// when the CreateComment method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceCreateCommentFunc struct {
+	defaultHook func(context.Context, *Changeset, string) error
+	hooks       []func(context.Context, *Changeset, string) error
+	history     []PushPermissionChangesetSourceCreateCommentFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateComment delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) CreateComment(v0 context.Context, v1 *Changeset, v2 string) error {
+	r0 := m.CreateCommentFunc.nextHook()(v0, v1, v2)
+	m.CreateCommentFunc.appendCall(PushPermissionChangesetSourceCreateCommentFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CreateComment method
+// of the parent MockPushPermissionChangesetSource instance is invoked and
+// the hook queue is empty.
+func (f *PushPermissionChangesetSourceCreateCommentFunc) SetDefaultHook(hook func(context.Context, *Changeset, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateComment method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceCreateCommentFunc) PushHook(hook func(context.Context, *Changeset, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceCreateCommentFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceCreateCommentFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, string) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceCreateCommentFunc) nextHook() func(context.Context, *Changeset, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceCreateCommentFunc) appendCall(r0 PushPermissionChangesetSourceCreateCommentFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceCreateCommentFuncCall objects describing the
+// invocations of this function.
+func (f *PushPermissionChangesetSourceCreateCommentFunc) History() []PushPermissionChangesetSourceCreateCommentFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceCreateCommentFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceCreateCommentFuncCall is an object that
+// describes an invocation of method CreateComment on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceCreateCommentFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceCreateCommentFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceCreateCommentFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceGetNamespaceForkFunc describes the behavior
+// when the GetNamespaceFork method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceGetNamespaceForkFunc struct {
+	defaultHook func(context.Context, *types.Repo, string) (*types.Repo, error)
+	hooks       []func(context.Context, *types.Repo, string) (*types.Repo, error)
+	history     []PushPermissionChangesetSourceGetNamespaceForkFuncCall
+	mutex       sync.Mutex
+}
+
+// GetNamespaceFork delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) GetNamespaceFork(v0 context.Context, v1 *types.Repo, v2 string) (*types.Repo, error) {
+	r0, r1 := m.GetNamespaceForkFunc.nextHook()(v0, v1, v2)
+	m.GetNamespaceForkFunc.appendCall(PushPermissionChangesetSourceGetNamespaceForkFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetNamespaceFork
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) SetDefaultHook(hook func(context.Context, *types.Repo, string) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetNamespaceFork method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) PushHook(hook func(context.Context, *types.Repo, string) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.Repo, string) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, *types.Repo, string) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) nextHook() func(context.Context, *types.Repo, string) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) appendCall(r0 PushPermissionChangesetSourceGetNamespaceForkFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceGetNamespaceForkFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceGetNamespaceForkFunc) History() []PushPermissionChangesetSourceGetNamespaceForkFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceGetNamespaceForkFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceGetNamespaceForkFuncCall is an object that
+// describes an invocation of method GetNamespaceFork on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceGetNamespaceForkFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.Repo
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceGetNamespaceForkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceGetNamespaceForkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PushPermissionChangesetSourceGetUserForkFunc describes the behavior when
+// the GetUserFork method of the parent MockPushPermissionChangesetSource
+// instance is invoked.
+type PushPermissionChangesetSourceGetUserForkFunc struct {
+	defaultHook func(context.Context, *types.Repo) (*types.Repo, error)
+	hooks       []func(context.Context, *types.Repo) (*types.Repo, error)
+	history     []PushPermissionChangesetSourceGetUserForkFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUserFork delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) GetUserFork(v0 context.Context, v1 *types.Repo) (*types.Repo, error) {
+	r0, r1 := m.GetUserForkFunc.nextHook()(v0, v1)
+	m.GetUserForkFunc.appendCall(PushPermissionChangesetSourceGetUserForkFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUserFork method
+// of the parent MockPushPermissionChangesetSource instance is invoked and
+// the hook queue is empty.
+func (f *PushPermissionChangesetSourceGetUserForkFunc) SetDefaultHook(hook func(context.Context, *types.Repo) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUserFork method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceGetUserForkFunc) PushHook(hook func(context.Context, *types.Repo) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceGetUserForkFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, *types.Repo) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceGetUserForkFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, *types.Repo) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceGetUserForkFunc) nextHook() func(context.Context, *types.Repo) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceGetUserForkFunc) appendCall(r0 PushPermissionChangesetSourceGetUserForkFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceGetUserForkFuncCall objects describing the
+// invocations of this function.
+func (f *PushPermissionChangesetSourceGetUserForkFunc) History() []PushPermissionChangesetSourceGetUserForkFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceGetUserForkFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceGetUserForkFuncCall is an object that
+// describes an invocation of method GetUserFork on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceGetUserForkFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *types.Repo
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceGetUserForkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceGetUserForkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PushPermissionChangesetSourceGitserverPushConfigFunc describes the
+// behavior when the GitserverPushConfig method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceGitserverPushConfigFunc struct {
+	defaultHook func(*types.Repo) (*protocol.PushConfig, error)
+	hooks       []func(*types.Repo) (*protocol.PushConfig, error)
+	history     []PushPermissionChangesetSourceGitserverPushConfigFuncCall
+	mutex       sync.Mutex
+}
+
+// GitserverPushConfig delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) GitserverPushConfig(v0 *types.Repo) (*protocol.PushConfig, error) {
+	r0, r1 := m.GitserverPushConfigFunc.nextHook()(v0)
+	m.GitserverPushConfigFunc.appendCall(PushPermissionChangesetSourceGitserverPushConfigFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GitserverPushConfig
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) SetDefaultHook(hook func(*types.Repo) (*protocol.PushConfig, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GitserverPushConfig method of the parent
+// MockPushPermissionChangesetSource instance invokes the hook at the front
+// of the queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) PushHook(hook func(*types.Repo) (*protocol.PushConfig, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) SetDefaultReturn(r0 *protocol.PushConfig, r1 error) {
+	f.SetDefaultHook(func(*types.Repo) (*protocol.PushConfig, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) PushReturn(r0 *protocol.PushConfig, r1 error) {
+	f.PushHook(func(*types.Repo) (*protocol.PushConfig, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) nextHook() func(*types.Repo) (*protocol.PushConfig, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) appendCall(r0 PushPermissionChangesetSourceGitserverPushConfigFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceGitserverPushConfigFuncCall objects
+// describing the invocations of this function.
+func (f *PushPermissionChangesetSourceGitserverPushConfigFunc) History() []PushPermissionChangesetSourceGitserverPushConfigFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceGitserverPushConfigFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceGitserverPushConfigFuncCall is an object
+// that describes an invocation of method GitserverPushConfig on an instance
+// of MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceGitserverPushConfigFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 *types.Repo
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *protocol.PushConfig
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceGitserverPushConfigFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceGitserverPushConfigFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// PushPermissionChangesetSourceLoadChangesetFunc describes the behavior
+// when the LoadChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceLoadChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset) error
+	hooks       []func(context.Context, *Changeset) error
+	history     []PushPermissionChangesetSourceLoadChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// LoadChangeset delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) LoadChangeset(v0 context.Context, v1 *Changeset) error {
+	r0 := m.LoadChangesetFunc.nextHook()(v0, v1)
+	m.LoadChangesetFunc.appendCall(PushPermissionChangesetSourceLoadChangesetFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the LoadChangeset method
+// of the parent MockPushPermissionChangesetSource instance is invoked and
+// the hook queue is empty.
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LoadChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) PushHook(hook func(context.Context, *Changeset) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) nextHook() func(context.Context, *Changeset) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) appendCall(r0 PushPermissionChangesetSourceLoadChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceLoadChangesetFuncCall objects describing the
+// invocations of this function.
+func (f *PushPermissionChangesetSourceLoadChangesetFunc) History() []PushPermissionChangesetSourceLoadChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceLoadChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceLoadChangesetFuncCall is an object that
+// describes an invocation of method LoadChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceLoadChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceLoadChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceLoadChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceMergeChangesetFunc describes the behavior
+// when the MergeChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceMergeChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset, bool) error
+	hooks       []func(context.Context, *Changeset, bool) error
+	history     []PushPermissionChangesetSourceMergeChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// MergeChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) MergeChangeset(v0 context.Context, v1 *Changeset, v2 bool) error {
+	r0 := m.MergeChangesetFunc.nextHook()(v0, v1, v2)
+	m.MergeChangesetFunc.appendCall(PushPermissionChangesetSourceMergeChangesetFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MergeChangeset
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MergeChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) PushHook(hook func(context.Context, *Changeset, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) nextHook() func(context.Context, *Changeset, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) appendCall(r0 PushPermissionChangesetSourceMergeChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceMergeChangesetFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceMergeChangesetFunc) History() []PushPermissionChangesetSourceMergeChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceMergeChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceMergeChangesetFuncCall is an object that
+// describes an invocation of method MergeChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceMergeChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceMergeChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceMergeChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceReopenChangesetFunc describes the behavior
+// when the ReopenChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceReopenChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset) error
+	hooks       []func(context.Context, *Changeset) error
+	history     []PushPermissionChangesetSourceReopenChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// ReopenChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) ReopenChangeset(v0 context.Context, v1 *Changeset) error {
+	r0 := m.ReopenChangesetFunc.nextHook()(v0, v1)
+	m.ReopenChangesetFunc.appendCall(PushPermissionChangesetSourceReopenChangesetFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReopenChangeset
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReopenChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) PushHook(hook func(context.Context, *Changeset) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) nextHook() func(context.Context, *Changeset) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) appendCall(r0 PushPermissionChangesetSourceReopenChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceReopenChangesetFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceReopenChangesetFunc) History() []PushPermissionChangesetSourceReopenChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceReopenChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceReopenChangesetFuncCall is an object that
+// describes an invocation of method ReopenChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceReopenChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceReopenChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceReopenChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceUpdateChangesetFunc describes the behavior
+// when the UpdateChangeset method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceUpdateChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset) error
+	hooks       []func(context.Context, *Changeset) error
+	history     []PushPermissionChangesetSourceUpdateChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) UpdateChangeset(v0 context.Context, v1 *Changeset) error {
+	r0 := m.UpdateChangesetFunc.nextHook()(v0, v1)
+	m.UpdateChangesetFunc.appendCall(PushPermissionChangesetSourceUpdateChangesetFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateChangeset
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateChangeset method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) PushHook(hook func(context.Context, *Changeset) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) nextHook() func(context.Context, *Changeset) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) appendCall(r0 PushPermissionChangesetSourceUpdateChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceUpdateChangesetFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceUpdateChangesetFunc) History() []PushPermissionChangesetSourceUpdateChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceUpdateChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceUpdateChangesetFuncCall is an object that
+// describes an invocation of method UpdateChangeset on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceUpdateChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceUpdateChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceUpdateChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceValidateAuthenticatorFunc describes the
+// behavior when the ValidateAuthenticator method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceValidateAuthenticatorFunc struct {
+	defaultHook func(context.Context) error
+	hooks       []func(context.Context) error
+	history     []PushPermissionChangesetSourceValidateAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// ValidateAuthenticator delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) ValidateAuthenticator(v0 context.Context) error {
+	r0 := m.ValidateAuthenticatorFunc.nextHook()(v0)
+	m.ValidateAuthenticatorFunc.appendCall(PushPermissionChangesetSourceValidateAuthenticatorFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ValidateAuthenticator method of the parent
+// MockPushPermissionChangesetSource instance is invoked and the hook queue
+// is empty.
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) SetDefaultHook(hook func(context.Context) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ValidateAuthenticator method of the parent
+// MockPushPermissionChangesetSource instance invokes the hook at the front
+// of the queue and discards it. After the queue is empty, the default hook
+// function is invoked for any future action.
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) PushHook(hook func(context.Context) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context) error {
+		return r0
+	})
+}
+
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) nextHook() func(context.Context) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) appendCall(r0 PushPermissionChangesetSourceValidateAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceValidateAuthenticatorFuncCall objects
+// describing the invocations of this function.
+func (f *PushPermissionChangesetSourceValidateAuthenticatorFunc) History() []PushPermissionChangesetSourceValidateAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceValidateAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceValidateAuthenticatorFuncCall is an object
+// that describes an invocation of method ValidateAuthenticator on an
+// instance of MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceValidateAuthenticatorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceValidateAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceValidateAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// PushPermissionChangesetSourceWithAuthenticatorFunc describes the behavior
+// when the WithAuthenticator method of the parent
+// MockPushPermissionChangesetSource instance is invoked.
+type PushPermissionChangesetSourceWithAuthenticatorFunc struct {
+	defaultHook func(auth.Authenticator) (ChangesetSource, error)
+	hooks       []func(auth.Authenticator) (ChangesetSource, error)
+	history     []PushPermissionChangesetSourceWithAuthenticatorFuncCall
+	mutex       sync.Mutex
+}
+
+// WithAuthenticator delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockPushPermissionChangesetSource) WithAuthenticator(v0 auth.Authenticator) (ChangesetSource, error) {
+	r0, r1 := m.WithAuthenticatorFunc.nextHook()(v0)
+	m.WithAuthenticatorFunc.appendCall(PushPermissionChangesetSourceWithAuthenticatorFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the WithAuthenticator
+// method of the parent MockPushPermissionChangesetSource instance is
+// invoked and the hook queue is empty.
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) SetDefaultHook(hook func(auth.Authenticator) (ChangesetSource, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithAuthenticator method of the parent MockPushPermissionChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) PushHook(hook func(auth.Authenticator) (ChangesetSource, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) SetDefaultReturn(r0 ChangesetSource, r1 error) {
+	f.SetDefaultHook(func(auth.Authenticator) (ChangesetSource, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) PushReturn(r0 ChangesetSource, r1 error) {
+	f.PushHook(func(auth.Authenticator) (ChangesetSource, error) {
+		return r0, r1
+	})
+}
+
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) nextHook() func(auth.Authenticator) (ChangesetSource, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) appendCall(r0 PushPermissionChangesetSourceWithAuthenticatorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// PushPermissionChangesetSourceWithAuthenticatorFuncCall objects describing
+// the invocations of this function.
+func (f *PushPermissionChangesetSourceWithAuthenticatorFunc) History() []PushPermissionChangesetSourceWithAuthenticatorFuncCall {
+	f.mutex.Lock()
+	history := make([]PushPermissionChangesetSourceWithAuthenticatorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// PushPermissionChangesetSourceWithAuthenticatorFuncCall is an object that
+// describes an invocation of method WithAuthenticator on an instance of
+// MockPushPermissionChangesetSource.
+type PushPermissionChangesetSourceWithAuthenticatorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 auth.Authenticator
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 ChangesetSource
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c PushPermissionChangesetSourceWithAuthenticatorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c PushPermissionChangesetSourceWithAuthenticatorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockSourcerStore is a mock implementation of the SourcerStore interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources)
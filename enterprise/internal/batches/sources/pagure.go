@@ -0,0 +1,378 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type PagureSource struct {
+	client *pagure.Client
+}
+
+var (
+	_ ForkableChangesetSource       = PagureSource{}
+	_ PushPermissionChangesetSource = PagureSource{}
+)
+
+func NewPagureSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*PagureSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.PagureConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := pagure.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Pagure client")
+	}
+
+	return &PagureSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s PagureSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s PagureSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("PagureSource", a)
+	}
+
+	return &PagureSource{client: s.client.WithAuthenticator(a)}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s PagureSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.WhoAmI(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s PagureSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	id, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrapf(err, "converting external ID %q", cs.ExternalID)
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return setPagureChangesetMetadata(pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s PagureSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	input := s.changesetToPullRequestInput(cs)
+
+	// Pagure happily opens a second pull request for the same branch, so we
+	// have to look for an existing one first.
+	pr, err := s.findOpenPullRequest(ctx, project, input)
+	if err != nil {
+		return false, err
+	}
+	if pr != nil {
+		return true, setPagureChangesetMetadata(pr, cs)
+	}
+
+	pr, err = s.client.CreatePullRequest(ctx, project, input)
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	return false, setPagureChangesetMetadata(pr, cs)
+}
+
+func (s PagureSource) findOpenPullRequest(ctx context.Context, project *pagure.Project, input pagure.CreatePullRequestInput) (*pagure.PullRequest, error) {
+	sourceID := project.ID
+	if input.RepoFrom != nil {
+		sourceID = input.RepoFrom.ID
+	}
+
+	it := s.client.ListPullRequests(ctx, project, pagure.ListPullRequestsArgs{
+		Status: pagure.PullRequestStatusOpen,
+	})
+	for it.Next() {
+		pr := it.Current()
+		if pr.BranchFrom != input.BranchFrom || pr.Branch != input.BranchTo {
+			continue
+		}
+		fromID := project.ID
+		if pr.RepoFrom != nil {
+			fromID = pr.RepoFrom.ID
+		}
+		if fromID == sourceID {
+			return pr, nil
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, errors.Wrap(err, "listing pull requests")
+	}
+
+	return nil, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s PagureSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	pr := cs.Metadata.(*pagure.PullRequest)
+
+	if pr.Status == pagure.PullRequestStatusClosed {
+		return nil
+	}
+
+	if err := s.client.ClosePullRequest(ctx, project, pr.ID); err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return s.reloadPullRequest(ctx, project, pr.ID, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s PagureSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	pr := cs.Metadata.(*pagure.PullRequest)
+
+	if pr.Status != pagure.PullRequestStatusClosed {
+		return nil
+	}
+
+	if err := s.client.ReopenPullRequest(ctx, project, pr.ID); err != nil {
+		return errors.Wrap(err, "reopening pull request")
+	}
+
+	return s.reloadPullRequest(ctx, project, pr.ID, cs)
+}
+
+// UpdateChangeset can update Changesets.
+//
+// Pagure only allows updating the title and description of a pull request,
+// so changes to the base branch are not applied.
+func (s PagureSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	pr := cs.Metadata.(*pagure.PullRequest)
+
+	updated, err := s.client.UpdatePullRequest(ctx, project, pr.ID, pagure.UpdatePullRequestInput{
+		Title:          cs.Title,
+		InitialComment: cs.Body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return setPagureChangesetMetadata(updated, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s PagureSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	pr := cs.Metadata.(*pagure.PullRequest)
+
+	return s.client.CreatePullRequestComment(ctx, project, pr.ID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Pagure doesn't support squash merges through its API, so squash is ignored.
+// If the changeset cannot be merged, because it is in an unmergeable state,
+// ChangesetNotMergeableError is returned.
+func (s PagureSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	pr := cs.Metadata.(*pagure.PullRequest)
+
+	if err := s.client.MergePullRequest(ctx, project, pr.ID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "merging pull request")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.reloadPullRequest(ctx, project, pr.ID, cs)
+}
+
+// CanPush returns true if the currently authenticated user has commit access
+// to the given repo. Access granted through groups isn't taken into account,
+// since Pagure doesn't expose the members of groups to other users.
+func (s PagureSource) CanPush(ctx context.Context, repo *types.Repo) (bool, error) {
+	username, err := s.client.WhoAmI(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "getting the current user")
+	}
+
+	// The synced repo metadata can be stale, so we always ask for the current
+	// access list.
+	project, err := s.client.GetProject(ctx, repo.Metadata.(*pagure.Project).URLPath)
+	if err != nil {
+		return false, errors.Wrap(err, "getting project")
+	}
+
+	for _, access := range []string{"owner", "admin", "commit"} {
+		for _, user := range project.AccessUsers[access] {
+			if user == username {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// GetNamespaceFork returns a repo pointing to a fork of the given repo in
+// the given namespace, ensuring that the fork exists and is a fork of the
+// target repo. Pagure only creates forks in the namespace of the current
+// user, so forks in other namespaces must already exist.
+func (s PagureSource) GetNamespaceFork(ctx context.Context, targetRepo *types.Repo, namespace string) (*types.Repo, error) {
+	return s.getFork(ctx, targetRepo, namespace, "")
+}
+
+// GetUserFork returns a repo pointing to a fork of the given repo in the
+// currently authenticated user's namespace.
+func (s PagureSource) GetUserFork(ctx context.Context, targetRepo *types.Repo) (*types.Repo, error) {
+	username, err := s.client.WhoAmI(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting the current user")
+	}
+
+	return s.getFork(ctx, targetRepo, username, username)
+}
+
+// getFork returns the fork of the target repo in the given namespace, creating
+// it if it doesn't exist yet. username is the name of the current user, and is
+// only looked up when empty and a fork has to be created.
+func (s PagureSource) getFork(ctx context.Context, targetRepo *types.Repo, namespace, username string) (*types.Repo, error) {
+	targetMeta := targetRepo.Metadata.(*pagure.Project)
+
+	// Figure out if we already have the fork.
+	fork, err := s.client.GetFork(ctx, targetMeta, namespace)
+	if err == nil {
+		if fork.Parent == nil || fork.Parent.ID != targetMeta.ID {
+			return nil, errors.Errorf("project %q is not a fork of %q", fork.Fullname, targetMeta.Fullname)
+		}
+		return s.copyRepoAsFork(targetRepo, fork)
+	} else if !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "checking for fork existence")
+	}
+
+	if username == "" {
+		if username, err = s.client.WhoAmI(ctx); err != nil {
+			return nil, errors.Wrap(err, "getting the current user")
+		}
+	}
+	if username != namespace {
+		return nil, errors.Errorf("cannot fork into namespace %q: Pagure only forks into the namespace of the current user %q", namespace, username)
+	}
+
+	if err := s.client.ForkProject(ctx, targetMeta); err != nil {
+		return nil, errors.Wrap(err, "forking project")
+	}
+
+	fork, err = s.client.GetFork(ctx, targetMeta, namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting created fork")
+	}
+
+	return s.copyRepoAsFork(targetRepo, fork)
+}
+
+func (s PagureSource) copyRepoAsFork(targetRepo *types.Repo, fork *pagure.Project) (*types.Repo, error) {
+	targetMeta := targetRepo.Metadata.(*pagure.Project)
+
+	u, err := url.Parse(targetMeta.FullURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing project URL")
+	}
+
+	// The clone URL of a project is its full URL, so we replace the path of
+	// the project including the host, to avoid replacing a matching prefix
+	// of a longer path.
+	forkRepo, err := CopyRepoAsFork(
+		targetRepo,
+		fork,
+		path.Join(u.Host, targetMeta.URLPath),
+		path.Join(u.Host, fork.Fullname),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating target repo sources")
+	}
+
+	return forkRepo, nil
+}
+
+func (s PagureSource) reloadPullRequest(ctx context.Context, project *pagure.Project, id int, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, project, id)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return setPagureChangesetMetadata(pr, cs)
+}
+
+func (s PagureSource) changesetToPullRequestInput(cs *Changeset) pagure.CreatePullRequestInput {
+	input := pagure.CreatePullRequestInput{
+		Title:          cs.Title,
+		InitialComment: cs.Body,
+		BranchTo:       gitdomain.AbbreviateRef(cs.BaseRef),
+		BranchFrom:     gitdomain.AbbreviateRef(cs.HeadRef),
+	}
+
+	// If we're forking, then we need to set the source repository as well.
+	if cs.RemoteRepo != cs.TargetRepo {
+		input.RepoFrom = cs.RemoteRepo.Metadata.(*pagure.Project)
+	}
+
+	return input
+}
+
+func setPagureChangesetMetadata(pr *pagure.PullRequest, cs *Changeset) error {
+	if err := cs.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+package sources
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPagureSource_LoadChangeset(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		s, cs, save := setupPagureTest(t, "PagureSource_LoadChangeset_found")
+		defer save(t)
+
+		cs.ExternalID = "1"
+		require.NoError(t, s.LoadChangeset(context.Background(), cs))
+		assertPagureGolden(t, "PagureSource_LoadChangeset_found", cs)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		s, cs, save := setupPagureTest(t, "PagureSource_LoadChangeset_not-found")
+		defer save(t)
+
+		cs.ExternalID = "999"
+		err := s.LoadChangeset(context.Background(), cs)
+		assert.True(t, errors.HasType(err, ChangesetNotFoundError{}))
+	})
+}
+
+func TestPagureSource_CreateChangeset(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		s, cs, save := setupPagureTest(t, "PagureSource_CreateChangeset_success")
+		defer save(t)
+
+		cs.HeadRef = "refs/heads/batch-changes/create"
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, "3", cs.ExternalID)
+		assert.Empty(t, cs.ExternalForkNamespace)
+	})
+
+	t.Run("already-exists", func(t *testing.T) {
+		s, cs, save := setupPagureTest(t, "PagureSource_CreateChangeset_already-exists")
+		defer save(t)
+
+		// The fixture also contains a pull request for the same branch from
+		// a fork, which must not be mistaken for the changeset.
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "1", cs.ExternalID)
+	})
+
+	t.Run("fork", func(t *testing.T) {
+		s, cs, save := setupPagureTest(t, "PagureSource_CreateChangeset_fork")
+		defer save(t)
+
+		fork, err := s.copyRepoAsFork(cs.TargetRepo, &pagure.Project{
+			ID:       11240,
+			Name:     "batch-changes-test",
+			Fullname: "forks/sg-bot/batch-changes-test",
+			URLPath:  "fork/sg-bot/batch-changes-test",
+			User:     &pagure.User{Name: "sg-bot"},
+		})
+		require.NoError(t, err)
+		cs.RemoteRepo = fork
+
+		exists, err := s.CreateChangeset(context.Background(), cs)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, "4", cs.ExternalID)
+		assert.Equal(t, "sg-bot", cs.ExternalForkNamespace)
+	})
+}
+
+func TestPagureSource_ChangesetActions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status pagure.PullRequestStatus
+		action func(PagureSource, context.Context, *Changeset) error
+		want   pagure.PullRequestStatus
+	}{
+		"CloseChangeset_success": {
+			status: pagure.PullRequestStatusOpen,
+			action: PagureSource.CloseChangeset,
+			want:   pagure.PullRequestStatusClosed,
+		},
+		"ReopenChangeset_success": {
+			status: pagure.PullRequestStatusClosed,
+			action: PagureSource.ReopenChangeset,
+			want:   pagure.PullRequestStatusOpen,
+		},
+		"UpdateChangeset_success": {
+			status: pagure.PullRequestStatusOpen,
+			action: PagureSource.UpdateChangeset,
+			want:   pagure.PullRequestStatusOpen,
+		},
+		"MergeChangeset_success": {
+			status: pagure.PullRequestStatusOpen,
+			action: func(s PagureSource, ctx context.Context, cs *Changeset) error {
+				return s.MergeChangeset(ctx, cs, false)
+			},
+			want: pagure.PullRequestStatusMerged,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			name := "PagureSource_" + name
+			s, cs, save := setupPagureTest(t, name)
+			defer save(t)
+
+			setPagureMetadata(t, cs, tc.status)
+			require.NoError(t, tc.action(*s, context.Background(), cs))
+
+			assert.Equal(t, tc.want, cs.Metadata.(*pagure.PullRequest).Status)
+			assertPagureGolden(t, name, cs)
+		})
+	}
+}
+
+func TestPagureSource_ChangesetActions_noop(t *testing.T) {
+	// The cassette is empty, so any request made by the source fails.
+	s, cs, save := setupPagureTest(t, "PagureSource_ChangesetActions_noop")
+	defer save(t)
+
+	setPagureMetadata(t, cs, pagure.PullRequestStatusClosed)
+	require.NoError(t, s.CloseChangeset(context.Background(), cs))
+
+	setPagureMetadata(t, cs, pagure.PullRequestStatusOpen)
+	require.NoError(t, s.ReopenChangeset(context.Background(), cs))
+}
+
+func TestPagureSource_MergeChangeset_conflict(t *testing.T) {
+	s, cs, save := setupPagureTest(t, "PagureSource_MergeChangeset_conflict")
+	defer save(t)
+
+	setPagureMetadata(t, cs, pagure.PullRequestStatusOpen)
+	err := s.MergeChangeset(context.Background(), cs, false)
+	assert.True(t, errors.HasType(err, ChangesetNotMergeableError{}))
+}
+
+func TestPagureSource_CreateComment(t *testing.T) {
+	s, cs, save := setupPagureTest(t, "PagureSource_CreateComment_success")
+	defer save(t)
+
+	setPagureMetadata(t, cs, pagure.PullRequestStatusOpen)
+	require.NoError(t, s.CreateComment(context.Background(), cs, "Hello from Sourcegraph"))
+}
+
+func TestPagureSource_ValidateAuthenticator(t *testing.T) {
+	s, _, save := setupPagureTest(t, "PagureSource_ValidateAuthenticator_success")
+	defer save(t)
+
+	require.NoError(t, s.ValidateAuthenticator(context.Background()))
+}
+
+func TestPagureSource_WithAuthenticator(t *testing.T) {
+	s, _, save := setupPagureTest(t, "PagureSource_WithAuthenticator")
+	defer save(t)
+
+	t.Run("supported", func(t *testing.T) {
+		for name, a := range map[string]auth.Authenticator{
+			"BasicAuth":        &auth.BasicAuth{},
+			"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+		} {
+			t.Run(name, func(t *testing.T) {
+				src, err := s.WithAuthenticator(a)
+				require.NoError(t, err)
+				assert.Same(t, a, src.(*PagureSource).client.Authenticator())
+			})
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := s.WithAuthenticator(&auth.OAuthBearerToken{})
+		assert.True(t, errors.HasType(err, UnsupportedAuthenticatorError{}))
+	})
+}
+
+func TestPagureSource_CanPush(t *testing.T) {
+	for name, want := range map[string]bool{
+		"commit":    true,
+		"no-access": false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, cs, save := setupPagureTest(t, "PagureSource_CanPush_"+name)
+			defer save(t)
+
+			have, err := s.CanPush(context.Background(), cs.TargetRepo)
+			require.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+}
+
+func TestPagureSource_GetUserFork(t *testing.T) {
+	for _, name := range []string{"existing", "create"} {
+		t.Run(name, func(t *testing.T) {
+			s, cs, save := setupPagureTest(t, "PagureSource_GetUserFork_"+name)
+			defer save(t)
+
+			fork, err := s.GetUserFork(context.Background(), cs.TargetRepo)
+			require.NoError(t, err)
+
+			assert.Equal(t, "forks/sg-bot/batch-changes-test", fork.Metadata.(*pagure.Project).Fullname)
+			assert.Equal(t, []string{"https://pagure.io/forks/sg-bot/batch-changes-test"}, fork.CloneURLs())
+		})
+	}
+}
+
+func TestPagureSource_GetNamespaceFork(t *testing.T) {
+	s, cs, save := setupPagureTest(t, "PagureSource_GetNamespaceFork_other-namespace")
+	defer save(t)
+
+	// Pagure can't fork into the namespaces of other users.
+	_, err := s.GetNamespaceFork(context.Background(), cs.TargetRepo, "someone-else")
+	assert.ErrorContains(t, err, "only forks into the namespace of the current user")
+}
+
+func setupPagureTest(t *testing.T, name string) (*PagureSource, *Changeset, func(testing.TB)) {
+	t.Helper()
+
+	// The test fixtures and golden files were recorded against the
+	// batch-changes-test project on pagure.io.
+	cf, save := newClientFactory(t, name)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindPagure,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.PagureConnection{
+			Url:   "https://pagure.io",
+			Token: os.Getenv("PAGURE_TOKEN"),
+		})),
+	}
+
+	s, err := NewPagureSource(context.Background(), svc, cf)
+	require.NoError(t, err)
+
+	repo := &types.Repo{
+		Metadata: &pagure.Project{
+			ID:       11234,
+			Name:     "batch-changes-test",
+			Fullname: "batch-changes-test",
+			FullURL:  "https://pagure.io/batch-changes-test",
+			URLPath:  "batch-changes-test",
+		},
+		Sources: map[string]*types.SourceInfo{
+			"extsvc:pagure:1": {
+				ID:       "extsvc:pagure:1",
+				CloneURL: "https://pagure.io/batch-changes-test",
+			},
+		},
+	}
+
+	cs := &Changeset{
+		Title:      "Update README again",
+		Body:       "This changes the README, again.",
+		HeadRef:    "refs/heads/batch-changes/test",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+
+	return s, cs, save
+}
+
+func setPagureMetadata(t *testing.T, cs *Changeset, status pagure.PullRequestStatus) {
+	t.Helper()
+
+	project := cs.TargetRepo.Metadata.(*pagure.Project)
+	require.NoError(t, cs.SetMetadata(&pagure.PullRequest{
+		ID:         1,
+		Status:     status,
+		Branch:     "main",
+		BranchFrom: "batch-changes/test",
+		Project:    project,
+		RepoFrom:   project,
+	}))
+}
+
+func assertPagureGolden(t *testing.T, name string, cs *Changeset) {
+	t.Helper()
+
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Metadata.(*pagure.PullRequest))
+}
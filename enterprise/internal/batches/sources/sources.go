@@ -290,7 +290,10 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 		case *schema.GitHubConnection,
 			*schema.BitbucketServerConnection,
 			*schema.GitLabConnection,
-			*schema.BitbucketCloudConnection:
+			*schema.BitbucketCloudConnection,
+			*schema.GerritConnection,
+			*schema.AzureDevOpsConnection,
+			*schema.PagureConnection:
 			return e, nil
 		}
 	}
@@ -310,6 +313,12 @@ func buildChangesetSource(ctx context.Context, cf *httpcli.Factory, externalServ
 		return NewBitbucketServerSource(ctx, externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, externalService, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(ctx, externalService, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, externalService, cf)
+	case extsvc.KindPagure:
+		return NewPagureSource(ctx, externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -359,6 +368,15 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeGerrit:
+		return errors.New("require username/password to push commits to Gerrit")
+
+	case extsvc.TypeAzureDevOps:
+		return errors.New("require username/token to push commits to Azure DevOps")
+
+	case extsvc.TypePagure:
+		return errors.New("require username/token to push commits to Pagure")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -372,7 +390,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeGerrit, extsvc.TypeAzureDevOps, extsvc.TypePagure:
 		u.User = url.UserPassword(username, password)
 
 	default:
@@ -423,7 +441,27 @@ func GetRemoteRepo(
 	// even check if the changeset source is forkable, let alone set up the
 	// remote repo: we can just return the target repo and be done with it.
 	if ch.ExternalForkNamespace == "" && (spec == nil || !spec.IsFork()) {
-		return targetRepo, nil
+		// Some code hosts let us check whether we can push to the target repo
+		// at all. If we can't, we publish the changeset from a user fork
+		// instead of failing to push.
+		pss, ok := css.(PushPermissionChangesetSource)
+		if !ok || spec == nil {
+			return targetRepo, nil
+		}
+
+		canPush, err := pss.CanPush(ctx, targetRepo)
+		if err != nil {
+			return nil, errors.Wrap(err, "checking push permission")
+		}
+		if canPush {
+			return targetRepo, nil
+		}
+
+		repo, err := pss.GetUserFork(ctx, targetRepo)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting user fork")
+		}
+		return repo, nil
 	}
 
 	fss, ok := css.(ForkableChangesetSource)
@@ -520,6 +520,50 @@ func TestGetRemoteRepo(t *testing.T) {
 		})
 	})
 
+	t.Run("push permission", func(t *testing.T) {
+		t.Run("can push", func(t *testing.T) {
+			css := NewMockPushPermissionChangesetSource()
+			css.CanPushFunc.SetDefaultReturn(true, nil)
+
+			remoteRepo, err := GetRemoteRepo(ctx, css, targetRepo, &btypes.Changeset{}, &btypes.ChangesetSpec{})
+			assert.Nil(t, err)
+			assert.Same(t, targetRepo, remoteRepo)
+			mockassert.NotCalled(t, css.GetUserForkFunc)
+		})
+
+		t.Run("cannot push", func(t *testing.T) {
+			want := &types.Repo{}
+			css := NewMockPushPermissionChangesetSource()
+			css.CanPushFunc.SetDefaultReturn(false, nil)
+			css.GetUserForkFunc.SetDefaultReturn(want, nil)
+
+			remoteRepo, err := GetRemoteRepo(ctx, css, targetRepo, &btypes.Changeset{}, &btypes.ChangesetSpec{})
+			assert.Nil(t, err)
+			assert.Same(t, want, remoteRepo)
+			mockassert.CalledOnce(t, css.GetUserForkFunc)
+		})
+
+		t.Run("without changeset spec", func(t *testing.T) {
+			css := NewMockPushPermissionChangesetSource()
+
+			remoteRepo, err := GetRemoteRepo(ctx, css, targetRepo, &btypes.Changeset{}, nil)
+			assert.Nil(t, err)
+			assert.Same(t, targetRepo, remoteRepo)
+			mockassert.NotCalled(t, css.CanPushFunc)
+		})
+
+		t.Run("error", func(t *testing.T) {
+			want := errors.New("source error")
+			css := NewMockPushPermissionChangesetSource()
+			css.CanPushFunc.SetDefaultReturn(false, want)
+
+			remoteRepo, err := GetRemoteRepo(ctx, css, targetRepo, &btypes.Changeset{}, &btypes.ChangesetSpec{})
+			assert.Nil(t, remoteRepo)
+			assert.ErrorContains(t, err, want.Error())
+			mockassert.NotCalled(t, css.GetUserForkFunc)
+		})
+	})
+
 	t.Run("forks enabled", func(t *testing.T) {
 		forkNamespace := "<user>"
 
@@ -3,6 +3,8 @@ package state
 import (
 	"time"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -14,6 +16,10 @@ func setDraft(c *btypes.Changeset) *btypes.Changeset {
 		m.IsDraft = true
 	case *gitlab.MergeRequest:
 		m.WorkInProgress = true
+	case *gerritbatches.AnnotatedChange:
+		m.WorkInProgress = true
+	case *adobatches.AnnotatedPullRequest:
+		m.IsDraft = true
 	}
 	return c
 }
@@ -11,17 +11,22 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -112,6 +117,12 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
+
+	case *adobatches.AnnotatedPullRequest:
+		return computeAzureDevOpsBuildState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -251,6 +262,53 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+// computeGerritCheckState maps the Verified label, which is where CI systems
+// vote on Gerrit changes, to a check state.
+func computeGerritCheckState(change *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := change.Labels["Verified"]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	switch {
+	case label.Rejected != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
+// computeAzureDevOpsBuildState computes the check state from the build
+// validation policies of the pull request. Other policies, such as required
+// reviewers, are reflected in the review state.
+func computeAzureDevOpsBuildState(pr *adobatches.AnnotatedPullRequest) btypes.ChangesetCheckState {
+	var states []btypes.ChangesetCheckState
+	for _, p := range pr.BuildPolicies() {
+		// Builds that don't apply to the changed files are not run at all.
+		if p.Status == azuredevops.PolicyEvaluationStatusNotApplicable {
+			continue
+		}
+		states = append(states, parseAzureDevOpsBuildState(p.Status))
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseAzureDevOpsBuildState(s azuredevops.PolicyEvaluationStatus) btypes.ChangesetCheckState {
+	switch s {
+	case azuredevops.PolicyEvaluationStatusRejected, azuredevops.PolicyEvaluationStatusBroken:
+		return btypes.ChangesetCheckStateFailed
+	case azuredevops.PolicyEvaluationStatusQueued, azuredevops.PolicyEvaluationStatusRunning:
+		return btypes.ChangesetCheckStatePending
+	case azuredevops.PolicyEvaluationStatusApproved:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -515,6 +573,47 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *adobatches.AnnotatedPullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = btypes.ChangesetExternalStateMerged
+		case azuredevops.PullRequestStatusActive:
+			if m.IsDraft {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
+	case *pagure.PullRequest:
+		switch m.Status {
+		case pagure.PullRequestStatusClosed:
+			s = btypes.ChangesetExternalStateClosed
+		case pagure.PullRequestStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case pagure.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Pagure pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +683,42 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// Only the Code-Review label is about reviews: a -2 or -1 requests
+		// changes, and a +2 approves the change. A +1 alone doesn't allow the
+		// change to be submitted, so it's still pending.
+		label := m.Labels["Code-Review"]
+		switch {
+		case label.Rejected != nil, label.Disliked != nil:
+			states[btypes.ChangesetReviewStateChangesRequested] = true
+		case label.Approved != nil:
+			states[btypes.ChangesetReviewStateApproved] = true
+		default:
+			states[btypes.ChangesetReviewStatePending] = true
+		}
+
+	case *adobatches.AnnotatedPullRequest:
+		// Approvals with suggestions are still approvals, and waiting for the
+		// author is Azure DevOps' way of requesting changes.
+		for _, r := range m.Reviewers {
+			switch {
+			case r.Vote >= azuredevops.ReviewerVoteApprovedWithSuggestions:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case r.Vote <= azuredevops.ReviewerVoteWaitingForAuthor:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
+	case *pagure.PullRequest:
+		// Pagure has no reviews, only a minimum score of +1 comments that a
+		// project can require before pull requests can be merged.
+		if m.ThresholdReached != nil && *m.ThresholdReached {
+			return btypes.ChangesetReviewStateApproved, nil
+		}
+		return btypes.ChangesetReviewStatePending, nil
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
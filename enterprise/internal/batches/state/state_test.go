@@ -7,11 +7,16 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -407,6 +412,107 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		labels map[string]gerrit.ChangeLabel
+		want   btypes.ChangesetCheckState
+	}{
+		"no Verified label": {
+			labels: map[string]gerrit.ChangeLabel{"Code-Review": {Approved: &gerrit.Account{}}},
+			want:   btypes.ChangesetCheckStateUnknown,
+		},
+		"no votes": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		"verified": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {Approved: &gerrit.Account{}}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		"failed": {
+			labels: map[string]gerrit.ChangeLabel{"Verified": {Rejected: &gerrit.Account{}}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := gerritChangeset(timeutil.Now(), gerrit.ChangeStatusNew, tc.labels)
+			if have := computeCheckState(c, nil); have != tc.want {
+				t.Errorf("wrong check state. have=%s, want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeAzureDevOpsBuildState(t *testing.T) {
+	t.Parallel()
+
+	build := func(status azuredevops.PolicyEvaluationStatus) *azuredevops.PolicyEvaluation {
+		return &azuredevops.PolicyEvaluation{
+			Status: status,
+			Configuration: azuredevops.PolicyConfiguration{
+				IsEnabled: true,
+				Type:      azuredevops.PolicyType{ID: azuredevops.PolicyTypeBuild},
+			},
+		}
+	}
+	reviewers := &azuredevops.PolicyEvaluation{
+		Status: azuredevops.PolicyEvaluationStatusRejected,
+		Configuration: azuredevops.PolicyConfiguration{
+			IsEnabled: true,
+			Type:      azuredevops.PolicyType{ID: "fa4e907d-c16b-4a4c-9dfa-4906e5d171dd"},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		policies []*azuredevops.PolicyEvaluation
+		want     btypes.ChangesetCheckState
+	}{
+		"no policies": {
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		"only non-build policies": {
+			policies: []*azuredevops.PolicyEvaluation{reviewers},
+			want:     btypes.ChangesetCheckStateUnknown,
+		},
+		"not applicable": {
+			policies: []*azuredevops.PolicyEvaluation{build(azuredevops.PolicyEvaluationStatusNotApplicable)},
+			want:     btypes.ChangesetCheckStateUnknown,
+		},
+		"running": {
+			policies: []*azuredevops.PolicyEvaluation{
+				build(azuredevops.PolicyEvaluationStatusApproved),
+				build(azuredevops.PolicyEvaluationStatusRunning),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		"passed": {
+			policies: []*azuredevops.PolicyEvaluation{
+				build(azuredevops.PolicyEvaluationStatusApproved),
+				build(azuredevops.PolicyEvaluationStatusNotApplicable),
+				reviewers,
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		"failed": {
+			policies: []*azuredevops.PolicyEvaluation{
+				build(azuredevops.PolicyEvaluationStatusApproved),
+				build(azuredevops.PolicyEvaluationStatusRejected),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := azureDevOpsChangeset(timeutil.Now(), azuredevops.PullRequestStatusActive, nil)
+			c.Metadata.(*adobatches.AnnotatedPullRequest).Policies = tc.policies
+			if have := computeCheckState(c, nil); have != tc.want {
+				t.Errorf("wrong check state. have=%s, want=%s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +642,102 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - no votes",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - recommended",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Recommended: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Approved: &gerrit.Account{}},
+				"Verified":    {Rejected: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "gerrit - disliked",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Disliked: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "gerrit - rejected",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, map[string]gerrit.ChangeLabel{
+				"Code-Review": {Rejected: &gerrit.Account{}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name:      "azure devops - no reviewers",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "azure devops - approved with suggestions",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, []azuredevops.ReviewerVote{
+				azuredevops.ReviewerVoteApprovedWithSuggestions,
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "azure devops - waiting for author",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, []azuredevops.ReviewerVote{
+				azuredevops.ReviewerVoteApproved,
+				azuredevops.ReviewerVoteWaitingForAuthor,
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "azure devops - rejected",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, []azuredevops.ReviewerVote{
+				azuredevops.ReviewerVoteRejected,
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name: "azure devops - no vote",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, []azuredevops.ReviewerVote{
+				azuredevops.ReviewerVoteNoVote,
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "pagure - no minimum score",
+			changeset: pagureChangeset(daysAgo(0), pagure.PullRequestStatusOpen, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "pagure - threshold not reached",
+			changeset: pagureChangeset(daysAgo(0), pagure.PullRequestStatusOpen, boolPtr(false)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "pagure - threshold reached",
+			changeset: pagureChangeset(daysAgo(0), pagure.PullRequestStatusOpen, boolPtr(true)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +946,72 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "gerrit - new",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "gerrit - work in progress",
+			changeset: setDraft(gerritChangeset(daysAgo(10), gerrit.ChangeStatusNew, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "gerrit - merged",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gerrit - abandoned",
+			changeset: gerritChangeset(daysAgo(10), gerrit.ChangeStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "azure devops - active",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusActive, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "azure devops - draft",
+			changeset: setDraft(azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusActive, nil)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
+		{
+			name:      "azure devops - completed",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusCompleted, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "azure devops - abandoned",
+			changeset: azureDevOpsChangeset(daysAgo(10), azuredevops.PullRequestStatusAbandoned, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "pagure - open",
+			changeset: pagureChangeset(daysAgo(10), pagure.PullRequestStatusOpen, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "pagure - merged",
+			changeset: pagureChangeset(daysAgo(10), pagure.PullRequestStatusMerged, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "pagure - closed",
+			changeset: pagureChangeset(daysAgo(10), pagure.PullRequestStatusClosed, nil),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
 	}
 
 	for i, tc := range tests {
@@ -899,6 +1167,50 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, labels map[string]gerrit.ChangeLabel) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerritbatches.AnnotatedChange{
+			Change: &gerrit.Change{
+				Status: status,
+				Labels: labels,
+			},
+		},
+	}
+}
+
+func azureDevOpsChangeset(updatedAt time.Time, status azuredevops.PullRequestStatus, votes []azuredevops.ReviewerVote) *btypes.Changeset {
+	var reviewers []azuredevops.Reviewer
+	for _, v := range votes {
+		reviewers = append(reviewers, azuredevops.Reviewer{Vote: v})
+	}
+
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAzureDevOps,
+		UpdatedAt:           updatedAt,
+		Metadata: &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{
+				Status:    status,
+				Reviewers: reviewers,
+			},
+		},
+	}
+}
+
+func pagureChangeset(updatedAt time.Time, status pagure.PullRequestStatus, thresholdReached *bool) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypePagure,
+		UpdatedAt:           updatedAt,
+		Metadata: &pagure.PullRequest{
+			Status:           status,
+			ThresholdReached: thresholdReached,
+		},
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
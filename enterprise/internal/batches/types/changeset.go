@@ -8,13 +8,16 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/go-diff/diff"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/lib/batches"
@@ -420,6 +423,39 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *gerritbatches.AnnotatedChange:
+		c.Metadata = pr
+		c.ExternalID = pr.ChangeNumber()
+		c.ExternalServiceType = extsvc.TypeGerrit
+		c.ExternalBranch = pr.HeadRef()
+		c.ExternalUpdatedAt = pr.Updated.Time
+		// Gerrit changes are always pushed to the target project.
+		c.ExternalForkNamespace = ""
+	case *adobatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceRefName)
+		// Azure DevOps doesn't tell when a pull request was last updated.
+		c.ExternalUpdatedAt = pr.CreationDate
+		if pr.ClosedDate != nil {
+			c.ExternalUpdatedAt = *pr.ClosedDate
+		}
+		c.ExternalForkNamespace = ""
+	case *pagure.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypePagure
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.BranchFrom)
+		c.ExternalUpdatedAt = pr.LastUpdated.Time
+
+		// Pagure only forks into user namespaces, so the owner of a fork is
+		// its namespace.
+		if pr.RepoFrom != nil && pr.Project != nil && pr.RepoFrom.ID != pr.Project.ID && pr.RepoFrom.User != nil {
+			c.ExternalForkNamespace = pr.RepoFrom.User.Name
+		} else {
+			c.ExternalForkNamespace = ""
+		}
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +483,12 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Subject, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Title, nil
+	case *pagure.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +508,12 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Username, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreatedBy.UniqueName, nil
+	case *pagure.PullRequest:
+		return m.User.Name, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +541,18 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Owner.Email, nil
+	case *adobatches.AnnotatedPullRequest:
+		// The unique name is only an e-mail address on Azure DevOps Services;
+		// Azure DevOps Server uses domain account names.
+		if strings.Contains(m.CreatedBy.UniqueName, "@") {
+			return m.CreatedBy.UniqueName, nil
+		}
+		return "", nil
+	case *pagure.PullRequest:
+		// Pagure doesn't expose the e-mail addresses of users.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +571,12 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *gerritbatches.AnnotatedChange:
+		return m.Created.Time
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreationDate
+	case *pagure.PullRequest:
+		return m.DateCreated.Time
 	default:
 		return time.Time{}
 	}
@@ -527,6 +593,12 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.Body(), nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Description, nil
+	case *pagure.PullRequest:
+		return m.InitialComment, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +644,12 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *gerritbatches.AnnotatedChange:
+		return m.URL(), nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.URL(), nil
+	case *pagure.PullRequest:
+		return m.FullURL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -744,6 +822,27 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    status,
 			})
 		}
+
+	case *pagure.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Comments))
+		var kind ChangesetEventKind
+
+		for _, comment := range m.Comments {
+			// Notifications are comments Pagure posts itself, and duplicate
+			// the state we already get from the pull request.
+			if comment.Notification {
+				continue
+			}
+			if kind, err = ChangesetEventKindFor(comment); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         comment.Key(),
+				Kind:        kind,
+				Metadata:    comment,
+			})
+		}
 	}
 	return events, nil
 }
@@ -761,6 +860,15 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.CurrentRevision, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeSourceCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeSourceCommit.CommitID, nil
+	case *pagure.PullRequest:
+		return m.CommitStop, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +886,12 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return m.HeadRef(), nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.SourceRefName, nil
+	case *pagure.PullRequest:
+		return "refs/heads/" + m.BranchFrom, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +910,22 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *gerritbatches.AnnotatedChange:
+		// The base of a change is the parent of its current patch set, not
+		// the current head of the target branch.
+		if commit, ok := m.CurrentCommit(); ok && len(commit.Parents) > 0 {
+			return commit.Parents[0].Commit, nil
+		}
+		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeTargetCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeTargetCommit.CommitID, nil
+	case *pagure.PullRequest:
+		// Pagure doesn't tell which commit of the target branch a pull
+		// request is based on.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,6 +943,12 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *gerritbatches.AnnotatedChange:
+		return "refs/heads/" + m.Branch, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.TargetRefName, nil
+	case *pagure.PullRequest:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1135,6 +1271,9 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusCreated, nil
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated, nil
+
+	case *pagure.Comment:
+		return ChangesetEventKindPagureCommented, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1249,6 +1388,11 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlab.MergeRequestReopenedEvent), nil
 		}
+	case strings.HasPrefix(string(k), "pagure"):
+		switch k {
+		case ChangesetEventKindPagureCommented:
+			return new(pagure.Comment), nil
+		}
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
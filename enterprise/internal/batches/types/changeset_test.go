@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
@@ -175,6 +176,37 @@ func TestChangeset_SetMetadata(t *testing.T) {
 				ExternalUpdatedAt:   time.Unix(10, 0),
 			},
 		},
+		"pagure with fork": {
+			meta: &pagure.PullRequest{
+				ID:          12345,
+				BranchFrom:  "branch",
+				LastUpdated: pagure.Timestamp{Time: time.Unix(10, 0)},
+				Project:     &pagure.Project{ID: 1},
+				RepoFrom:    &pagure.Project{ID: 2, User: &pagure.User{Name: "fork"}},
+			},
+			want: &Changeset{
+				ExternalID:            "12345",
+				ExternalServiceType:   extsvc.TypePagure,
+				ExternalBranch:        "refs/heads/branch",
+				ExternalForkNamespace: "fork",
+				ExternalUpdatedAt:     time.Unix(10, 0),
+			},
+		},
+		"pagure without fork": {
+			meta: &pagure.PullRequest{
+				ID:          12345,
+				BranchFrom:  "branch",
+				LastUpdated: pagure.Timestamp{Time: time.Unix(10, 0)},
+				Project:     &pagure.Project{ID: 1, User: &pagure.User{Name: "owner"}},
+				RepoFrom:    &pagure.Project{ID: 1, User: &pagure.User{Name: "owner"}},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypePagure,
+				ExternalBranch:      "refs/heads/branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			have := &Changeset{}
@@ -0,0 +1,67 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDartGenerator(t *testing.T) {
+	// There is no pinned default image for the dart indexer, so jobs are only
+	// inferred when the site configuration maps "dart" to an indexer image.
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer",
+			repositoryContents: map[string]string{
+				"app/pubspec.yaml":              "",
+				"app/lib/main.dart":             "",
+				"packages/widgets/pubspec.yaml": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "sourcegraph/lsif-dart:insiders"
+	mockIndexerMap(t, map[string]string{"dart": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "pub packages",
+			repositoryContents: map[string]string{
+				"app/pubspec.yaml":              "",
+				"app/lib/main.dart":             "",
+				"packages/widgets/pubspec.yaml": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "app",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dart pub get"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "app",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"lsif_indexer"},
+					Outfile:     "dump.lsif",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/widgets",
+							Image:    expectedIndexerImage,
+							Commands: []string{"dart pub get"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/widgets",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"lsif_indexer"},
+					Outfile:     "dump.lsif",
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,69 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	// There is no pinned default image for the dotnet indexer, so jobs are only
+	// inferred when the site configuration maps "dotnet" to an indexer image.
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer",
+			repositoryContents: map[string]string{
+				"app/App.sln":                  "",
+				"app/App.csproj":               "",
+				"app/src/Lib/Lib.csproj":       "",
+				"tools/Tools.sln":              "",
+				"tools/Cli/Cli.csproj":         "",
+				"standalone/Standalone.csproj": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "sourcegraph/scip-dotnet:insiders"
+	mockIndexerMap(t, map[string]string{"dotnet": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solutions and projects",
+			repositoryContents: map[string]string{
+				"app/App.sln":                  "",
+				"app/App.csproj":               "",
+				"app/src/Lib/Lib.csproj":       "",
+				"tools/Tools.sln":              "",
+				"tools/Cli/Cli.csproj":         "",
+				"standalone/Standalone.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "app",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "standalone",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "tools",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -29,6 +29,55 @@ func TestJavaGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "sbt and gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"build.sbt":                            "",
+				"modules/core/build.sbt":               "",
+				"android/settings.gradle.kts":          "",
+				"android/build.gradle.kts":             "",
+				"android/app/build.gradle.kts":         "",
+				"android/app/src/main/App.kt":          "",
+				"src/main/scala/com/example/App.scala": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "gradle kotlin builds",
+			repositoryContents: map[string]string{
+				"android/settings.gradle.kts":  "",
+				"android/build.gradle.kts":     "",
+				"android/app/build.gradle.kts": "",
+				"server/build.gradle.kts":      "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "android",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-java", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
 		generatorTestCase{
 			description: "java project without lsif-java.json (no match)",
 			repositoryContents: map[string]string{
@@ -0,0 +1,67 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	// There is no pinned default image for the php indexer, so jobs are only
+	// inferred when the site configuration maps "php" to an indexer image.
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer",
+			repositoryContents: map[string]string{
+				"composer.json":                 "",
+				"packages/client/composer.json": "",
+				"src/App.php":                   "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "sourcegraph/scip-php:insiders"
+	mockIndexerMap(t, map[string]string{"php": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer packages",
+			repositoryContents: map[string]string{
+				"composer.json":                 "",
+				"packages/client/composer.json": "",
+				"src/App.php":                   "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-progress"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-php"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/client",
+							Image:    expectedIndexerImage,
+							Commands: []string{"composer install --no-interaction --no-progress"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/client",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-php"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
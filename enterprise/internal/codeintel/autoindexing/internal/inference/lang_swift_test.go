@@ -0,0 +1,67 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestSwiftGenerator(t *testing.T) {
+	// There is no pinned default image for the swift indexer, so jobs are only
+	// inferred when the site configuration maps "swift" to an indexer image.
+	testGenerators(t,
+		generatorTestCase{
+			description: "no configured indexer",
+			repositoryContents: map[string]string{
+				"Package.swift":                    "",
+				"Sources/App/main.swift":           "",
+				"Modules/Networking/Package.swift": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+
+	expectedIndexerImage := "sourcegraph/scip-swift:insiders"
+	mockIndexerMap(t, map[string]string{"swift": expectedIndexerImage})
+
+	testGenerators(t,
+		generatorTestCase{
+			description: "swift packages",
+			repositoryContents: map[string]string{
+				"Package.swift":                    "",
+				"Sources/App/main.swift":           "",
+				"Modules/Networking/Package.swift": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    expectedIndexerImage,
+							Commands: []string{"swift build"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-swift", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "Modules/Networking",
+							Image:    expectedIndexerImage,
+							Commands: []string{"swift build"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "Modules/Networking",
+					Indexer:     expectedIndexerImage,
+					IndexerArgs: []string{"scip-swift", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -15,6 +15,9 @@ var Indexes = indexesAPI{}
 
 type indexesAPI struct{}
 
+// Only indexers with a pinned image are listed here. Jobs for languages whose
+// indexer has no pinned image yet (dart, dotnet, php and swift) are only
+// inferred when the site configuration maps the language to an indexer image.
 var defaultIndexers = map[string]string{
 	"clang":      "sourcegraph/lsif-clang",
 	"go":         "sourcegraph/lsif-go",
@@ -50,22 +53,32 @@ func DefaultIndexerForLang(language string) (string, bool) {
 	return fmt.Sprintf("%s@%s", indexer, sha), true
 }
 
+// indexerForLang returns the indexer image configured for the given language
+// in the site configuration, or its default indexer image.
+func indexerForLang(language string) (string, bool) {
+	if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
+		return indexer, true
+	}
+
+	return DefaultIndexerForLang(language)
+}
+
 func (api indexesAPI) LuaAPI() map[string]lua.LGFunction {
 	return map[string]lua.LGFunction{
 		"get": util.WrapLuaFunction(func(state *lua.LState) error {
 			language := state.CheckString(1)
 
-			if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
-				state.Push(luar.New(state, indexer))
-				return nil
-			}
-
-			if indexer, ok := DefaultIndexerForLang(language); ok {
+			if indexer, ok := indexerForLang(language); ok {
 				state.Push(luar.New(state, indexer))
 				return nil
 			}
 
 			return errors.Newf("no indexer is registered for %q", language)
 		}),
+		"has": util.WrapLuaFunction(func(state *lua.LState) error {
+			_, ok := indexerForLang(state.CheckString(1))
+			state.Push(lua.LBool(ok))
+			return nil
+		}),
 	}
 }
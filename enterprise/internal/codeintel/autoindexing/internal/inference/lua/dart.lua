@@ -0,0 +1,52 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexes = require "sg.autoindex.indexes"
+
+-- The dart indexer has no pinned image yet, so jobs are only inferred when the
+-- site configuration maps "dart" to an indexer image.
+local indexer = indexes.has "dart" and indexes.get "dart" or nil
+local outfile = "dump.lsif"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment ".dart_tool",
+  pattern.new_path_segment ".pub-cache",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "pubspec.yaml",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when pubspec.yaml files exist
+  generate = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "dart pub get" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "lsif_indexer" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
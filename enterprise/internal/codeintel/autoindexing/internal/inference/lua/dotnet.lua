@@ -0,0 +1,65 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
+local indexes = require "sg.autoindex.indexes"
+
+-- The dotnet indexer has no pinned image yet, so jobs are only inferred when the
+-- site configuration maps "dotnet" to an indexer image.
+local indexer = indexes.has "dotnet" and indexes.get "dotnet" or nil
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when solution or C# project files exist. Projects within the directory
+  -- of a solution are indexed as part of that solution.
+  generate = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local solution_dirs = {}
+    for i = 1, #paths do
+      if string.match(paths[i], "%.sln$") then
+        solution_dirs[path.dirname(paths[i])] = true
+      end
+    end
+
+    local jobs = {}
+    local visited = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+      local is_solution = string.match(paths[i], "%.sln$") ~= nil
+
+      if
+        visited[root] == nil
+        and (is_solution or (solution_dirs[root] == nil and not util.has_ancestor_in(root, solution_dirs)))
+      then
+        table.insert(jobs, {
+          steps = {},
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-dotnet", "index" },
+          outfile = outfile,
+        })
+
+        visited[root] = true
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -2,6 +2,9 @@ local path = require "path"
 local recognizer = require "sg.autoindex.recognizer"
 local pattern = require "sg.autoindex.patterns"
 
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
 local indexer = require("sg.autoindex.indexes").get "java"
 local outfile = "index.scip"
 
@@ -24,17 +27,40 @@ return recognizer.new_path_recognizer {
     api:register(recognizer.new_path_recognizer {
       patterns = {
         pattern.new_path_literal "lsif-java.json",
+        pattern.new_path_basename "build.sbt",
+        pattern.new_path_basename "build.gradle.kts",
+        pattern.new_path_basename "settings.gradle.kts",
+        pattern.new_path_exclude(shared.exclude_paths),
       },
 
-      -- Invoked when lsif-java.json exists in root of repository
+      -- Invoked when lsif-java.json exists in root of repository, or when sbt or
+      -- Gradle Kotlin builds exist
       generate = function(api, paths)
-        return {
-          steps = {},
-          root = "",
-          indexer = indexer,
-          indexer_args = { "scip-java", "index", "--build-tool=scip" },
-          outfile = outfile,
-        }
+        if util.contains(paths, "lsif-java.json") then
+          return {
+            steps = {},
+            root = "",
+            indexer = indexer,
+            indexer_args = { "scip-java", "index", "--build-tool=scip" },
+            outfile = outfile,
+          }
+        end
+
+        -- Nested builds are subprojects of the outermost build, which scip-java
+        -- indexes as a whole
+        local jobs = {}
+        local roots = util.outermost_dirs(paths)
+        for i = 1, #roots do
+          table.insert(jobs, {
+            steps = {},
+            root = roots[i],
+            indexer = indexer,
+            indexer_args = { "scip-java", "index" },
+            outfile = outfile,
+          })
+        end
+
+        return jobs
       end,
     })
 
@@ -0,0 +1,51 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexes = require "sg.autoindex.indexes"
+
+-- The php indexer has no pinned image yet, so jobs are only inferred when the
+-- site configuration maps "php" to an indexer image.
+local indexer = indexes.has "php" and indexes.get "php" or nil
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "composer.json",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when composer.json files exist
+  generate = function(_, paths)
+    if indexer == nil then
+      return {}
+    end
+
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            commands = { "composer install --no-interaction --no-progress" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-php" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
@@ -2,11 +2,15 @@ local config = require("sg.autoindex.config").new {}
 
 for _, name in ipairs {
   "clang",
+  "dart",
+  "dotnet",
   "go",
   "java",
+  "php",
   "python",
   "ruby",
   "rust",
+  "swift",
   "test",
   "typescript",
 } do
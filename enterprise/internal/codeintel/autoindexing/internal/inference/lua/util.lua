@@ -1,3 +1,5 @@
+local path = require "path"
+
 local contains = function(table, element)
   for i = 1, #table do
     if table[i] == element then
@@ -36,9 +38,49 @@ local with_new_head = function(slice, element)
   return new
 end
 
+-- Returns true if a proper ancestor of the given directory is a key of the given table.
+local has_ancestor_in = function(dir, dirs)
+  if dir == "" then
+    return false
+  end
+
+  local ancestors = path.ancestors(dir)
+  for i = 1, #ancestors do
+    if dirs[ancestors[i]] then
+      return true
+    end
+  end
+
+  return false
+end
+
+-- Returns the distinct directories of the given paths that are not nested within
+-- the directory of another given path, in order of first occurrence.
+local outermost_dirs = function(paths)
+  local dirs = {}
+  for i = 1, #paths do
+    dirs[path.dirname(paths[i])] = true
+  end
+
+  local outermost = {}
+  local visited = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+
+    if visited[dir] == nil and not has_ancestor_in(dir, dirs) then
+      table.insert(outermost, dir)
+      visited[dir] = true
+    end
+  end
+
+  return outermost
+end
+
 return {
   contains = contains,
   contains_any = contains_any,
+  has_ancestor_in = has_ancestor_in,
+  outermost_dirs = outermost_dirs,
   reverse = reverse,
   with_new_head = with_new_head,
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestEmptyGenerators(t *testing.T) {
@@ -157,6 +159,15 @@ func testGenerator(t *testing.T, testCase generatorTestCase) {
 	})
 }
 
+// mockIndexerMap sets the indexer images configured in the site configuration
+// for the duration of the test.
+func mockIndexerMap(t *testing.T, indexerMap map[string]string) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelAutoIndexingIndexerMap: indexerMap,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+}
+
 func sortIndexJobs(s []config.IndexJob) []config.IndexJob {
 	sort.Slice(s, func(i, j int) bool {
 		return s[i].Indexer < s[j].Indexer || (s[i].Indexer == s[j].Indexer && s[i].Root < s[j].Root)
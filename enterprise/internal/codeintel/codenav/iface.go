@@ -3,6 +3,7 @@ package codenav
 import (
 	"context"
 
+	"github.com/grafana/regexp"
 	"github.com/sourcegraph/go-diff/diff"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
@@ -22,6 +23,8 @@ type UploadService interface {
 type GitserverClient interface {
 	CommitsExist(ctx context.Context, commits []gitserver.RepositoryCommit) ([]bool, error)
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
+	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
+	ListFiles(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error)
 }
 
 type DBStore interface {
@@ -24,10 +24,20 @@ type LsifStore interface {
 	// Definition
 	GetDefinitionLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
 
+	// Type definition
+	GetTypeDefinitionLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
+
+	// Prototypes
+	GetPrototypeLocations(ctx context.Context, uploadID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error)
+
 	// Monikers
 	GetMonikersByPosition(ctx context.Context, uploadID int, path string, line, character int) (_ [][]precise.MonikerData, err error)
 	GetBulkMonikerLocations(ctx context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, limit, offset int) (_ []shared.Location, totalCount int, err error)
 
+	// Calls
+	GetEnclosingFunctions(ctx context.Context, bundleID int, path string, ranges []types.Range) (_ []shared.SymbolLocation, err error)
+	GetCalleeOccurrences(ctx context.Context, bundleID int, path string, line, character int) (_ []shared.SymbolLocation, err error)
+
 	// Packages
 	GetPackageInformation(ctx context.Context, uploadID int, path, packageInformationID string) (_ precise.PackageInformationData, _ bool, err error)
 
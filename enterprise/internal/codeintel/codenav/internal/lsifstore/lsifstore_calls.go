@@ -0,0 +1,254 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetEnclosingFunctions returns, for each of the given ranges within the given document, the definition
+// of the function or method whose body contains the range. The output slice is relative to the input
+// ranges; ranges that do not occur within a function body (including function definitions themselves)
+// are paired with a location with an empty symbol. Only SCIP indexes are supported.
+func (s *store) GetEnclosingFunctions(ctx context.Context, bundleID int, path string, ranges []types.Range) (_ []shared.SymbolLocation, err error) {
+	ctx, trace, endObservation := s.operations.getEnclosingFunctions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("numRanges", len(ranges)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		locationsDocumentQuery,
+		bundleID,
+		path,
+		bundleID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+	trace.AddEvent("SCIPData", attribute.Int("numOccurrences", len(documentData.SCIPData.Occurrences)))
+
+	enclosing := make([]shared.SymbolLocation, 0, len(ranges))
+	for _, occurrence := range findEnclosingFunctions(documentData.SCIPData, ranges) {
+		if occurrence == nil {
+			enclosing = append(enclosing, shared.SymbolLocation{})
+			continue
+		}
+
+		enclosing = append(enclosing, shared.SymbolLocation{
+			Location: shared.Location{
+				DumpID: bundleID,
+				Path:   path,
+				Range:  translateRange(scip.NewRange(occurrence.Range)),
+			},
+			Symbol: occurrence.Symbol,
+		})
+	}
+
+	return enclosing, nil
+}
+
+// GetCalleeOccurrences returns the locations of the calls made within the body of the function or method
+// defined at the given position. Only SCIP indexes are supported.
+func (s *store) GetCalleeOccurrences(ctx context.Context, bundleID int, path string, line, character int) (_ []shared.SymbolLocation, err error) {
+	ctx, trace, endObservation := s.operations.getCalleeOccurrences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		locationsDocumentQuery,
+		bundleID,
+		path,
+		bundleID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, err
+	}
+	trace.AddEvent("SCIPData", attribute.Int("numOccurrences", len(documentData.SCIPData.Occurrences)))
+
+	occurrences := findCalleeOccurrences(documentData.SCIPData, int32(line), int32(character))
+	trace.AddEvent("findCalleeOccurrences", attribute.Int("numCallees", len(occurrences)))
+
+	callees := make([]shared.SymbolLocation, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		callees = append(callees, shared.SymbolLocation{
+			Location: shared.Location{
+				DumpID: bundleID,
+				Path:   path,
+				Range:  translateRange(scip.NewRange(occurrence.Range)),
+			},
+			Symbol: occurrence.Symbol,
+		})
+	}
+
+	return callees, nil
+}
+
+// The SCIP indexes we store do not record the extent of definitions, so the body of a function is
+// approximated by the source text between its definition and the next "boundary" definition of the
+// document: any definition of a non-local symbol that is not a parameter. Local symbols (variables,
+// closures) and parameters are declared within bodies and do not end them.
+
+// findEnclosingFunctions returns, for each of the given ranges, the definition occurrence of the function
+// whose body contains it, or nil if there is no such function.
+func findEnclosingFunctions(document *scip.Document, ranges []types.Range) []*scip.Occurrence {
+	boundaries := newSymbolKinds().boundaryDefinitions(document)
+
+	enclosing := make([]*scip.Occurrence, 0, len(ranges))
+	for _, r := range ranges {
+		start := scip.Position{Line: int32(r.Start.Line), Character: int32(r.Start.Character)}
+
+		// Find the first boundary starting at or after the given range
+		i := sort.Search(len(boundaries), func(i int) bool {
+			return !positionBefore(boundaries[i].start, start)
+		})
+
+		if i < len(boundaries) && boundaries[i].start == start {
+			// The range is a definition itself
+			enclosing = append(enclosing, nil)
+			continue
+		}
+
+		// The enclosing function is the last boundary before the given range
+		if i--; i < 0 || !boundaries[i].isFunction {
+			enclosing = append(enclosing, nil)
+			continue
+		}
+
+		enclosing = append(enclosing, boundaries[i].occurrence)
+	}
+
+	return enclosing
+}
+
+// findCalleeOccurrences returns the reference occurrences of functions within the body of the function
+// defined at the given position, ordered by their position.
+func findCalleeOccurrences(document *scip.Document, line, character int32) []*scip.Occurrence {
+	kinds := newSymbolKinds()
+	boundaries := kinds.boundaryDefinitions(document)
+
+	position := scip.Position{Line: line, Character: character}
+
+	var body *scip.Range
+	for i, boundary := range boundaries {
+		r := scip.NewRange(boundary.occurrence.Range)
+		if !boundary.isFunction || positionBefore(position, r.Start) || positionBefore(r.End, position) {
+			continue
+		}
+
+		body = &scip.Range{Start: r.End, End: scip.Position{Line: 1<<31 - 1}}
+		if i+1 < len(boundaries) {
+			body.End = boundaries[i+1].start
+		}
+		break
+	}
+	if body == nil {
+		return nil
+	}
+
+	var callees []*scip.Occurrence
+	for _, occurrence := range document.Occurrences {
+		if scip.SymbolRole_Definition.Matches(occurrence) || !kinds.isFunction(occurrence.Symbol) {
+			continue
+		}
+
+		start := scip.NewRange(occurrence.Range).Start
+		if positionBefore(start, body.Start) || !positionBefore(start, body.End) {
+			continue
+		}
+
+		callees = append(callees, occurrence)
+	}
+	sort.SliceStable(callees, func(i, j int) bool {
+		return positionBefore(scip.NewRange(callees[i].Range).Start, scip.NewRange(callees[j].Range).Start)
+	})
+
+	return callees
+}
+
+type boundaryDefinition struct {
+	occurrence *scip.Occurrence
+	start      scip.Position
+	isFunction bool
+}
+
+// symbolKinds caches the descriptor suffix of parsed symbol names.
+type symbolKinds map[string]scip.Descriptor_Suffix
+
+func newSymbolKinds() symbolKinds {
+	return symbolKinds{}
+}
+
+// boundaryDefinitions returns the definitions of the given document that delimit function bodies,
+// ordered by their position.
+func (k symbolKinds) boundaryDefinitions(document *scip.Document) []boundaryDefinition {
+	var boundaries []boundaryDefinition
+	for _, occurrence := range document.Occurrences {
+		if !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+
+		switch k.suffix(occurrence.Symbol) {
+		case scip.Descriptor_Local, scip.Descriptor_Parameter, scip.Descriptor_TypeParameter, scip.Descriptor_UnspecifiedSuffix:
+			continue
+		}
+
+		boundaries = append(boundaries, boundaryDefinition{
+			occurrence: occurrence,
+			start:      scip.NewRange(occurrence.Range).Start,
+			isFunction: k.isFunction(occurrence.Symbol),
+		})
+	}
+	sort.SliceStable(boundaries, func(i, j int) bool {
+		return positionBefore(boundaries[i].start, boundaries[j].start)
+	})
+
+	return boundaries
+}
+
+// isFunction returns true if the given symbol names a function or a method.
+func (k symbolKinds) isFunction(symbol string) bool {
+	return k.suffix(symbol) == scip.Descriptor_Method
+}
+
+// suffix returns the suffix of the last descriptor of the given symbol, or an unspecified suffix if
+// the symbol cannot be parsed.
+func (k symbolKinds) suffix(symbol string) scip.Descriptor_Suffix {
+	if symbol == "" {
+		return scip.Descriptor_UnspecifiedSuffix
+	}
+	if scip.IsLocalSymbol(symbol) {
+		return scip.Descriptor_Local
+	}
+	if suffix, ok := k[symbol]; ok {
+		return suffix
+	}
+
+	suffix := scip.Descriptor_UnspecifiedSuffix
+	if parsed, err := scip.ParseSymbol(symbol); err == nil && len(parsed.Descriptors) > 0 {
+		suffix = parsed.Descriptors[len(parsed.Descriptors)-1].Suffix
+	}
+	k[symbol] = suffix
+
+	return suffix
+}
+
+// positionBefore returns true if a occurs strictly before b.
+func positionBefore(a, b scip.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
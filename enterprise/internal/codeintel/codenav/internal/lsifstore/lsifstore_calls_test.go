@@ -0,0 +1,109 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+)
+
+const (
+	testFunctionA  = "scip-go gomod example v1 `main`/A()."
+	testFunctionB  = "scip-go gomod example v1 `main`/B()."
+	testFunctionC  = "scip-go gomod example v1 `main`/C()."
+	testParameterP = "scip-go gomod example v1 `main`/B().(p)"
+	testVariableV  = "scip-go gomod example v1 `main`/V."
+)
+
+// testCallsDocument models the following document:
+//
+//	0 | package main
+//	1 |
+//	2 | func A() {
+//	3 | 	B()
+//	4 | 	x := 1
+//	5 | 	C()
+//	6 | }
+//	7 |
+//	8 | var V = B()
+//	9 |
+//	10 | func B(p int) {
+//	11 | 	A()
+//	12 | 	V
+//	13 | }
+var testCallsDocument = &scip.Document{
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{2, 5, 6}, Symbol: testFunctionA, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{3, 1, 2}, Symbol: testFunctionB},
+		{Range: []int32{4, 1, 2}, Symbol: "local 1", SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{5, 1, 2}, Symbol: testFunctionC},
+		{Range: []int32{8, 4, 5}, Symbol: testVariableV, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{8, 8, 9}, Symbol: testFunctionB},
+		{Range: []int32{10, 5, 6}, Symbol: testFunctionB, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{10, 7, 8}, Symbol: testParameterP, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{11, 1, 2}, Symbol: testFunctionA},
+		{Range: []int32{12, 1, 2}, Symbol: testVariableV},
+	},
+}
+
+func TestFindEnclosingFunctions(t *testing.T) {
+	ranges := []types.Range{
+		newRange(3, 1, 3, 2),   // B() in A
+		newRange(5, 1, 5, 2),   // C() in A
+		newRange(8, 8, 8, 9),   // B() in the initializer of V
+		newRange(10, 5, 10, 6), // definition of B
+		newRange(11, 1, 11, 2), // A() in B, after the parameter p
+		newRange(12, 1, 12, 2), // V in B
+		newRange(0, 0, 0, 7),   // before any definition
+	}
+
+	var symbols []string
+	for _, occurrence := range findEnclosingFunctions(testCallsDocument, ranges) {
+		symbol := ""
+		if occurrence != nil {
+			symbol = occurrence.Symbol
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	expected := []string{testFunctionA, testFunctionA, "", "", testFunctionB, testFunctionB, ""}
+	if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected enclosing functions (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindCalleeOccurrences(t *testing.T) {
+	testCases := []struct {
+		explanation     string
+		line, character int32
+		expected        []*scip.Occurrence
+	}{
+		{
+			explanation: "calls within A",
+			line:        2,
+			character:   5,
+			expected:    []*scip.Occurrence{testCallsDocument.Occurrences[1], testCallsDocument.Occurrences[3]},
+		},
+		{
+			explanation: "calls within B ignore references to variables",
+			line:        10,
+			character:   6,
+			expected:    []*scip.Occurrence{testCallsDocument.Occurrences[8]},
+		},
+		{
+			explanation: "position is not a function definition",
+			line:        8,
+			character:   4,
+			expected:    nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		callees := findCalleeOccurrences(testCallsDocument, testCase.line, testCase.character)
+		if diff := cmp.Diff(testCase.expected, callees, cmp.Comparer(func(a, b *scip.Occurrence) bool { return a == b })); diff != "" {
+			t.Errorf("unexpected callees (-want +got):\n%s  -- %s", diff, testCase.explanation)
+		}
+	}
+}
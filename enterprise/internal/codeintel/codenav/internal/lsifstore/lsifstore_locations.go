@@ -38,6 +38,22 @@ func (s *store) GetImplementationLocations(ctx context.Context, bundleID int, pa
 	return s.getLocations(ctx, extractor, "implementation_ranges", extractImplementationRanges, s.operations.getImplementations, bundleID, path, line, character, limit, offset)
 }
 
+// GetTypeDefinitionLocations returns the set of locations defining the type of the symbol at the given position.
+// Only SCIP indexes carry type definition relationships.
+func (s *store) GetTypeDefinitionLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
+	return s.getRelatedLocations(ctx, isTypeDefinitionRelationship, s.operations.getTypeDefinitions, bundleID, path, line, character, limit, offset)
+}
+
+// GetPrototypeLocations returns the set of locations defining the symbols implemented by the symbol at the
+// given position (e.g. the interface methods satisfied by a method). This is the reverse of implementations.
+// Only SCIP indexes carry implementation relationships from the implementing symbol.
+func (s *store) GetPrototypeLocations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) (_ []shared.Location, _ int, err error) {
+	return s.getRelatedLocations(ctx, isImplementationRelationship, s.operations.getPrototypes, bundleID, path, line, character, limit, offset)
+}
+
+func isTypeDefinitionRelationship(rel *scip.Relationship) bool { return rel.IsTypeDefinition }
+func isImplementationRelationship(rel *scip.Relationship) bool { return rel.IsImplementation }
+
 func (s *store) getLocations(
 	ctx context.Context,
 	lsifExtractor func(precise.RangeData) precise.ID,
@@ -78,43 +94,16 @@ func (s *store) getLocations(
 				locations = append(locations, convertSCIPRangesToLocations(ranges, bundleID, path)...)
 			}
 
-			if occurrence.Symbol != "" && !scip.IsLocalSymbol(occurrence.Symbol) {
-				monikerLocations, err := s.scanQualifiedMonikerLocations(s.db.Query(ctx, sqlf.Sprintf(
-					locationsSymbolSearchQuery,
-					pq.Array([]string{occurrence.Symbol}),
-					pq.Array([]int{bundleID}),
-					sqlf.Sprintf(scipFieldName),
-					bundleID,
-					path,
-					sqlf.Sprintf(scipFieldName),
-				)))
+			if symbols := extractOccurrenceSymbols(documentData.SCIPData, occurrence); len(symbols) != 0 {
+				symbolLocations, err := s.getSymbolLocations(ctx, scipFieldName, symbols, bundleID, path)
 				if err != nil {
 					return nil, 0, err
 				}
-				for _, monikerLocation := range monikerLocations {
-					for _, row := range monikerLocation.Locations {
-						locations = append(locations, shared.Location{
-							DumpID: monikerLocation.DumpID,
-							Path:   row.URI,
-							Range:  newRange(row.StartLine, row.StartCharacter, row.EndLine, row.EndCharacter),
-						})
-					}
-				}
+				locations = append(locations, symbolLocations...)
 			}
 
 			if len(locations) > 0 {
-				totalCount := len(locations)
-
-				if offset < len(locations) {
-					locations = locations[offset:]
-				} else {
-					locations = []shared.Location{}
-				}
-
-				if len(locations) > limit {
-					locations = locations[:limit]
-				}
-
+				locations, totalCount := paginateLocations(locations, limit, offset)
 				return locations, totalCount, nil
 			}
 		}
@@ -141,6 +130,144 @@ func (s *store) getLocations(
 	return locations, totalCount, nil
 }
 
+// getRelatedLocations returns the set of locations defining the symbols related to the symbol at the given
+// position through one of the relationships accepted by the given predicate.
+func (s *store) getRelatedLocations(
+	ctx context.Context,
+	isRelated func(*scip.Relationship) bool,
+	operation *observation.Operation,
+	bundleID int,
+	path string,
+	line, character, limit, offset int,
+) (_ []shared.Location, _ int, err error) {
+	ctx, trace, endObservation := operation.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		locationsDocumentQuery,
+		bundleID,
+		path,
+		bundleID,
+		path,
+	)))
+	if err != nil || !exists || documentData.SCIPData == nil {
+		return nil, 0, err
+	}
+
+	trace.AddEvent("SCIPData", attribute.Int("numOccurrences", len(documentData.SCIPData.Occurrences)))
+	occurrences := types.FindOccurrences(documentData.SCIPData.Occurrences, int32(line), int32(character))
+	trace.AddEvent("FindOccurences", attribute.Int("numIntersectingOccurrences", len(occurrences)))
+
+	for _, occurrence := range occurrences {
+		symbol, err := s.resolveSymbolInformation(ctx, bundleID, documentData.SCIPData, occurrence.Symbol)
+		if err != nil {
+			return nil, 0, err
+		}
+		symbols := extractRelatedSymbols(symbol, isRelated)
+		if len(symbols) == 0 {
+			continue
+		}
+
+		locations := convertSCIPRangesToLocations(extractDefinitionRangesOfSymbols(documentData.SCIPData, symbols), bundleID, path)
+		symbolLocations, err := s.getSymbolLocations(ctx, "definition_ranges", symbols, bundleID, path)
+		if err != nil {
+			return nil, 0, err
+		}
+		locations = append(locations, symbolLocations...)
+
+		if len(locations) > 0 {
+			locations, totalCount := paginateLocations(locations, limit, offset)
+			return locations, totalCount, nil
+		}
+	}
+
+	return nil, 0, nil
+}
+
+// resolveSymbolInformation returns the symbol information of the given symbol. Symbol information is only
+// emitted in the document defining the symbol, so uses of the symbol in other documents of the same index
+// are resolved by opening the documents defining it, the same way hover text is.
+func (s *store) resolveSymbolInformation(ctx context.Context, bundleID int, document *scip.Document, symbolName string) (*scip.SymbolInformation, error) {
+	if symbolName == "" {
+		return nil, nil
+	}
+	if symbol := findSymbolInformation(symbolName, document); symbol != nil || scip.IsLocalSymbol(symbolName) {
+		return symbol, nil
+	}
+
+	documents, err := s.scanDocumentData(s.db.Query(ctx, sqlf.Sprintf(
+		hoverSymbolsQuery,
+		pq.Array([]string{symbolName}),
+		pq.Array([]int{bundleID}),
+		bundleID,
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	scipDocuments := make([]*scip.Document, 0, len(documents))
+	for _, document := range documents {
+		if document.SCIPData != nil {
+			scipDocuments = append(scipDocuments, document.SCIPData)
+		}
+	}
+
+	return findSymbolInformation(symbolName, scipDocuments...), nil
+}
+
+// getSymbolLocations returns the locations of the given symbols, read from the given field of the symbols
+// table, in the documents of the given index other than the given path.
+func (s *store) getSymbolLocations(ctx context.Context, scipFieldName string, symbols []string, bundleID int, path string) ([]shared.Location, error) {
+	monikerLocations, err := s.scanQualifiedMonikerLocations(s.db.Query(ctx, sqlf.Sprintf(
+		locationsSymbolSearchQuery,
+		pq.Array(symbols),
+		pq.Array([]int{bundleID}),
+		sqlf.Sprintf(scipFieldName),
+		bundleID,
+		path,
+		sqlf.Sprintf(scipFieldName),
+	)))
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []shared.Location
+	for _, monikerLocation := range monikerLocations {
+		for _, row := range monikerLocation.Locations {
+			locations = append(locations, shared.Location{
+				DumpID: monikerLocation.DumpID,
+				Path:   row.URI,
+				Range:  newRange(row.StartLine, row.StartCharacter, row.EndLine, row.EndCharacter),
+			})
+		}
+	}
+
+	return locations, nil
+}
+
+// paginateLocations returns the page of the given locations described by limit and offset, along with the
+// total number of locations.
+func paginateLocations(locations []shared.Location, limit, offset int) ([]shared.Location, int) {
+	totalCount := len(locations)
+
+	if offset < len(locations) {
+		locations = locations[offset:]
+	} else {
+		locations = []shared.Location{}
+	}
+
+	if len(locations) > limit {
+		locations = locations[:limit]
+	}
+
+	return locations, totalCount
+}
+
 const locationsDocumentQuery = `
 (
 	SELECT
@@ -617,6 +744,71 @@ func extractImplementationRanges(document *scip.Document, occurrence *scip.Occur
 	return extractOccurrenceData(document, occurrence).implementations
 }
 
+// extractOccurrenceSymbols returns the symbol of the given occurrence if it can be searched for outside
+// of the given document.
+func extractOccurrenceSymbols(document *scip.Document, occurrence *scip.Occurrence) []string {
+	if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+		return nil
+	}
+
+	return []string{occurrence.Symbol}
+}
+
+// findSymbolInformation returns the symbol information of the given symbol from the first of the given
+// documents that has it.
+func findSymbolInformation(symbolName string, documents ...*scip.Document) *scip.SymbolInformation {
+	for _, document := range documents {
+		if symbol := types.FindSymbol(document, symbolName); symbol != nil {
+			return symbol
+		}
+	}
+
+	return nil
+}
+
+// extractRelatedSymbols returns the symbols related to the given symbol through one of the relationships
+// accepted by the given predicate.
+func extractRelatedSymbols(symbol *scip.SymbolInformation, isRelated func(*scip.Relationship) bool) []string {
+	if symbol == nil {
+		return nil
+	}
+
+	var symbols []string
+	seen := map[string]struct{}{}
+	for _, rel := range symbol.Relationships {
+		if _, ok := seen[rel.Symbol]; ok || !isRelated(rel) {
+			continue
+		}
+
+		seen[rel.Symbol] = struct{}{}
+		symbols = append(symbols, rel.Symbol)
+	}
+
+	return symbols
+}
+
+// extractDefinitionRangesOfSymbols returns the ranges of the occurrences defining one of the given symbols
+// within the given document.
+func extractDefinitionRangesOfSymbols(document *scip.Document, symbols []string) []*scip.Range {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	symbolSet := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		symbolSet[symbol] = struct{}{}
+	}
+
+	var ranges []*scip.Range
+	for _, occ := range document.Occurrences {
+		if _, ok := symbolSet[occ.Symbol]; ok && scip.SymbolRole_Definition.Matches(occ) {
+			ranges = append(ranges, scip.NewRange(occ.Range))
+		}
+	}
+
+	return ranges
+}
+
 func extractHoverData(document *scip.Document, occurrence *scip.Occurrence) []string {
 	return extractOccurrenceData(document, occurrence).hoverText
 }
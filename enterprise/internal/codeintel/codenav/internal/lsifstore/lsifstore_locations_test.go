@@ -579,3 +579,116 @@ func TestExtractOccurrenceData(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractRelatedDefinitions(t *testing.T) {
+	document := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{1, 5, 11}, Symbol: "scip-go gomod example v1 `main`/Reader#", SymbolRoles: 1},
+			{Range: []int32{2, 1, 5}, Symbol: "scip-go gomod example v1 `main`/Reader#Read.", SymbolRoles: 1},
+			{Range: []int32{5, 5, 9}, Symbol: "scip-go gomod example v1 `main`/File#", SymbolRoles: 1},
+			{Range: []int32{7, 14, 18}, Symbol: "scip-go gomod example v1 `main`/File#Read.", SymbolRoles: 1},
+			{Range: []int32{10, 4, 5}, Symbol: "scip-go gomod example v1 `main`/f.", SymbolRoles: 1},
+			{Range: []int32{10, 7, 11}, Symbol: "scip-go gomod example v1 `main`/File#"},
+			{Range: []int32{12, 0, 1}, Symbol: "scip-go gomod example v1 `main`/f."},
+		},
+		Symbols: []*scip.SymbolInformation{
+			{
+				Symbol: "scip-go gomod example v1 `main`/File#Read.",
+				Relationships: []*scip.Relationship{
+					{Symbol: "scip-go gomod example v1 `main`/Reader#Read.", IsImplementation: true},
+					{Symbol: "scip-go gomod other v1 `other`/Reader#Read.", IsImplementation: true},
+				},
+			},
+			{
+				Symbol: "scip-go gomod example v1 `main`/f.",
+				Relationships: []*scip.Relationship{
+					{Symbol: "scip-go gomod example v1 `main`/File#", IsTypeDefinition: true},
+				},
+			},
+		},
+	}
+
+	// A document using symbols defined in the document above. It has no
+	// symbol information of its own.
+	otherDocument := &scip.Document{
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{3, 1, 2}, Symbol: "scip-go gomod example v1 `main`/f."},
+			{Range: []int32{4, 5, 9}, Symbol: "scip-go gomod example v1 `main`/File#Read."},
+		},
+	}
+
+	testCases := []struct {
+		explanation     string
+		isRelated       func(*scip.Relationship) bool
+		document        *scip.Document
+		documents       []*scip.Document
+		occurrence      *scip.Occurrence
+		expectedSymbols []string
+		expectedRanges  []*scip.Range
+	}{
+		{
+			explanation:     "type definition of a variable reference",
+			isRelated:       isTypeDefinitionRelationship,
+			document:        document,
+			documents:       []*scip.Document{document},
+			occurrence:      document.Occurrences[6],
+			expectedSymbols: []string{"scip-go gomod example v1 `main`/File#"},
+			expectedRanges:  []*scip.Range{scip.NewRange([]int32{5, 5, 9})},
+		},
+		{
+			explanation:     "prototypes of a method, including ones defined in other indexes",
+			isRelated:       isImplementationRelationship,
+			document:        document,
+			documents:       []*scip.Document{document},
+			occurrence:      document.Occurrences[3],
+			expectedSymbols: []string{"scip-go gomod example v1 `main`/Reader#Read.", "scip-go gomod other v1 `other`/Reader#Read."},
+			expectedRanges:  []*scip.Range{scip.NewRange([]int32{2, 1, 5})},
+		},
+		{
+			explanation:     "symbol without relationships",
+			isRelated:       isImplementationRelationship,
+			document:        document,
+			documents:       []*scip.Document{document},
+			occurrence:      document.Occurrences[0],
+			expectedSymbols: nil,
+			expectedRanges:  nil,
+		},
+		{
+			explanation:     "use in a document without the symbol information",
+			isRelated:       isTypeDefinitionRelationship,
+			document:        otherDocument,
+			documents:       []*scip.Document{otherDocument},
+			occurrence:      otherDocument.Occurrences[0],
+			expectedSymbols: nil,
+			expectedRanges:  nil,
+		},
+		{
+			explanation:     "type definition of a use in another document, resolved from the defining document",
+			isRelated:       isTypeDefinitionRelationship,
+			document:        otherDocument,
+			documents:       []*scip.Document{otherDocument, document},
+			occurrence:      otherDocument.Occurrences[0],
+			expectedSymbols: []string{"scip-go gomod example v1 `main`/File#"},
+			expectedRanges:  nil, // defined in the other document, found by symbol search
+		},
+		{
+			explanation:     "prototypes of a use in another document, resolved from the defining document",
+			isRelated:       isImplementationRelationship,
+			document:        otherDocument,
+			documents:       []*scip.Document{otherDocument, document},
+			occurrence:      otherDocument.Occurrences[1],
+			expectedSymbols: []string{"scip-go gomod example v1 `main`/Reader#Read.", "scip-go gomod other v1 `other`/Reader#Read."},
+			expectedRanges:  nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		symbols := extractRelatedSymbols(findSymbolInformation(testCase.occurrence.Symbol, testCase.documents...), testCase.isRelated)
+		if diff := cmp.Diff(testCase.expectedSymbols, symbols); diff != "" {
+			t.Errorf("unexpected symbols (-want +got):\n%s -- %s", diff, testCase.explanation)
+		}
+		if diff := cmp.Diff(testCase.expectedRanges, extractDefinitionRangesOfSymbols(testCase.document, symbols)); diff != "" {
+			t.Errorf("unexpected ranges (-want +got):\n%s -- %s", diff, testCase.explanation)
+		}
+	}
+}
@@ -88,6 +88,14 @@ func (s *store) GetMonikersByPosition(ctx context.Context, uploadID int, path st
 							return nil, err
 						}
 
+						occurrenceMonikers = append(occurrenceMonikers, relatedMoniker)
+					}
+					if rel.IsTypeDefinition {
+						relatedMoniker, err := symbolNameToQualifiedMoniker(rel.Symbol, precise.TypeDefinition)
+						if err != nil {
+							return nil, err
+						}
+
 						occurrenceMonikers = append(occurrenceMonikers, relatedMoniker)
 					}
 				}
@@ -17,8 +17,13 @@ type operations struct {
 	getDiagnostics         *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
+	getTypeDefinitions     *observation.Operation
+	getPrototypes          *observation.Operation
 	getRanges              *observation.Operation
 	getStencil             *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getRenamePreview       *observation.Operation
 	getDumpsByIDs          *observation.Operation
 	getClosestDumpsForBlob *observation.Operation
 }
@@ -49,8 +54,13 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getDiagnostics:         op("getDiagnostics"),
 		getHover:               op("getHover"),
 		getDefinitions:         op("getDefinitions"),
+		getTypeDefinitions:     op("getTypeDefinitions"),
+		getPrototypes:          op("getPrototypes"),
 		getRanges:              op("getRanges"),
 		getStencil:             op("getStencil"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
+		getRenamePreview:       op("getRenamePreview"),
 		getDumpsByIDs:          op("GetDumpsByIDs"),
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 	}
@@ -0,0 +1,284 @@
+package codenav
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// renameDiffContextLines is the number of unchanged lines surrounding the edited lines of a rename diff.
+const renameDiffContextLines = 3
+
+// isIdentifier returns true if the given name can replace the name of a symbol.
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !isIdentifierRune(r) || (i == 0 && unicode.IsDigit(r)) {
+			return false
+		}
+	}
+
+	return name != ""
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// splitLines splits the given file contents into lines, each retaining its trailing newline.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// lineText returns the given line without its line terminator.
+func lineText(line string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
+
+// identifierAt returns the identifier of the given line enclosing the given character offset.
+func identifierAt(line string, character int) string {
+	if character < 0 || character > len(line) {
+		return ""
+	}
+
+	start := character
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(line[:start])
+		if !isIdentifierRune(r) {
+			break
+		}
+		start -= size
+	}
+
+	end := character
+	for end < len(line) {
+		r, size := utf8.DecodeRuneInString(line[end:])
+		if !isIdentifierRune(r) {
+			break
+		}
+		end += size
+	}
+
+	return line[start:end]
+}
+
+// findIdentifiers returns the offsets of the whole-word occurrences of name within the given line.
+func findIdentifiers(line, name string) (offsets []int) {
+	for offset := 0; offset+len(name) <= len(line); {
+		i := strings.Index(line[offset:], name)
+		if i < 0 {
+			break
+		}
+		start, end := offset+i, offset+i+len(name)
+
+		before, _ := utf8.DecodeLastRuneInString(line[:start])
+		after, _ := utf8.DecodeRuneInString(line[end:])
+		if (start == 0 || !isIdentifierRune(before)) && (end == len(line) || !isIdentifierRune(after)) {
+			offsets = append(offsets, start)
+		}
+
+		offset = end
+	}
+
+	return offsets
+}
+
+// narrowRenameRange returns the range of the occurrence of name within the given range of the given
+// file. Some indexers attach the qualified name of a symbol to its occurrences; the last occurrence of
+// name within the range is used. This method returns false if the range does not contain the name.
+func narrowRenameRange(lines []string, r types.Range, name string) (types.Range, bool) {
+	if r.Start.Line < 0 || r.Start.Line >= len(lines) {
+		return types.Range{}, false
+	}
+	line := lineText(lines[r.Start.Line])
+
+	start, end := r.Start.Character, r.End.Character
+	if r.End.Line != r.Start.Line || end > len(line) {
+		end = len(line)
+	}
+	if start < 0 || start > end {
+		return types.Range{}, false
+	}
+
+	var offsets []int
+	for _, offset := range findIdentifiers(line, name) {
+		if offset >= start && offset+len(name) <= end {
+			offsets = append(offsets, offset)
+		}
+	}
+	if len(offsets) == 0 {
+		return types.Range{}, false
+	}
+	offset := offsets[len(offsets)-1]
+
+	return types.Range{
+		Start: types.Position{Line: r.Start.Line, Character: offset},
+		End:   types.Position{Line: r.Start.Line, Character: offset + len(name)},
+	}, true
+}
+
+// renameFileDiff returns a git-style diff of the given file replacing the given single-line ranges with
+// the new name. The given ranges must not overlap.
+func renameFileDiff(path string, lines []string, ranges []types.Range, newName string) *diff.FileDiff {
+	rangesByLine := map[int][]types.Range{}
+	for _, r := range ranges {
+		rangesByLine[r.Start.Line] = append(rangesByLine[r.Start.Line], r)
+	}
+	if len(rangesByLine) == 0 {
+		return nil
+	}
+
+	changedLines := make([]int, 0, len(rangesByLine))
+	for line := range rangesByLine {
+		changedLines = append(changedLines, line)
+	}
+	sort.Ints(changedLines)
+
+	// Replace the ranges of each changed line from right to left so that offsets remain valid
+	newLines := make(map[int]string, len(changedLines))
+	for _, line := range changedLines {
+		lineRanges := rangesByLine[line]
+		sort.Slice(lineRanges, func(i, j int) bool { return lineRanges[i].Start.Character > lineRanges[j].Start.Character })
+
+		text := lines[line]
+		for _, r := range lineRanges {
+			text = text[:r.Start.Character] + newName + text[r.End.Character:]
+		}
+		newLines[line] = text
+	}
+
+	// Merge the context windows of nearby changed lines into a single hunk
+	type window struct{ start, end int }
+	var windows []window
+	for _, line := range changedLines {
+		start, end := line-renameDiffContextLines, line+renameDiffContextLines
+		if start < 0 {
+			start = 0
+		}
+		if end > len(lines)-1 {
+			end = len(lines) - 1
+		}
+
+		if n := len(windows); n > 0 && start <= windows[n-1].end+1 {
+			windows[n-1].end = end
+		} else {
+			windows = append(windows, window{start, end})
+		}
+	}
+
+	hunks := make([]*diff.Hunk, 0, len(windows))
+	for _, w := range windows {
+		hunk := &diff.Hunk{
+			OrigStartLine: int32(w.start + 1),
+			OrigLines:     int32(w.end - w.start + 1),
+			NewStartLine:  int32(w.start + 1),
+			NewLines:      int32(w.end - w.start + 1),
+		}
+
+		var body bytes.Buffer
+		for i := w.start; i <= w.end; {
+			if _, ok := newLines[i]; !ok {
+				body.WriteString(" " + lines[i])
+				i++
+				continue
+			}
+
+			// Removals of a run of changed lines precede their additions
+			j := i
+			for ; j <= w.end; j++ {
+				if _, ok := newLines[j]; !ok {
+					break
+				}
+				body.WriteString("-" + lines[j])
+			}
+			if !strings.HasSuffix(lines[j-1], "\n") {
+				// The last line of the original file has no trailing newline
+				body.WriteString("\n")
+				hunk.OrigNoNewlineAt = int32(body.Len())
+			}
+			for k := i; k < j; k++ {
+				body.WriteString("+" + newLines[k])
+			}
+			i = j
+		}
+		hunk.Body = body.Bytes()
+
+		hunks = append(hunks, hunk)
+	}
+
+	return &diff.FileDiff{
+		OrigName: "a/" + path,
+		NewName:  "b/" + path,
+		Extended: []string{"diff --git a/" + path + " b/" + path},
+		Hunks:    hunks,
+	}
+}
+
+// renameFileKey identifies a file of a repository at a particular commit.
+type renameFileKey struct {
+	repositoryID int
+	commit       string
+	path         string
+}
+
+// renameEditKey identifies the range of a file replaced by a rename edit.
+type renameEditKey struct {
+	file renameFileKey
+	r    types.Range
+}
+
+// renameFiles reads and caches the lines of the files inspected while renaming a symbol.
+type renameFiles struct {
+	gitserver GitserverClient
+	lines     map[renameFileKey][]string
+}
+
+func newRenameFiles(gitserver GitserverClient) *renameFiles {
+	return &renameFiles{
+		gitserver: gitserver,
+		lines:     map[renameFileKey][]string{},
+	}
+}
+
+// get returns the lines of the given file.
+func (f *renameFiles) get(ctx context.Context, key renameFileKey) ([]string, error) {
+	if lines, ok := f.lines[key]; ok {
+		return lines, nil
+	}
+
+	content, err := f.gitserver.RawContents(ctx, key.repositoryID, key.commit, key.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.RawContents")
+	}
+
+	lines := splitLines(content)
+	f.lines[key] = lines
+	return lines, nil
+}
+
+// sortRenameEdits sorts the given edits by path and position.
+func sortRenameEdits(edits []shared.RenameEdit) {
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].Path != edits[j].Path {
+			return edits[i].Path < edits[j].Path
+		}
+		if edits[i].Range.Start.Line != edits[j].Range.Start.Line {
+			return edits[i].Range.Start.Line < edits[j].Range.Start.Line
+		}
+
+		return edits[i].Range.Start.Character < edits[j].Range.Start.Character
+	})
+}
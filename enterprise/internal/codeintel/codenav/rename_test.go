@@ -0,0 +1,69 @@
+package codenav
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+)
+
+func TestNarrowRenameRange(t *testing.T) {
+	lines := splitLines([]byte("x := lib.Add(Adder, 1)\nreturn Add\r\n"))
+
+	testCases := []struct {
+		r        types.Range
+		expected types.Range
+		ok       bool
+	}{
+		{r: newRange(0, 5, 0, 12), expected: newRange(0, 9, 0, 12), ok: true}, // qualified name
+		{r: newRange(0, 9, 0, 12), expected: newRange(0, 9, 0, 12), ok: true}, // exact name
+		{r: newRange(1, 7, 2, 0), expected: newRange(1, 7, 1, 10), ok: true},  // multi-line range
+		{r: newRange(0, 13, 0, 18), ok: false},                                // partial word
+		{r: newRange(0, 0, 0, 1), ok: false},                                  // other symbol
+		{r: newRange(4, 0, 4, 3), ok: false},                                  // out of bounds
+	}
+
+	for _, testCase := range testCases {
+		r, ok := narrowRenameRange(lines, testCase.r, "Add")
+		if ok != testCase.ok || r != testCase.expected {
+			t.Errorf("unexpected range for %v. want=%v (%v) have=%v (%v)", testCase.r, testCase.expected, testCase.ok, r, ok)
+		}
+	}
+}
+
+func TestRenameFileDiff(t *testing.T) {
+	lines := splitLines([]byte("a := Add(Add(1, 2), 3)\n2\n3\n4\n5\n6\n7\n8\n9\nreturn Add"))
+	ranges := []types.Range{
+		newRange(0, 9, 0, 12),
+		newRange(0, 5, 0, 8),
+		newRange(9, 7, 9, 10),
+	}
+
+	fileDiff, err := diff.PrintFileDiff(renameFileDiff("sub/a.go", lines, ranges, "Plus"))
+	if err != nil {
+		t.Fatalf("unexpected error printing diff: %s", err)
+	}
+
+	expected := "diff --git a/sub/a.go b/sub/a.go\n" +
+		"--- a/sub/a.go\n" +
+		"+++ b/sub/a.go\n" +
+		"@@ -1,4 +1,4 @@\n" +
+		"-a := Add(Add(1, 2), 3)\n" +
+		"+a := Plus(Plus(1, 2), 3)\n" +
+		" 2\n" +
+		" 3\n" +
+		" 4\n" +
+		"@@ -7,4 +7,4 @@\n" +
+		" 7\n" +
+		" 8\n" +
+		" 9\n" +
+		"-return Add\n" +
+		"\\ No newline at end of file\n" +
+		"+return Plus\n" +
+		"\\ No newline at end of file\n"
+	if diff := cmp.Diff(expected, string(fileDiff)); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -2,9 +2,13 @@ package codenav
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/grafana/regexp"
 	traceLog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel/attribute"
 
@@ -244,23 +248,9 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	// more local results remaining, just as we did above.
 	if cursor.Phase == "remote" {
 		if cursor.RemoteCursor.UploadBatchIDs == nil {
-			cursor.RemoteCursor.UploadBatchIDs = []int{}
-			definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, cursor.OrderedMonikers, requestState)
-			if err != nil {
+			if cursor.RemoteCursor.UploadBatchIDs, err = s.getRemoteDefinitionUploadIDs(ctx, cursor.OrderedMonikers, adjustedUploads, requestState); err != nil {
 				return nil, cursor, err
 			}
-			for i := range definitionUploads {
-				found := false
-				for j := range adjustedUploads {
-					if definitionUploads[i].ID == adjustedUploads[j].Upload.ID {
-						found = true
-						break
-					}
-				}
-				if !found {
-					cursor.RemoteCursor.UploadBatchIDs = append(cursor.RemoteCursor.UploadBatchIDs, definitionUploads[i].ID)
-				}
-			}
 		}
 
 		for len(locations) < args.Limit {
@@ -291,6 +281,31 @@ func (s *Service) GetReferences(ctx context.Context, args shared.RequestArgs, re
 	return referenceLocations, cursor, nil
 }
 
+// getRemoteDefinitionUploadIDs returns the identifiers of the uploads that provide any of the given monikers,
+// excluding the given visible uploads.
+func (s *Service) getRemoteDefinitionUploadIDs(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, visibleUploads []visibleUpload, requestState RequestState) ([]int, error) {
+	definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, orderedMonikers, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for i := range definitionUploads {
+		found := false
+		for j := range visibleUploads {
+			if definitionUploads[i].ID == visibleUploads[j].Upload.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ids = append(ids, definitionUploads[i].ID)
+		}
+	}
+
+	return ids, nil
+}
+
 // getUploadsWithDefinitionsForMonikers returns the set of uploads that provide any of the given monikers.
 // This method will not return uploads for commits which are unknown to gitserver.
 func (s *Service) getUploadsWithDefinitionsForMonikers(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData, requestState RequestState) ([]types.Dump, error) {
@@ -768,6 +783,482 @@ func (s *Service) GetImplementations(ctx context.Context, args shared.RequestArg
 	return implementationLocations, cursor, nil
 }
 
+// getAllReferenceLocations returns at most limit references to the symbol at the visible uploads. The
+// references within the visible uploads are followed by the references within the uploads of other
+// repositories, exactly as GetReferences would return them when paging through the entire result set.
+func (s *Service) getAllReferenceLocations(ctx context.Context, args shared.RequestArgs, requestState RequestState, visibleUploads []visibleUpload, orderedMonikers []precise.QualifiedMonikerData, limit int, trace observation.TraceLogger) ([]shared.Location, error) {
+	locations, _, err := s.getPageLocalLocations(ctx, s.lsifstore.GetReferenceLocations, visibleUploads, &shared.LocalCursor{}, limit, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteCursor := shared.RemoteCursor{}
+	if remoteCursor.UploadBatchIDs, err = s.getRemoteDefinitionUploadIDs(ctx, orderedMonikers, visibleUploads, requestState); err != nil {
+		return nil, err
+	}
+	for len(locations) < limit {
+		remoteLocations, hasMore, err := s.getPageRemoteLocations(ctx, "references", visibleUploads, orderedMonikers, &remoteCursor, limit-len(locations), trace, args, requestState)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, remoteLocations...)
+
+		if !hasMore {
+			break
+		}
+	}
+
+	return locations, nil
+}
+
+// callHierarchyReferencesLimit is the maximum number of references to a function inspected to find
+// its callers.
+const callHierarchyReferencesLimit = 1000
+
+// GetIncomingCalls returns the functions calling the function at the given position along with the
+// locations of the calls. The references to the function found in the visible uploads and, through
+// monikers, in the uploads of other repositories are grouped by the function enclosing them. Only
+// SCIP indexes record enough information to determine enclosing functions. At most args.Limit
+// callers are returned.
+func (s *Service) GetIncomingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []shared.CallHierarchyItem, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+			traceLog.Int("limit", args.Limit),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedMonikers, err := s.getOrderedMonikers(ctx, visibleUploads, "import", "export")
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numMonikers", len(orderedMonikers)),
+		attribute.String("monikers", monikersToString(orderedMonikers)))
+
+	locations, err := s.getAllReferenceLocations(ctx, args, requestState, visibleUploads, orderedMonikers, callHierarchyReferencesLimit, trace)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferences", len(locations)))
+
+	// Group the references by the function enclosing them
+	type caller struct {
+		definition shared.SymbolLocation
+		calls      []shared.Location
+	}
+	var callers []*caller
+	callersByDefinition := map[shared.Location]*caller{}
+
+	for _, locationsInDocument := range groupLocationsByDocument(locations) {
+		ranges := make([]types.Range, 0, len(locationsInDocument))
+		for _, location := range locationsInDocument {
+			ranges = append(ranges, location.Range)
+		}
+
+		enclosingFunctions, err := s.lsifstore.GetEnclosingFunctions(ctx, locationsInDocument[0].DumpID, locationsInDocument[0].Path, ranges)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetEnclosingFunctions")
+		}
+
+		for i, enclosingFunction := range enclosingFunctions {
+			if enclosingFunction.Symbol == "" {
+				continue
+			}
+
+			c, ok := callersByDefinition[enclosingFunction.Location]
+			if !ok {
+				if len(callers) >= args.Limit {
+					continue
+				}
+
+				c = &caller{definition: enclosingFunction}
+				callers = append(callers, c)
+				callersByDefinition[enclosingFunction.Location] = c
+			}
+			c.calls = append(c.calls, locationsInDocument[i])
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCallers", len(callers)))
+
+	// Adjust the locations back to the appropriate range in the target commits
+	items := make([]shared.CallHierarchyItem, 0, len(callers))
+	for _, c := range callers {
+		item, ok, err := s.getCallHierarchyItem(ctx, args, requestState, c.definition.Symbol, []shared.Location{c.definition.Location}, c.calls)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// GetOutgoingCalls returns the functions called by the function at the given position along with the
+// locations of the calls. The calls are the references to functions occurring within the body of the
+// definition of the function at the given position. The definitions of the callees are resolved within
+// the same upload or, through monikers, within the uploads of other repositories. Only SCIP indexes
+// record enough information to determine function bodies. At most args.Limit callees are returned.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []shared.CallHierarchyItem, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+			traceLog.Int("limit", args.Limit),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	// The requested position may be a call of the function rather than its definition
+	definitions, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the calls within the body of the function by callee
+	type callee struct {
+		symbol string
+		calls  []shared.Location
+	}
+	var callees []*callee
+	calleesBySymbol := map[string]*callee{}
+
+	for _, definition := range definitions {
+		occurrences, err := s.lsifstore.GetCalleeOccurrences(ctx, definition.DumpID, definition.Path, definition.Range.Start.Line, definition.Range.Start.Character)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetCalleeOccurrences")
+		}
+
+		for _, occurrence := range occurrences {
+			c, ok := calleesBySymbol[occurrence.Symbol]
+			if !ok {
+				if len(callees) >= args.Limit {
+					continue
+				}
+
+				c = &callee{symbol: occurrence.Symbol}
+				callees = append(callees, c)
+				calleesBySymbol[occurrence.Symbol] = c
+			}
+			c.calls = append(c.calls, occurrence.Location)
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCallees", len(callees)))
+
+	items := make([]shared.CallHierarchyItem, 0, len(callees))
+	for _, c := range callees {
+		// Resolve the definition of the callee from its first call
+		call := c.calls[0]
+		upload, ok := requestState.dataLoader.GetUploadFromCacheMap(call.DumpID)
+		if !ok {
+			continue
+		}
+
+		calleeDefinitions, err := s.getDefinitionLocations(ctx, []visibleUpload{{
+			Upload:                upload,
+			TargetPath:            upload.Root + call.Path,
+			TargetPosition:        call.Range.Start,
+			TargetPathWithoutRoot: call.Path,
+		}}, requestState, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		item, ok, err := s.getCallHierarchyItem(ctx, args, requestState, c.symbol, calleeDefinitions, c.calls)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// getCallHierarchyItem translates the definitions and call locations of a function (relative to the indexed
+// commits) into equivalent locations in the requested commit. If none of the calls are visible to the current
+// actor, a false-valued flag is returned.
+func (s *Service) getCallHierarchyItem(ctx context.Context, args shared.RequestArgs, requestState RequestState, symbol string, definitions, calls []shared.Location) (shared.CallHierarchyItem, bool, error) {
+	adjustedCalls, err := s.getUploadLocations(ctx, args, requestState, calls, true)
+	if err != nil || len(adjustedCalls) == 0 {
+		return shared.CallHierarchyItem{}, false, err
+	}
+
+	adjustedDefinitions, err := s.getUploadLocations(ctx, args, requestState, definitions, true)
+	if err != nil {
+		return shared.CallHierarchyItem{}, false, err
+	}
+
+	return shared.CallHierarchyItem{
+		Symbol:      symbol,
+		Definitions: adjustedDefinitions,
+		CallRanges:  adjustedCalls,
+	}, true, nil
+}
+
+// renameReferencesLimit is the maximum number of references to a symbol inspected to build a rename
+// preview.
+const renameReferencesLimit = 10000
+
+// renameSearchFilesLimit is the maximum number of files of each repository searched for imprecise
+// occurrences of a renamed symbol.
+const renameSearchFilesLimit = 500
+
+// GetRenamePreview returns the edits renaming the symbol at the given position to the given name, grouped
+// by repository and commit. Precise edits are derived from the definitions and references of the symbol
+// within the visible uploads and, through monikers, within the uploads of other repositories. Locations
+// that cannot be adjusted to the requested commit are dropped. The files of each affected repository that
+// share an extension with one of its precise edits are then searched for the remaining whole-word
+// occurrences of the old name, which are returned as imprecise edits.
+func (s *Service) GetRenamePreview(ctx context.Context, args shared.RequestArgs, requestState RequestState, newName string) (_ shared.RenamePreview, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getRenamePreview, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+			traceLog.String("newName", newName),
+		},
+	})
+	defer endObservation()
+
+	if !isIdentifier(newName) {
+		return shared.RenamePreview{}, errors.Newf("invalid name %q", newName)
+	}
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return shared.RenamePreview{}, err
+	}
+
+	orderedMonikers, err := s.getOrderedMonikers(ctx, visibleUploads, "import", "export")
+	if err != nil {
+		return shared.RenamePreview{}, err
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numMonikers", len(orderedMonikers)),
+		attribute.String("monikers", monikersToString(orderedMonikers)))
+
+	definitions, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return shared.RenamePreview{}, err
+	}
+	references, err := s.getAllReferenceLocations(ctx, args, requestState, visibleUploads, orderedMonikers, renameReferencesLimit, trace)
+	if err != nil {
+		return shared.RenamePreview{}, err
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numDefinitions", len(definitions)),
+		attribute.Int("numReferences", len(references)))
+
+	// Edits must apply to the requested commit, so we do not fall back to the indexed commit
+	locations, err := s.getUploadLocations(ctx, args, requestState, append(definitions, references...), false)
+	if err != nil {
+		return shared.RenamePreview{}, err
+	}
+
+	files := newRenameFiles(s.gitserver)
+
+	// The old name is the identifier at the requested position, provided that precise data exists there
+	var oldName string
+	position := types.Position{Line: args.Line, Character: args.Character}
+	for _, location := range locations {
+		if location.Dump.RepositoryID != args.RepositoryID || location.TargetCommit != args.Commit || location.Path != args.Path || !rangeContainsPosition(location.TargetRange, position) {
+			continue
+		}
+
+		lines, err := files.get(ctx, renameFileKey{repositoryID: args.RepositoryID, commit: args.Commit, path: args.Path})
+		if err != nil {
+			return shared.RenamePreview{}, err
+		}
+		if args.Line < len(lines) {
+			oldName = identifierAt(lineText(lines[args.Line]), args.Character)
+		}
+		break
+	}
+	if oldName == "" || oldName == newName {
+		return shared.RenamePreview{OldName: oldName, NewName: newName}, nil
+	}
+
+	// Group the precise edits by repository and commit
+	var repositories []*shared.RepositoryRenameEdits
+	repositoriesByCommit := map[codeintelgitserver.RepositoryCommit]*shared.RepositoryRenameEdits{}
+	preciseEdits := map[renameEditKey]struct{}{}
+
+	for _, location := range locations {
+		file := renameFileKey{repositoryID: location.Dump.RepositoryID, commit: location.TargetCommit, path: location.Path}
+		lines, err := files.get(ctx, file)
+		if err != nil {
+			return shared.RenamePreview{}, err
+		}
+
+		r, ok := narrowRenameRange(lines, location.TargetRange, oldName)
+		if !ok {
+			continue
+		}
+		if _, ok := preciseEdits[renameEditKey{file: file, r: r}]; ok {
+			continue
+		}
+		preciseEdits[renameEditKey{file: file, r: r}] = struct{}{}
+
+		key := codeintelgitserver.RepositoryCommit{RepositoryID: file.repositoryID, Commit: file.commit}
+		repository, ok := repositoriesByCommit[key]
+		if !ok {
+			repository = &shared.RepositoryRenameEdits{
+				RepositoryID:   file.repositoryID,
+				RepositoryName: location.Dump.RepositoryName,
+				Commit:         file.commit,
+			}
+			repositories = append(repositories, repository)
+			repositoriesByCommit[key] = repository
+		}
+		repository.Edits = append(repository.Edits, shared.RenameEdit{Path: file.path, Range: r})
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numRepositories", len(repositories)),
+		attribute.Int("numPreciseEdits", len(preciseEdits)))
+
+	preview := shared.RenamePreview{
+		OldName:      oldName,
+		NewName:      newName,
+		Repositories: make([]shared.RepositoryRenameEdits, 0, len(repositories)),
+	}
+	for _, repository := range repositories {
+		impreciseEdits, err := s.getImpreciseRenameEdits(ctx, *repository, oldName, files, preciseEdits)
+		if err != nil {
+			return shared.RenamePreview{}, err
+		}
+		trace.AddEvent("TODO Domain Owner",
+			attribute.Int("repositoryID", repository.RepositoryID),
+			attribute.Int("numImpreciseEdits", len(impreciseEdits)))
+
+		if repository.Diff, err = s.getRenameDiff(ctx, *repository, newName, files); err != nil {
+			return shared.RenamePreview{}, err
+		}
+
+		repository.Edits = append(repository.Edits, impreciseEdits...)
+		sortRenameEdits(repository.Edits)
+		preview.Repositories = append(preview.Repositories, *repository)
+	}
+
+	return preview, nil
+}
+
+// getImpreciseRenameEdits searches the files of the given repository that share an extension with one
+// of its precise edits for whole-word occurrences of the old name not covered by a precise edit. At most
+// renameSearchFilesLimit files are searched.
+func (s *Service) getImpreciseRenameEdits(ctx context.Context, repository shared.RepositoryRenameEdits, oldName string, files *renameFiles, preciseEdits map[renameEditKey]struct{}) ([]shared.RenameEdit, error) {
+	extensions := map[string]struct{}{}
+	for _, edit := range repository.Edits {
+		if extension := filepath.Ext(edit.Path); extension != "" {
+			extensions[extension] = struct{}{}
+		}
+	}
+	if len(extensions) == 0 {
+		return nil, nil
+	}
+
+	patterns := make([]string, 0, len(extensions))
+	for extension := range extensions {
+		patterns = append(patterns, regexp.QuoteMeta(extension))
+	}
+	sort.Strings(patterns)
+	pattern, err := regexp.Compile("(?:" + strings.Join(patterns, "|") + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := s.gitserver.ListFiles(ctx, repository.RepositoryID, repository.Commit, pattern)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.ListFiles")
+	}
+	sort.Strings(paths)
+	if len(paths) > renameSearchFilesLimit {
+		paths = paths[:renameSearchFilesLimit]
+	}
+
+	var edits []shared.RenameEdit
+	for _, path := range paths {
+		file := renameFileKey{repositoryID: repository.RepositoryID, commit: repository.Commit, path: path}
+		lines, err := files.get(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, line := range lines {
+			for _, offset := range findIdentifiers(lineText(line), oldName) {
+				r := types.Range{
+					Start: types.Position{Line: i, Character: offset},
+					End:   types.Position{Line: i, Character: offset + len(oldName)},
+				}
+				if _, ok := preciseEdits[renameEditKey{file: file, r: r}]; ok {
+					continue
+				}
+
+				edits = append(edits, shared.RenameEdit{Path: path, Range: r, Imprecise: true})
+			}
+		}
+	}
+
+	return edits, nil
+}
+
+// getRenameDiff returns a git-style unified diff applying the precise edits of the given repository.
+func (s *Service) getRenameDiff(ctx context.Context, repository shared.RepositoryRenameEdits, newName string, files *renameFiles) ([]byte, error) {
+	var paths []string
+	rangesByPath := map[string][]types.Range{}
+	for _, edit := range repository.Edits {
+		if edit.Imprecise {
+			continue
+		}
+		if _, ok := rangesByPath[edit.Path]; !ok {
+			paths = append(paths, edit.Path)
+		}
+		rangesByPath[edit.Path] = append(rangesByPath[edit.Path], edit.Range)
+	}
+	sort.Strings(paths)
+
+	fileDiffs := make([]*diff.FileDiff, 0, len(paths))
+	for _, path := range paths {
+		lines, err := files.get(ctx, renameFileKey{repositoryID: repository.RepositoryID, commit: repository.Commit, path: path})
+		if err != nil {
+			return nil, err
+		}
+
+		fileDiffs = append(fileDiffs, renameFileDiff(path, lines, rangesByPath[path], newName))
+	}
+
+	return diff.PrintMultiFileDiff(fileDiffs)
+}
+
 // GetDefinitions returns the set of locations defining the symbol at the given position.
 func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []types.UploadLocation, err error) {
 	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getDefinitions, serviceObserverThreshold, observation.Args{
@@ -790,13 +1281,42 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 		return nil, err
 	}
 
+	locations, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// getDefinitionLocations returns the set of locations defining the symbol at the target position of the
+// given visible uploads. The locations are relative to the indexed commits.
+func (s *Service) getDefinitionLocations(ctx context.Context, visibleUploads []visibleUpload, requestState RequestState, trace observation.TraceLogger) ([]shared.Location, error) {
+	return s.getRelatedDefinitionLocations(ctx, s.lsifstore.GetDefinitionLocations, precise.Import, visibleUploads, requestState, trace)
+}
+
+// getRelatedDefinitionLocations returns the set of locations defining a symbol related to the symbol at the
+// target position of the given visible uploads (the symbol itself, its type, etc). Definitions within the
+// visible uploads are found with the given function. Otherwise, definitions are searched in other uploads
+// through the monikers of the given kind. The locations are relative to the indexed commits.
+func (s *Service) getRelatedDefinitionLocations(ctx context.Context, getLocations getLocationsFn, monikerKind string, visibleUploads []visibleUpload, requestState RequestState, trace observation.TraceLogger) ([]shared.Location, error) {
 	// Gather the "local" reference locations that are reachable via a referenceResult vertex.
 	// If the definition exists within the index, it should be reachable via an LSIF graph
 	// traversal and should not require an additional moniker search in the same index.
 	for i := range visibleUploads {
 		trace.AddEvent("TODO Domain Owner", attribute.Int("uploadID", visibleUploads[i].Upload.ID))
 
-		locations, _, err := s.lsifstore.GetDefinitionLocations(
+		locations, _, err := getLocations(
 			ctx,
 			visibleUploads[i].Upload.ID,
 			visibleUploads[i].TargetPathWithoutRoot,
@@ -810,12 +1330,12 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 		}
 		if len(locations) > 0 {
 			// If we have a local definition, we won't find a better one and can exit early
-			return s.getUploadLocations(ctx, args, requestState, locations, true)
+			return locations, nil
 		}
 	}
 
-	// Gather all import monikers attached to the ranges enclosing the requested position
-	orderedMonikers, err := s.getOrderedMonikers(ctx, visibleUploads, "import")
+	// Gather all monikers of the given kind attached to the ranges enclosing the requested position
+	orderedMonikers, err := s.getOrderedMonikers(ctx, visibleUploads, monikerKind)
 	if err != nil {
 		return nil, err
 	}
@@ -841,15 +1361,74 @@ func (s *Service) GetDefinitions(ctx context.Context, args shared.RequestArgs, r
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numXrepoLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all definitions
-	// are occurring at the same commit they are looking at.
+	return locations, nil
+}
+
+// GetTypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+func (s *Service) GetTypeDefinitions(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []types.UploadLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getTypeDefinitions, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.getRelatedDefinitionLocations(ctx, s.lsifstore.GetTypeDefinitionLocations, precise.TypeDefinition, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
 
 	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
 	if err != nil {
 		return nil, err
 	}
-	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// GetPrototypes returns the set of locations defining the symbols implemented by the symbol at the given
+// position, such as the interface methods satisfied by a method. This is the reverse of GetImplementations.
+func (s *Service) GetPrototypes(ctx context.Context, args shared.RequestArgs, requestState RequestState) (_ []types.UploadLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getPrototypes, serviceObserverThreshold, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.Int("repositoryID", args.RepositoryID),
+			traceLog.String("commit", args.Commit),
+			traceLog.String("path", args.Path),
+			traceLog.Int("numUploads", len(requestState.GetCacheUploads())),
+			traceLog.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+			traceLog.Int("line", args.Line),
+			traceLog.Int("character", args.Character),
+		},
+	})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, err := s.getRelatedDefinitionLocations(ctx, s.lsifstore.GetPrototypeLocations, precise.Implementation, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedLocations", len(adjustedLocations)))
 
 	return adjustedLocations, nil
 }
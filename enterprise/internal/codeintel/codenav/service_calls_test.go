@@ -0,0 +1,184 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// The function is defined at a.go:testRange1 and referenced three times
+	mockLsifStore.GetReferenceLocationsFunc.SetDefaultHook(func(ctx context.Context, uploadID int, path string, line, character, limit, offset int) ([]shared.Location, int, error) {
+		if uploadID != 51 {
+			return nil, 0, nil
+		}
+
+		locations := []shared.Location{
+			{DumpID: 51, Path: "a.go", Range: testRange1},
+			{DumpID: 51, Path: "a.go", Range: testRange2},
+			{DumpID: 51, Path: "b.go", Range: testRange3},
+			{DumpID: 51, Path: "b.go", Range: testRange4},
+		}
+		return locations, len(locations), nil
+	})
+	mockLsifStore.GetEnclosingFunctionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string, ranges []types.Range) ([]shared.SymbolLocation, error) {
+		if path == "a.go" {
+			return []shared.SymbolLocation{
+				{},
+				{Location: shared.Location{DumpID: 51, Path: "a.go", Range: testRange5}, Symbol: "g"},
+			}, nil
+		}
+
+		h := shared.SymbolLocation{Location: shared.Location{DumpID: 51, Path: "b.go", Range: testRange2}, Symbol: "h"}
+		return []shared.SymbolLocation{h, h}, nil
+	})
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        10,
+	}
+	items, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedItems := []shared.CallHierarchyItem{
+		{
+			Symbol:      "g",
+			Definitions: []types.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange5}},
+			CallRanges:  []types.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange2}},
+		},
+		{
+			Symbol:      "h",
+			Definitions: []types.UploadLocation{{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange2}},
+			CallRanges: []types.UploadLocation{
+				{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange3},
+				{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange4},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedItems, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	// Callers past the limit are dropped
+	mockRequest.Limit = 1
+	items, err = svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+	if diff := cmp.Diff(expectedItems[:1], items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// The requested function is defined at a.go:testRange1 and calls g (defined at b.go:testRange5)
+	// twice and h (not defined in any index) once.
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(ctx context.Context, uploadID int, path string, line, character, limit, offset int) ([]shared.Location, int, error) {
+		switch {
+		case uploadID == 51 && line == 10:
+			return []shared.Location{{DumpID: 51, Path: "a.go", Range: testRange1}}, 1, nil
+		case uploadID == 51 && path == "a.go" && line == testRange2.Start.Line:
+			return []shared.Location{{DumpID: 51, Path: "b.go", Range: testRange5}}, 1, nil
+		}
+		return nil, 0, nil
+	})
+	mockLsifStore.GetCalleeOccurrencesFunc.SetDefaultHook(func(ctx context.Context, bundleID int, path string, line, character int) ([]shared.SymbolLocation, error) {
+		if bundleID != 51 || path != "a.go" || line != testRange1.Start.Line {
+			return nil, nil
+		}
+
+		return []shared.SymbolLocation{
+			{Location: shared.Location{DumpID: 51, Path: "a.go", Range: testRange2}, Symbol: "g"},
+			{Location: shared.Location{DumpID: 51, Path: "a.go", Range: testRange3}, Symbol: "h"},
+			{Location: shared.Location{DumpID: 51, Path: "a.go", Range: testRange4}, Symbol: "g"},
+		}, nil
+	})
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+		Limit:        10,
+	}
+	items, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedItems := []shared.CallHierarchyItem{
+		{
+			Symbol:      "g",
+			Definitions: []types.UploadLocation{{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange5}},
+			CallRanges: []types.UploadLocation{
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange2},
+				{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange4},
+			},
+		},
+		{
+			Symbol:      "h",
+			Definitions: []types.UploadLocation{},
+			CallRanges:  []types.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange3}},
+		},
+	}
+	if diff := cmp.Diff(expectedItems, items); diff != "" {
+		t.Errorf("unexpected items (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,86 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestPrototypes(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// The method satisfies an interface method defined in the same index
+	locations := []shared.Location{
+		{DumpID: 50, Path: "reader.go", Range: testRange3},
+	}
+	mockLsifStore.GetPrototypeLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+	}
+	adjustedLocations, err := svc.GetPrototypes(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying prototypes: %s", err)
+	}
+
+	expectedLocations := []types.UploadLocation{
+		{Dump: uploads[0], Path: "sub1/reader.go", TargetCommit: mockCommit, TargetRange: testRange3},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetDefinitionLocationsFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.GetDefinitionLocations. want=%d have=%d", 0, len(history))
+	}
+	if history := mockLsifStore.GetMonikersByPositionFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.GetMonikersByPosition. want=%d have=%d", 0, len(history))
+	}
+
+	// Without local prototypes, the implementation monikers are searched in other indexes
+	mockLsifStore.GetMonikersByPositionFunc.PushReturn([][]precise.MonikerData{{
+		{Kind: precise.Export, Scheme: "scip-go", Identifier: "scip-go gomod example v1 `main`/File#Read.", PackageInformationID: "scip:1"},
+		{Kind: precise.Implementation, Scheme: "scip-go", Identifier: "scip-go gomod std v1 `io`/Reader#Read.", PackageInformationID: "scip:2"},
+	}}, nil)
+	if _, err := svc.GetPrototypes(context.Background(), mockRequest, mockRequestState); err != nil {
+		t.Fatalf("unexpected error querying prototypes: %s", err)
+	}
+
+	if history := mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for uploadSvc.GetDumpsWithDefinitionsForMonikers. want=%d have=%d", 1, len(history))
+	} else if len(history[0].Arg1) != 1 || history[0].Arg1[0].Kind != precise.Implementation {
+		t.Errorf("unexpected monikers: %v", history[0].Arg1)
+	}
+}
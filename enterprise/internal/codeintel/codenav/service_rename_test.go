@@ -0,0 +1,162 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestRenamePreview(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{ID: 42}, mockCommit, "main.go", hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, RepositoryID: 42, RepositoryName: "github.com/test/lib", Commit: mockCommit},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	files := map[int]map[string]string{
+		42: {
+			"main.go": "package main\n\nfunc Add(a, b int) int { return a + b }\n\nfunc main() {\n\tAdd(1, 2)\n}\n",
+			"util.go": "package main\n\n// Add is also mentioned here, but AddAll is not\nvar _ = \"Add\"\n",
+			"README":  "Add",
+		},
+		43: {
+			"consumer.go": "package consumer\n\nvar Sum = lib.Add(1, 2)\n",
+		},
+	}
+	mockGitserverClient.RawContentsFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit, file string) ([]byte, error) {
+		return []byte(files[repositoryID][file]), nil
+	})
+	mockGitserverClient.ListFilesFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) (paths []string, _ error) {
+		for path := range files[repositoryID] {
+			if pattern.MatchString(path) {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	})
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	// Add is defined and referenced in main.go; the reference to the package clause is stale
+	definition := shared.Location{DumpID: 50, Path: "main.go", Range: newRange(2, 5, 2, 8)}
+	mockLsifStore.GetDefinitionLocationsFunc.PushReturn([]shared.Location{definition}, 1, nil)
+	references := []shared.Location{
+		definition,
+		{DumpID: 50, Path: "main.go", Range: newRange(5, 1, 5, 4)},
+		{DumpID: 50, Path: "main.go", Range: newRange(0, 0, 0, 7)},
+	}
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(references, len(references), nil)
+
+	// Add is referenced by its qualified name in another repository
+	mockLsifStore.GetMonikersByPositionFunc.SetDefaultReturn([][]precise.MonikerData{{
+		{Kind: precise.Export, Scheme: "gomod", Identifier: "github.com/test/lib:Add", PackageInformationID: "1"},
+	}}, nil)
+	mockLsifStore.GetPackageInformationFunc.SetDefaultReturn(precise.PackageInformationData{Name: "github.com/test/lib", Version: "v1"}, true, nil)
+	consumerDump := types.Dump{ID: 151, RepositoryID: 43, RepositoryName: "github.com/test/consumer", Commit: "cafebabe"}
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{151}, 1, 1, nil)
+	mockUploadSvc.GetDumpsByIDsFunc.PushReturn([]types.Dump{consumerDump}, nil)
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn([]shared.Location{{DumpID: 151, Path: "consumer.go", Range: newRange(2, 10, 2, 17)}}, 1, nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         "main.go",
+		Line:         5,
+		Character:    2,
+	}
+	preview, err := svc.GetRenamePreview(context.Background(), mockRequest, mockRequestState, "Plus")
+	if err != nil {
+		t.Fatalf("unexpected error querying rename preview: %s", err)
+	}
+
+	expectedPreview := shared.RenamePreview{
+		OldName: "Add",
+		NewName: "Plus",
+		Repositories: []shared.RepositoryRenameEdits{
+			{
+				RepositoryID:   42,
+				RepositoryName: "github.com/test/lib",
+				Commit:         mockCommit,
+				Edits: []shared.RenameEdit{
+					{Path: "main.go", Range: newRange(2, 5, 2, 8)},
+					{Path: "main.go", Range: newRange(5, 1, 5, 4)},
+					{Path: "util.go", Range: newRange(2, 3, 2, 6), Imprecise: true},
+					{Path: "util.go", Range: newRange(3, 9, 3, 12), Imprecise: true},
+				},
+				Diff: []byte("diff --git a/main.go b/main.go\n" +
+					"--- a/main.go\n" +
+					"+++ b/main.go\n" +
+					"@@ -1,7 +1,7 @@\n" +
+					" package main\n" +
+					" \n" +
+					"-func Add(a, b int) int { return a + b }\n" +
+					"+func Plus(a, b int) int { return a + b }\n" +
+					" \n" +
+					" func main() {\n" +
+					"-\tAdd(1, 2)\n" +
+					"+\tPlus(1, 2)\n" +
+					" }\n"),
+			},
+			{
+				RepositoryID:   43,
+				RepositoryName: "github.com/test/consumer",
+				Commit:         "cafebabe",
+				Edits: []shared.RenameEdit{
+					{Path: "consumer.go", Range: newRange(2, 14, 2, 17)},
+				},
+				Diff: []byte("diff --git a/consumer.go b/consumer.go\n" +
+					"--- a/consumer.go\n" +
+					"+++ b/consumer.go\n" +
+					"@@ -1,3 +1,3 @@\n" +
+					" package consumer\n" +
+					" \n" +
+					"-var Sum = lib.Add(1, 2)\n" +
+					"+var Sum = lib.Plus(1, 2)\n"),
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedPreview, preview); diff != "" {
+		t.Errorf("unexpected rename preview (-want +got):\n%s", diff)
+	}
+
+	for _, name := range []string{"", "1abc", "lib.Plus", "a b"} {
+		if _, err := svc.GetRenamePreview(context.Background(), mockRequest, mockRequestState, name); err == nil {
+			t.Errorf("expected error renaming to %q", name)
+		}
+	}
+}
+
+func newRange(startLine, startCharacter, endLine, endCharacter int) types.Range {
+	return types.Range{
+		Start: types.Position{Line: startLine, Character: startCharacter},
+		End:   types.Position{Line: endLine, Character: endCharacter},
+	}
+}
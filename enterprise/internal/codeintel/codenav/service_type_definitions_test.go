@@ -0,0 +1,152 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	codeintelgitserver "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestTypeDefinitions(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []types.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+	}
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn(nil, 0, nil)
+	mockLsifStore.GetTypeDefinitionLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 51,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+	}
+	adjustedLocations, err := svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []types.UploadLocation{
+		{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", TargetCommit: mockCommit, TargetRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	// Local type definitions do not require a moniker search
+	if history := mockLsifStore.GetMonikersByPositionFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.GetMonikersByPosition. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestTypeDefinitionsRemote(t *testing.T) {
+	// Set up mocks
+	mockStore := NewMockStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitServer := codeintelgitserver.New(&observation.TestContext, database.NewMockDB())
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitServer, &sgtypes.Repo{ID: 42}, mockCommit, mockPath, hunkCache)
+	mockRequestState.GitTreeTranslator = mockedGitTreeTranslator()
+	uploads := []types.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	dumps := []types.Dump{
+		{ID: 151, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockUploadSvc.GetDumpsWithDefinitionsForMonikersFunc.PushReturn(dumps, nil)
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []codeintelgitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	// The variable is imported from another index, as is its type
+	monikers := []precise.MonikerData{
+		{Kind: precise.Import, Scheme: "scip-go", Identifier: "scip-go gomod leftpad v1 `leftpad`/Default.", PackageInformationID: "scip:1"},
+		{Kind: precise.TypeDefinition, Scheme: "scip-go", Identifier: "scip-go gomod leftpad v1 `leftpad`/Padder#", PackageInformationID: "scip:1"},
+	}
+	mockLsifStore.GetMonikersByPositionFunc.PushReturn([][]precise.MonikerData{monikers}, nil)
+
+	packageInformation := precise.PackageInformationData{Manager: "gomod", Name: "leftpad", Version: "v1"}
+	mockLsifStore.GetPackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	locations := []shared.Location{
+		{DumpID: 151, Path: "padder.go", Range: testRange1},
+	}
+	mockLsifStore.GetBulkMonikerLocationsFunc.PushReturn(locations, len(locations), nil)
+
+	mockRequest := shared.RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         10,
+		Character:    20,
+	}
+	adjustedLocations, err := svc.GetTypeDefinitions(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []types.UploadLocation{
+		{Dump: dumps[0], Path: "sub2/padder.go", TargetCommit: "deadbeef2", TargetRange: testRange1},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.GetBulkMonikerLocationsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 1, len(history))
+	} else {
+		if history[0].Arg1 != "definitions" {
+			t.Errorf("unexpected table. want=%s have=%s", "definitions", history[0].Arg1)
+		}
+
+		// Only the type definition moniker is searched
+		if diff := cmp.Diff([]precise.MonikerData{monikers[1]}, history[0].Arg3); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+}
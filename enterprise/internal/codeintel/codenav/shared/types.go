@@ -1,7 +1,11 @@
 package shared
 
 import (
+	"fmt"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/git"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -12,6 +16,12 @@ type Location struct {
 	Range  types.Range
 }
 
+// SymbolLocation is the location of an occurrence of the given symbol.
+type SymbolLocation struct {
+	Location
+	Symbol string
+}
+
 type RequestArgs struct {
 	RepositoryID int
 	Commit       string
@@ -59,6 +69,74 @@ type CodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// CallHierarchyItem is a function calling (for incoming calls) or called by (for outgoing calls)
+// the requested function. The locations have been adjusted to fit the target (originally requested)
+// commit.
+type CallHierarchyItem struct {
+	Symbol string
+
+	// Definitions are the locations defining the function. For outgoing calls this may be empty
+	// when the callee is not defined in any index.
+	Definitions []types.UploadLocation
+
+	// CallRanges are the locations of the calls between the two functions. They always occur
+	// within the body of the calling function.
+	CallRanges []types.UploadLocation
+}
+
+// RenamePreview is the set of edits renaming a symbol in every repository with uploads defining or
+// referencing it.
+type RenamePreview struct {
+	OldName      string
+	NewName      string
+	Repositories []RepositoryRenameEdits
+}
+
+// RepositoryRenameEdits are the edits renaming a symbol within a single commit of a repository.
+type RepositoryRenameEdits struct {
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Edits          []RenameEdit
+
+	// Diff is a git-style unified diff applying the precise edits to the commit. Imprecise edits are
+	// left out so that they can be reviewed first.
+	Diff []byte
+}
+
+// RenameEdit replaces the old name of a symbol at the given range of a file with its new name.
+type RenameEdit struct {
+	Path  string
+	Range types.Range
+
+	// Imprecise is true if the edit comes from a search for the old name rather than from precise
+	// code intelligence data. Such edits may rename an unrelated symbol of the same name.
+	Imprecise bool
+}
+
+// ChangesetSpec returns a changeset spec committing the precise edits of the repository to the given
+// branch. The base repository is identified by its GraphQL ID and baseRef names the branch containing
+// the commit of the edits.
+func (e RepositoryRenameEdits) ChangesetSpec(baseRepository, baseRef, branch, oldName, newName string) *batcheslib.ChangesetSpec {
+	message := fmt.Sprintf("Rename %s to %s", oldName, newName)
+
+	return &batcheslib.ChangesetSpec{
+		BaseRepository: baseRepository,
+		HeadRepository: baseRepository,
+		BaseRef:        git.EnsureRefPrefix(baseRef),
+		BaseRev:        e.Commit,
+		HeadRef:        git.EnsureRefPrefix(branch),
+		Title:          message,
+		Body:           message,
+		Commits: []batcheslib.GitCommitDescription{
+			{
+				Message: message,
+				Diff:    e.Diff,
+			},
+		},
+	}
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
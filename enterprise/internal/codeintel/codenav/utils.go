@@ -131,3 +131,28 @@ func dedupeRanges(ranges []types.Range) []types.Range {
 	}
 	return dedup
 }
+
+// groupLocationsByDocument groups the given locations by upload and path. The groups, and the locations
+// within each group, retain the order of the input slice.
+func groupLocationsByDocument(locations []shared.Location) [][]shared.Location {
+	type document struct {
+		dumpID int
+		path   string
+	}
+
+	var groups [][]shared.Location
+	indexes := map[document]int{}
+	for _, location := range locations {
+		key := document{location.DumpID, location.Path}
+
+		i, ok := indexes[key]
+		if !ok {
+			i = len(groups)
+			indexes[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], location)
+	}
+
+	return groups
+}
@@ -271,8 +271,17 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Repo:    string(m.Name),
 			Content: string(m.Name),
 		}
+	case *result.OwnerMatch:
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Commit:  string(m.CommitID),
+			Content: m.Identifier(),
+		}
 	case *result.FileMatch:
-		lang, _ := enry.GetLanguageByExtension(m.Path)
+		lang := m.Language
+		if lang == "" {
+			lang, _ = enry.GetLanguageByExtension(m.Path)
+		}
 		return &MetaEnvironment{
 			Repo:    string(m.Repo.Name),
 			Path:    m.Path,
@@ -56,7 +56,12 @@ func countLang(r result.Match) map[MatchKey]int {
 	var lang string
 	switch match := r.(type) {
 	case *result.FileMatch:
-		lang, _ = enry.GetLanguageByExtension(match.Path)
+		// Prefer the language detected by the search backend, so that the
+		// aggregation agrees with the lang: filter.
+		lang = match.Language
+		if lang == "" {
+			lang, _ = enry.GetLanguageByExtension(match.Path)
+		}
 	default:
 	}
 	if lang != "" {
@@ -155,6 +160,8 @@ func matchContent(event result.Match) []string {
 		}
 	case *result.RepoMatch:
 		return []string{string(match.RepoName().Name)}
+	case *result.OwnerMatch:
+		return []string{match.Identifier()}
 	case *result.CommitMatch:
 		if match.DiffPreview != nil { // signals this is a Diff match
 			return nil
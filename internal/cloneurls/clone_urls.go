@@ -47,6 +47,7 @@ func RepoSourceCloneURLToRepoName(ctx context.Context, db database.DB, cloneURL
 			extsvc.KindBitbucketCloud,
 			extsvc.KindAWSCodeCommit,
 			extsvc.KindGitolite,
+			extsvc.KindGitea,
 			extsvc.KindPhabricator,
 			extsvc.KindOther,
 		},
@@ -134,6 +135,9 @@ func getRepoNameFromService(ctx context.Context, cloneURL string, svc *types.Ext
 	case *schema.GitoliteConnection:
 		rs = reposource.Gitolite{GitoliteConnection: c}
 		// Gitolite type does not have URL
+	case *schema.GiteaConnection:
+		rs = reposource.Gitea{GiteaConnection: c}
+		host = c.Url
 	case *schema.PhabricatorConnection:
 		// If this repository is mirrored by Phabricator, its clone URL should be
 		// handled by a supported code host or an OtherExternalServiceConnection.
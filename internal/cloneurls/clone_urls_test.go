@@ -22,6 +22,11 @@ func TestReposourceCloneURLToRepoName(t *testing.T) {
 			Kind:        extsvc.KindGitHub,
 			DisplayName: "GITHUB #1",
 			Config:      extsvc.NewUnencryptedConfig(`{"url": "https://github.example.com", "repositoryQuery": ["none"], "token": "abc"}`),
+		}, {
+			ID:          2,
+			Kind:        extsvc.KindGitea,
+			DisplayName: "GITEA #1",
+			Config:      extsvc.NewUnencryptedConfig(`{"url": "https://gitea.example.com", "token": "abc"}`),
 		}},
 		nil,
 	)
@@ -44,6 +49,11 @@ func TestReposourceCloneURLToRepoName(t *testing.T) {
 			cloneURL:     "https://github.example.com/user/repo.git",
 			wantRepoName: api.RepoName("github.example.com/user/repo"),
 		},
+		{
+			name:         "match existing gitea external service",
+			cloneURL:     "git@gitea.example.com:user/repo.git",
+			wantRepoName: api.RepoName("gitea.example.com/user/repo"),
+		},
 		{
 			name:         "fallback for github.com",
 			cloneURL:     "https://github.com/user/repo",
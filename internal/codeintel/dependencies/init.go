@@ -1,6 +1,8 @@
 package dependencies
 
 import (
+	"time"
+
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/background"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -33,3 +35,9 @@ func CrateSyncerJob(observationCtx *observation.Context, dependenciesSvc backgro
 		background.NewCrateSyncer(observationCtx, dependenciesSvc, gitserverClient, extSvcStore),
 	}
 }
+
+func LockfileIndexerJob(observationCtx *observation.Context, dependenciesSvc background.DependenciesService, gitserverClient background.GitserverClient, interval time.Duration, batchSize int) []goroutine.BackgroundRoutine {
+	return []goroutine.BackgroundRoutine{
+		background.NewLockfileIndexer(observationCtx, dependenciesSvc, gitserverClient, interval, batchSize),
+	}
+}
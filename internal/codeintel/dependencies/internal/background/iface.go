@@ -17,6 +17,7 @@ import (
 type GitserverClient interface {
 	ArchiveReader(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, options gitserver.ArchiveOptions) (io.ReadCloser, error)
 	RequestRepoUpdate(context.Context, api.RepoName, time.Duration) (*protocol.RepoUpdateResponse, error)
+	ResolveRevision(ctx context.Context, repo api.RepoName, spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error)
 }
 
 type ExternalServiceStore interface {
@@ -26,4 +27,7 @@ type ExternalServiceStore interface {
 
 type DependenciesService interface {
 	UpsertDependencyRepos(ctx context.Context, deps []shared.Repo) (_ []shared.Repo, err error)
+	SelectReposForLockfileIndexing(ctx context.Context, batchSize int, interval time.Duration) (_ []shared.LockfileIndexingCandidate, err error)
+	TouchLockfileIndex(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ bool, err error)
+	UpsertLockfileGraph(ctx context.Context, repoID api.RepoID, commit api.CommitID, graphs map[string]*shared.DependencyGraph) (err error)
 }
@@ -0,0 +1,123 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/lockfiles"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type lockfileIndexerJob struct {
+	dependenciesSvc DependenciesService
+	gitClient       GitserverClient
+	operations      *operations
+	logger          log.Logger
+	interval        time.Duration
+	batchSize       int
+}
+
+// NewLockfileIndexer returns a background routine that periodically parses the
+// lockfiles at the HEAD of repositories that changed since they were last
+// indexed, and stores their dependency graphs.
+func NewLockfileIndexer(
+	observationCtx *observation.Context,
+	dependenciesSvc DependenciesService,
+	gitClient GitserverClient,
+	interval time.Duration,
+	batchSize int,
+) goroutine.BackgroundRoutine {
+	job := lockfileIndexerJob{
+		dependenciesSvc: dependenciesSvc,
+		gitClient:       gitClient,
+		operations:      newOperations(observationCtx),
+		logger:          log.Scoped("lockfileIndexer", "indexes the dependency graphs recorded in lockfiles"),
+		interval:        interval,
+		batchSize:       batchSize,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"codeintel.lockfile-indexer", "indexes the dependency graphs recorded in the lockfiles of repositories",
+		interval,
+		goroutine.HandlerFunc(job.handleLockfileIndexer),
+	)
+}
+
+func (b *lockfileIndexerJob) handleLockfileIndexer(ctx context.Context) (err error) {
+	ctx, _, endObservation := b.operations.handleLockfileIndexer.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	candidates, err := b.dependenciesSvc.SelectReposForLockfileIndexing(ctx, b.batchSize, b.interval)
+	if err != nil {
+		return errors.Wrap(err, "failed to select repositories for lockfile indexing")
+	}
+
+	var errs error
+	for _, candidate := range candidates {
+		if err := b.indexRepo(ctx, candidate); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "failed to index lockfiles of repo %s", candidate.RepoName))
+		}
+	}
+
+	return errs
+}
+
+func (b *lockfileIndexerJob) indexRepo(ctx context.Context, candidate shared.LockfileIndexingCandidate) error {
+	// We should use an internal actor when doing cross service calls.
+	clientCtx := actor.WithInternalActor(ctx)
+
+	commit, err := b.gitClient.ResolveRevision(clientCtx, candidate.RepoName, "HEAD", gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) || gitdomain.IsRepoNotExist(err) {
+			// Empty or not yet cloned repositories don't have any lockfiles.
+			return b.dependenciesSvc.UpsertLockfileGraph(ctx, candidate.RepoID, "", nil)
+		}
+		return err
+	}
+
+	if indexed, err := b.dependenciesSvc.TouchLockfileIndex(ctx, candidate.RepoID, commit); err != nil || indexed {
+		return err
+	}
+
+	graphs, err := b.parseLockfiles(clientCtx, candidate.RepoName, commit)
+	if err != nil {
+		return err
+	}
+
+	return b.dependenciesSvc.UpsertLockfileGraph(ctx, candidate.RepoID, commit, graphs)
+}
+
+func (b *lockfileIndexerJob) parseLockfiles(ctx context.Context, repoName api.RepoName, commit api.CommitID) (map[string]*shared.DependencyGraph, error) {
+	reader, err := b.gitClient.ArchiveReader(
+		ctx,
+		nil,
+		repoName,
+		gitserver.ArchiveOptions{
+			Treeish:   string(commit),
+			Format:    gitserver.ArchiveFormatTar,
+			Pathspecs: lockfiles.Pathspecs(),
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to git archive lockfiles")
+	}
+	defer reader.Close()
+
+	return lockfiles.ParseArchive(reader, func(path string, err error) {
+		b.logger.Warn("failed to parse lockfile",
+			log.String("repo", string(repoName)),
+			log.String("path", path),
+			log.Error(err),
+		)
+	})
+}
@@ -8,16 +8,21 @@ import (
 )
 
 type operations struct {
-	handleCrateSyncer *observation.Operation
+	handleCrateSyncer     *observation.Operation
+	handleLockfileIndexer *observation.Operation
 }
 
+var m = new(metrics.SingletonREDMetrics)
+
 func newOperations(observationCtx *observation.Context) *operations {
-	m := metrics.NewREDMetrics(
-		observationCtx.Registerer,
-		"codeintel_dependencies_background",
-		metrics.WithLabels("op"),
-		metrics.WithCountHelp("Total number of method invocations."),
-	)
+	m := m.Get(func() *metrics.REDMetrics {
+		return metrics.NewREDMetrics(
+			observationCtx.Registerer,
+			"codeintel_dependencies_background",
+			metrics.WithLabels("op"),
+			metrics.WithCountHelp("Total number of method invocations."),
+		)
+	})
 
 	op := func(name string) *observation.Operation {
 		return observationCtx.Operation(observation.Op{
@@ -28,6 +33,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		handleCrateSyncer: op("HandleCrateSyncer"),
+		handleCrateSyncer:     op("HandleCrateSyncer"),
+		handleLockfileIndexer: op("HandleLockfileIndexer"),
 	}
 }
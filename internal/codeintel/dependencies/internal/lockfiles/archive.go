@@ -0,0 +1,53 @@
+package lockfiles
+
+import (
+	"archive/tar"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+// maxLockfileSize is the size above which lockfiles are skipped instead of
+// parsed, to bound the memory used while parsing.
+const maxLockfileSize = 64 * 1024 * 1024
+
+// ParseArchive parses all lockfiles in the given tar archive of a repository,
+// e.g. as returned by git archive with the pathspecs returned by Pathspecs.
+// It returns the non-empty dependency graphs keyed by lockfile path. A
+// lockfile that fails to parse is reported to onError and skipped, so that a
+// single malformed lockfile doesn't prevent reading the others.
+func ParseArchive(r io.Reader, onError func(path string, err error)) (map[string]*shared.DependencyGraph, error) {
+	graphs := map[string]*shared.DependencyGraph{}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			break
+		}
+
+		if header.Typeflag != tar.TypeReg || strings.HasSuffix(header.Name, "/") {
+			continue
+		}
+		if header.Size > maxLockfileSize || !IsLockfile(header.Name) {
+			continue
+		}
+
+		graph, err := Parse(header.Name, tr)
+		if err != nil {
+			onError(header.Name, err)
+			continue
+		}
+		if graph.Empty() {
+			continue
+		}
+
+		graphs[header.Name] = graph
+	}
+
+	return graphs, nil
+}
@@ -0,0 +1,41 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"golang.org/x/mod/module"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+// parseGoSum parses a go.sum file. go.sum files don't record how modules
+// depend on each other, so the resulting graph is flat.
+func parseGoSum(r io.Reader) (*shared.DependencyGraph, error) {
+	graph := shared.NewDependencyGraph()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		// Modules only listed with the hash of their go.mod file are part of
+		// the module graph, but none of their packages are built.
+		path, version := fields[0], fields[1]
+		if strings.HasSuffix(version, "/go.mod") {
+			continue
+		}
+
+		mod := module.Version{Path: path, Version: version}
+		if module.Check(mod.Path, mod.Version) != nil {
+			continue
+		}
+		graph.AddPackage(reposource.NewGoVersionedPackage(mod))
+	}
+
+	return graph, scanner.Err()
+}
@@ -0,0 +1,40 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+// parseGradleLockfile parses a gradle.lockfile, which lists the Maven
+// coordinates of the resolved dependencies of each configuration but doesn't
+// record how they depend on each other.
+func parseGradleLockfile(r io.Reader) (*shared.DependencyGraph, error) {
+	graph := shared.NewDependencyGraph()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		coordinates, _, _ := strings.Cut(line, "=")
+		if strings.Count(coordinates, ":") != 2 {
+			// Skips the "empty=..." line listing the configurations without
+			// any dependencies.
+			continue
+		}
+
+		pkg, err := reposource.ParseMavenVersionedPackage(coordinates)
+		if err != nil || pkg.Version == "" {
+			continue
+		}
+		graph.AddPackage(pkg)
+	}
+
+	return graph, scanner.Err()
+}
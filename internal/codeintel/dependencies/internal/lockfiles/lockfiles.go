@@ -0,0 +1,68 @@
+// Package lockfiles parses the lockfiles of various package managers into
+// dependency graphs.
+package lockfiles
+
+import (
+	"io"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type parser func(r io.Reader) (*shared.DependencyGraph, error)
+
+// parsers maps the base names of the supported lockfiles to their parsers.
+var parsers = map[string]parser{
+	"go.sum":            parseGoSum,
+	"package-lock.json": parsePackageLockJSON,
+	"yarn.lock":         parseYarnLock,
+	"pnpm-lock.yaml":    parsePnpmLock,
+	"Cargo.lock":        parseCargoLock,
+	"poetry.lock":       parsePoetryLock,
+	"requirements.txt":  parseRequirementsTxt,
+	"Gemfile.lock":      parseGemfileLock,
+	"gradle.lockfile":   parseGradleLockfile,
+}
+
+// Pathspecs returns the pathspecs matching all supported lockfiles anywhere in
+// a repository. The pathspecs can match more than just lockfiles, so paths
+// must still be checked with IsLockfile.
+func Pathspecs() []gitdomain.Pathspec {
+	pathspecs := make([]gitdomain.Pathspec, 0, len(parsers))
+	for name := range parsers {
+		pathspecs = append(pathspecs, gitdomain.PathspecSuffix(name))
+	}
+	return pathspecs
+}
+
+// IsLockfile returns true if the file at the given path is a supported
+// lockfile. Lockfiles of vendored dependencies are ignored, as they don't
+// describe what the repository depends on.
+func IsLockfile(p string) bool {
+	if _, ok := parsers[path.Base(p)]; !ok {
+		return false
+	}
+	for _, dir := range strings.Split(path.Dir(p), "/") {
+		if dir == "node_modules" || dir == "vendor" {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses the contents of the lockfile at the given path.
+func Parse(p string, r io.Reader) (*shared.DependencyGraph, error) {
+	parse, ok := parsers[path.Base(p)]
+	if !ok {
+		return nil, errors.Newf("unsupported lockfile %q", p)
+	}
+
+	graph, err := parse(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %q", p)
+	}
+	return graph, nil
+}
@@ -0,0 +1,205 @@
+package lockfiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		fidelity shared.LockfileFidelity
+		want     []string
+	}{
+		{
+			path:     "go.sum",
+			fidelity: shared.LockfileFidelityFlat,
+			want: []string{
+				"go:github.com/google/go-cmp@v0.5.9 (direct)",
+				"go:golang.org/x/net@v0.0.0-20220722155237-a158d28d115b (direct)",
+			},
+		},
+		{
+			path:     "package-lock.json",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:@types/node@18.11.18 (direct)",
+				"npm:debug@2.6.9 -> npm:ms@2.0.0",
+				"npm:express@4.18.2 (direct) -> npm:debug@2.6.9, npm:ms@2.1.3",
+				"npm:ms@2.0.0",
+				"npm:ms@2.1.3",
+			},
+		},
+		{
+			path:     "v1/package-lock.json",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:debug@2.6.9 -> npm:ms@2.0.0",
+				"npm:express@4.18.2 (direct) -> npm:debug@2.6.9, npm:ms@2.1.3",
+				"npm:ms@2.0.0",
+				"npm:ms@2.1.3",
+			},
+		},
+		{
+			path:     "yarn.lock",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:@babel/code-frame@7.18.6 (direct) -> npm:@babel/highlight@7.18.6",
+				"npm:@babel/highlight@7.18.6 -> npm:js-tokens@4.0.0",
+				"npm:js-tokens@4.0.0",
+				"npm:string-width@4.2.3 (direct)",
+			},
+		},
+		{
+			path:     "berry/yarn.lock",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:chalk@4.1.2 -> npm:supports-color@7.2.0",
+				"npm:lodash@4.17.20 (direct)",
+				"npm:supports-color@7.2.0",
+			},
+		},
+		{
+			path:     "pnpm-lock.yaml",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:@babel/core@7.20.12 (direct) -> npm:debug@4.3.4",
+				"npm:debug@4.3.4 -> npm:ms@2.1.2, npm:supports-color@5.5.0",
+				"npm:lodash@4.17.21 (direct)",
+				"npm:ms@2.1.2",
+				"npm:supports-color@5.5.0",
+			},
+		},
+		{
+			path:     "pnpm6/pnpm-lock.yaml",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"npm:debug@4.3.4 (direct) -> npm:ms@2.1.2, npm:supports-color@5.5.0",
+				"npm:ms@2.1.2",
+				"npm:supports-color@5.5.0",
+			},
+		},
+		{
+			path:     "Cargo.lock",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"rust-analyzer:libc@0.2.139",
+				"rust-analyzer:rand@0.8.5 (direct) -> rust-analyzer:libc@0.2.139",
+				"rust-analyzer:syn@1.0.107 (direct)",
+				"rust-analyzer:syn@2.0.0",
+			},
+		},
+		{
+			path:     "poetry.lock",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"python:certifi@2022.12.7",
+				"python:requests@2.28.1 (direct) -> python:certifi@2022.12.7, python:urllib3@1.26.13",
+				"python:urllib3@1.26.13",
+			},
+		},
+		{
+			path:     "requirements.txt",
+			fidelity: shared.LockfileFidelityFlat,
+			want: []string{
+				"python:django@4.1.5 (direct)",
+				"python:requests@2.28.1 (direct)",
+				"python:urllib3@1.26.13 (direct)",
+			},
+		},
+		{
+			path:     "Gemfile.lock",
+			fidelity: shared.LockfileFidelityGraph,
+			want: []string{
+				"scip-ruby:mini_portile2@2.8.1",
+				"scip-ruby:nokogiri@1.14.0 (direct) -> scip-ruby:racc@1.6.2",
+				"scip-ruby:racc@1.6.2",
+				"scip-ruby:rack@3.0.4 (direct)",
+			},
+		},
+		{
+			path:     "gradle.lockfile",
+			fidelity: shared.LockfileFidelityFlat,
+			want: []string{
+				"semanticdb:com.google.guava:failureaccess@1.0.1 (direct)",
+				"semanticdb:com.google.guava:guava@31.1-jre (direct)",
+			},
+		},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tc.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			graph, err := Parse(tc.path, f)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if have := graph.Fidelity(); have != tc.fidelity {
+				t.Errorf("unexpected fidelity: want %q, have %q", tc.fidelity, have)
+			}
+			if diff := cmp.Diff(tc.want, render(graph)); diff != "" {
+				t.Errorf("unexpected graph (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsLockfile(t *testing.T) {
+	for path, want := range map[string]bool{
+		"go.sum":                              true,
+		"web/package-lock.json":               true,
+		"crates/foo/Cargo.lock":               true,
+		"go.mod":                              false,
+		"mygo.sum":                            false,
+		"node_modules/foo/package-lock.json":  false,
+		"vendor/github.com/foo/bar/go.sum":    false,
+		"third_party/vendored/Gemfile.lock":   true,
+		"requirements-dev.txt":                false,
+		"app/src/main/resources/yarn.lock.md": false,
+	} {
+		if have := IsLockfile(path); have != want {
+			t.Errorf("IsLockfile(%q): want %v, have %v", path, want, have)
+		}
+	}
+}
+
+func render(graph *shared.DependencyGraph) []string {
+	direct := map[string]bool{}
+	for _, pkg := range graph.DirectDependencies() {
+		direct[format(pkg)] = true
+	}
+
+	var lines []string
+	for _, pkg := range graph.Packages() {
+		line := format(pkg)
+		if direct[line] {
+			line += " (direct)"
+		}
+
+		var deps []string
+		for _, dep := range graph.DependsOn(pkg) {
+			deps = append(deps, format(dep))
+		}
+		if len(deps) > 0 {
+			line += " -> " + strings.Join(deps, ", ")
+		}
+
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func format(pkg shared.PackageDependency) string {
+	return fmt.Sprintf("%s:%s@%s", pkg.Scheme(), pkg.PackageSyntax(), pkg.PackageVersion())
+}
@@ -0,0 +1,499 @@
+package lockfiles
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// newNpmPackage returns the npm package with the given name and version. It
+// returns false for packages that aren't installed from the npm registry, such
+// as packages installed from tarballs or git repositories.
+func newNpmPackage(name, version string) (*reposource.NpmVersionedPackage, bool) {
+	pkg, err := reposource.ParseNpmVersionedPackage(name + "@" + version)
+	if err != nil {
+		return nil, false
+	}
+	return pkg, true
+}
+
+// splitNpmSpec splits a dependency specifier such as "@types/node@^18.0.0"
+// into the package name and the version range.
+func splitNpmSpec(spec string) (name, versionRange string) {
+	if spec == "" {
+		return "", ""
+	}
+	i := strings.Index(spec[1:], "@")
+	if i == -1 {
+		return spec, ""
+	}
+	i++
+	return spec[:i], spec[i+1:]
+}
+
+//
+// package-lock.json
+
+type packageLockJSON struct {
+	// Packages is keyed by the path of the package relative to the root of the
+	// project. The root project itself has the empty path. This is set from
+	// lockfile version 2 onwards.
+	Packages map[string]packageLockPackage `json:"packages"`
+
+	// Dependencies holds the tree of packages in lockfile version 1.
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+func (p packageLockPackage) dependencyNames() []string {
+	var names []string
+	for _, deps := range []map[string]string{p.Dependencies, p.DevDependencies, p.OptionalDependencies, p.PeerDependencies} {
+		for name := range deps {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Requires     map[string]string                `json:"requires"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+func parsePackageLockJSON(r io.Reader) (*shared.DependencyGraph, error) {
+	var lockfile packageLockJSON
+	if err := json.NewDecoder(r).Decode(&lockfile); err != nil {
+		return nil, err
+	}
+
+	graph := shared.NewDependencyGraph()
+	if lockfile.Packages != nil {
+		addPackageLockPackages(graph, lockfile.Packages)
+	} else {
+		addPackageLockDependencies(graph, lockfile.Dependencies, nil)
+	}
+	return graph, nil
+}
+
+// addPackageLockPackages adds the packages of a lockfile in version 2 or 3.
+func addPackageLockPackages(graph *shared.DependencyGraph, packages map[string]packageLockPackage) {
+	pkgs := make(map[string]*reposource.NpmVersionedPackage, len(packages))
+	for path, entry := range packages {
+		i := strings.LastIndex(path, "node_modules/")
+		if i == -1 || entry.Link {
+			continue
+		}
+
+		name := entry.Name
+		if name == "" {
+			name = path[i+len("node_modules/"):]
+		}
+		if pkg, ok := newNpmPackage(name, entry.Version); ok {
+			pkgs[path] = pkg
+			graph.AddPackage(pkg)
+		}
+	}
+
+	for path, entry := range packages {
+		for _, name := range entry.dependencyNames() {
+			to, ok := pkgs[resolvePackageLockPath(packages, path, name)]
+			if !ok {
+				continue
+			}
+
+			// Paths outside of node_modules belong to the root project or
+			// one of its workspaces.
+			if from, ok := pkgs[path]; ok {
+				graph.AddDependency(from, to)
+			} else if !strings.Contains(path, "node_modules/") {
+				graph.AddDirectDependency(to)
+			}
+		}
+	}
+}
+
+// resolvePackageLockPath returns the path of the package with the given name
+// as seen from the package at the given path, following the resolution
+// algorithm of Node.js.
+func resolvePackageLockPath(packages map[string]packageLockPackage, from, name string) string {
+	dir := from
+	for {
+		candidate := "node_modules/" + name
+		if dir != "" {
+			candidate = dir + "/" + candidate
+		}
+		if _, ok := packages[candidate]; ok {
+			return candidate
+		}
+		if dir == "" {
+			return ""
+		}
+
+		if i := strings.LastIndex(dir, "node_modules/"); i == -1 {
+			dir = ""
+		} else {
+			dir = strings.TrimSuffix(dir[:i], "/")
+		}
+	}
+}
+
+// addPackageLockDependencies adds the tree of packages of a lockfile in
+// version 1. Scopes holds the enclosing levels of the tree, innermost first.
+// Version 1 doesn't record which packages the project depends on directly.
+func addPackageLockDependencies(graph *shared.DependencyGraph, deps map[string]packageLockDependency, scopes []map[string]packageLockDependency) {
+	scopes = append([]map[string]packageLockDependency{deps}, scopes...)
+
+	for name, dep := range deps {
+		from, ok := newNpmPackage(name, dep.Version)
+		if !ok {
+			continue
+		}
+		graph.AddPackage(from)
+
+		for required := range dep.Requires {
+			for _, scope := range append([]map[string]packageLockDependency{dep.Dependencies}, scopes...) {
+				if resolved, ok := scope[required]; ok {
+					if to, ok := newNpmPackage(required, resolved.Version); ok {
+						graph.AddDependency(from, to)
+					}
+					break
+				}
+			}
+		}
+
+		addPackageLockDependencies(graph, dep.Dependencies, scopes)
+	}
+}
+
+//
+// yarn.lock
+
+var yarnBerryMetadataPattern = lazyregexp.New(`(?m)^__metadata:`)
+
+func parseYarnLock(r io.Reader) (*shared.DependencyGraph, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if yarnBerryMetadataPattern.Match(data) {
+		return parseYarnBerryLock(data)
+	}
+	return parseYarnClassicLock(data)
+}
+
+type yarnClassicEntry struct {
+	specs        []string
+	version      string
+	dependencies []string
+}
+
+// parseYarnClassicLock parses the custom format used by yarn 1, which doesn't
+// record which packages the project depends on directly.
+func parseYarnClassicLock(data []byte) (*shared.DependencyGraph, error) {
+	var (
+		entries []*yarnClassicEntry
+		current *yarnClassicEntry
+		inDeps  bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case indent == 0:
+			current = &yarnClassicEntry{}
+			for _, spec := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				current.specs = append(current.specs, unquote(strings.TrimSpace(spec)))
+			}
+			entries = append(entries, current)
+			inDeps = false
+
+		case current == nil:
+			continue
+
+		case indent == 2:
+			key, value, _ := strings.Cut(trimmed, " ")
+			if key == "version" {
+				current.version = unquote(value)
+			}
+			inDeps = key == "dependencies:" || key == "optionalDependencies:"
+
+		case inDeps:
+			name, versionRange := splitYarnClassicDependency(trimmed)
+			current.dependencies = append(current.dependencies, name+"@"+versionRange)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	graph := shared.NewDependencyGraph()
+	bySpec := map[string]*reposource.NpmVersionedPackage{}
+	for _, entry := range entries {
+		name, versionRange := splitNpmSpec(entry.specs[0])
+		if strings.HasPrefix(versionRange, "npm:") {
+			// Aliased packages are installed under a different name.
+			name, _ = splitNpmSpec(strings.TrimPrefix(versionRange, "npm:"))
+		}
+
+		pkg, ok := newNpmPackage(name, entry.version)
+		if !ok {
+			continue
+		}
+		graph.AddPackage(pkg)
+		for _, spec := range entry.specs {
+			bySpec[spec] = pkg
+		}
+	}
+
+	for _, entry := range entries {
+		from, ok := bySpec[entry.specs[0]]
+		if !ok {
+			continue
+		}
+		for _, spec := range entry.dependencies {
+			if to, ok := bySpec[spec]; ok {
+				graph.AddDependency(from, to)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+func splitYarnClassicDependency(line string) (name, versionRange string) {
+	if strings.HasPrefix(line, `"`) {
+		if end := strings.Index(line[1:], `"`); end != -1 {
+			return line[1 : end+1], unquote(strings.TrimSpace(line[end+2:]))
+		}
+	}
+	name, versionRange, _ = strings.Cut(line, " ")
+	return name, unquote(strings.TrimSpace(versionRange))
+}
+
+type yarnBerryEntry struct {
+	Version              string            `yaml:"version"`
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+// parseYarnBerryLock parses the YAML format used from yarn 2 onwards. The
+// dependencies of the workspaces of the project are its direct dependencies.
+func parseYarnBerryLock(data []byte) (*shared.DependencyGraph, error) {
+	var lockfile map[string]yarnBerryEntry
+	if err := yaml.Unmarshal(data, &lockfile); err != nil {
+		return nil, err
+	}
+
+	graph := shared.NewDependencyGraph()
+	byKey := map[string]*reposource.NpmVersionedPackage{}
+	bySpec := map[string]*reposource.NpmVersionedPackage{}
+	for key, entry := range lockfile {
+		name, resolution := splitNpmSpec(entry.Resolution)
+		if !strings.HasPrefix(resolution, "npm:") {
+			continue
+		}
+
+		pkg, ok := newNpmPackage(name, entry.Version)
+		if !ok {
+			continue
+		}
+		graph.AddPackage(pkg)
+		byKey[key] = pkg
+		for _, spec := range strings.Split(key, ",") {
+			bySpec[strings.TrimSpace(spec)] = pkg
+		}
+	}
+
+	for key, entry := range lockfile {
+		from, isPackage := byKey[key]
+		_, resolution := splitNpmSpec(entry.Resolution)
+		isWorkspace := strings.HasPrefix(resolution, "workspace:")
+
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, versionRange := range deps {
+				if !strings.Contains(versionRange, ":") {
+					versionRange = "npm:" + versionRange
+				}
+				to, ok := bySpec[name+"@"+versionRange]
+				if !ok {
+					continue
+				}
+
+				switch {
+				case isWorkspace:
+					graph.AddDirectDependency(to)
+				case isPackage:
+					graph.AddDependency(from, to)
+				}
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+//
+// pnpm-lock.yaml
+
+type pnpmLock struct {
+	LockfileVersion      any                     `yaml:"lockfileVersion"`
+	Dependencies         map[string]any          `yaml:"dependencies"`
+	DevDependencies      map[string]any          `yaml:"devDependencies"`
+	OptionalDependencies map[string]any          `yaml:"optionalDependencies"`
+	Importers            map[string]pnpmImporter `yaml:"importers"`
+	Packages             map[string]pnpmPackage  `yaml:"packages"`
+}
+
+type pnpmImporter struct {
+	Dependencies         map[string]any `yaml:"dependencies"`
+	DevDependencies      map[string]any `yaml:"devDependencies"`
+	OptionalDependencies map[string]any `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+func parsePnpmLock(r io.Reader) (*shared.DependencyGraph, error) {
+	var lockfile pnpmLock
+	if err := yaml.NewDecoder(r).Decode(&lockfile); err != nil {
+		return nil, err
+	}
+
+	// Lockfile version 6 changed the format of package keys from
+	// "/name/version" to "/name@version".
+	version, _ := strconv.ParseFloat(strings.Trim(strings.TrimSpace(toString(lockfile.LockfileVersion)), `'"`), 64)
+	v6 := version >= 6
+
+	graph := shared.NewDependencyGraph()
+	pkgs := make(map[string]*reposource.NpmVersionedPackage, len(lockfile.Packages))
+	for key := range lockfile.Packages {
+		if pkg, ok := pnpmPackageFromKey(key, v6); ok {
+			pkgs[key] = pkg
+			graph.AddPackage(pkg)
+		}
+	}
+
+	resolve := func(name, version string) (*reposource.NpmVersionedPackage, bool) {
+		key := version
+		if !strings.HasPrefix(version, "/") {
+			sep := "/"
+			if v6 {
+				sep = "@"
+			}
+			key = "/" + name + sep + version
+		}
+		pkg, ok := pkgs[key]
+		return pkg, ok
+	}
+
+	importers := []pnpmImporter{{
+		Dependencies:         lockfile.Dependencies,
+		DevDependencies:      lockfile.DevDependencies,
+		OptionalDependencies: lockfile.OptionalDependencies,
+	}}
+	for _, importer := range lockfile.Importers {
+		importers = append(importers, importer)
+	}
+	for _, importer := range importers {
+		for _, deps := range []map[string]any{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			for name, value := range deps {
+				// From version 6 onwards, the dependencies of importers
+				// record the specifier next to the resolved version.
+				if m, ok := value.(map[string]any); ok {
+					value = m["version"]
+				}
+				if to, ok := resolve(name, toString(value)); ok {
+					graph.AddDirectDependency(to)
+				}
+			}
+		}
+	}
+
+	for key, entry := range lockfile.Packages {
+		from, ok := pkgs[key]
+		if !ok {
+			continue
+		}
+		for _, deps := range []map[string]string{entry.Dependencies, entry.OptionalDependencies} {
+			for name, version := range deps {
+				if to, ok := resolve(name, version); ok {
+					graph.AddDependency(from, to)
+				}
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// pnpmPackageFromKey parses keys such as "/@babel/core/7.20.0_supports-color@5.5.0"
+// (before version 6) or "/@babel/core@7.20.0(supports-color@5.5.0)", which
+// encode the package name and version, and the versions of the peer
+// dependencies the package was resolved with.
+func pnpmPackageFromKey(key string, v6 bool) (*reposource.NpmVersionedPackage, bool) {
+	key = strings.TrimPrefix(key, "/")
+
+	var name, version string
+	if v6 {
+		key, _, _ = strings.Cut(key, "(")
+		name, version = splitNpmSpec(key)
+	} else {
+		i := strings.LastIndex(key, "/")
+		if i == -1 {
+			return nil, false
+		}
+		name, version = key[:i], key[i+1:]
+		version, _, _ = strings.Cut(version, "_")
+	}
+
+	return newNpmPackage(name, version)
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func unquote(s string) string {
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s
+}
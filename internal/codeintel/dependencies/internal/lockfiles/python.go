@@ -0,0 +1,115 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+func newPythonPackage(name, version string) *reposource.PythonVersionedPackage {
+	return reposource.NewPythonVersionedPackage(
+		shared.NormalizePackageName(shared.PythonPackagesScheme, reposource.PackageName(name)),
+		version,
+	)
+}
+
+// parsePoetryLock parses a poetry.lock file, which doesn't record which
+// packages the project depends on directly.
+func parsePoetryLock(r io.Reader) (*shared.DependencyGraph, error) {
+	var lock struct {
+		Packages []struct {
+			Name         string         `toml:"name"`
+			Version      string         `toml:"version"`
+			Dependencies map[string]any `toml:"dependencies"`
+		} `toml:"package"`
+	}
+	if _, err := toml.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+
+	type poetryPackage struct {
+		pkg          *reposource.PythonVersionedPackage
+		dependencies []string
+	}
+
+	packages := make([]*poetryPackage, 0, len(lock.Packages))
+	for _, p := range lock.Packages {
+		current := &poetryPackage{pkg: newPythonPackage(p.Name, p.Version)}
+		for name := range p.Dependencies {
+			current.dependencies = append(current.dependencies, name)
+		}
+		packages = append(packages, current)
+	}
+
+	graph := shared.NewDependencyGraph()
+	byName := map[reposource.PackageName]*reposource.PythonVersionedPackage{}
+	for _, p := range packages {
+		graph.AddPackage(p.pkg)
+		byName[p.pkg.Name] = p.pkg
+	}
+	for _, p := range packages {
+		for _, name := range p.dependencies {
+			if to, ok := byName[newPythonPackage(name, "").Name]; ok {
+				graph.AddDependency(p.pkg, to)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// parseRequirementsTxt parses the packages pinned to an exact version in a
+// requirements.txt file, such as the output of pip freeze.
+func parseRequirementsTxt(r io.Reader) (*shared.DependencyGraph, error) {
+	graph := shared.NewDependencyGraph()
+
+	var logical strings.Builder
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Comments start with a # preceded by whitespace.
+		if i := strings.Index(line, " #"); i != -1 {
+			line = line[:i]
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			logical.WriteString(strings.TrimSuffix(line, `\`))
+			continue
+		}
+		logical.WriteString(line)
+		requirement := strings.TrimSpace(logical.String())
+		logical.Reset()
+
+		// Skip options such as -r other-requirements.txt or --index-url.
+		if requirement == "" || strings.HasPrefix(requirement, "-") {
+			continue
+		}
+
+		// Drop environment markers and per-requirement options.
+		requirement, _, _ = strings.Cut(requirement, ";")
+		requirement, _, _ = strings.Cut(requirement, " --")
+
+		name, version, ok := strings.Cut(requirement, "==")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, "[")
+		name = strings.TrimSpace(name)
+		version = strings.TrimSpace(strings.TrimPrefix(version, "="))
+		if name == "" || version == "" || strings.Contains(version, "*") {
+			continue
+		}
+
+		graph.AddPackage(newPythonPackage(name, version))
+	}
+
+	return graph, scanner.Err()
+}
@@ -0,0 +1,102 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+// parseGemfileLock parses a Gemfile.lock file. The gems listed in the
+// DEPENDENCIES section, and the dependencies of local gems (such as the gem
+// being developed in the repository) are the direct dependencies.
+func parseGemfileLock(r io.Reader) (*shared.DependencyGraph, error) {
+	type edge struct {
+		from *reposource.RubyVersionedPackage
+		to   string
+	}
+
+	var (
+		section string
+		current *reposource.RubyVersionedPackage
+		inPath  bool
+		edges   []edge
+		direct  []string
+		byName  = map[string]*reposource.RubyVersionedPackage{}
+	)
+
+	graph := shared.NewDependencyGraph()
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == 0 {
+			section = trimmed
+			continue
+		}
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+			name, version := splitGemSpec(trimmed)
+			switch indent {
+			case 4:
+				current, inPath = nil, section == "PATH"
+				if inPath || version == "" {
+					continue
+				}
+				current = reposource.NewRubyVersionedPackage(reposource.PackageName(name), version)
+				graph.AddPackage(current)
+				byName[name] = current
+
+			case 6:
+				if inPath {
+					direct = append(direct, name)
+				} else if current != nil {
+					edges = append(edges, edge{from: current, to: name})
+				}
+			}
+
+		case "DEPENDENCIES":
+			if indent == 2 {
+				name, _ := splitGemSpec(trimmed)
+				direct = append(direct, strings.TrimSuffix(name, "!"))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range edges {
+		if to, ok := byName[e.to]; ok {
+			graph.AddDependency(e.from, to)
+		}
+	}
+	for _, name := range direct {
+		if pkg, ok := byName[name]; ok {
+			graph.AddDirectDependency(pkg)
+		}
+	}
+
+	return graph, nil
+}
+
+// splitGemSpec splits lines such as "nokogiri (1.13.10-x86_64-linux)" into
+// the gem name and the version, without the platform suffix. For
+// dependencies, the version is the required version range instead.
+func splitGemSpec(spec string) (name, version string) {
+	name, version, _ = strings.Cut(spec, " ")
+	version = strings.Trim(version, "()")
+	if !strings.ContainsAny(version, "<>=~, ") {
+		version, _, _ = strings.Cut(version, "-")
+	}
+	return name, version
+}
@@ -0,0 +1,98 @@
+package lockfiles
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+type cargoPackage struct {
+	name         string
+	version      string
+	dependencies []string
+
+	// local is true for the crates of the workspace itself, which aren't
+	// fetched from a registry or git repository.
+	local bool
+}
+
+// cargoLock is the part of a Cargo.lock file we use.
+type cargoLock struct {
+	Packages []struct {
+		Name         string   `toml:"name"`
+		Version      string   `toml:"version"`
+		Source       string   `toml:"source"`
+		Dependencies []string `toml:"dependencies"`
+	} `toml:"package"`
+}
+
+func parseCargoLock(r io.Reader) (*shared.DependencyGraph, error) {
+	var lock cargoLock
+	if _, err := toml.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, err
+	}
+
+	crates := make([]cargoPackage, 0, len(lock.Packages))
+	for _, p := range lock.Packages {
+		crates = append(crates, cargoPackage{
+			name:         p.Name,
+			version:      p.Version,
+			dependencies: p.Dependencies,
+			local:        p.Source == "",
+		})
+	}
+
+	graph := shared.NewDependencyGraph()
+	byName := map[string][]*reposource.RustVersionedPackage{}
+	byNameVersion := map[string]*reposource.RustVersionedPackage{}
+	for _, crate := range crates {
+		if crate.local || crate.name == "" || crate.version == "" {
+			continue
+		}
+
+		pkg := reposource.NewRustVersionedPackage(reposource.PackageName(crate.name), crate.version)
+		graph.AddPackage(pkg)
+		byName[crate.name] = append(byName[crate.name], pkg)
+		byNameVersion[crate.name+" "+crate.version] = pkg
+	}
+
+	// Dependencies are written as "name", or as "name version" if multiple
+	// versions of the crate are locked. Version 1 of the format also appends
+	// the source of the crate in parentheses.
+	resolve := func(dep string) (*reposource.RustVersionedPackage, bool) {
+		fields := strings.Fields(dep)
+		switch {
+		case len(fields) == 0:
+			return nil, false
+		case len(fields) > 1:
+			pkg, ok := byNameVersion[fields[0]+" "+fields[1]]
+			return pkg, ok
+		case len(byName[fields[0]]) == 1:
+			return byName[fields[0]][0], true
+		default:
+			return nil, false
+		}
+	}
+
+	for _, crate := range crates {
+		from, isPackage := byNameVersion[crate.name+" "+crate.version]
+		for _, dep := range crate.dependencies {
+			to, ok := resolve(dep)
+			if !ok {
+				continue
+			}
+
+			if crate.local {
+				graph.AddDirectDependency(to)
+			} else if isPackage {
+				graph.AddDependency(from, to)
+			}
+		}
+	}
+
+	return graph, nil
+}
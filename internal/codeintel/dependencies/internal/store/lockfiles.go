@@ -0,0 +1,391 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UpsertLockfileGraph replaces the lockfile dependency graphs stored for the
+// given repository with the graphs of the lockfiles found at the given
+// commit, keyed by the path of the lockfile. Only the graphs of the most
+// recently indexed commit of a repository are kept. An empty map records
+// that the commit doesn't contain any lockfiles.
+func (s *store) UpsertLockfileGraph(ctx context.Context, repoID api.RepoID, commit api.CommitID, graphs map[string]*shared.DependencyGraph) (err error) {
+	ctx, _, endObservation := s.operations.upsertLockfileGraph.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repoID", int(repoID)),
+		log.String("commit", string(commit)),
+		log.Int("numLockfiles", len(graphs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.db.Done(err) }()
+
+	if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteLockfileGraphQuery, repoID, repoID)); err != nil {
+		return err
+	}
+
+	if len(graphs) == 0 {
+		return tx.db.Exec(ctx, sqlf.Sprintf(
+			insertLockfileQuery,
+			repoID,
+			dbutil.CommitBytea(commit),
+			nil,
+			pq.Array([]int{}),
+			shared.LockfileFidelityFlat,
+		))
+	}
+
+	lockfiles := make([]string, 0, len(graphs))
+	for lockfile := range graphs {
+		lockfiles = append(lockfiles, lockfile)
+	}
+	sort.Strings(lockfiles)
+
+	for _, lockfile := range lockfiles {
+		if err := tx.insertLockfileGraph(ctx, repoID, commit, lockfile, graphs[lockfile]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const deleteLockfileGraphQuery = `
+WITH deleted_references AS (
+	DELETE FROM codeintel_lockfile_references
+	WHERE resolution_repository_id = %s
+)
+DELETE FROM codeintel_lockfiles
+WHERE repository_id = %s
+`
+
+const insertLockfileQuery = `
+INSERT INTO codeintel_lockfiles (repository_id, commit_bytea, lockfile, codeintel_lockfile_reference_ids, fidelity)
+VALUES (%s, %s, %s, %s, %s)
+`
+
+func (s *store) insertLockfileGraph(ctx context.Context, repoID api.RepoID, commit api.CommitID, lockfile string, graph *shared.DependencyGraph) error {
+	packages := graph.Packages()
+	ids := make(map[packageKey]int, len(packages))
+
+	if err := batch.WithInserterWithReturn(
+		ctx,
+		s.db.Handle(),
+		"codeintel_lockfile_references",
+		batch.MaxNumPostgresParameters,
+		[]string{
+			"repository_name",
+			"revspec",
+			"package_scheme",
+			"package_name",
+			"package_version",
+			"resolution_lockfile",
+			"resolution_repository_id",
+			"resolution_commit_bytea",
+		},
+		"",
+		[]string{"id", "package_scheme", "package_name", "package_version"},
+		func(rows dbutil.Scanner) error {
+			var (
+				id  int
+				key packageKey
+			)
+			if err := rows.Scan(&id, &key.scheme, &key.name, &key.version); err != nil {
+				return err
+			}
+			ids[key] = id
+			return nil
+		},
+		func(inserter *batch.Inserter) error {
+			for _, pkg := range packages {
+				if err := inserter.Insert(
+					ctx,
+					pkg.RepoName(),
+					pkg.GitTagFromVersion(),
+					pkg.Scheme(),
+					pkg.PackageSyntax(),
+					pkg.PackageVersion(),
+					lockfile,
+					repoID,
+					dbutil.CommitBytea(commit),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	if graph.Fidelity() == shared.LockfileFidelityGraph {
+		if err := s.db.Exec(ctx, sqlf.Sprintf(createLockfileEdgesTemporaryTableQuery)); err != nil {
+			return err
+		}
+
+		if err := batch.InsertValues(
+			ctx,
+			s.db.Handle(),
+			"t_codeintel_lockfile_edges",
+			batch.MaxNumPostgresParameters,
+			[]string{"id", "depends_on"},
+			loadLockfileEdgesChannel(graph, packages, ids),
+		); err != nil {
+			return err
+		}
+
+		if err := s.db.Exec(ctx, sqlf.Sprintf(updateLockfileEdgesQuery)); err != nil {
+			return err
+		}
+	}
+
+	directIDs := []int{}
+	for _, pkg := range graph.DirectDependencies() {
+		directIDs = append(directIDs, ids[keyOf(pkg)])
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(
+		insertLockfileQuery,
+		repoID,
+		dbutil.CommitBytea(commit),
+		lockfile,
+		pq.Array(directIDs),
+		graph.Fidelity(),
+	))
+}
+
+const createLockfileEdgesTemporaryTableQuery = `
+CREATE TEMPORARY TABLE IF NOT EXISTS t_codeintel_lockfile_edges (
+	id integer NOT NULL,
+	depends_on integer[] NOT NULL
+) ON COMMIT DROP
+`
+
+const updateLockfileEdgesQuery = `
+WITH edges AS (
+	DELETE FROM t_codeintel_lockfile_edges
+	RETURNING id, depends_on
+)
+UPDATE codeintel_lockfile_references r
+SET depends_on = edges.depends_on
+FROM edges
+WHERE r.id = edges.id
+`
+
+func loadLockfileEdgesChannel(graph *shared.DependencyGraph, packages []shared.PackageDependency, ids map[packageKey]int) <-chan []any {
+	ch := make(chan []any, len(packages))
+
+	go func() {
+		defer close(ch)
+
+		for _, pkg := range packages {
+			deps := graph.DependsOn(pkg)
+			if len(deps) == 0 {
+				continue
+			}
+
+			dependsOn := make([]int, 0, len(deps))
+			for _, dep := range deps {
+				dependsOn = append(dependsOn, ids[keyOf(dep)])
+			}
+			ch <- []any{ids[keyOf(pkg)], pq.Array(dependsOn)}
+		}
+	}()
+
+	return ch
+}
+
+type packageKey struct {
+	scheme  string
+	name    string
+	version string
+}
+
+func keyOf(pkg shared.PackageDependency) packageKey {
+	return packageKey{scheme: pkg.Scheme(), name: string(pkg.PackageSyntax()), version: pkg.PackageVersion()}
+}
+
+// TouchLockfileIndex marks the lockfiles of the given repository as indexed
+// again if they were indexed at the given commit. It returns false if they
+// weren't, in which case the lockfiles need to be indexed at that commit.
+func (s *store) TouchLockfileIndex(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.touchLockfileIndex.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repoID", int(repoID)),
+		log.String("commit", string(commit)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.db.ExecResult(ctx, sqlf.Sprintf(touchLockfileIndexQuery, repoID, dbutil.CommitBytea(commit)))
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+const touchLockfileIndexQuery = `
+UPDATE codeintel_lockfiles
+SET updated_at = NOW()
+WHERE repository_id = %s AND commit_bytea = %s
+`
+
+// SelectReposForLockfileIndexing returns cloned repositories whose lockfiles
+// have never been indexed, or that changed since their lockfiles were last
+// indexed at least the given interval ago. Repositories that were never
+// indexed come first, followed by the ones indexed longest ago.
+func (s *store) SelectReposForLockfileIndexing(ctx context.Context, batchSize int, interval time.Duration) (_ []shared.LockfileIndexingCandidate, err error) {
+	ctx, _, endObservation := s.operations.selectReposForLockfileIndexing.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSize", batchSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanLockfileIndexingCandidates(s.db.Query(ctx, sqlf.Sprintf(
+		selectReposForLockfileIndexingQuery,
+		interval/time.Second,
+		batchSize,
+	)))
+}
+
+const selectReposForLockfileIndexingQuery = `
+SELECT r.id, r.name
+FROM repo r
+JOIN gitserver_repos gr ON gr.repo_id = r.id
+LEFT JOIN LATERAL (
+	SELECT MAX(lf.updated_at) AS last_indexed_at
+	FROM codeintel_lockfiles lf
+	WHERE lf.repository_id = r.id
+) lf ON true
+WHERE
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL AND
+	gr.clone_status = 'cloned' AND
+	(
+		lf.last_indexed_at IS NULL OR
+		(gr.last_changed > lf.last_indexed_at AND lf.last_indexed_at < NOW() - (%s * '1 second'::interval))
+	)
+ORDER BY lf.last_indexed_at NULLS FIRST, r.id
+LIMIT %s
+`
+
+// LockfileDependencies returns the dependencies recorded in the lockfiles of
+// the given repository at the given commit.
+func (s *store) LockfileDependencies(ctx context.Context, repoID api.RepoID, commit api.CommitID) (deps []shared.LockfileDependency, err error) {
+	ctx, _, endObservation := s.operations.lockfileDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repoID", int(repoID)),
+		log.String("commit", string(commit)),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("numDependencies", len(deps)),
+		}})
+	}()
+
+	return scanLockfileDependencies(s.db.Query(ctx, sqlf.Sprintf(lockfileDependenciesQuery, repoID, dbutil.CommitBytea(commit))))
+}
+
+const lockfileDependenciesQuery = `
+SELECT
+	r.id,
+	r.resolution_lockfile,
+	r.package_scheme,
+	r.package_name,
+	r.package_version,
+	r.id = ANY(lf.codeintel_lockfile_reference_ids) AS direct,
+	r.depends_on
+FROM codeintel_lockfiles lf
+JOIN codeintel_lockfile_references r ON
+	r.resolution_repository_id = lf.repository_id AND
+	r.resolution_commit_bytea = lf.commit_bytea AND
+	r.resolution_lockfile = lf.lockfile
+WHERE lf.repository_id = %s AND lf.commit_bytea = %s
+ORDER BY r.resolution_lockfile, r.id
+`
+
+// LockfileDependents returns the repositories whose lockfiles reference any
+// version of the given package.
+func (s *store) LockfileDependents(ctx context.Context, scheme string, name reposource.PackageName) (dependents []shared.LockfileDependent, err error) {
+	ctx, _, endObservation := s.operations.lockfileDependents.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("scheme", scheme),
+		log.String("name", string(name)),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("numDependents", len(dependents)),
+		}})
+	}()
+
+	return scanLockfileDependents(s.db.Query(ctx, sqlf.Sprintf(lockfileDependentsQuery, scheme, name)))
+}
+
+const lockfileDependentsQuery = `
+SELECT
+	r.resolution_repository_id,
+	r.resolution_commit_bytea,
+	r.resolution_lockfile,
+	r.package_version,
+	r.id = ANY(lf.codeintel_lockfile_reference_ids) AS direct
+FROM codeintel_lockfile_references r
+JOIN codeintel_lockfiles lf ON
+	lf.repository_id = r.resolution_repository_id AND
+	lf.commit_bytea = r.resolution_commit_bytea AND
+	lf.lockfile = r.resolution_lockfile
+JOIN repo ON repo.id = r.resolution_repository_id
+WHERE
+	r.package_scheme = %s AND
+	r.package_name = %s AND
+	repo.deleted_at IS NULL
+ORDER BY r.resolution_repository_id, r.resolution_lockfile, r.package_version
+`
+
+// ListLockfileIndexesOpts are options for listing lockfile indexes.
+type ListLockfileIndexesOpts struct {
+	// After is the ID of the last repository of the previous page.
+	After api.RepoID
+	Limit int
+}
+
+// ListLockfileIndexes returns the repositories with at least one indexed
+// lockfile and the commit their lockfiles were indexed at, ordered by
+// repository ID.
+func (s *store) ListLockfileIndexes(ctx context.Context, opts ListLockfileIndexesOpts) (indexes []shared.LockfileIndex, err error) {
+	ctx, _, endObservation := s.operations.listLockfileIndexes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("after", int(opts.After)),
+		log.Int("limit", opts.Limit),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("numIndexes", len(indexes)),
+		}})
+	}()
+
+	return scanLockfileIndexes(s.db.Query(ctx, sqlf.Sprintf(listLockfileIndexesQuery, opts.After, makeLimit(opts.Limit))))
+}
+
+const listLockfileIndexesQuery = `
+SELECT DISTINCT ON (lf.repository_id) lf.repository_id, r.name, lf.commit_bytea
+FROM codeintel_lockfiles lf
+JOIN repo r ON r.id = lf.repository_id
+WHERE
+	lf.lockfile IS NOT NULL AND
+	r.deleted_at IS NULL AND
+	lf.repository_id > %s
+ORDER BY lf.repository_id
+%s
+`
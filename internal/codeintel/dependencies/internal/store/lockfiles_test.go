@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestUpsertLockfileGraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	repo := &types.Repo{Name: "github.com/sourcegraph/app"}
+	if err := db.Repos().Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	express := npmPackage("express", "4.18.2")
+	debug := npmPackage("debug", "2.6.9")
+	ms := npmPackage("ms", "2.0.0")
+
+	graph := shared.NewDependencyGraph()
+	graph.AddDirectDependency(express)
+	graph.AddDependency(express, debug)
+	graph.AddDependency(debug, ms)
+
+	commit := api.CommitID("deadbeef01deadbeef02deadbeef03deadbeef04")
+	if err := store.UpsertLockfileGraph(ctx, repo.ID, commit, map[string]*shared.DependencyGraph{"package-lock.json": graph}); err != nil {
+		t.Fatalf("unexpected error upserting lockfile graph: %s", err)
+	}
+
+	deps, err := store.LockfileDependencies(ctx, repo.ID, commit)
+	if err != nil {
+		t.Fatalf("unexpected error listing lockfile dependencies: %s", err)
+	}
+
+	ids := map[string]int{}
+	for _, dep := range deps {
+		ids[string(dep.Name)] = dep.ID
+	}
+	wantDeps := []shared.LockfileDependency{
+		{ID: ids["debug"], Lockfile: "package-lock.json", Scheme: "npm", Name: "debug", Version: "2.6.9", DependsOn: []int{ids["ms"]}},
+		{ID: ids["express"], Lockfile: "package-lock.json", Scheme: "npm", Name: "express", Version: "4.18.2", Direct: true, DependsOn: []int{ids["debug"]}},
+		{ID: ids["ms"], Lockfile: "package-lock.json", Scheme: "npm", Name: "ms", Version: "2.0.0", DependsOn: []int{}},
+	}
+	if diff := cmp.Diff(wantDeps, sortedByName(deps)); diff != "" {
+		t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+
+	dependents, err := store.LockfileDependents(ctx, "npm", "debug")
+	if err != nil {
+		t.Fatalf("unexpected error listing lockfile dependents: %s", err)
+	}
+	wantDependents := []shared.LockfileDependent{
+		{RepoID: repo.ID, Commit: commit, Lockfile: "package-lock.json", Version: "2.6.9"},
+	}
+	if diff := cmp.Diff(wantDependents, dependents); diff != "" {
+		t.Errorf("unexpected dependents (-want +got):\n%s", diff)
+	}
+
+	if touched, err := store.TouchLockfileIndex(ctx, repo.ID, commit); err != nil {
+		t.Fatalf("unexpected error touching lockfile index: %s", err)
+	} else if !touched {
+		t.Errorf("expected lockfile index of indexed commit to be touched")
+	}
+
+	// Indexing a newer commit without lockfiles replaces the previous graph
+	newCommit := api.CommitID("deadbeef05deadbeef06deadbeef07deadbeef08")
+	if touched, err := store.TouchLockfileIndex(ctx, repo.ID, newCommit); err != nil {
+		t.Fatalf("unexpected error touching lockfile index: %s", err)
+	} else if touched {
+		t.Errorf("expected lockfile index of unindexed commit not to be touched")
+	}
+	if err := store.UpsertLockfileGraph(ctx, repo.ID, newCommit, nil); err != nil {
+		t.Fatalf("unexpected error upserting lockfile graph: %s", err)
+	}
+
+	if deps, err := store.LockfileDependencies(ctx, repo.ID, commit); err != nil {
+		t.Fatalf("unexpected error listing lockfile dependencies: %s", err)
+	} else if len(deps) != 0 {
+		t.Errorf("expected dependencies of previous commit to be removed, have %v", deps)
+	}
+	if dependents, err := store.LockfileDependents(ctx, "npm", "debug"); err != nil {
+		t.Fatalf("unexpected error listing lockfile dependents: %s", err)
+	} else if len(dependents) != 0 {
+		t.Errorf("expected no dependents, have %v", dependents)
+	}
+	if touched, err := store.TouchLockfileIndex(ctx, repo.ID, newCommit); err != nil {
+		t.Fatalf("unexpected error touching lockfile index: %s", err)
+	} else if !touched {
+		t.Errorf("expected lockfile index of commit without lockfiles to be touched")
+	}
+}
+
+func TestSelectReposForLockfileIndexing(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	for _, name := range []api.RepoName{"github.com/sourcegraph/indexed", "github.com/sourcegraph/unindexed", "github.com/sourcegraph/uncloned"} {
+		if err := db.Repos().Create(ctx, &types.Repo{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE gitserver_repos SET clone_status = 'cloned', last_changed = NOW() - '1 hour'::interval WHERE repo_id IN (1, 2)`); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpsertLockfileGraph(ctx, 1, "deadbeef01deadbeef02deadbeef03deadbeef04", nil); err != nil {
+		t.Fatalf("unexpected error upserting lockfile graph: %s", err)
+	}
+
+	candidates, err := store.SelectReposForLockfileIndexing(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error selecting repos: %s", err)
+	}
+
+	want := []shared.LockfileIndexingCandidate{
+		{RepoID: 2, RepoName: "github.com/sourcegraph/unindexed"},
+	}
+	if diff := cmp.Diff(want, candidates); diff != "" {
+		t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+	}
+}
+
+func npmPackage(name, version string) shared.PackageDependency {
+	return shared.TestPackageDependencyLiteral(
+		api.RepoName("npm/"+name),
+		"v"+version,
+		shared.NpmPackagesScheme,
+		reposource.PackageName(name),
+		version,
+	)
+}
+
+func sortedByName(deps []shared.LockfileDependency) []shared.LockfileDependency {
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps
+}
@@ -8,18 +8,20 @@ import (
 )
 
 type operations struct {
-	deleteDependencyReposByID    *observation.Operation
-	listDependencyRepos          *observation.Operation
-	lockfileDependencies         *observation.Operation
-	lockfileDependents           *observation.Operation
-	preciseDependencies          *observation.Operation
-	preciseDependents            *observation.Operation
-	selectRepoRevisionsToResolve *observation.Operation
-	updateResolvedRevisions      *observation.Operation
-	upsertDependencyRepos        *observation.Operation
-	upsertLockfileGraph          *observation.Operation
-	listLockfileIndexes          *observation.Operation
-	getLockfileIndex             *observation.Operation
+	deleteDependencyReposByID      *observation.Operation
+	listDependencyRepos            *observation.Operation
+	lockfileDependencies           *observation.Operation
+	lockfileDependents             *observation.Operation
+	preciseDependencies            *observation.Operation
+	preciseDependents              *observation.Operation
+	selectRepoRevisionsToResolve   *observation.Operation
+	updateResolvedRevisions        *observation.Operation
+	upsertDependencyRepos          *observation.Operation
+	upsertLockfileGraph            *observation.Operation
+	listLockfileIndexes            *observation.Operation
+	getLockfileIndex               *observation.Operation
+	touchLockfileIndex             *observation.Operation
+	selectReposForLockfileIndexing *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -43,17 +45,19 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		deleteDependencyReposByID:    op("DeleteDependencyReposByID"),
-		listDependencyRepos:          op("ListDependencyRepos"),
-		lockfileDependencies:         op("LockfileDependencies"),
-		lockfileDependents:           op("LockfileDependents"),
-		preciseDependencies:          op("PreciseDependencies"),
-		preciseDependents:            op("PreciseDependents"),
-		selectRepoRevisionsToResolve: op("SelectRepoRevisionsToResolve"),
-		updateResolvedRevisions:      op("UpdateResolvedRevisions"),
-		upsertDependencyRepos:        op("UpsertDependencyRepos"),
-		upsertLockfileGraph:          op("UpsertLockfileGraph"),
-		listLockfileIndexes:          op("ListLockfileIndexes"),
-		getLockfileIndex:             op("GetLockfileIndex"),
+		deleteDependencyReposByID:      op("DeleteDependencyReposByID"),
+		listDependencyRepos:            op("ListDependencyRepos"),
+		lockfileDependencies:           op("LockfileDependencies"),
+		lockfileDependents:             op("LockfileDependents"),
+		preciseDependencies:            op("PreciseDependencies"),
+		preciseDependents:              op("PreciseDependents"),
+		selectRepoRevisionsToResolve:   op("SelectRepoRevisionsToResolve"),
+		updateResolvedRevisions:        op("UpdateResolvedRevisions"),
+		upsertDependencyRepos:          op("UpsertDependencyRepos"),
+		upsertLockfileGraph:            op("UpsertLockfileGraph"),
+		listLockfileIndexes:            op("ListLockfileIndexes"),
+		getLockfileIndex:               op("GetLockfileIndex"),
+		touchLockfileIndex:             op("TouchLockfileIndex"),
+		selectReposForLockfileIndexing: op("SelectReposForLockfileIndexing"),
 	}
 }
@@ -1,6 +1,9 @@
 package store
 
 import (
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -19,3 +22,47 @@ func scanDependencyRepo(s dbutil.Scanner) (shared.Repo, error) {
 // Scans `[]shared.Repo`
 
 var scanDependencyRepos = basestore.NewSliceScanner(scanDependencyRepo)
+
+//
+// Scans `[]shared.LockfileDependency`
+
+var scanLockfileDependencies = basestore.NewSliceScanner(func(s dbutil.Scanner) (shared.LockfileDependency, error) {
+	var v shared.LockfileDependency
+	err := s.Scan(&v.ID, &v.Lockfile, &v.Scheme, &v.Name, &v.Version, &v.Direct, pq.Array(&v.DependsOn))
+	return v, err
+})
+
+//
+// Scans `[]shared.LockfileDependent`
+
+var scanLockfileDependents = basestore.NewSliceScanner(func(s dbutil.Scanner) (shared.LockfileDependent, error) {
+	var (
+		v      shared.LockfileDependent
+		commit dbutil.CommitBytea
+	)
+	err := s.Scan(&v.RepoID, &commit, &v.Lockfile, &v.Version, &v.Direct)
+	v.Commit = api.CommitID(commit)
+	return v, err
+})
+
+//
+// Scans `[]shared.LockfileIndexingCandidate`
+
+var scanLockfileIndexingCandidates = basestore.NewSliceScanner(func(s dbutil.Scanner) (shared.LockfileIndexingCandidate, error) {
+	var v shared.LockfileIndexingCandidate
+	err := s.Scan(&v.RepoID, &v.RepoName)
+	return v, err
+})
+
+//
+// Scans `[]shared.LockfileIndex`
+
+var scanLockfileIndexes = basestore.NewSliceScanner(func(s dbutil.Scanner) (shared.LockfileIndex, error) {
+	var (
+		v      shared.LockfileIndex
+		commit dbutil.CommitBytea
+	)
+	err := s.Scan(&v.RepoID, &v.RepoName, &commit)
+	v.Commit = api.CommitID(commit)
+	return v, err
+})
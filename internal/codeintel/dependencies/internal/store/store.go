@@ -2,11 +2,13 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -21,6 +23,12 @@ type Store interface {
 	ListDependencyRepos(ctx context.Context, opts ListDependencyReposOpts) (dependencyRepos []shared.Repo, err error)
 	UpsertDependencyRepos(ctx context.Context, deps []shared.Repo) (newDeps []shared.Repo, err error)
 	DeleteDependencyReposByID(ctx context.Context, ids ...int) (err error)
+	UpsertLockfileGraph(ctx context.Context, repoID api.RepoID, commit api.CommitID, graphs map[string]*shared.DependencyGraph) (err error)
+	TouchLockfileIndex(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ bool, err error)
+	SelectReposForLockfileIndexing(ctx context.Context, batchSize int, interval time.Duration) (_ []shared.LockfileIndexingCandidate, err error)
+	LockfileDependencies(ctx context.Context, repoID api.RepoID, commit api.CommitID) (deps []shared.LockfileDependency, err error)
+	LockfileDependents(ctx context.Context, scheme string, name reposource.PackageName) (dependents []shared.LockfileDependent, err error)
+	ListLockfileIndexes(ctx context.Context, opts ListLockfileIndexesOpts) (indexes []shared.LockfileIndex, err error)
 }
 
 // store manages the database tables for package dependencies.
@@ -0,0 +1,35 @@
+package dependencies
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/lockfiles"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// ParseLockfiles reads the lockfiles of the given repository at the given
+// commit from gitserver and returns their dependency graphs, keyed by lockfile
+// path. Lockfiles that fail to parse are reported to onError and skipped.
+func ParseLockfiles(
+	ctx context.Context,
+	gitClient GitserverClient,
+	checker authz.SubRepoPermissionChecker,
+	repo api.RepoName,
+	commit api.CommitID,
+	onError func(path string, err error),
+) (map[string]*shared.DependencyGraph, error) {
+	reader, err := gitClient.ArchiveReader(ctx, checker, repo, gitserver.ArchiveOptions{
+		Treeish:   string(commit),
+		Format:    gitserver.ArchiveFormatTar,
+		Pathspecs: lockfiles.Pathspecs(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return lockfiles.ParseArchive(reader, onError)
+}
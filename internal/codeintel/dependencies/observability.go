@@ -8,9 +8,16 @@ import (
 )
 
 type operations struct {
-	listDependencyRepos       *observation.Operation
-	upsertDependencyRepos     *observation.Operation
-	deleteDependencyReposByID *observation.Operation
+	listDependencyRepos            *observation.Operation
+	upsertDependencyRepos          *observation.Operation
+	deleteDependencyReposByID      *observation.Operation
+	upsertLockfileGraph            *observation.Operation
+	touchLockfileIndex             *observation.Operation
+	selectReposForLockfileIndexing *observation.Operation
+	lockfileDependencies           *observation.Operation
+	lockfileDependents             *observation.Operation
+	listLockfileIndexes            *observation.Operation
+	lockfileGraphs                 *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -34,8 +41,15 @@ func newOperations(observationCtx *observation.Context) *operations {
 	}
 
 	return &operations{
-		listDependencyRepos:       op("ListDependencyRepos"),
-		upsertDependencyRepos:     op("UpsertDependencyRepos"),
-		deleteDependencyReposByID: op("DeleteDependencyReposByID"),
+		listDependencyRepos:            op("ListDependencyRepos"),
+		upsertDependencyRepos:          op("UpsertDependencyRepos"),
+		deleteDependencyReposByID:      op("DeleteDependencyReposByID"),
+		upsertLockfileGraph:            op("UpsertLockfileGraph"),
+		touchLockfileIndex:             op("TouchLockfileIndex"),
+		selectReposForLockfileIndexing: op("SelectReposForLockfileIndexing"),
+		lockfileDependencies:           op("LockfileDependencies"),
+		lockfileDependents:             op("LockfileDependents"),
+		listLockfileIndexes:            op("ListLockfileIndexes"),
+		lockfileGraphs:                 op("LockfileGraphs"),
 	}
 }
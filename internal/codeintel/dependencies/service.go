@@ -2,11 +2,14 @@ package dependencies
 
 import (
 	"context"
+	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Service encapsulates the resolution and persistence of dependencies at the repository and package levels.
@@ -65,3 +68,112 @@ func (s *Service) DeleteDependencyReposByID(ctx context.Context, ids ...int) (er
 
 	return s.store.DeleteDependencyReposByID(ctx, ids...)
 }
+
+// UpsertLockfileGraph replaces the lockfile dependency graphs of the given
+// repository with the graphs of the lockfiles found at the given commit,
+// keyed by lockfile path.
+func (s *Service) UpsertLockfileGraph(ctx context.Context, repoID api.RepoID, commit api.CommitID, graphs map[string]*shared.DependencyGraph) (err error) {
+	ctx, _, endObservation := s.operations.upsertLockfileGraph.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.UpsertLockfileGraph(ctx, repoID, commit, graphs)
+}
+
+// TouchLockfileIndex returns true if the lockfiles of the given repository
+// have already been indexed at the given commit, and marks them as
+// freshly indexed.
+func (s *Service) TouchLockfileIndex(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.touchLockfileIndex.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.TouchLockfileIndex(ctx, repoID, commit)
+}
+
+// SelectReposForLockfileIndexing returns up to batchSize repositories whose
+// lockfiles need to be (re-)indexed.
+func (s *Service) SelectReposForLockfileIndexing(ctx context.Context, batchSize int, interval time.Duration) (_ []shared.LockfileIndexingCandidate, err error) {
+	ctx, _, endObservation := s.operations.selectReposForLockfileIndexing.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.SelectReposForLockfileIndexing(ctx, batchSize, interval)
+}
+
+// LockfileDependencies returns the packages referenced by the lockfiles of
+// the given repository at the given commit.
+func (s *Service) LockfileDependencies(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ []shared.LockfileDependency, err error) {
+	ctx, _, endObservation := s.operations.lockfileDependencies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.LockfileDependencies(ctx, repoID, commit)
+}
+
+// LockfileDependents returns the repositories whose lockfiles reference any
+// version of the given package. The ecosystem is one of the names accepted
+// by the repo:depends.on() search predicate, e.g. "npm" or "cargo".
+func (s *Service) LockfileDependents(ctx context.Context, ecosystem string, name reposource.PackageName) (_ []shared.LockfileDependent, err error) {
+	ctx, _, endObservation := s.operations.lockfileDependents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	scheme, ok := shared.EcosystemSchemes[ecosystem]
+	if !ok {
+		return nil, errors.Newf("unknown package ecosystem %q", ecosystem)
+	}
+
+	return s.store.LockfileDependents(ctx, scheme, shared.NormalizePackageName(scheme, name))
+}
+
+type ListLockfileIndexesOpts = store.ListLockfileIndexesOpts
+
+// ListLockfileIndexes returns the repositories with at least one indexed
+// lockfile, ordered by repository ID.
+func (s *Service) ListLockfileIndexes(ctx context.Context, opts ListLockfileIndexesOpts) (_ []shared.LockfileIndex, err error) {
+	ctx, _, endObservation := s.operations.listLockfileIndexes.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.ListLockfileIndexes(ctx, opts)
+}
+
+// LockfileGraphs returns the dependency graphs of the lockfiles of the given
+// repository at the given commit, keyed by lockfile path, as stored by the
+// lockfile indexer. It returns no graphs if the repository's lockfiles
+// weren't indexed at that commit.
+func (s *Service) LockfileGraphs(ctx context.Context, repoID api.RepoID, commit api.CommitID) (_ map[string]*shared.DependencyGraph, err error) {
+	ctx, _, endObservation := s.operations.lockfileGraphs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	deps, err := s.store.LockfileDependencies(ctx, repoID, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make(map[int]shared.PackageDependency, len(deps))
+	for _, dep := range deps {
+		packages[dep.ID] = shared.PackageDependencyLiteral{
+			SchemeValue:         dep.Scheme,
+			PackageSyntaxValue:  dep.Name,
+			PackageVersionValue: dep.Version,
+		}
+	}
+
+	graphs := map[string]*shared.DependencyGraph{}
+	for _, dep := range deps {
+		graph, ok := graphs[dep.Lockfile]
+		if !ok {
+			graph = shared.NewDependencyGraph()
+			graphs[dep.Lockfile] = graph
+		}
+
+		pkg := packages[dep.ID]
+		graph.AddPackage(pkg)
+		if dep.Direct {
+			graph.AddDirectDependency(pkg)
+		}
+		for _, id := range dep.DependsOn {
+			if to, ok := packages[id]; ok {
+				graph.AddDependency(pkg, to)
+			}
+		}
+	}
+
+	return graphs, nil
+}
@@ -8,3 +8,15 @@ const (
 	RustPackagesScheme   = "rust-analyzer"
 	RubyPackagesScheme   = "scip-ruby"
 )
+
+// EcosystemSchemes maps the package ecosystem names users refer to, e.g. in
+// the repo:depends.on() search predicate, to the schemes packages of that
+// ecosystem are stored under.
+var EcosystemSchemes = map[string]string{
+	"cargo": RustPackagesScheme,
+	"gem":   RubyPackagesScheme,
+	"go":    GoPackagesScheme,
+	"maven": JVMPackagesScheme,
+	"npm":   NpmPackagesScheme,
+	"pypi":  PythonPackagesScheme,
+}
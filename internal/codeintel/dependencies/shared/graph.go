@@ -0,0 +1,124 @@
+package shared
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependencyGraph is the graph of packages referenced by a single lockfile.
+type DependencyGraph struct {
+	packages map[string]PackageDependency
+	edges    map[string]map[string]struct{}
+	direct   map[string]struct{}
+}
+
+// NewDependencyGraph returns an empty dependency graph.
+func NewDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		packages: map[string]PackageDependency{},
+		edges:    map[string]map[string]struct{}{},
+		direct:   map[string]struct{}{},
+	}
+}
+
+// AddPackage adds the given package to the graph.
+func (g *DependencyGraph) AddPackage(pkg PackageDependency) {
+	g.packages[packageKey(pkg)] = pkg
+}
+
+// AddDependency adds both packages to the graph and records that from
+// depends on to.
+func (g *DependencyGraph) AddDependency(from, to PackageDependency) {
+	g.AddPackage(from)
+	g.AddPackage(to)
+
+	fromKey, toKey := packageKey(from), packageKey(to)
+	if fromKey == toKey {
+		return
+	}
+	if _, ok := g.edges[fromKey]; !ok {
+		g.edges[fromKey] = map[string]struct{}{}
+	}
+	g.edges[fromKey][toKey] = struct{}{}
+}
+
+// AddDirectDependency adds the given package to the graph and records that
+// the project owning the lockfile depends on it directly.
+func (g *DependencyGraph) AddDirectDependency(pkg PackageDependency) {
+	g.AddPackage(pkg)
+	g.direct[packageKey(pkg)] = struct{}{}
+}
+
+// Empty returns true if the graph contains no packages.
+func (g *DependencyGraph) Empty() bool {
+	return len(g.packages) == 0
+}
+
+// Fidelity returns whether the edges between the packages are known.
+func (g *DependencyGraph) Fidelity() LockfileFidelity {
+	if len(g.edges) == 0 {
+		return LockfileFidelityFlat
+	}
+	return LockfileFidelityGraph
+}
+
+// Packages returns all packages of the graph in a stable order.
+func (g *DependencyGraph) Packages() []PackageDependency {
+	keys := make([]string, 0, len(g.packages))
+	for key := range g.packages {
+		keys = append(keys, key)
+	}
+	return g.sorted(keys)
+}
+
+// DirectDependencies returns the packages the project owning the lockfile
+// depends on directly. Not every lockfile format records those, in which case
+// all packages that no other package depends on are considered direct
+// dependencies. For flat graphs, this is every package.
+func (g *DependencyGraph) DirectDependencies() []PackageDependency {
+	if len(g.direct) > 0 {
+		keys := make([]string, 0, len(g.direct))
+		for key := range g.direct {
+			keys = append(keys, key)
+		}
+		return g.sorted(keys)
+	}
+
+	dependedOn := map[string]struct{}{}
+	for _, tos := range g.edges {
+		for to := range tos {
+			dependedOn[to] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(g.packages))
+	for key := range g.packages {
+		if _, ok := dependedOn[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return g.sorted(keys)
+}
+
+// DependsOn returns the packages the given package depends on.
+func (g *DependencyGraph) DependsOn(pkg PackageDependency) []PackageDependency {
+	tos := g.edges[packageKey(pkg)]
+	keys := make([]string, 0, len(tos))
+	for key := range tos {
+		keys = append(keys, key)
+	}
+	return g.sorted(keys)
+}
+
+func (g *DependencyGraph) sorted(keys []string) []PackageDependency {
+	sort.Strings(keys)
+	pkgs := make([]PackageDependency, 0, len(keys))
+	for _, key := range keys {
+		pkgs = append(pkgs, g.packages[key])
+	}
+	return pkgs
+}
+
+func packageKey(pkg PackageDependency) string {
+	return strings.Join([]string{pkg.Scheme(), string(pkg.PackageSyntax()), pkg.PackageVersion()}, "\x00")
+}
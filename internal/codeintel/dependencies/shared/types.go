@@ -1,8 +1,11 @@
 package shared
 
 import (
+	"strings"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 )
 
 type Repo struct {
@@ -49,3 +52,73 @@ func (d PackageDependencyLiteral) GitTagFromVersion() string             { retur
 func (d PackageDependencyLiteral) Scheme() string                        { return d.SchemeValue }
 func (d PackageDependencyLiteral) PackageSyntax() reposource.PackageName { return d.PackageSyntaxValue }
 func (d PackageDependencyLiteral) PackageVersion() string                { return d.PackageVersionValue }
+
+// LockfileFidelity describes how much of the dependency graph of a lockfile
+// is known.
+type LockfileFidelity string
+
+const (
+	// LockfileFidelityFlat means only the list of packages is known, not how
+	// they depend on each other.
+	LockfileFidelityFlat LockfileFidelity = "flat"
+
+	// LockfileFidelityGraph means the edges between the packages are known.
+	LockfileFidelityGraph LockfileFidelity = "graph"
+)
+
+// LockfileDependency is a package referenced by a lockfile of a repository at
+// a given commit.
+type LockfileDependency struct {
+	ID       int
+	Lockfile string
+	Scheme   string
+	Name     reposource.PackageName
+	Version  string
+
+	// Direct is true if the repository depends on the package itself rather
+	// than through one of its other dependencies.
+	Direct bool
+
+	// DependsOn holds the IDs of the other dependencies of the same lockfile
+	// this package depends on.
+	DependsOn []int
+}
+
+// LockfileDependent is a repository depending on a package, as of the most
+// recently indexed commit of the repository.
+type LockfileDependent struct {
+	RepoID   api.RepoID
+	Commit   api.CommitID
+	Lockfile string
+	Version  string
+	Direct   bool
+}
+
+// LockfileIndex is the commit a repository's lockfiles were last indexed at.
+type LockfileIndex struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName
+	Commit   api.CommitID
+}
+
+// LockfileIndexingCandidate is a repository whose lockfiles are due to be
+// indexed.
+type LockfileIndexingCandidate struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName
+}
+
+var pythonPackageNameSeparators = lazyregexp.New(`[-_.]+`)
+
+// NormalizePackageName returns the canonical form of the given package name
+// in the ecosystem of the given scheme, so that different spellings of the
+// same package compare equal.
+func NormalizePackageName(scheme string, name reposource.PackageName) reposource.PackageName {
+	switch scheme {
+	case PythonPackagesScheme:
+		// See https://peps.python.org/pep-0503/#normalized-names.
+		return reposource.PackageName(pythonPackageNameSeparators.ReplaceAllString(strings.ToLower(string(name)), "-"))
+	default:
+		return name
+	}
+}
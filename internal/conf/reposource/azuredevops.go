@@ -0,0 +1,14 @@
+package reposource
+
+import (
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// AzureDevOpsRepoName returns the name of the repository named repo in the
+// given organization (or collection, on Azure DevOps Server) and project. host
+// includes the virtual directory Azure DevOps Server is served from, if any.
+func AzureDevOpsRepoName(host, org, project, repo string) api.RepoName {
+	return api.RepoName(path.Join(host, org, project, repo))
+}
@@ -0,0 +1,25 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestAzureDevOpsRepoName(t *testing.T) {
+	tests := []struct {
+		host, org, project, repo string
+		want                     api.RepoName
+	}{
+		{"dev.azure.com", "sgtest", "sourcegraph", "src-cli", "dev.azure.com/sgtest/sourcegraph/src-cli"},
+		{"tfs.mycorp.com", "DefaultCollection", "Platform", "api", "tfs.mycorp.com/DefaultCollection/Platform/api"},
+		{"tfs.mycorp.com/tfs", "DefaultCollection", "Platform", "api", "tfs.mycorp.com/tfs/DefaultCollection/Platform/api"},
+		{"dev.azure.com", "sgtest", "My Project", "My Repo", "dev.azure.com/sgtest/My Project/My Repo"},
+	}
+
+	for _, test := range tests {
+		if got := AzureDevOpsRepoName(test.host, test.org, test.project, test.repo); got != test.want {
+			t.Errorf("expected %q but got %q", test.want, got)
+		}
+	}
+}
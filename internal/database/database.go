@@ -39,7 +39,9 @@ type DB interface {
 	Permissions() PermissionStore
 	Phabricator() PhabricatorStore
 	Repos() RepoStore
+	RepoInventory() RepoInventoryStore
 	RepoKVPs() RepoKVPStore
+	RepoOwnershipCoverage() RepoOwnershipCoverageStore
 	RolePermissions() RolePermissionStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
@@ -180,10 +182,18 @@ func (d *db) Repos() RepoStore {
 	return ReposWith(d.logger, d.Store)
 }
 
+func (d *db) RepoInventory() RepoInventoryStore {
+	return RepoInventoryWith(d.Store)
+}
+
 func (d *db) RepoKVPs() RepoKVPStore {
 	return &repoKVPStore{d.Store}
 }
 
+func (d *db) RepoOwnershipCoverage() RepoOwnershipCoverageStore {
+	return RepoOwnershipCoverageWith(d.Store)
+}
+
 func (d *db) RolePermissions() RolePermissionStore {
 	return RolePermissionsWith(d.Store)
 }
@@ -3694,9 +3694,15 @@ type MockDB struct {
 	// QueryRowContextFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryRowContext.
 	QueryRowContextFunc *DBQueryRowContextFunc
+	// RepoInventoryFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoInventory.
+	RepoInventoryFunc *DBRepoInventoryFunc
 	// RepoKVPsFunc is an instance of a mock function object controlling the
 	// behavior of the method RepoKVPs.
 	RepoKVPsFunc *DBRepoKVPsFunc
+	// RepoOwnershipCoverageFunc is an instance of a mock function object
+	// controlling the behavior of the method RepoOwnershipCoverage.
+	RepoOwnershipCoverageFunc *DBRepoOwnershipCoverageFunc
 	// RepoStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoStatistics.
 	RepoStatisticsFunc *DBRepoStatisticsFunc
@@ -3882,11 +3888,21 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		RepoInventoryFunc: &DBRepoInventoryFunc{
+			defaultHook: func() (r0 RepoInventoryStore) {
+				return
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() (r0 RepoKVPStore) {
 				return
 			},
 		},
+		RepoOwnershipCoverageFunc: &DBRepoOwnershipCoverageFunc{
+			defaultHook: func() (r0 RepoOwnershipCoverageStore) {
+				return
+			},
+		},
 		RepoStatisticsFunc: &DBRepoStatisticsFunc{
 			defaultHook: func() (r0 RepoStatisticsStore) {
 				return
@@ -4109,11 +4125,21 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.QueryRowContext")
 			},
 		},
+		RepoInventoryFunc: &DBRepoInventoryFunc{
+			defaultHook: func() RepoInventoryStore {
+				panic("unexpected invocation of MockDB.RepoInventory")
+			},
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: func() RepoKVPStore {
 				panic("unexpected invocation of MockDB.RepoKVPs")
 			},
 		},
+		RepoOwnershipCoverageFunc: &DBRepoOwnershipCoverageFunc{
+			defaultHook: func() RepoOwnershipCoverageStore {
+				panic("unexpected invocation of MockDB.RepoOwnershipCoverage")
+			},
+		},
 		RepoStatisticsFunc: &DBRepoStatisticsFunc{
 			defaultHook: func() RepoStatisticsStore {
 				panic("unexpected invocation of MockDB.RepoStatistics")
@@ -4286,9 +4312,15 @@ func NewMockDBFrom(i DB) *MockDB {
 		QueryRowContextFunc: &DBQueryRowContextFunc{
 			defaultHook: i.QueryRowContext,
 		},
+		RepoInventoryFunc: &DBRepoInventoryFunc{
+			defaultHook: i.RepoInventory,
+		},
 		RepoKVPsFunc: &DBRepoKVPsFunc{
 			defaultHook: i.RepoKVPs,
 		},
+		RepoOwnershipCoverageFunc: &DBRepoOwnershipCoverageFunc{
+			defaultHook: i.RepoOwnershipCoverage,
+		},
 		RepoStatisticsFunc: &DBRepoStatisticsFunc{
 			defaultHook: i.RepoStatistics,
 		},
@@ -6866,6 +6898,104 @@ func (c DBQueryRowContextFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRepoInventoryFunc describes the behavior when the RepoInventory method
+// of the parent MockDB instance is invoked.
+type DBRepoInventoryFunc struct {
+	defaultHook func() RepoInventoryStore
+	hooks       []func() RepoInventoryStore
+	history     []DBRepoInventoryFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoInventory delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDB) RepoInventory() RepoInventoryStore {
+	r0 := m.RepoInventoryFunc.nextHook()()
+	m.RepoInventoryFunc.appendCall(DBRepoInventoryFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RepoInventory method
+// of the parent MockDB instance is invoked and the hook queue is empty.
+func (f *DBRepoInventoryFunc) SetDefaultHook(hook func() RepoInventoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoInventory method of the parent MockDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBRepoInventoryFunc) PushHook(hook func() RepoInventoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRepoInventoryFunc) SetDefaultReturn(r0 RepoInventoryStore) {
+	f.SetDefaultHook(func() RepoInventoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRepoInventoryFunc) PushReturn(r0 RepoInventoryStore) {
+	f.PushHook(func() RepoInventoryStore {
+		return r0
+	})
+}
+
+func (f *DBRepoInventoryFunc) nextHook() func() RepoInventoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRepoInventoryFunc) appendCall(r0 DBRepoInventoryFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRepoInventoryFuncCall objects describing
+// the invocations of this function.
+func (f *DBRepoInventoryFunc) History() []DBRepoInventoryFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRepoInventoryFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRepoInventoryFuncCall is an object that describes an invocation of
+// method RepoInventory on an instance of MockDB.
+type DBRepoInventoryFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoInventoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRepoInventoryFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRepoInventoryFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBRepoKVPsFunc describes the behavior when the RepoKVPs method of the
 // parent MockDB instance is invoked.
 type DBRepoKVPsFunc struct {
@@ -6964,6 +7094,105 @@ func (c DBRepoKVPsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRepoOwnershipCoverageFunc describes the behavior when the
+// RepoOwnershipCoverage method of the parent MockDB instance is invoked.
+type DBRepoOwnershipCoverageFunc struct {
+	defaultHook func() RepoOwnershipCoverageStore
+	hooks       []func() RepoOwnershipCoverageStore
+	history     []DBRepoOwnershipCoverageFuncCall
+	mutex       sync.Mutex
+}
+
+// RepoOwnershipCoverage delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDB) RepoOwnershipCoverage() RepoOwnershipCoverageStore {
+	r0 := m.RepoOwnershipCoverageFunc.nextHook()()
+	m.RepoOwnershipCoverageFunc.appendCall(DBRepoOwnershipCoverageFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// RepoOwnershipCoverage method of the parent MockDB instance is invoked and
+// the hook queue is empty.
+func (f *DBRepoOwnershipCoverageFunc) SetDefaultHook(hook func() RepoOwnershipCoverageStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepoOwnershipCoverage method of the parent MockDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBRepoOwnershipCoverageFunc) PushHook(hook func() RepoOwnershipCoverageStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRepoOwnershipCoverageFunc) SetDefaultReturn(r0 RepoOwnershipCoverageStore) {
+	f.SetDefaultHook(func() RepoOwnershipCoverageStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRepoOwnershipCoverageFunc) PushReturn(r0 RepoOwnershipCoverageStore) {
+	f.PushHook(func() RepoOwnershipCoverageStore {
+		return r0
+	})
+}
+
+func (f *DBRepoOwnershipCoverageFunc) nextHook() func() RepoOwnershipCoverageStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRepoOwnershipCoverageFunc) appendCall(r0 DBRepoOwnershipCoverageFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRepoOwnershipCoverageFuncCall objects
+// describing the invocations of this function.
+func (f *DBRepoOwnershipCoverageFunc) History() []DBRepoOwnershipCoverageFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRepoOwnershipCoverageFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRepoOwnershipCoverageFuncCall is an object that describes an invocation
+// of method RepoOwnershipCoverage on an instance of MockDB.
+type DBRepoOwnershipCoverageFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoOwnershipCoverageStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRepoOwnershipCoverageFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRepoOwnershipCoverageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBRepoStatisticsFunc describes the behavior when the RepoStatistics
 // method of the parent MockDB instance is invoked.
 type DBRepoStatisticsFunc struct {
@@ -32118,6 +32347,1402 @@ func (c PhabricatorStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockRepoInventoryStore is a mock implementation of the RepoInventoryStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRepoInventoryStore struct {
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *RepoInventoryStoreGetFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *RepoInventoryStoreHandleFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *RepoInventoryStoreTransactFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *RepoInventoryStoreUpsertFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *RepoInventoryStoreWithFunc
+}
+
+// NewMockRepoInventoryStore creates a new mock of the RepoInventoryStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockRepoInventoryStore() *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *RepoInventory, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 RepoInventoryStore, r1 error) {
+				return
+			},
+		},
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoInventory) (r0 error) {
+				return
+			},
+		},
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 RepoInventoryStore) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoInventoryStore creates a new mock of the
+// RepoInventoryStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockRepoInventoryStore() *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (*RepoInventory, error) {
+				panic("unexpected invocation of MockRepoInventoryStore.Get")
+			},
+		},
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockRepoInventoryStore.Handle")
+			},
+		},
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: func(context.Context) (RepoInventoryStore, error) {
+				panic("unexpected invocation of MockRepoInventoryStore.Transact")
+			},
+		},
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoInventory) error {
+				panic("unexpected invocation of MockRepoInventoryStore.Upsert")
+			},
+		},
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) RepoInventoryStore {
+				panic("unexpected invocation of MockRepoInventoryStore.With")
+			},
+		},
+	}
+}
+
+// NewMockRepoInventoryStoreFrom creates a new mock of the
+// MockRepoInventoryStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockRepoInventoryStoreFrom(i RepoInventoryStore) *MockRepoInventoryStore {
+	return &MockRepoInventoryStore{
+		GetFunc: &RepoInventoryStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		HandleFunc: &RepoInventoryStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		TransactFunc: &RepoInventoryStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpsertFunc: &RepoInventoryStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+		WithFunc: &RepoInventoryStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// RepoInventoryStoreGetFunc describes the behavior when the Get method of
+// the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreGetFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*RepoInventory, error)
+	hooks       []func(context.Context, api.RepoID) (*RepoInventory, error)
+	history     []RepoInventoryStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) Get(v0 context.Context, v1 api.RepoID) (*RepoInventory, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(RepoInventoryStoreGetFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*RepoInventory, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockRepoInventoryStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *RepoInventoryStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*RepoInventory, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoInventoryStoreGetFunc) SetDefaultReturn(r0 *RepoInventory, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*RepoInventory, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoInventoryStoreGetFunc) PushReturn(r0 *RepoInventory, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*RepoInventory, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoInventoryStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*RepoInventory, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoInventoryStoreGetFunc) appendCall(r0 RepoInventoryStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoInventoryStoreGetFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreGetFunc) History() []RepoInventoryStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoInventoryStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoInventoryStoreGetFuncCall is an object that describes an invocation
+// of method Get on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *RepoInventory
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoInventoryStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoInventoryStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoInventoryStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []RepoInventoryStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(RepoInventoryStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoInventoryStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoInventoryStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *RepoInventoryStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoInventoryStoreHandleFunc) appendCall(r0 RepoInventoryStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoInventoryStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreHandleFunc) History() []RepoInventoryStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoInventoryStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoInventoryStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoInventoryStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoInventoryStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoInventoryStoreTransactFunc describes the behavior when the Transact
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreTransactFunc struct {
+	defaultHook func(context.Context) (RepoInventoryStore, error)
+	hooks       []func(context.Context) (RepoInventoryStore, error)
+	history     []RepoInventoryStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) Transact(v0 context.Context) (RepoInventoryStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(RepoInventoryStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockRepoInventoryStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoInventoryStoreTransactFunc) SetDefaultHook(hook func(context.Context) (RepoInventoryStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreTransactFunc) PushHook(hook func(context.Context) (RepoInventoryStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoInventoryStoreTransactFunc) SetDefaultReturn(r0 RepoInventoryStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (RepoInventoryStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoInventoryStoreTransactFunc) PushReturn(r0 RepoInventoryStore, r1 error) {
+	f.PushHook(func(context.Context) (RepoInventoryStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoInventoryStoreTransactFunc) nextHook() func(context.Context) (RepoInventoryStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoInventoryStoreTransactFunc) appendCall(r0 RepoInventoryStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoInventoryStoreTransactFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreTransactFunc) History() []RepoInventoryStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoInventoryStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoInventoryStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoInventoryStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoInventoryStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoInventoryStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoInventoryStoreUpsertFunc describes the behavior when the Upsert
+// method of the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreUpsertFunc struct {
+	defaultHook func(context.Context, *RepoInventory) error
+	hooks       []func(context.Context, *RepoInventory) error
+	history     []RepoInventoryStoreUpsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) Upsert(v0 context.Context, v1 *RepoInventory) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(RepoInventoryStoreUpsertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreUpsertFunc) SetDefaultHook(hook func(context.Context, *RepoInventory) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upsert method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreUpsertFunc) PushHook(hook func(context.Context, *RepoInventory) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoInventoryStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *RepoInventory) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoInventoryStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *RepoInventory) error {
+		return r0
+	})
+}
+
+func (f *RepoInventoryStoreUpsertFunc) nextHook() func(context.Context, *RepoInventory) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoInventoryStoreUpsertFunc) appendCall(r0 RepoInventoryStoreUpsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoInventoryStoreUpsertFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreUpsertFunc) History() []RepoInventoryStoreUpsertFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoInventoryStoreUpsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoInventoryStoreUpsertFuncCall is an object that describes an
+// invocation of method Upsert on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreUpsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *RepoInventory
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoInventoryStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoInventoryStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoInventoryStoreWithFunc describes the behavior when the With method of
+// the parent MockRepoInventoryStore instance is invoked.
+type RepoInventoryStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) RepoInventoryStore
+	hooks       []func(basestore.ShareableStore) RepoInventoryStore
+	history     []RepoInventoryStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoInventoryStore) With(v0 basestore.ShareableStore) RepoInventoryStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(RepoInventoryStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockRepoInventoryStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoInventoryStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) RepoInventoryStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockRepoInventoryStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoInventoryStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoInventoryStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoInventoryStoreWithFunc) SetDefaultReturn(r0 RepoInventoryStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) RepoInventoryStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoInventoryStoreWithFunc) PushReturn(r0 RepoInventoryStore) {
+	f.PushHook(func(basestore.ShareableStore) RepoInventoryStore {
+		return r0
+	})
+}
+
+func (f *RepoInventoryStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoInventoryStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoInventoryStoreWithFunc) appendCall(r0 RepoInventoryStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoInventoryStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *RepoInventoryStoreWithFunc) History() []RepoInventoryStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoInventoryStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoInventoryStoreWithFuncCall is an object that describes an invocation
+// of method With on an instance of MockRepoInventoryStore.
+type RepoInventoryStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoInventoryStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoInventoryStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoInventoryStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockRepoOwnershipCoverageStore is a mock implementation of the
+// RepoOwnershipCoverageStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRepoOwnershipCoverageStore struct {
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *RepoOwnershipCoverageStoreGetFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *RepoOwnershipCoverageStoreHandleFunc
+	// ListFunc is an instance of a mock function object controlling the
+	// behavior of the method List.
+	ListFunc *RepoOwnershipCoverageStoreListFunc
+	// TransactFunc is an instance of a mock function object controlling the
+	// behavior of the method Transact.
+	TransactFunc *RepoOwnershipCoverageStoreTransactFunc
+	// UpsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Upsert.
+	UpsertFunc *RepoOwnershipCoverageStoreUpsertFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *RepoOwnershipCoverageStoreWithFunc
+}
+
+// NewMockRepoOwnershipCoverageStore creates a new mock of the
+// RepoOwnershipCoverageStore interface. All methods return zero values for
+// all results, unless overwritten.
+func NewMockRepoOwnershipCoverageStore() *MockRepoOwnershipCoverageStore {
+	return &MockRepoOwnershipCoverageStore{
+		GetFunc: &RepoOwnershipCoverageStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 *RepoOwnershipCoverage, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &RepoOwnershipCoverageStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		ListFunc: &RepoOwnershipCoverageStoreListFunc{
+			defaultHook: func(context.Context, RepoOwnershipCoverageListOptions) (r0 []*RepoOwnershipCoverage, r1 error) {
+				return
+			},
+		},
+		TransactFunc: &RepoOwnershipCoverageStoreTransactFunc{
+			defaultHook: func(context.Context) (r0 RepoOwnershipCoverageStore, r1 error) {
+				return
+			},
+		},
+		UpsertFunc: &RepoOwnershipCoverageStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoOwnershipCoverage) (r0 error) {
+				return
+			},
+		},
+		WithFunc: &RepoOwnershipCoverageStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 RepoOwnershipCoverageStore) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRepoOwnershipCoverageStore creates a new mock of the
+// RepoOwnershipCoverageStore interface. All methods panic on invocation,
+// unless overwritten.
+func NewStrictMockRepoOwnershipCoverageStore() *MockRepoOwnershipCoverageStore {
+	return &MockRepoOwnershipCoverageStore{
+		GetFunc: &RepoOwnershipCoverageStoreGetFunc{
+			defaultHook: func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error) {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.Get")
+			},
+		},
+		HandleFunc: &RepoOwnershipCoverageStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.Handle")
+			},
+		},
+		ListFunc: &RepoOwnershipCoverageStoreListFunc{
+			defaultHook: func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error) {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.List")
+			},
+		},
+		TransactFunc: &RepoOwnershipCoverageStoreTransactFunc{
+			defaultHook: func(context.Context) (RepoOwnershipCoverageStore, error) {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.Transact")
+			},
+		},
+		UpsertFunc: &RepoOwnershipCoverageStoreUpsertFunc{
+			defaultHook: func(context.Context, *RepoOwnershipCoverage) error {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.Upsert")
+			},
+		},
+		WithFunc: &RepoOwnershipCoverageStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) RepoOwnershipCoverageStore {
+				panic("unexpected invocation of MockRepoOwnershipCoverageStore.With")
+			},
+		},
+	}
+}
+
+// NewMockRepoOwnershipCoverageStoreFrom creates a new mock of the
+// MockRepoOwnershipCoverageStore interface. All methods delegate to the
+// given implementation, unless overwritten.
+func NewMockRepoOwnershipCoverageStoreFrom(i RepoOwnershipCoverageStore) *MockRepoOwnershipCoverageStore {
+	return &MockRepoOwnershipCoverageStore{
+		GetFunc: &RepoOwnershipCoverageStoreGetFunc{
+			defaultHook: i.Get,
+		},
+		HandleFunc: &RepoOwnershipCoverageStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		ListFunc: &RepoOwnershipCoverageStoreListFunc{
+			defaultHook: i.List,
+		},
+		TransactFunc: &RepoOwnershipCoverageStoreTransactFunc{
+			defaultHook: i.Transact,
+		},
+		UpsertFunc: &RepoOwnershipCoverageStoreUpsertFunc{
+			defaultHook: i.Upsert,
+		},
+		WithFunc: &RepoOwnershipCoverageStoreWithFunc{
+			defaultHook: i.With,
+		},
+	}
+}
+
+// RepoOwnershipCoverageStoreGetFunc describes the behavior when the Get
+// method of the parent MockRepoOwnershipCoverageStore instance is invoked.
+type RepoOwnershipCoverageStoreGetFunc struct {
+	defaultHook func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error)
+	hooks       []func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error)
+	history     []RepoOwnershipCoverageStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) Get(v0 context.Context, v1 api.RepoID) (*RepoOwnershipCoverage, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(RepoOwnershipCoverageStoreGetFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockRepoOwnershipCoverageStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoOwnershipCoverageStoreGetFunc) SetDefaultHook(hook func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockRepoOwnershipCoverageStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoOwnershipCoverageStoreGetFunc) PushHook(hook func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreGetFunc) SetDefaultReturn(r0 *RepoOwnershipCoverage, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreGetFunc) PushReturn(r0 *RepoOwnershipCoverage, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreGetFunc) nextHook() func(context.Context, api.RepoID) (*RepoOwnershipCoverage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreGetFunc) appendCall(r0 RepoOwnershipCoverageStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreGetFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreGetFunc) History() []RepoOwnershipCoverageStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreGetFuncCall is an object that describes an
+// invocation of method Get on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *RepoOwnershipCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoOwnershipCoverageStoreHandleFunc describes the behavior when the
+// Handle method of the parent MockRepoOwnershipCoverageStore instance is
+// invoked.
+type RepoOwnershipCoverageStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []RepoOwnershipCoverageStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(RepoOwnershipCoverageStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockRepoOwnershipCoverageStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoOwnershipCoverageStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockRepoOwnershipCoverageStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoOwnershipCoverageStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreHandleFunc) appendCall(r0 RepoOwnershipCoverageStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreHandleFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreHandleFunc) History() []RepoOwnershipCoverageStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoOwnershipCoverageStoreListFunc describes the behavior when the List
+// method of the parent MockRepoOwnershipCoverageStore instance is invoked.
+type RepoOwnershipCoverageStoreListFunc struct {
+	defaultHook func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error)
+	hooks       []func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error)
+	history     []RepoOwnershipCoverageStoreListFuncCall
+	mutex       sync.Mutex
+}
+
+// List delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) List(v0 context.Context, v1 RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error) {
+	r0, r1 := m.ListFunc.nextHook()(v0, v1)
+	m.ListFunc.appendCall(RepoOwnershipCoverageStoreListFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the List method of the
+// parent MockRepoOwnershipCoverageStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoOwnershipCoverageStoreListFunc) SetDefaultHook(hook func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// List method of the parent MockRepoOwnershipCoverageStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoOwnershipCoverageStoreListFunc) PushHook(hook func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreListFunc) SetDefaultReturn(r0 []*RepoOwnershipCoverage, r1 error) {
+	f.SetDefaultHook(func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreListFunc) PushReturn(r0 []*RepoOwnershipCoverage, r1 error) {
+	f.PushHook(func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreListFunc) nextHook() func(context.Context, RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreListFunc) appendCall(r0 RepoOwnershipCoverageStoreListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreListFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreListFunc) History() []RepoOwnershipCoverageStoreListFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreListFuncCall is an object that describes an
+// invocation of method List on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 RepoOwnershipCoverageListOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*RepoOwnershipCoverage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoOwnershipCoverageStoreTransactFunc describes the behavior when the
+// Transact method of the parent MockRepoOwnershipCoverageStore instance is
+// invoked.
+type RepoOwnershipCoverageStoreTransactFunc struct {
+	defaultHook func(context.Context) (RepoOwnershipCoverageStore, error)
+	hooks       []func(context.Context) (RepoOwnershipCoverageStore, error)
+	history     []RepoOwnershipCoverageStoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) Transact(v0 context.Context) (RepoOwnershipCoverageStore, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(RepoOwnershipCoverageStoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockRepoOwnershipCoverageStore instance is invoked and the
+// hook queue is empty.
+func (f *RepoOwnershipCoverageStoreTransactFunc) SetDefaultHook(hook func(context.Context) (RepoOwnershipCoverageStore, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockRepoOwnershipCoverageStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoOwnershipCoverageStoreTransactFunc) PushHook(hook func(context.Context) (RepoOwnershipCoverageStore, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreTransactFunc) SetDefaultReturn(r0 RepoOwnershipCoverageStore, r1 error) {
+	f.SetDefaultHook(func(context.Context) (RepoOwnershipCoverageStore, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreTransactFunc) PushReturn(r0 RepoOwnershipCoverageStore, r1 error) {
+	f.PushHook(func(context.Context) (RepoOwnershipCoverageStore, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreTransactFunc) nextHook() func(context.Context) (RepoOwnershipCoverageStore, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreTransactFunc) appendCall(r0 RepoOwnershipCoverageStoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreTransactFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreTransactFunc) History() []RepoOwnershipCoverageStoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreTransactFuncCall is an object that describes an
+// invocation of method Transact on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoOwnershipCoverageStore
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RepoOwnershipCoverageStoreUpsertFunc describes the behavior when the
+// Upsert method of the parent MockRepoOwnershipCoverageStore instance is
+// invoked.
+type RepoOwnershipCoverageStoreUpsertFunc struct {
+	defaultHook func(context.Context, *RepoOwnershipCoverage) error
+	hooks       []func(context.Context, *RepoOwnershipCoverage) error
+	history     []RepoOwnershipCoverageStoreUpsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Upsert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) Upsert(v0 context.Context, v1 *RepoOwnershipCoverage) error {
+	r0 := m.UpsertFunc.nextHook()(v0, v1)
+	m.UpsertFunc.appendCall(RepoOwnershipCoverageStoreUpsertFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Upsert method of the
+// parent MockRepoOwnershipCoverageStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoOwnershipCoverageStoreUpsertFunc) SetDefaultHook(hook func(context.Context, *RepoOwnershipCoverage) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upsert method of the parent MockRepoOwnershipCoverageStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RepoOwnershipCoverageStoreUpsertFunc) PushHook(hook func(context.Context, *RepoOwnershipCoverage) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreUpsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *RepoOwnershipCoverage) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreUpsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *RepoOwnershipCoverage) error {
+		return r0
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreUpsertFunc) nextHook() func(context.Context, *RepoOwnershipCoverage) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreUpsertFunc) appendCall(r0 RepoOwnershipCoverageStoreUpsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreUpsertFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreUpsertFunc) History() []RepoOwnershipCoverageStoreUpsertFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreUpsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreUpsertFuncCall is an object that describes an
+// invocation of method Upsert on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreUpsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *RepoOwnershipCoverage
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreUpsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreUpsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoOwnershipCoverageStoreWithFunc describes the behavior when the With
+// method of the parent MockRepoOwnershipCoverageStore instance is invoked.
+type RepoOwnershipCoverageStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) RepoOwnershipCoverageStore
+	hooks       []func(basestore.ShareableStore) RepoOwnershipCoverageStore
+	history     []RepoOwnershipCoverageStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRepoOwnershipCoverageStore) With(v0 basestore.ShareableStore) RepoOwnershipCoverageStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(RepoOwnershipCoverageStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockRepoOwnershipCoverageStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoOwnershipCoverageStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) RepoOwnershipCoverageStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockRepoOwnershipCoverageStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoOwnershipCoverageStoreWithFunc) PushHook(hook func(basestore.ShareableStore) RepoOwnershipCoverageStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoOwnershipCoverageStoreWithFunc) SetDefaultReturn(r0 RepoOwnershipCoverageStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) RepoOwnershipCoverageStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoOwnershipCoverageStoreWithFunc) PushReturn(r0 RepoOwnershipCoverageStore) {
+	f.PushHook(func(basestore.ShareableStore) RepoOwnershipCoverageStore {
+		return r0
+	})
+}
+
+func (f *RepoOwnershipCoverageStoreWithFunc) nextHook() func(basestore.ShareableStore) RepoOwnershipCoverageStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoOwnershipCoverageStoreWithFunc) appendCall(r0 RepoOwnershipCoverageStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoOwnershipCoverageStoreWithFuncCall
+// objects describing the invocations of this function.
+func (f *RepoOwnershipCoverageStoreWithFunc) History() []RepoOwnershipCoverageStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoOwnershipCoverageStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoOwnershipCoverageStoreWithFuncCall is an object that describes an
+// invocation of method With on an instance of
+// MockRepoOwnershipCoverageStore.
+type RepoOwnershipCoverageStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 RepoOwnershipCoverageStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoOwnershipCoverageStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoOwnershipCoverageStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockRepoStore is a mock implementation of the RepoStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoInventory is the language breakdown of a repository as of a given
+// commit.
+type RepoInventory struct {
+	RepoID   api.RepoID
+	CommitID api.CommitID
+
+	// Languages are the programming languages used in the repository,
+	// ordered by name.
+	Languages []RepoLanguage
+
+	UpdatedAt time.Time
+}
+
+// RepoLanguage is the amount of code of a repository written in a programming
+// language.
+type RepoLanguage struct {
+	Language   string
+	TotalBytes int64
+	TotalLines int64
+}
+
+// TotalBytes returns the total number of bytes of code in all languages of
+// the inventory.
+func (inv *RepoInventory) TotalBytes() (total int64) {
+	for _, l := range inv.Languages {
+		total += l.TotalBytes
+	}
+	return total
+}
+
+// RepoLanguageFilter matches repositories by the share of their code written in
+// a language.
+type RepoLanguageFilter struct {
+	// Language is the canonical name of the language, e.g. "Java".
+	Language string
+
+	// MinPercent is the minimum share of bytes of code of the repository
+	// written in Language. If it is zero, any amount of code matches.
+	MinPercent float64
+
+	// Negated inverts the filter.
+	Negated bool
+}
+
+type RepoInventoryStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) RepoInventoryStore
+	Transact(context.Context) (RepoInventoryStore, error)
+
+	// Upsert replaces the inventory of the repository.
+	Upsert(ctx context.Context, inv *RepoInventory) error
+
+	// Get returns the inventory of the given repository, or nil if it hasn't
+	// been computed yet.
+	Get(ctx context.Context, repoID api.RepoID) (*RepoInventory, error)
+}
+
+var _ RepoInventoryStore = (*repoInventoryStore)(nil)
+
+// repoInventoryStore is responsible for data stored in the repo_inventory and
+// repo_inventory_languages tables.
+type repoInventoryStore struct {
+	*basestore.Store
+}
+
+// RepoInventoryWith instantiates and returns a new RepoInventoryStore using the
+// other store handle.
+func RepoInventoryWith(other basestore.ShareableStore) RepoInventoryStore {
+	return &repoInventoryStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoInventoryStore) With(other basestore.ShareableStore) RepoInventoryStore {
+	return &repoInventoryStore{Store: s.Store.With(other)}
+}
+
+func (s *repoInventoryStore) Transact(ctx context.Context) (RepoInventoryStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &repoInventoryStore{Store: txBase}, err
+}
+
+func (s *repoInventoryStore) Upsert(ctx context.Context, inv *RepoInventory) (err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		upsertRepoInventoryQueryFmtstr,
+		inv.RepoID,
+		inv.CommitID,
+		inv.TotalBytes(),
+	)); err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteRepoInventoryLanguagesQueryFmtstr, inv.RepoID)); err != nil {
+		return err
+	}
+
+	inserter := batch.NewInserter(ctx, tx.Handle(), "repo_inventory_languages", batch.MaxNumPostgresParameters, "repo_id", "language", "total_bytes", "total_lines")
+	for _, l := range inv.Languages {
+		if err := inserter.Insert(ctx, inv.RepoID, l.Language, l.TotalBytes, l.TotalLines); err != nil {
+			return err
+		}
+	}
+	return inserter.Flush(ctx)
+}
+
+const upsertRepoInventoryQueryFmtstr = `
+-- source: internal/database/repo_inventory.go:repoInventoryStore.Upsert
+INSERT INTO repo_inventory (repo_id, commit_id, total_bytes, updated_at)
+VALUES (%s, %s, %s, now())
+ON CONFLICT (repo_id) DO UPDATE SET
+	commit_id   = EXCLUDED.commit_id,
+	total_bytes = EXCLUDED.total_bytes,
+	updated_at  = EXCLUDED.updated_at
+`
+
+const deleteRepoInventoryLanguagesQueryFmtstr = `
+-- source: internal/database/repo_inventory.go:repoInventoryStore.Upsert
+DELETE FROM repo_inventory_languages WHERE repo_id = %s
+`
+
+func (s *repoInventoryStore) Get(ctx context.Context, repoID api.RepoID) (_ *RepoInventory, err error) {
+	inv, ok, err := basestore.NewFirstScanner(scanRepoInventory)(s.Query(ctx, sqlf.Sprintf(getRepoInventoryQueryFmtstr, repoID)))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listRepoInventoryLanguagesQueryFmtstr, repoID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var l RepoLanguage
+		if err := rows.Scan(&l.Language, &l.TotalBytes, &l.TotalLines); err != nil {
+			return nil, err
+		}
+		inv.Languages = append(inv.Languages, l)
+	}
+
+	return inv, nil
+}
+
+func scanRepoInventory(sc dbutil.Scanner) (*RepoInventory, error) {
+	var inv RepoInventory
+	if err := sc.Scan(&inv.RepoID, &inv.CommitID, &inv.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+const getRepoInventoryQueryFmtstr = `
+-- source: internal/database/repo_inventory.go:repoInventoryStore.Get
+SELECT ri.repo_id, ri.commit_id, ri.updated_at
+FROM repo_inventory ri
+JOIN repo ON repo.id = ri.repo_id
+WHERE repo.deleted_at IS NULL AND ri.repo_id = %s
+`
+
+const listRepoInventoryLanguagesQueryFmtstr = `
+-- source: internal/database/repo_inventory.go:repoInventoryStore.Get
+SELECT language, total_bytes, total_lines
+FROM repo_inventory_languages
+WHERE repo_id = %s
+ORDER BY language
+`
+
+// repoLanguageFilterQuery returns the condition on the repo table for f.
+func repoLanguageFilterQuery(f RepoLanguageFilter) *sqlf.Query {
+	cond := sqlf.Sprintf(repoLanguageFilterQueryFmtstr, f.Language, f.MinPercent)
+	if f.Negated {
+		return sqlf.Sprintf("NOT %s", cond)
+	}
+	return cond
+}
+
+const repoLanguageFilterQueryFmtstr = `
+EXISTS (
+	SELECT 1
+	FROM repo_inventory_languages ril
+	JOIN repo_inventory ri ON ri.repo_id = ril.repo_id
+	WHERE
+		ril.repo_id = repo.id AND
+		ril.language = %s AND
+		ril.total_bytes > 0 AND
+		ril.total_bytes * 100 >= %s * ri.total_bytes
+)`
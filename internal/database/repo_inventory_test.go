@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoInventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.RepoInventory()
+
+	repo1, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	repo2, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo2"})
+	repo3, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo3"})
+
+	got, err := store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, store.Upsert(ctx, &RepoInventory{
+		RepoID:   repo1.ID,
+		CommitID: "deadbeef",
+		Languages: []RepoLanguage{
+			{Language: "Java", TotalBytes: 700, TotalLines: 70},
+			{Language: "Kotlin", TotalBytes: 300, TotalLines: 30},
+		},
+	}))
+	require.NoError(t, store.Upsert(ctx, &RepoInventory{
+		RepoID:   repo2.ID,
+		CommitID: "cafebabe",
+		Languages: []RepoLanguage{
+			{Language: "Java", TotalBytes: 200, TotalLines: 20},
+			{Language: "Kotlin", TotalBytes: 800, TotalLines: 80},
+		},
+	}))
+
+	got, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, api.CommitID("deadbeef"), got.CommitID)
+	assert.Equal(t, int64(1000), got.TotalBytes())
+	assert.Len(t, got.Languages, 2)
+
+	// Upserting replaces the languages.
+	require.NoError(t, store.Upsert(ctx, &RepoInventory{
+		RepoID:   repo1.ID,
+		CommitID: "f00dcafe",
+		Languages: []RepoLanguage{
+			{Language: "Java", TotalBytes: 600, TotalLines: 60},
+			{Language: "Kotlin", TotalBytes: 400, TotalLines: 40},
+		},
+	}))
+	got, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, api.CommitID("f00dcafe"), got.CommitID)
+	assert.Equal(t, []RepoLanguage{
+		{Language: "Java", TotalBytes: 600, TotalLines: 60},
+		{Language: "Kotlin", TotalBytes: 400, TotalLines: 40},
+	}, got.Languages)
+
+	// The repo list filters are backed by the same tables.
+	listIDs := func(filters ...RepoLanguageFilter) []api.RepoID {
+		t.Helper()
+		repos, err := db.Repos().List(ctx, ReposListOptions{
+			LanguageFilters: filters,
+			OrderBy:         RepoListOrderBy{{Field: RepoListID}},
+		})
+		require.NoError(t, err)
+		return types.Repos(repos).IDs()
+	}
+
+	assert.Equal(t, []api.RepoID{repo1.ID, repo2.ID}, listIDs(RepoLanguageFilter{Language: "Java"}))
+	assert.Equal(t, []api.RepoID{repo1.ID}, listIDs(RepoLanguageFilter{Language: "Java", MinPercent: 60}))
+	assert.Equal(t, []api.RepoID{repo2.ID, repo3.ID}, listIDs(RepoLanguageFilter{Language: "Java", MinPercent: 60, Negated: true}))
+	assert.Equal(t, []api.RepoID{repo2.ID}, listIDs(
+		RepoLanguageFilter{Language: "Kotlin", MinPercent: 50},
+		RepoLanguageFilter{Language: "Java", MinPercent: 10},
+	))
+	assert.Empty(t, listIDs(RepoLanguageFilter{Language: "Go"}))
+}
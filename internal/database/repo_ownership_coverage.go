@@ -0,0 +1,248 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoOwnershipCoverage is the share of files of a repository that have at
+// least one owner according to its CODEOWNERS file, as of a given commit.
+type RepoOwnershipCoverage struct {
+	RepoID   api.RepoID
+	CommitID api.CommitID
+
+	// CodeownersPath is the path of the CODEOWNERS file used to compute the
+	// coverage. It is empty if the repository has no valid CODEOWNERS file.
+	CodeownersPath string
+
+	TotalFiles int
+	OwnedFiles int
+
+	// Directories is the coverage of each top-level directory. Files at the
+	// root of the repository are counted in the directory named "".
+	Directories []DirectoryOwnershipCoverage
+
+	UpdatedAt time.Time
+}
+
+// DirectoryOwnershipCoverage is the ownership coverage of a top-level
+// directory of a repository.
+type DirectoryOwnershipCoverage struct {
+	Directory  string
+	TotalFiles int
+	OwnedFiles int
+}
+
+// RepoOwnershipCoverageListOptions specifies the options for listing
+// ownership coverage.
+type RepoOwnershipCoverageListOptions struct {
+	// RepoIDs, if non-empty, limits the results to the given repositories.
+	RepoIDs []api.RepoID
+
+	// OnlyCodeowners limits the results to repositories with a valid
+	// CODEOWNERS file.
+	OnlyCodeowners bool
+
+	*LimitOffset
+}
+
+type RepoOwnershipCoverageStore interface {
+	basestore.ShareableStore
+
+	With(other basestore.ShareableStore) RepoOwnershipCoverageStore
+	Transact(context.Context) (RepoOwnershipCoverageStore, error)
+
+	// Upsert replaces the ownership coverage of the repository.
+	Upsert(ctx context.Context, coverage *RepoOwnershipCoverage) error
+
+	// Get returns the ownership coverage of the given repository, or nil if
+	// it hasn't been computed yet.
+	Get(ctx context.Context, repoID api.RepoID) (*RepoOwnershipCoverage, error)
+
+	// List returns the ownership coverage of all repositories matching the
+	// given options, ordered by repository ID.
+	List(ctx context.Context, opts RepoOwnershipCoverageListOptions) ([]*RepoOwnershipCoverage, error)
+}
+
+var _ RepoOwnershipCoverageStore = (*repoOwnershipCoverageStore)(nil)
+
+// repoOwnershipCoverageStore is responsible for data stored in the
+// repo_ownership_coverage and repo_ownership_coverage_directories tables.
+type repoOwnershipCoverageStore struct {
+	*basestore.Store
+}
+
+// RepoOwnershipCoverageWith instantiates and returns a new
+// RepoOwnershipCoverageStore using the other store handle.
+func RepoOwnershipCoverageWith(other basestore.ShareableStore) RepoOwnershipCoverageStore {
+	return &repoOwnershipCoverageStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *repoOwnershipCoverageStore) With(other basestore.ShareableStore) RepoOwnershipCoverageStore {
+	return &repoOwnershipCoverageStore{Store: s.Store.With(other)}
+}
+
+func (s *repoOwnershipCoverageStore) Transact(ctx context.Context) (RepoOwnershipCoverageStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &repoOwnershipCoverageStore{Store: txBase}, err
+}
+
+func (s *repoOwnershipCoverageStore) Upsert(ctx context.Context, c *RepoOwnershipCoverage) (err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var codeownersPath *string
+	if c.CodeownersPath != "" {
+		codeownersPath = &c.CodeownersPath
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		upsertRepoOwnershipCoverageQueryFmtstr,
+		c.RepoID,
+		c.CommitID,
+		codeownersPath,
+		c.TotalFiles,
+		c.OwnedFiles,
+	)); err != nil {
+		return err
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteRepoOwnershipCoverageDirectoriesQueryFmtstr, c.RepoID)); err != nil {
+		return err
+	}
+
+	inserter := batch.NewInserter(ctx, tx.Handle(), "repo_ownership_coverage_directories", batch.MaxNumPostgresParameters, "repo_id", "directory", "total_files", "owned_files")
+	for _, d := range c.Directories {
+		if err := inserter.Insert(ctx, c.RepoID, d.Directory, d.TotalFiles, d.OwnedFiles); err != nil {
+			return err
+		}
+	}
+	return inserter.Flush(ctx)
+}
+
+const upsertRepoOwnershipCoverageQueryFmtstr = `
+-- source: internal/database/repo_ownership_coverage.go:repoOwnershipCoverageStore.Upsert
+INSERT INTO repo_ownership_coverage (repo_id, commit_id, codeowners_path, total_files, owned_files, updated_at)
+VALUES (%s, %s, %s, %s, %s, now())
+ON CONFLICT (repo_id) DO UPDATE SET
+	commit_id       = EXCLUDED.commit_id,
+	codeowners_path = EXCLUDED.codeowners_path,
+	total_files     = EXCLUDED.total_files,
+	owned_files     = EXCLUDED.owned_files,
+	updated_at      = EXCLUDED.updated_at
+`
+
+const deleteRepoOwnershipCoverageDirectoriesQueryFmtstr = `
+-- source: internal/database/repo_ownership_coverage.go:repoOwnershipCoverageStore.Upsert
+DELETE FROM repo_ownership_coverage_directories WHERE repo_id = %s
+`
+
+func (s *repoOwnershipCoverageStore) Get(ctx context.Context, repoID api.RepoID) (*RepoOwnershipCoverage, error) {
+	cs, err := s.List(ctx, RepoOwnershipCoverageListOptions{RepoIDs: []api.RepoID{repoID}})
+	if err != nil || len(cs) == 0 {
+		return nil, err
+	}
+	return cs[0], nil
+}
+
+func (s *repoOwnershipCoverageStore) List(ctx context.Context, opts RepoOwnershipCoverageListOptions) (_ []*RepoOwnershipCoverage, err error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("repo.deleted_at IS NULL")}
+	if len(opts.RepoIDs) > 0 {
+		conds = append(conds, sqlf.Sprintf("roc.repo_id = ANY (%s)", pq.Array(opts.RepoIDs)))
+	}
+	if opts.OnlyCodeowners {
+		conds = append(conds, sqlf.Sprintf("roc.codeowners_path IS NOT NULL"))
+	}
+
+	coverages, err := scanRepoOwnershipCoverages(s.Query(ctx, sqlf.Sprintf(
+		listRepoOwnershipCoverageQueryFmtstr,
+		sqlf.Join(conds, "AND"),
+		opts.LimitOffset.SQL(),
+	)))
+	if err != nil || len(coverages) == 0 {
+		return coverages, err
+	}
+
+	ids := make([]api.RepoID, 0, len(coverages))
+	byID := make(map[api.RepoID]*RepoOwnershipCoverage, len(coverages))
+	for _, c := range coverages {
+		ids = append(ids, c.RepoID)
+		byID[c.RepoID] = c
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listRepoOwnershipCoverageDirectoriesQueryFmtstr, pq.Array(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var (
+			repoID api.RepoID
+			d      DirectoryOwnershipCoverage
+		)
+		if err := rows.Scan(&repoID, &d.Directory, &d.TotalFiles, &d.OwnedFiles); err != nil {
+			return nil, err
+		}
+		byID[repoID].Directories = append(byID[repoID].Directories, d)
+	}
+
+	return coverages, nil
+}
+
+var scanRepoOwnershipCoverages = basestore.NewSliceScanner(scanRepoOwnershipCoverage)
+
+func scanRepoOwnershipCoverage(sc dbutil.Scanner) (*RepoOwnershipCoverage, error) {
+	var (
+		c              RepoOwnershipCoverage
+		codeownersPath sql.NullString
+	)
+	if err := sc.Scan(
+		&c.RepoID,
+		&c.CommitID,
+		&codeownersPath,
+		&c.TotalFiles,
+		&c.OwnedFiles,
+		&c.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	c.CodeownersPath = codeownersPath.String
+	return &c, nil
+}
+
+const listRepoOwnershipCoverageQueryFmtstr = `
+-- source: internal/database/repo_ownership_coverage.go:repoOwnershipCoverageStore.List
+SELECT
+	roc.repo_id,
+	roc.commit_id,
+	roc.codeowners_path,
+	roc.total_files,
+	roc.owned_files,
+	roc.updated_at
+FROM repo_ownership_coverage roc
+JOIN repo ON repo.id = roc.repo_id
+WHERE %s
+ORDER BY roc.repo_id
+%s
+`
+
+const listRepoOwnershipCoverageDirectoriesQueryFmtstr = `
+-- source: internal/database/repo_ownership_coverage.go:repoOwnershipCoverageStore.List
+SELECT repo_id, directory, total_files, owned_files
+FROM repo_ownership_coverage_directories
+WHERE repo_id = ANY (%s)
+ORDER BY repo_id, directory
+`
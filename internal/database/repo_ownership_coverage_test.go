@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRepoOwnershipCoverage(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.RepoOwnershipCoverage()
+
+	repo1, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo1"})
+	repo2, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{Name: "repo2"})
+
+	got, err := store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, store.Upsert(ctx, &RepoOwnershipCoverage{
+		RepoID:         repo1.ID,
+		CommitID:       "deadbeef",
+		CodeownersPath: ".github/CODEOWNERS",
+		TotalFiles:     3,
+		OwnedFiles:     2,
+		Directories: []DirectoryOwnershipCoverage{
+			{Directory: "", TotalFiles: 1, OwnedFiles: 0},
+			{Directory: "cmd", TotalFiles: 2, OwnedFiles: 2},
+		},
+	}))
+	require.NoError(t, store.Upsert(ctx, &RepoOwnershipCoverage{
+		RepoID:     repo2.ID,
+		CommitID:   "cafebabe",
+		TotalFiles: 1,
+		Directories: []DirectoryOwnershipCoverage{
+			{Directory: "", TotalFiles: 1},
+		},
+	}))
+
+	got, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ".github/CODEOWNERS", got.CodeownersPath)
+	assert.Equal(t, 2, got.OwnedFiles)
+	assert.Len(t, got.Directories, 2)
+
+	// Upserting replaces the directories.
+	require.NoError(t, store.Upsert(ctx, &RepoOwnershipCoverage{
+		RepoID:         repo1.ID,
+		CommitID:       "f00dcafe",
+		CodeownersPath: "CODEOWNERS",
+		TotalFiles:     1,
+		OwnedFiles:     1,
+		Directories: []DirectoryOwnershipCoverage{
+			{Directory: "cmd", TotalFiles: 1, OwnedFiles: 1},
+		},
+	}))
+	got, err = store.Get(ctx, repo1.ID)
+	require.NoError(t, err)
+	assert.Equal(t, api.CommitID("f00dcafe"), got.CommitID)
+	assert.Equal(t, []DirectoryOwnershipCoverage{{Directory: "cmd", TotalFiles: 1, OwnedFiles: 1}}, got.Directories)
+
+	all, err := store.List(ctx, RepoOwnershipCoverageListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	withCodeowners, err := store.List(ctx, RepoOwnershipCoverageListOptions{OnlyCodeowners: true})
+	require.NoError(t, err)
+	require.Len(t, withCodeowners, 1)
+	assert.Equal(t, repo1.ID, withCodeowners[0].RepoID)
+
+	// The repo list filters are backed by the same table.
+	repos, err := db.Repos().List(ctx, ReposListOptions{OnlyCodeowners: true})
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, repo1.ID, repos[0].ID)
+
+	repos, err = db.Repos().List(ctx, ReposListOptions{NoCodeowners: true})
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, repo2.ID, repos[0].ID)
+}
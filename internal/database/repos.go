@@ -29,9 +29,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -553,12 +555,16 @@ func scanRepo(logger log.Logger, rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(awscodecommit.Repository)
 	case extsvc.TypeGitolite:
 		r.Metadata = new(gitolite.Repo)
+	case extsvc.TypeGitea:
+		r.Metadata = new(gitea.Repository)
 	case extsvc.TypePerforce:
 		r.Metadata = new(perforce.Depot)
 	case extsvc.TypePhabricator:
 		r.Metadata = new(phabricator.Repo)
 	case extsvc.TypePagure:
 		r.Metadata = new(pagure.Project)
+	case extsvc.TypeAzureDevOps:
+		r.Metadata = new(azuredevops.Repository)
 	case extsvc.TypeOther:
 		r.Metadata = new(extsvc.OtherRepoMetadata)
 	case extsvc.TypeJVMPackages:
@@ -631,6 +637,9 @@ type ReposListOptions struct {
 	// IDs of repos to list. When zero-valued, this is omitted from the predicate set.
 	IDs []api.RepoID
 
+	// ExcludeIDs of repos to exclude from the list. When zero-valued, this is omitted from the predicate set.
+	ExcludeIDs []api.RepoID
+
 	// UserID, if non zero, will limit the set of results to repositories added by the user
 	// through external services. Mutually exclusive with the ExternalServiceIDs and SearchContextID options.
 	UserID int32
@@ -683,6 +692,18 @@ type ReposListOptions struct {
 	// OnlyIndexed excludes repositories that are not indexed by zoekt from the list.
 	OnlyIndexed bool
 
+	// NoCodeowners excludes repositories with a valid CODEOWNERS file from the list.
+	NoCodeowners bool
+
+	// OnlyCodeowners excludes repositories without a valid CODEOWNERS file from the list.
+	OnlyCodeowners bool
+
+	// LanguageFilters filters repositories by the share of their code written
+	// in a language, according to their inventory in the repo_inventory table.
+	// Repositories whose inventory hasn't been computed yet match only
+	// negated filters.
+	LanguageFilters []RepoLanguageFilter
+
 	// CloneStatus if set will only return repos of that clone status.
 	CloneStatus types.CloneStatus
 
@@ -983,6 +1004,10 @@ func (s *repoStore) listSQL(ctx context.Context, tr *trace.Trace, opt ReposListO
 		where = append(where, sqlf.Sprintf("id = ANY (%s)", pq.Array(opt.IDs)))
 	}
 
+	if len(opt.ExcludeIDs) > 0 {
+		where = append(where, sqlf.Sprintf("NOT (id = ANY (%s))", pq.Array(opt.ExcludeIDs)))
+	}
+
 	if len(opt.ExternalRepos) > 0 {
 		er := make([]*sqlf.Query, 0, len(opt.ExternalRepos))
 		for _, spec := range opt.ExternalRepos {
@@ -1037,6 +1062,15 @@ func (s *repoStore) listSQL(ctx context.Context, tr *trace.Trace, opt ReposListO
 	if opt.OnlyIndexed {
 		where = append(where, sqlf.Sprintf("zr.index_status = 'indexed'"))
 	}
+	if opt.NoCodeowners {
+		where = append(where, sqlf.Sprintf("NOT EXISTS (SELECT 1 FROM repo_ownership_coverage roc WHERE roc.repo_id = repo.id AND roc.codeowners_path IS NOT NULL)"))
+	}
+	if opt.OnlyCodeowners {
+		where = append(where, sqlf.Sprintf("EXISTS (SELECT 1 FROM repo_ownership_coverage roc WHERE roc.repo_id = repo.id AND roc.codeowners_path IS NOT NULL)"))
+	}
+	for _, f := range opt.LanguageFilters {
+		where = append(where, repoLanguageFilterQuery(f))
+	}
 
 	if opt.FailedFetch {
 		where = append(where, sqlf.Sprintf("gr.last_error IS NOT NULL"))
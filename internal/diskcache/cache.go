@@ -6,12 +6,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
-	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/opentracing/opentracing-go/ext"
@@ -31,7 +31,9 @@ type Store interface {
 	// will fill the cache first. OpenWithPath also performs single-flighting for fetcher.
 	OpenWithPath(ctx context.Context, key []string, fetcher FetcherWithPath) (file *File, err error)
 	// Evict will remove files from store.Dir until it is smaller than
-	// maxCacheSizeBytes. It evicts files with the oldest modification time first.
+	// maxCacheSizeBytes. The order in which files are evicted is decided by the
+	// store's EvictionPolicy, which by default evicts files with the oldest
+	// modification time first. Pinned files are never evicted.
 	Evict(maxCacheSizeBytes int64) (stats EvictStats, err error)
 }
 
@@ -53,6 +55,27 @@ type store struct {
 	// which can be used to attach fields to a Honeycomb event.
 	beforeEvict func(string, observation.TraceLogger)
 
+	// policy decides in which order files are evicted.
+	policy EvictionPolicy
+
+	// pinned, when non-nil, returns key prefixes of entries which must never
+	// be evicted.
+	pinned func() [][]string
+
+	// highWaterMarkBytes when non-zero is the cache size above which fetches
+	// synchronously evict files until the cache is smaller than
+	// lowWaterMarkBytes, rather than waiting for the next call to Evict.
+	highWaterMarkBytes int64
+	lowWaterMarkBytes  int64
+
+	// mu serializes evictions and protects size and sizeKnown.
+	mu sync.Mutex
+	// size is the size of the cache after the last eviction, plus the size of
+	// the entries fetched since then. It is only tracked if
+	// highWaterMarkBytes is set.
+	size      int64
+	sizeKnown bool
+
 	observe *operations
 }
 
@@ -60,12 +83,16 @@ type store struct {
 //
 // It can optionally be configured with a background timeout
 // (with `diskcache.WithBackgroundTimeout`), a pre-evict callback
-// (with `diskcache.WithBeforeEvict`) and with a configured observation context
-// (with `diskcache.WithobservationCtx`).
+// (with `diskcache.WithBeforeEvict`), an eviction policy
+// (with `diskcache.WithEvictionPolicy`), a set of pinned entries
+// (with `diskcache.WithPinned`), a high-water mark
+// (with `diskcache.WithHighWaterMark`) and with a configured observation
+// context (with `diskcache.WithobservationCtx`).
 func NewStore(dir, component string, opts ...StoreOpt) Store {
 	s := &store{
 		dir:       dir,
 		component: component,
+		policy:    LRU(),
 	}
 
 	for _, opt := range opts {
@@ -89,6 +116,31 @@ func WithBeforeEvict(f func(string, observation.TraceLogger)) func(*store) {
 	return func(s *store) { s.beforeEvict = f }
 }
 
+// WithEvictionPolicy sets the policy deciding which files are evicted first.
+func WithEvictionPolicy(p EvictionPolicy) func(*store) {
+	return func(s *store) { s.policy = p }
+}
+
+// WithPinned sets a function returning key prefixes of entries which are
+// never evicted. For example, if keys start with a repository name, returning
+// [][]string{{"github.com/sourcegraph/sourcegraph"}} pins all entries of that
+// repository. The function is called on every eviction, so the set of pinned
+// entries can change over time.
+func WithPinned(pinned func() [][]string) func(*store) {
+	return func(s *store) { s.pinned = pinned }
+}
+
+// WithHighWaterMark makes fetches check the size of the cache. When it grows
+// above highWaterMarkBytes, files are evicted until it is smaller than
+// lowWaterMarkBytes before the fetch returns. This protects the disk from
+// bursts of fetches happening between two calls to Evict.
+func WithHighWaterMark(highWaterMarkBytes, lowWaterMarkBytes int64) func(*store) {
+	return func(s *store) {
+		s.highWaterMarkBytes = highWaterMarkBytes
+		s.lowWaterMarkBytes = lowWaterMarkBytes
+	}
+}
+
 func WithobservationCtx(ctx *observation.Context) func(*store) {
 	return func(s *store) { s.observe = newOperations(ctx, s.component) }
 }
@@ -139,6 +191,9 @@ func (s *store) OpenWithPath(ctx context.Context, key []string, fetcher FetcherW
 			// Update modified time. Modified time is used to decide which
 			// files to evict from the cache.
 			touch(file.Path)
+			if s.policy != nil {
+				s.policy.Accessed(file.Path, time.Now())
+			}
 		}
 	}()
 
@@ -183,7 +238,11 @@ func (s *store) OpenWithPath(ctx context.Context, key []string, fetcher FetcherW
 			ctx, cancel = withIsolatedTimeout(ctx, s.backgroundTimeout)
 			defer cancel()
 		}
-		f, err = doFetch(ctx, path, fetcher, trace)
+		var fetched bool
+		f, fetched, err = doFetch(ctx, path, fetcher, trace)
+		if err == nil && fetched {
+			s.checkHighWaterMark(f, trace)
+		}
 		ch <- result{f, err}
 	}(ctx)
 
@@ -214,7 +273,10 @@ func EncodeKeyComponents(components []string) []string {
 	return encoded
 }
 
-func doFetch(ctx context.Context, path string, fetcher FetcherWithPath, trace observation.TraceLogger) (file *File, err error) {
+// doFetch opens the file at path, fetching it first if it doesn't exist yet.
+// fetched is true if this call created the file, rather than another call
+// that held the lock for path before us.
+func doFetch(ctx context.Context, path string, fetcher FetcherWithPath, trace observation.TraceLogger) (file *File, fetched bool, err error) {
 	// We have to grab the lock for this key, so we can fetch or wait for
 	// someone else to finish fetching.
 	urlMu := urlMu(path)
@@ -226,21 +288,21 @@ func doFetch(ctx context.Context, path string, fetcher FetcherWithPath, trace ob
 
 	// Since we acquired the lock we may have timed out.
 	if ctx.Err() != nil {
-		return nil, ctx.Err()
+		return nil, false, ctx.Err()
 	}
 
 	// Since we acquired urlMu, someone else may have put the archive onto
 	// the disk.
 	f, err := os.Open(path)
 	if err == nil {
-		return &File{File: f, Path: path}, nil
+		return &File{File: f, Path: path}, false, nil
 	}
 	// Just in case we failed due to something bad on the FS, remove
 	_ = os.Remove(path)
 
 	// Fetch since we still can't open up the file
 	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
-		return nil, errors.Wrap(err, "could not create archive cache dir")
+		return nil, false, errors.Wrap(err, "could not create archive cache dir")
 	}
 
 	// We write to a temporary path to prevent another Open finding a
@@ -248,8 +310,15 @@ func doFetch(ctx context.Context, path string, fetcher FetcherWithPath, trace ob
 	// it.
 	tmpPath := path + ".part"
 	f, err = os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if os.IsNotExist(err) {
+		// A janitor may have removed the directory because it was empty.
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, false, errors.Wrap(err, "could not create archive cache dir")
+		}
+		f, err = os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	}
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create temporary archive cache item")
+		return nil, false, errors.Wrap(err, "failed to create temporary archive cache item")
 	}
 	f.Close()
 	defer os.Remove(tmpPath)
@@ -257,31 +326,31 @@ func doFetch(ctx context.Context, path string, fetcher FetcherWithPath, trace ob
 	// We are now ready to actually fetch the file.
 	err = fetcher(ctx, tmpPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to fetch missing archive cache item")
+		return nil, false, errors.Wrap(err, "failed to fetch missing archive cache item")
 	}
 
 	// Sync the contents to disk. If we crash we don't want to leave behind
 	// invalid zip files due to unwritten OS buffers.
 	if err := fsync(tmpPath); err != nil {
-		return nil, errors.Wrap(err, "failed to sync cache item to disk")
+		return nil, false, errors.Wrap(err, "failed to sync cache item to disk")
 	}
 
 	// Put the partially written file in the correct place and open
 	err = os.Rename(tmpPath, path)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to put cache item in place")
+		return nil, false, errors.Wrap(err, "failed to put cache item in place")
 	}
 
 	// Sync the directory. We need to ensure the rename is recorded to disk.
 	if err := fsync(filepath.Dir(path)); err != nil {
-		return nil, errors.Wrap(err, "failed to sync cache directory to disk")
+		return nil, false, errors.Wrap(err, "failed to sync cache directory to disk")
 	}
 
 	f, err = os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return &File{File: f, Path: path}, nil
+	return &File{File: f, Path: path}, true, nil
 }
 
 // EvictStats is information gathered during Evict.
@@ -291,24 +360,60 @@ type EvictStats struct {
 
 	// Evicted is the number of items evicted.
 	Evicted int
+
+	// Pinned is the number of items which were not evicted because they are
+	// pinned.
+	Pinned int
 }
 
 func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
-	_, trace, endObservation := s.observe.evict.With(context.Background(), &err, observation.Args{LogFields: []otelog.Field{
-		otelog.Int64("maxCacheSizeBytes", maxCacheSizeBytes),
-	}})
-	endObservation(1, observation.Args{})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.evictLocked(maxCacheSizeBytes, "")
+}
+
+// checkHighWaterMark evicts files if fetching f made the cache grow above the
+// high-water mark. f itself is never evicted.
+func (s *store) checkHighWaterMark(f *File, trace observation.TraceLogger) {
+	if s.highWaterMarkBytes <= 0 {
+		return
+	}
+
+	fi, err := os.Stat(f.Path)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	isZip := func(fi fs.FileInfo) bool {
-		return strings.HasSuffix(fi.Name(), ".zip")
+	if s.sizeKnown {
+		s.size += fi.Size()
+	} else {
+		entries, err := s.entries()
+		if err != nil {
+			log.Printf("failed to compute size of %s: %s", s.dir, err)
+			return
+		}
+		s.size, s.sizeKnown = entriesSize(entries), true
 	}
 
-	type absFileInfo struct {
-		absPath string
-		info    fs.FileInfo
+	if s.size <= s.highWaterMarkBytes {
+		return
 	}
-	entries := []absFileInfo{}
-	err = filepath.Walk(s.dir,
+
+	trace.AddEvent("cache size above high-water mark",
+		attribute.Int64("sizeBytes", s.size),
+		attribute.Int64("highWaterMarkBytes", s.highWaterMarkBytes))
+	if _, err := s.evictLocked(s.lowWaterMarkBytes, f.Path); err != nil {
+		log.Printf("failed to evict from %s: %s", s.dir, err)
+	}
+}
+
+// entries returns all files in s.dir.
+func (s *store) entries() ([]Entry, error) {
+	entries := []Entry{}
+	err := filepath.Walk(s.dir,
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				if os.IsNotExist(err) {
@@ -321,42 +426,111 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 				return err
 			}
 			if !info.IsDir() {
-				entries = append(entries, absFileInfo{absPath: path, info: info})
+				entries = append(entries, Entry{Path: path, Size: info.Size(), ModTime: info.ModTime()})
 			}
 			return nil
 		})
-	if err != nil {
-		if os.IsNotExist(err) {
-			return stats, nil
+	if err != nil && os.IsNotExist(err) {
+		return entries, nil
+	}
+	return entries, err
+}
+
+func entriesSize(entries []Entry) (size int64) {
+	for _, entry := range entries {
+		size += entry.Size
+	}
+	return size
+}
+
+// isPinned returns a function which reports whether the file at path belongs
+// to an entry whose key starts with one of the pinned key prefixes.
+func (s *store) isPinned() func(path string) bool {
+	if s.pinned == nil {
+		return func(string) bool { return false }
+	}
+
+	prefixes := []string{}
+	for _, key := range s.pinned() {
+		if len(key) == 0 {
+			continue
 		}
+		prefixes = append(prefixes, filepath.Join(append([]string{s.dir}, EncodeKeyComponents(key)...)...))
+	}
+
+	return func(path string) bool {
+		for _, prefix := range prefixes {
+			if path == prefix+".zip" || strings.HasPrefix(path, prefix+string(filepath.Separator)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// evictLocked removes files until the cache is smaller than
+// maxCacheSizeBytes, never evicting the file at keep. The caller must hold
+// s.mu.
+func (s *store) evictLocked(maxCacheSizeBytes int64, keep string) (stats EvictStats, err error) {
+	_, trace, endObservation := s.observe.evict.With(context.Background(), &err, observation.Args{LogFields: []otelog.Field{
+		otelog.Int64("maxCacheSizeBytes", maxCacheSizeBytes),
+	}})
+	endObservation(1, observation.Args{})
+
+	isZip := func(path string) bool {
+		return strings.HasSuffix(path, ".zip")
+	}
+
+	entries, err := s.entries()
+	if err != nil {
 		return stats, errors.Wrapf(err, "failed to ReadDir %s", s.dir)
 	}
 
 	// Sum up the total size of all zips
-	var size int64
-	for _, entry := range entries {
-		size += entry.info.Size()
-	}
+	size := entriesSize(entries)
 	stats.CacheSize = size
+	defer func() {
+		s.size, s.sizeKnown = size, true
+	}()
 
 	// Nothing to evict
 	if size <= maxCacheSizeBytes {
 		return stats, nil
 	}
 
+	policy := s.policy
+	if policy == nil {
+		policy = LRU()
+	}
+
 	// Keep removing files until we are under the cache size. Remove the
-	// oldest first.
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].info.ModTime().Before(entries[j].info.ModTime())
+	// files with the lowest score first.
+	now := time.Now()
+	scores := make(map[string]float64, len(entries))
+	for _, entry := range entries {
+		scores[entry.Path] = policy.Score(entry, now)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		si, sj := scores[entries[i].Path], scores[entries[j].Path]
+		if si != sj {
+			return si < sj
+		}
+		return entries[i].ModTime.Before(entries[j].ModTime)
 	})
+
+	isPinned := s.isPinned()
 	for _, entry := range entries {
 		if size <= maxCacheSizeBytes {
 			break
 		}
-		if !isZip(entry.info) {
+		if !isZip(entry.Path) || entry.Path == keep {
+			continue
+		}
+		path := entry.Path
+		if isPinned(path) {
+			stats.Pinned++
 			continue
 		}
-		path := entry.absPath
 		if s.beforeEvict != nil {
 			s.beforeEvict(path, trace)
 		}
@@ -366,12 +540,14 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 			log.Printf("failed to remove %s: %s", path, err)
 			continue
 		}
+		policy.Removed(path)
 		stats.Evicted++
-		size -= entry.info.Size()
+		size -= entry.Size
 	}
 
 	trace.SetAttributes(
 		attribute.Int("evicted", stats.Evicted),
+		attribute.Int("pinned", stats.Pinned),
 		attribute.Int64("beforeSizeBytes", stats.CacheSize),
 		attribute.Int64("afterSizeBytes", size),
 	)
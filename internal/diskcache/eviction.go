@@ -0,0 +1,154 @@
+package diskcache
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Entry is a file in the cache which is considered for eviction.
+type Entry struct {
+	// Path is the path to the file on disk.
+	Path string
+
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// ModTime is the modification time of the file, which is updated every
+	// time the entry is opened.
+	ModTime time.Time
+}
+
+// EvictionPolicy decides which entries are evicted first when the cache is
+// larger than its maximum size.
+type EvictionPolicy interface {
+	// Accessed is called every time the entry at path is opened.
+	Accessed(path string, now time.Time)
+
+	// Removed is called after the entry at path has been evicted.
+	Removed(path string)
+
+	// Score returns how valuable it is to keep e in the cache. Entries with
+	// the lowest score are evicted first. Ties are broken by evicting the
+	// least recently used entry first.
+	Score(e Entry, now time.Time) float64
+}
+
+// ParseEvictionPolicy returns the eviction policy with the given name. It is
+// meant to be used to configure the policy from an environment variable.
+//
+// The supported names are "lru" (the default when name is empty),
+// "size-weighted-lru" and "lfu".
+func ParseEvictionPolicy(name string) (EvictionPolicy, error) {
+	switch strings.ToLower(name) {
+	case "", "lru":
+		return LRU(), nil
+	case "size-weighted-lru":
+		return SizeWeightedLRU(), nil
+	case "lfu":
+		return NewLFU(defaultLFUHalfLife), nil
+	default:
+		return nil, errors.Errorf("unknown eviction policy %q", name)
+	}
+}
+
+// LRU returns a policy which evicts the least recently used entries first.
+func LRU() EvictionPolicy {
+	return lru{}
+}
+
+type lru struct{}
+
+func (lru) Accessed(string, time.Time) {}
+func (lru) Removed(string)             {}
+
+func (lru) Score(e Entry, now time.Time) float64 {
+	return -now.Sub(e.ModTime).Seconds()
+}
+
+// SizeWeightedLRU returns a policy which evicts entries with the largest
+// product of size and time since last use first. A large archive which hasn't
+// been used for a while is evicted before many small ones, which frees up
+// space with fewer cache misses.
+func SizeWeightedLRU() EvictionPolicy {
+	return sizeWeightedLRU{}
+}
+
+type sizeWeightedLRU struct{}
+
+func (sizeWeightedLRU) Accessed(string, time.Time) {}
+func (sizeWeightedLRU) Removed(string)             {}
+
+func (sizeWeightedLRU) Score(e Entry, now time.Time) float64 {
+	age := now.Sub(e.ModTime).Seconds()
+	if age < 0 {
+		age = 0
+	}
+	// Add a second so that the size still counts for entries that were just
+	// used.
+	return -(age + 1) * float64(e.Size)
+}
+
+// defaultLFUHalfLife is the half-life of access counts used by the "lfu"
+// policy returned by ParseEvictionPolicy.
+const defaultLFUHalfLife = time.Hour
+
+// NewLFU returns a policy which evicts the least frequently used entries
+// first. Access counts are aged: they halve every halfLife, so that entries
+// which were popular a long time ago eventually get evicted.
+//
+// Access counts are kept in memory. Entries which haven't been opened since
+// the process started have a count of zero.
+func NewLFU(halfLife time.Duration) EvictionPolicy {
+	return &lfu{
+		halfLife: halfLife,
+		counts:   make(map[string]lfuCount),
+	}
+}
+
+type lfu struct {
+	halfLife time.Duration
+
+	mu     sync.Mutex
+	counts map[string]lfuCount
+}
+
+type lfuCount struct {
+	count float64
+	last  time.Time
+}
+
+// decayed returns the value of c at now.
+func (p *lfu) decayed(c lfuCount, now time.Time) float64 {
+	elapsed := now.Sub(c.last)
+	if elapsed <= 0 || p.halfLife <= 0 {
+		return c.count
+	}
+	return c.count * math.Exp2(-float64(elapsed)/float64(p.halfLife))
+}
+
+func (p *lfu) Accessed(path string, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c := p.counts[path]
+	p.counts[path] = lfuCount{count: p.decayed(c, now) + 1, last: now}
+}
+
+func (p *lfu) Removed(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.counts, path)
+}
+
+func (p *lfu) Score(e Entry, now time.Time) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c, ok := p.counts[e.Path]
+	if !ok {
+		return 0
+	}
+	return p.decayed(c, now)
+}
@@ -0,0 +1,193 @@
+package diskcache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestEvictionPolicies(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{Path: "old-small", Size: 1, ModTime: now.Add(-2 * time.Hour)},
+		{Path: "recent-big", Size: 1000, ModTime: now.Add(-time.Hour)},
+		{Path: "new-small", Size: 1, ModTime: now},
+	}
+
+	lowest := func(p EvictionPolicy) string {
+		t.Helper()
+		var (
+			path  string
+			score float64
+		)
+		for i, e := range entries {
+			if s := p.Score(e, now); i == 0 || s < score {
+				path, score = e.Path, s
+			}
+		}
+		return path
+	}
+
+	if got, want := lowest(LRU()), "old-small"; got != want {
+		t.Errorf("LRU: evicted %q first, want %q", got, want)
+	}
+
+	if got, want := lowest(SizeWeightedLRU()), "recent-big"; got != want {
+		t.Errorf("SizeWeightedLRU: evicted %q first, want %q", got, want)
+	}
+
+	lfu := NewLFU(time.Hour)
+	for i := 0; i < 8; i++ {
+		lfu.Accessed("old-small", now.Add(-2*time.Hour))
+	}
+	lfu.Accessed("recent-big", now.Add(-time.Hour))
+	lfu.Accessed("recent-big", now.Add(-time.Hour))
+	lfu.Accessed("new-small", now)
+	// old-small was used 8 times two half-lives ago, so its count has decayed
+	// to 2. recent-big was used twice one half-life ago, so its count is 1.
+	if got, want := lowest(lfu), "recent-big"; got != want {
+		t.Errorf("LFU: evicted %q first, want %q", got, want)
+	}
+	if got, want := lfu.Score(entries[0], now), 2.0; got != want {
+		t.Errorf("LFU: got score %f for old-small, want %f", got, want)
+	}
+
+	lfu.Removed("new-small")
+	if got, want := lowest(lfu), "new-small"; got != want {
+		t.Errorf("LFU: evicted %q first after removal, want %q", got, want)
+	}
+}
+
+func TestParseEvictionPolicy(t *testing.T) {
+	for _, name := range []string{"", "lru", "LRU", "size-weighted-lru", "lfu"} {
+		if _, err := ParseEvictionPolicy(name); err != nil {
+			t.Errorf("ParseEvictionPolicy(%q): unexpected error: %s", name, err)
+		}
+	}
+	if _, err := ParseEvictionPolicy("fifo"); err == nil {
+		t.Error("ParseEvictionPolicy(\"fifo\"): expected error")
+	}
+}
+
+func TestEvictPinned(t *testing.T) {
+	dir := t.TempDir()
+
+	store := &store{
+		dir:       dir,
+		component: "test",
+		policy:    LRU(),
+		pinned: func() [][]string {
+			return [][]string{{"github.com/sourcegraph/pinned"}}
+		},
+		observe: newOperations(&observation.TestContext, "test"),
+	}
+
+	open := func(key ...string) string {
+		t.Helper()
+		f, err := store.Open(context.Background(), key, func(ctx context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("x"))), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		return f.Path
+	}
+
+	pinned := open("github.com/sourcegraph/pinned", "deadbeef")
+	other := open("github.com/sourcegraph/other", "deadbeef")
+
+	stats, err := store.Evict(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Evicted != 1 || stats.Pinned != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if _, err := os.Stat(pinned); err != nil {
+		t.Fatalf("pinned entry was evicted: %s", err)
+	}
+	if _, err := os.Stat(other); !os.IsNotExist(err) {
+		t.Fatalf("expected unpinned entry to be evicted, got %v", err)
+	}
+}
+
+func TestHighWaterMark(t *testing.T) {
+	dir := t.TempDir()
+
+	store := &store{
+		dir:                dir,
+		component:          "test",
+		policy:             LRU(),
+		highWaterMarkBytes: 3,
+		lowWaterMarkBytes:  1,
+		observe:            newOperations(&observation.TestContext, "test"),
+	}
+
+	var paths []string
+	for _, key := range []string{"first", "second", "third", "fourth"} {
+		f, err := store.Open(context.Background(), []string{key}, func(ctx context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("x"))), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		paths = append(paths, f.Path)
+	}
+
+	// Fetching the fourth file made the cache grow above the high-water mark,
+	// so everything but the file we just fetched got evicted.
+	for i, path := range paths {
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != (i == len(paths)-1) {
+			t.Errorf("%d: unexpected existence of %s: %v", i, path, err)
+		}
+	}
+	if store.size != 1 {
+		t.Errorf("unexpected tracked size: got %d, want 1", store.size)
+	}
+}
+
+func TestHighWaterMarkConcurrentFetch(t *testing.T) {
+	dir := t.TempDir()
+
+	store := &store{
+		dir:                dir,
+		component:          "test",
+		policy:             LRU(),
+		highWaterMarkBytes: 10,
+		lowWaterMarkBytes:  1,
+		observe:            newOperations(&observation.TestContext, "test"),
+	}
+
+	// Both calls miss the cache. Only one of them fetches the file, the other
+	// one opens it once the fetch is done.
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f, err := store.Open(context.Background(), []string{"key"}, func(ctx context.Context) (io.ReadCloser, error) {
+				time.Sleep(100 * time.Millisecond)
+				return io.NopCloser(bytes.NewReader([]byte("x"))), nil
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			f.Close()
+		}()
+	}
+	wg.Wait()
+
+	if store.size != 1 {
+		t.Errorf("unexpected tracked size: got %d, want 1", store.size)
+	}
+}
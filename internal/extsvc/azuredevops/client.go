@@ -0,0 +1,277 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// apiVersion is the version of the Azure DevOps REST API used by the
+	// client. 6.0 is the newest version supported by both Azure DevOps
+	// Services and Azure DevOps Server 2020.
+	apiVersion = "6.0"
+
+	// continuationTokenHeader is the response header that carries the token
+	// for the next page of a paginated list.
+	continuationTokenHeader = "X-Ms-Continuationtoken"
+)
+
+// Client access an Azure DevOps instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client.
+	Config *schema.AzureDevOpsConnection
+
+	// URL is the base URL of Azure DevOps, https://dev.azure.com for
+	// Azure DevOps Services or the collection URL for Azure DevOps Server.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API.
+	httpClient httpcli.Doer
+
+	// auth is used to authenticate requests. It defaults to the username and
+	// personal access token of the code host connection.
+	auth auth.Authenticator
+
+	// RateLimit is the self-imposed rate limiter (since Azure DevOps throttles
+	// based on resource usage rather than advertised rate limits).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Azure DevOps API client with the
+// provided configuration. If a nil httpClient is provided,
+// httpcli.ExternalDoer will be used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		auth:       &auth.BasicAuth{Username: config.Username, Password: config.Token},
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// WithAuthenticator returns a copy of the client that authenticates requests
+// with the given authenticator instead of the code host connection
+// credentials.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	return &Client{
+		Config:     c.Config,
+		URL:        c.URL,
+		httpClient: c.httpClient,
+		auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
+// ListProjectsArgs defines options to be set on ListProjects method calls.
+type ListProjectsArgs struct {
+	// Org is the organization to list the projects of.
+	Org string
+	// Top is the maximum number of projects returned per page.
+	Top int
+	// ContinuationToken is the token returned by the previous call, or
+	// empty to start from the first page.
+	ContinuationToken string
+}
+
+// ListProjects returns a single page of projects of the given organization,
+// as well as the continuation token for the next page. The continuation token
+// is empty when there are no more pages.
+func (c *Client) ListProjects(ctx context.Context, opts ListProjectsArgs) ([]*Project, string, error) {
+	qs := make(url.Values)
+	if opts.Top > 0 {
+		qs.Set("$top", strconv.Itoa(opts.Top))
+	}
+	if opts.ContinuationToken != "" {
+		qs.Set("continuationToken", opts.ContinuationToken)
+	}
+
+	u := url.URL{Path: fmt.Sprintf("%s/_apis/projects", url.PathEscape(opts.Org)), RawQuery: qs.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var resp listResponse[*Project]
+	httpResp, err := c.do(ctx, req, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Value, httpResp.Header.Get(continuationTokenHeader), nil
+}
+
+// ListRepositoriesByProject returns all Git repositories of the given project.
+// The Azure DevOps API does not paginate this endpoint.
+func (c *Client) ListRepositoriesByProject(ctx context.Context, org, project string) ([]*Repository, error) {
+	u := url.URL{Path: fmt.Sprintf("%s/%s/_apis/git/repositories", url.PathEscape(org), url.PathEscape(project))}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*Repository]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	// Resolve relative to the base URL, keeping the path of Azure DevOps
+	// Server collections such as https://tfs.example.com/tfs/.
+	base := *c.URL
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	req.URL = base.ResolveReference(req.URL)
+
+	// Some endpoints are only available in preview versions of the API, so
+	// requests may set their own version.
+	qs := req.URL.Query()
+	if qs.Get("api-version") == "" {
+		qs.Set("api-version", apiVersion)
+	}
+	req.URL.RawQuery = qs.Encode()
+
+	if req.Header.Get("Content-Type") == "" && req.Method != "GET" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if err := c.auth.Authenticate(req); err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps redirects unauthenticated requests to a sign-in page
+	// with a 203 status code instead of returning 401.
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 || resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result == nil {
+		return resp, nil
+	}
+	return resp, json.Unmarshal(bs, result)
+}
+
+// listResponse is the envelope of all list endpoints of the Azure DevOps API.
+type listResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+// Project is an Azure DevOps project, which groups repositories.
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	Visibility  string `json:"visibility"`
+}
+
+// Repository is a Git repository within an Azure DevOps project.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int64   `json:"size"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled"`
+	IsFork        bool    `json:"isFork"`
+}
+
+// Org returns the name of the organization the repository belongs to,
+// extracted from its web URL.
+func (r *Repository) Org() (string, error) {
+	u, err := url.Parse(r.WebURL)
+	if err != nil {
+		return "", err
+	}
+	// Web URLs are of the form <base>/<org>/<project>/_git/<repo>.
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "_git" {
+		return "", errors.Errorf("unexpected Azure DevOps repository URL %q", r.WebURL)
+	}
+	return parts[len(parts)-4], nil
+}
+
+// IsPrivate returns true if the repository belongs to a private project.
+func (r *Repository) IsPrivate() bool {
+	return r.Project.Visibility != "public"
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusNonAuthoritativeInfo
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+func (e *httpError) HTTPStatusCode() int {
+	return e.StatusCode
+}
@@ -0,0 +1,79 @@
+package azuredevops
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestClient_ListProjects(t *testing.T) {
+	cli, save := NewTestClient(t, "ListProjects", *update)
+	defer save()
+
+	ctx := context.Background()
+
+	var (
+		projects []*Project
+		token    string
+		pages    int
+	)
+	for {
+		page, next, err := cli.ListProjects(ctx, ListProjectsArgs{
+			Org:               "sgtestazure",
+			Top:               2,
+			ContinuationToken: token,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		projects = append(projects, page...)
+		pages++
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	if pages != 2 {
+		t.Fatalf("expected 2 pages, got %d", pages)
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/ListProjects.json", *update, projects)
+}
+
+func TestClient_ListRepositoriesByProject(t *testing.T) {
+	cli, save := NewTestClient(t, "ListRepositoriesByProject", *update)
+	defer save()
+
+	repos, err := cli.ListRepositoriesByProject(context.Background(), "sgtestazure", "sgtestazure")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range repos {
+		org, err := r.Org()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if org != "sgtestazure" {
+			t.Errorf("unexpected org %q for repo %q", org, r.Name)
+		}
+	}
+
+	testutil.AssertGolden(t, "testdata/golden/ListRepositoriesByProject.json", *update, repos)
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,370 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// policyAPIVersion is the version of the policy evaluations API, which has
+// never left preview.
+const policyAPIVersion = "6.0-preview.1"
+
+// PullRequestStatus is the status of a pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+)
+
+// PullRequest is a pull request of an Azure DevOps Git repository.
+type PullRequest struct {
+	ID                    int               `json:"pullRequestId"`
+	CodeReviewID          int               `json:"codeReviewId"`
+	Repository            Repository        `json:"repository"`
+	Status                PullRequestStatus `json:"status"`
+	CreatedBy             Identity          `json:"createdBy"`
+	CreationDate          time.Time         `json:"creationDate"`
+	ClosedDate            *time.Time        `json:"closedDate,omitempty"`
+	Title                 string            `json:"title"`
+	Description           string            `json:"description,omitempty"`
+	SourceRefName         string            `json:"sourceRefName"`
+	TargetRefName         string            `json:"targetRefName"`
+	MergeStatus           string            `json:"mergeStatus,omitempty"`
+	IsDraft               bool              `json:"isDraft"`
+	LastMergeSourceCommit *CommitRef        `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *CommitRef        `json:"lastMergeTargetCommit,omitempty"`
+	Reviewers             []Reviewer        `json:"reviewers"`
+	URL                   string            `json:"url"`
+}
+
+// Identity is an Azure DevOps user or group.
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	// UniqueName is the email address of users of Azure DevOps Services and
+	// the domain account name of users of Azure DevOps Server.
+	UniqueName string `json:"uniqueName"`
+}
+
+// ReviewerVote is the vote of a reviewer of a pull request.
+type ReviewerVote int
+
+const (
+	ReviewerVoteApproved                ReviewerVote = 10
+	ReviewerVoteApprovedWithSuggestions ReviewerVote = 5
+	ReviewerVoteNoVote                  ReviewerVote = 0
+	ReviewerVoteWaitingForAuthor        ReviewerVote = -5
+	ReviewerVoteRejected                ReviewerVote = -10
+)
+
+// Reviewer is a reviewer of a pull request and their vote.
+type Reviewer struct {
+	Identity
+	Vote        ReviewerVote `json:"vote"`
+	IsRequired  bool         `json:"isRequired,omitempty"`
+	HasDeclined bool         `json:"hasDeclined,omitempty"`
+}
+
+// CommitRef refers to a commit.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// PullRequestInput is the input of CreatePullRequest.
+type PullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft"`
+}
+
+// PullRequestUpdateInput is the input of UpdatePullRequest. Only the fields
+// that are set are updated.
+type PullRequestUpdateInput struct {
+	Title         *string            `json:"title,omitempty"`
+	Description   *string            `json:"description,omitempty"`
+	Status        *PullRequestStatus `json:"status,omitempty"`
+	IsDraft       *bool              `json:"isDraft,omitempty"`
+	TargetRefName *string            `json:"targetRefName,omitempty"`
+
+	// LastMergeSourceCommit must be set to the current source commit of the
+	// pull request when completing it.
+	LastMergeSourceCommit *CommitRef                    `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *PullRequestCompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// MergeStrategy is the strategy used to complete a pull request.
+type MergeStrategy string
+
+const (
+	MergeStrategyNoFastForward MergeStrategy = "noFastForward"
+	MergeStrategySquash        MergeStrategy = "squash"
+)
+
+// PullRequestCompletionOptions controls how a pull request is completed.
+type PullRequestCompletionOptions struct {
+	MergeStrategy MergeStrategy `json:"mergeStrategy"`
+}
+
+// PullRequestSearchCriteria filters the pull requests returned by
+// ListPullRequests. Empty fields match all pull requests.
+type PullRequestSearchCriteria struct {
+	SourceRefName string
+	TargetRefName string
+	Status        PullRequestStatus
+}
+
+// PolicyEvaluationStatus is the status of a policy evaluation.
+type PolicyEvaluationStatus string
+
+const (
+	PolicyEvaluationStatusQueued        PolicyEvaluationStatus = "queued"
+	PolicyEvaluationStatusRunning       PolicyEvaluationStatus = "running"
+	PolicyEvaluationStatusApproved      PolicyEvaluationStatus = "approved"
+	PolicyEvaluationStatusRejected      PolicyEvaluationStatus = "rejected"
+	PolicyEvaluationStatusNotApplicable PolicyEvaluationStatus = "notApplicable"
+	PolicyEvaluationStatusBroken        PolicyEvaluationStatus = "broken"
+)
+
+// PolicyTypeBuild is the ID of the build validation policy type, which is the
+// same on every Azure DevOps instance.
+const PolicyTypeBuild = "0609b952-1397-4640-95ec-e00a01b2c241"
+
+// PolicyEvaluation is the result of evaluating a branch policy against a pull
+// request.
+type PolicyEvaluation struct {
+	EvaluationID  string                 `json:"evaluationId"`
+	Status        PolicyEvaluationStatus `json:"status"`
+	Configuration PolicyConfiguration    `json:"configuration"`
+}
+
+// PolicyConfiguration is a branch policy.
+type PolicyConfiguration struct {
+	ID         int        `json:"id"`
+	IsEnabled  bool       `json:"isEnabled"`
+	IsBlocking bool       `json:"isBlocking"`
+	Type       PolicyType `json:"type"`
+	Settings   struct {
+		DisplayName string `json:"displayName,omitempty"`
+	} `json:"settings"`
+}
+
+// PolicyType is the type of a branch policy.
+type PolicyType struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// ConnectionData describes the user and the instance a client is connected to.
+type ConnectionData struct {
+	AuthenticatedUser struct {
+		ID                  string `json:"id"`
+		ProviderDisplayName string `json:"providerDisplayName"`
+	} `json:"authenticatedUser"`
+}
+
+// GetPullRequest returns the pull request with the given ID in the given
+// repository.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	path, err := pullRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path+"/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListPullRequests returns the pull requests of the given repository that
+// match the given criteria.
+func (c *Client) ListPullRequests(ctx context.Context, repo *Repository, criteria PullRequestSearchCriteria) ([]*PullRequest, error) {
+	path, err := pullRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make(url.Values)
+	if criteria.SourceRefName != "" {
+		qs.Set("searchCriteria.sourceRefName", criteria.SourceRefName)
+	}
+	if criteria.TargetRefName != "" {
+		qs.Set("searchCriteria.targetRefName", criteria.TargetRefName)
+	}
+	if criteria.Status != "" {
+		qs.Set("searchCriteria.status", string(criteria.Status))
+	}
+
+	u := url.URL{Path: path, RawQuery: qs.Encode()}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PullRequest]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// CreatePullRequest creates a pull request in the given repository. Azure
+// DevOps responds with a 409 Conflict if there is already an active pull
+// request for the same source and target branches.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repository, input PullRequestInput) (*PullRequest, error) {
+	path, err := pullRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.send(ctx, "POST", path, input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the pull request with the given ID in the given
+// repository.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repository, id int, input PullRequestUpdateInput) (*PullRequest, error) {
+	path, err := pullRequestsPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.send(ctx, "PATCH", path+"/"+strconv.Itoa(id), input, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CompletePullRequest completes, that is merges, the given pull request of the
+// given repository with the given merge strategy.
+func (c *Client) CompletePullRequest(ctx context.Context, repo *Repository, pr *PullRequest, strategy MergeStrategy) (*PullRequest, error) {
+	status := PullRequestStatusCompleted
+	return c.UpdatePullRequest(ctx, repo, pr.ID, PullRequestUpdateInput{
+		Status:                &status,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions:     &PullRequestCompletionOptions{MergeStrategy: strategy},
+	})
+}
+
+// CreatePullRequestComment starts a new comment thread on the pull request
+// with the given ID in the given repository.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repository, id int, content string) error {
+	path, err := pullRequestsPath(repo)
+	if err != nil {
+		return err
+	}
+
+	type comment struct {
+		Content     string `json:"content"`
+		CommentType string `json:"commentType"`
+	}
+	thread := struct {
+		Comments []comment `json:"comments"`
+		Status   string    `json:"status"`
+	}{
+		Comments: []comment{{Content: content, CommentType: "text"}},
+		Status:   "active",
+	}
+
+	var result struct{}
+	return c.send(ctx, "POST", fmt.Sprintf("%s/%d/threads", path, id), thread, &result)
+}
+
+// GetPullRequestPolicyEvaluations returns the evaluations of the branch
+// policies that apply to the given pull request of the given repository.
+func (c *Client) GetPullRequestPolicyEvaluations(ctx context.Context, repo *Repository, pr *PullRequest) ([]*PolicyEvaluation, error) {
+	org, err := repo.Org()
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make(url.Values)
+	qs.Set("artifactId", fmt.Sprintf("vstfs:///CodeReview/CodeReviewId/%s/%d", repo.Project.ID, pr.CodeReviewID))
+	qs.Set("api-version", policyAPIVersion)
+
+	u := url.URL{
+		Path:     fmt.Sprintf("%s/%s/_apis/policy/evaluations", url.PathEscape(org), url.PathEscape(repo.Project.ID)),
+		RawQuery: qs.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PolicyEvaluation]
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// GetConnectionData returns the user the client is authenticated as in the
+// given organization.
+func (c *Client) GetConnectionData(ctx context.Context, org string) (*ConnectionData, error) {
+	qs := make(url.Values)
+	qs.Set("api-version", "6.0-preview")
+
+	u := url.URL{Path: url.PathEscape(org) + "/_apis/connectionData", RawQuery: qs.Encode()}
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data ConnectionData
+	if _, err := c.do(ctx, req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, input, result any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, result)
+	return err
+}
+
+// pullRequestsPath returns the relative URL of the pull requests of the given
+// repository.
+func pullRequestsPath(repo *Repository) (string, error) {
+	org, err := repo.Org()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/pullrequests",
+		url.PathEscape(org),
+		url.PathEscape(repo.Project.ID),
+		url.PathEscape(repo.ID),
+	), nil
+}
@@ -0,0 +1,108 @@
+package azuredevops
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+// testRepository is the repository the pull request fixtures were recorded
+// against.
+var testRepository = &Repository{
+	ID:     "c4d186ef-18a6-4de4-a610-aa9ebd4e1faa",
+	Name:   "src-cli",
+	WebURL: "https://dev.azure.com/sgtestazure/sgtestazure/_git/src-cli",
+	Project: Project{
+		ID:   "5ee9a6a3-4c4a-4f6a-9b57-6a0a1a3e8a11",
+		Name: "sgtestazure",
+	},
+}
+
+func TestClient_GetPullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequest", *update)
+	defer save()
+
+	pr, err := cli.GetPullRequest(context.Background(), testRepository, 7)
+	require.NoError(t, err)
+
+	assert.Equal(t, PullRequestStatusActive, pr.Status)
+	assert.Equal(t, ReviewerVoteApproved, pr.Reviewers[0].Vote)
+
+	testutil.AssertGolden(t, "testdata/golden/GetPullRequest.json", *update, pr)
+}
+
+func TestClient_ListPullRequests(t *testing.T) {
+	cli, save := NewTestClient(t, "ListPullRequests", *update)
+	defer save()
+
+	prs, err := cli.ListPullRequests(context.Background(), testRepository, PullRequestSearchCriteria{
+		SourceRefName: "refs/heads/batch-changes/test",
+		Status:        PullRequestStatusActive,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, prs, 1)
+	assert.Equal(t, 7, prs[0].ID)
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "CreatePullRequest", *update)
+	defer save()
+
+	pr, err := cli.CreatePullRequest(context.Background(), testRepository, PullRequestInput{
+		SourceRefName: "refs/heads/batch-changes/create",
+		TargetRefName: "refs/heads/main",
+		Title:         "Update README",
+		Description:   "This changes the README.",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, pr.ID)
+	assert.Equal(t, "refs/heads/batch-changes/create", pr.SourceRefName)
+}
+
+func TestClient_UpdatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "UpdatePullRequest", *update)
+	defer save()
+
+	title := "Update README again"
+	pr, err := cli.UpdatePullRequest(context.Background(), testRepository, 7, PullRequestUpdateInput{
+		Title: &title,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, title, pr.Title)
+}
+
+func TestClient_GetPullRequestPolicyEvaluations(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequestPolicyEvaluations", *update)
+	defer save()
+
+	evaluations, err := cli.GetPullRequestPolicyEvaluations(context.Background(), testRepository, &PullRequest{ID: 7, CodeReviewID: 7})
+	require.NoError(t, err)
+
+	testutil.AssertGolden(t, "testdata/golden/GetPullRequestPolicyEvaluations.json", *update, evaluations)
+}
+
+func TestClient_GetConnectionData(t *testing.T) {
+	cli, save := NewTestClient(t, "GetConnectionData", *update)
+	defer save()
+
+	data, err := cli.GetConnectionData(context.Background(), "sgtestazure")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Jane Doe", data.AuthenticatedUser.ProviderDisplayName)
+}
+
+func TestHTTPError(t *testing.T) {
+	err := &httpError{StatusCode: http.StatusConflict}
+
+	assert.True(t, errcode.IsHTTPErrorCode(err, http.StatusConflict))
+	assert.False(t, errcode.IsNotFound(err))
+}
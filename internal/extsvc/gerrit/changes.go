@@ -0,0 +1,252 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ChangeStatus is the status of a change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change, as returned by the changes API with the options
+// requested by GetChange.
+type Change struct {
+	ID             string       `json:"id"`
+	Project        string       `json:"project"`
+	Branch         string       `json:"branch"`
+	Topic          string       `json:"topic,omitempty"`
+	ChangeID       string       `json:"change_id"`
+	Subject        string       `json:"subject"`
+	Status         ChangeStatus `json:"status"`
+	Created        Timestamp    `json:"created"`
+	Updated        Timestamp    `json:"updated"`
+	Submittable    bool         `json:"submittable,omitempty"`
+	WorkInProgress bool         `json:"work_in_progress,omitempty"`
+	Number         int          `json:"_number"`
+	Owner          Account      `json:"owner"`
+
+	// Labels maps label names, such as "Code-Review" or "Verified", to their
+	// current votes.
+	Labels map[string]ChangeLabel `json:"labels,omitempty"`
+
+	CurrentRevision string              `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision `json:"revisions,omitempty"`
+}
+
+// ChangeNumber returns the change number as a string, which is how changes
+// are identified outside of Gerrit.
+func (c *Change) ChangeNumber() string {
+	return strconv.Itoa(c.Number)
+}
+
+// CurrentCommit returns the commit of the current patch set of the change, if
+// it was requested.
+func (c *Change) CurrentCommit() (Commit, bool) {
+	rev, ok := c.Revisions[c.CurrentRevision]
+	return rev.Commit, ok
+}
+
+// ChangeLabel holds the votes on a label of a change.
+type ChangeLabel struct {
+	// Approved, Rejected, Recommended and Disliked are the accounts who cast
+	// the maximum, minimum, and the positive and negative non-extreme votes
+	// respectively. Only one of Approved and Rejected is set: the votes of
+	// Rejected take precedence.
+	Approved    *Account `json:"approved,omitempty"`
+	Rejected    *Account `json:"rejected,omitempty"`
+	Recommended *Account `json:"recommended,omitempty"`
+	Disliked    *Account `json:"disliked,omitempty"`
+
+	// Blocking is true if the label blocks the change from being submitted.
+	Blocking bool `json:"blocking,omitempty"`
+
+	// All contains the votes of every reviewer on the label.
+	All []Approval `json:"all,omitempty"`
+}
+
+// Approval is a single vote on a label.
+type Approval struct {
+	Account
+	Value int `json:"value"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int    `json:"_number"`
+	Ref    string `json:"ref"`
+	Commit Commit `json:"commit"`
+}
+
+// Commit is the commit of a revision.
+type Commit struct {
+	Parents []CommitParent `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+type CommitParent struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// timestampLayout is the format of timestamps in the Gerrit REST API, which
+// are always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a point in time formatted the way the Gerrit REST API does.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.UTC().Format(timestampLayout))
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseInLocation(timestampLayout, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// ChangeIdentifier returns the identifier of the change with the given
+// Change-Id on the given branch of a project, which can be used instead of the
+// change number in the changes API.
+func ChangeIdentifier(project, branch, changeID string) string {
+	return project + "~" + strings.TrimPrefix(branch, "refs/heads/") + "~" + changeID
+}
+
+// GetChange returns the change with the given identifier, which is either its
+// number or the value returned by ChangeIdentifier.
+func (c *Client) GetChange(ctx context.Context, id string) (*Change, error) {
+	qs := make(url.Values)
+	for _, o := range []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT", "SUBMITTABLE"} {
+		qs.Add("o", o)
+	}
+
+	u := changeURL(id, "")
+	u.RawQuery = qs.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the change with the given identifier.
+func (c *Client) AbandonChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "abandon", struct{}{}, &Change{})
+}
+
+// RestoreChange restores the abandoned change with the given identifier.
+func (c *Client) RestoreChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "restore", struct{}{}, &Change{})
+}
+
+// SubmitChange submits the change with the given identifier. Gerrit responds
+// with a 409 Conflict if the change cannot be submitted.
+func (c *Client) SubmitChange(ctx context.Context, id string) error {
+	return c.postChange(ctx, id, "submit", struct{}{}, &Change{})
+}
+
+// ReviewInput is the input of SetReview.
+type ReviewInput struct {
+	Message string `json:"message,omitempty"`
+}
+
+// SetReview posts a review on the current patch set of the change with the
+// given identifier.
+func (c *Client) SetReview(ctx context.Context, id string, review ReviewInput) error {
+	var result struct{}
+	return c.postChange(ctx, id, "revisions/current/review", review, &result)
+}
+
+// SetCommitMessage creates a new patch set of the change with the given
+// identifier, with the same content but a different commit message.
+func (c *Client) SetCommitMessage(ctx context.Context, id, message string) error {
+	body, err := json.Marshal(struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	u := changeURL(id, "message")
+	req, err := http.NewRequest("PUT", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// GetAuthenticatedUser returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "a/accounts/self", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if _, err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (c *Client) postChange(ctx context.Context, id, action string, input, result any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return errors.Wrap(err, "marshalling request body")
+	}
+
+	u := changeURL(id, action)
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err = c.do(ctx, req, result)
+	return err
+}
+
+// changeURL returns the relative URL of the given action of a change. Change
+// identifiers can contain slashes, which must be escaped.
+func changeURL(id, action string) *url.URL {
+	path := "a/changes/" + id
+	rawPath := "a/changes/" + url.PathEscape(id)
+	if action != "" {
+		path += "/" + action
+		rawPath += "/" + action
+	}
+	return &url.URL{Path: path, RawPath: rawPath}
+}
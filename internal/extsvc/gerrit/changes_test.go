@@ -0,0 +1,144 @@
+package gerrit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testChange = `)]}'
+{
+  "id": "sourcegraph%2Fsourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "sourcegraph/sourcegraph",
+  "branch": "main",
+  "topic": "batch-changes/test",
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "subject": "Update README",
+  "status": "NEW",
+  "created": "2023-01-10 08:27:11.000000000",
+  "updated": "2023-01-11 10:01:02.123000000",
+  "submittable": true,
+  "_number": 4247,
+  "owner": {"_account_id": 1000096, "name": "Jane Doe", "email": "jane@example.com", "username": "jane"},
+  "labels": {
+    "Code-Review": {
+      "approved": {"_account_id": 1000097},
+      "all": [{"_account_id": 1000097, "value": 2}]
+    }
+  },
+  "current_revision": "184ebe53805e102605d11f6b143486d15c23a09c",
+  "revisions": {
+    "184ebe53805e102605d11f6b143486d15c23a09c": {
+      "_number": 2,
+      "ref": "refs/changes/47/4247/2",
+      "commit": {
+        "parents": [{"commit": "1eee2c9d8f352483781e772f35dc586a69ff5646", "subject": "Initial commit"}],
+        "subject": "Update README",
+        "message": "Update README\n\nChange-Id: I8473b95934b5732ac55d26311a706c9c2bde9940\n"
+      }
+    }
+  }
+}`
+
+func newChangesTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("urn", &schema.GerritConnection{Url: srv.URL, Username: "admin", Password: "secret"}, nil)
+	require.NoError(t, err)
+	return cli
+}
+
+func TestClient_GetChange(t *testing.T) {
+	cli := newChangesTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/a/changes/sourcegraph%2Fsourcegraph~main~I8473b95934b5732ac55d26311a706c9c2bde9940", r.URL.EscapedPath())
+		assert.ElementsMatch(t, []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT", "SUBMITTABLE"}, r.URL.Query()["o"])
+
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", username)
+		assert.Equal(t, "secret", password)
+
+		io.WriteString(w, testChange)
+	})
+
+	change, err := cli.GetChange(context.Background(), ChangeIdentifier("sourcegraph/sourcegraph", "refs/heads/main", "I8473b95934b5732ac55d26311a706c9c2bde9940"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4247, change.Number)
+	assert.Equal(t, "4247", change.ChangeNumber())
+	assert.Equal(t, ChangeStatusNew, change.Status)
+	assert.Equal(t, time.Date(2023, 1, 11, 10, 1, 2, 123000000, time.UTC), change.Updated.Time)
+	assert.Equal(t, "jane", change.Owner.Username)
+	assert.Equal(t, 2, change.Labels["Code-Review"].All[0].Value)
+
+	commit, ok := change.CurrentCommit()
+	require.True(t, ok)
+	assert.Equal(t, "1eee2c9d8f352483781e772f35dc586a69ff5646", commit.Parents[0].Commit)
+}
+
+func TestClient_GetChange_NotFound(t *testing.T) {
+	cli := newChangesTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not found: 4247", http.StatusNotFound)
+	})
+
+	_, err := cli.GetChange(context.Background(), "4247")
+	assert.True(t, errcode.IsNotFound(err))
+}
+
+func TestClient_ChangeActions(t *testing.T) {
+	var paths []string
+	cli := newChangesTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		username, _, _ := r.BasicAuth()
+		assert.Equal(t, "user", username)
+
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Method == "PUT" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		io.WriteString(w, ")]}'\n{}")
+	})
+	cli = cli.WithAuthenticator(&auth.BasicAuth{Username: "user", Password: "token"})
+
+	ctx := context.Background()
+	require.NoError(t, cli.AbandonChange(ctx, "1"))
+	require.NoError(t, cli.RestoreChange(ctx, "1"))
+	require.NoError(t, cli.SubmitChange(ctx, "1"))
+	require.NoError(t, cli.SetReview(ctx, "1", ReviewInput{Message: "hello"}))
+	require.NoError(t, cli.SetCommitMessage(ctx, "1", "Update README\n\nChange-Id: I123\n"))
+
+	assert.Equal(t, []string{
+		"POST /a/changes/1/abandon",
+		"POST /a/changes/1/restore",
+		"POST /a/changes/1/submit",
+		"POST /a/changes/1/revisions/current/review",
+		"PUT /a/changes/1/message",
+	}, paths)
+}
+
+func TestTimestamp_RoundTrip(t *testing.T) {
+	ts := Timestamp{time.Date(2023, 1, 11, 10, 1, 2, 123000000, time.UTC)}
+
+	data, err := ts.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `"2023-01-11 10:01:02.123000000"`, string(data))
+
+	var got Timestamp
+	require.NoError(t, got.UnmarshalJSON(data))
+	assert.True(t, ts.Equal(got.Time))
+}
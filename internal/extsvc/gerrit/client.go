@@ -3,13 +3,13 @@ package gerrit
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -26,6 +26,10 @@ type Client struct {
 	// URL is the base URL of Gerrit.
 	URL *url.URL
 
+	// auth is used to authenticate requests. It defaults to the username and
+	// password of the code host connection.
+	auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -48,10 +52,29 @@ func NewClient(urn string, config *schema.GerritConnection, httpClient httpcli.D
 		httpClient: httpClient,
 		Config:     config,
 		URL:        u,
+		auth:       &auth.BasicAuth{Username: config.Username, Password: config.Password},
 		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
 	}, nil
 }
 
+// WithAuthenticator returns a copy of the client that authenticates requests
+// with the given authenticator instead of the code host connection
+// credentials.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	return &Client{
+		httpClient: c.httpClient,
+		Config:     c.Config,
+		URL:        c.URL,
+		auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// Authenticator returns the authenticator used by the client.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
 type ListAccountsResponse []Account
 
 func (c *Client) ListAccountsByEmail(ctx context.Context, email string) (ListAccountsResponse, error) {
@@ -171,7 +194,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	req.URL = c.URL.ResolveReference(req.URL)
 
 	// Add Basic Auth headers for authenticated requests.
-	req.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.Config.Username+":"+c.Config.Password)))
+	if err := c.auth.Authenticate(req); err != nil {
+		return nil, err
+	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
 		return nil, err
@@ -199,6 +224,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		}
 	}
 
+	// Some endpoints respond with 204 No Content, in which case callers don't
+	// expect a result.
+	if result == nil {
+		return resp, nil
+	}
+
 	// The first 4 characters of the Gerrit API responses need to be stripped, see: https://gerrit-review.googlesource.com/Documentation/rest-api.html#output .
 	if len(bs) < 4 {
 		return nil, &httpError{
@@ -0,0 +1,245 @@
+//nolint:bodyclose // Body is closed in Client.do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client access a Gitea or Forgejo instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.GiteaConnection
+
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a concept
+	// of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns an authenticated Gitea API client with the provided
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will
+// be used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// ListOrgRepos returns an iterator over all repositories of the given
+// organization that are visible to the client.
+func (c *Client) ListOrgRepos(ctx context.Context, org string, perPage int) *iterator.Iterator[*Repository] {
+	return c.listRepos(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos", nil, perPage, false)
+}
+
+// ListUserRepos returns an iterator over all repositories owned by the given
+// user that are visible to the client.
+func (c *Client) ListUserRepos(ctx context.Context, user string, perPage int) *iterator.Iterator[*Repository] {
+	return c.listRepos(ctx, "api/v1/users/"+url.PathEscape(user)+"/repos", nil, perPage, false)
+}
+
+// SearchRepos returns an iterator over all repositories matching the given
+// keyword that are visible to the client. An empty query matches all
+// repositories.
+func (c *Client) SearchRepos(ctx context.Context, query string, perPage int) *iterator.Iterator[*Repository] {
+	qs := make(url.Values)
+	if query != "" {
+		qs.Set("q", query)
+	}
+	return c.listRepos(ctx, "api/v1/repos/search", qs, perPage, true)
+}
+
+// searchReposResponse is the envelope returned by the repository search
+// endpoint.
+type searchReposResponse struct {
+	OK   bool          `json:"ok"`
+	Data []*Repository `json:"data"`
+}
+
+func (c *Client) listRepos(ctx context.Context, path string, qs url.Values, perPage int, search bool) *iterator.Iterator[*Repository] {
+	if qs == nil {
+		qs = make(url.Values)
+	}
+	if perPage <= 0 {
+		perPage = 50
+	}
+	page := 1
+	done := false
+
+	return iterator.New(func() ([]*Repository, error) {
+		if done {
+			return nil, nil
+		}
+
+		qs.Set("page", strconv.Itoa(page))
+		qs.Set("limit", strconv.Itoa(perPage))
+		u := url.URL{Path: path, RawQuery: qs.Encode()}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			repos []*Repository
+			resp  *http.Response
+		)
+		if search {
+			var sr searchReposResponse
+			if resp, err = c.do(ctx, req, &sr); err != nil {
+				return nil, err
+			}
+			repos = sr.Data
+		} else {
+			if resp, err = c.do(ctx, req, &repos); err != nil {
+				return nil, err
+			}
+		}
+
+		if hasNextPage(resp.Header) {
+			page++
+		} else {
+			done = true
+		}
+
+		return repos, nil
+	})
+}
+
+// hasNextPage returns true if the Link header of a list response points to a
+// next page.
+func hasNextPage(h http.Header) bool {
+	for _, link := range strings.Split(h.Get("Link"), ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	if req.Header.Get("Content-Type") == "" && req.Method != "GET" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.Config.Token != "" {
+		req.Header.Add("Authorization", "token "+c.Config.Token)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// Permissions are the permissions of the authenticated user on a repository.
+type Permissions struct {
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
+}
+
+// Repository is a Gitea repository.
+type Repository struct {
+	ID            int64        `json:"id"`
+	Owner         *User        `json:"owner"`
+	Name          string       `json:"name"`
+	FullName      string       `json:"full_name"`
+	Description   string       `json:"description"`
+	Empty         bool         `json:"empty"`
+	Private       bool         `json:"private"`
+	Internal      bool         `json:"internal"`
+	Fork          bool         `json:"fork"`
+	Mirror        bool         `json:"mirror"`
+	Archived      bool         `json:"archived"`
+	Parent        *Repository  `json:"parent,omitempty"`
+	Size          int          `json:"size"`
+	HTMLURL       string       `json:"html_url"`
+	SSHURL        string       `json:"ssh_url"`
+	CloneURL      string       `json:"clone_url"`
+	DefaultBranch string       `json:"default_branch"`
+	StarsCount    int          `json:"stars_count"`
+	ForksCount    int          `json:"forks_count"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	Permissions   *Permissions `json:"permissions,omitempty"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,83 @@
+package gitea
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOrgRepos", *update)
+	defer save()
+
+	repos := collect(t, cli.ListOrgRepos(context.Background(), "sourcegraph-testing", 2))
+
+	testutil.AssertGolden(t, "testdata/golden/ListOrgRepos.json", *update, repos)
+}
+
+func TestClient_ListUserRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListUserRepos", *update)
+	defer save()
+
+	repos := collect(t, cli.ListUserRepos(context.Background(), "sgtest", 2))
+
+	testutil.AssertGolden(t, "testdata/golden/ListUserRepos.json", *update, repos)
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "SearchRepos", *update)
+	defer save()
+
+	repos := collect(t, cli.SearchRepos(context.Background(), "tools", 2))
+
+	testutil.AssertGolden(t, "testdata/golden/SearchRepos.json", *update, repos)
+}
+
+func TestHasNextPage(t *testing.T) {
+	for _, tc := range []struct {
+		link string
+		want bool
+	}{
+		{"", false},
+		{`<https://gitea.com/api/v1/repos/search?limit=2&page=2>; rel="last"`, false},
+		{`<https://gitea.com/api/v1/repos/search?limit=2&page=2>; rel="next", <https://gitea.com/api/v1/repos/search?limit=2&page=3>; rel="last"`, true},
+	} {
+		h := http.Header{}
+		if tc.link != "" {
+			h.Set("Link", tc.link)
+		}
+		if have := hasNextPage(h); have != tc.want {
+			t.Errorf("hasNextPage(%q) = %t, want %t", tc.link, have, tc.want)
+		}
+	}
+}
+
+func collect(t *testing.T, it *iterator.Iterator[*Repository]) []*Repository {
+	t.Helper()
+
+	var repos []*Repository
+	for it.Next() {
+		repos = append(repos, it.Current())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return repos
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.Root().GetHandler()))
+	}
+	os.Exit(m.Run())
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -28,6 +29,10 @@ type Client struct {
 	// HTTP Client used to communicate with the API
 	httpClient httpcli.Doer
 
+	// auth is used to authenticate requests instead of the token of the code
+	// host connection, if set.
+	auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Pagure does not have a concept
 	// of rate limiting in HTTP response headers).
 	rateLimit *ratelimit.InstrumentedLimiter
@@ -54,6 +59,26 @@ func NewClient(urn string, config *schema.PagureConnection, httpClient httpcli.D
 	}, nil
 }
 
+// WithAuthenticator returns a copy of the client that authenticates requests
+// with the given authenticator instead of the token of the code host
+// connection. Pagure API tokens double as passwords when pushing over HTTPS,
+// so the password of basic auth credentials is used as the API token.
+func (c *Client) WithAuthenticator(a auth.Authenticator) *Client {
+	return &Client{
+		Config:     c.Config,
+		URL:        c.URL,
+		httpClient: c.httpClient,
+		auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// Authenticator returns the authenticator set with WithAuthenticator, or nil
+// if the client uses the token of the code host connection.
+func (c *Client) Authenticator() auth.Authenticator {
+	return c.auth
+}
+
 // ListProjectsArgs defines options to be set on ListProjects method calls.
 type ListProjectsArgs struct {
 	Cursor    *Pagination
@@ -128,8 +153,8 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
 
-	if c.Config.Token != "" {
-		req.Header.Add("Authorization", "token "+c.Config.Token)
+	if err := c.authenticate(req); err != nil {
+		return nil, err
 	}
 
 	if err := c.rateLimit.Wait(ctx); err != nil {
@@ -159,6 +184,22 @@ func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.R
 	return resp, json.Unmarshal(bs, result)
 }
 
+func (c *Client) authenticate(req *http.Request) error {
+	switch a := c.auth.(type) {
+	case nil:
+		if c.Config.Token != "" {
+			req.Header.Add("Authorization", "token "+c.Config.Token)
+		}
+	case *auth.BasicAuth:
+		req.Header.Add("Authorization", "token "+a.Password)
+	case *auth.BasicAuthWithSSH:
+		req.Header.Add("Authorization", "token "+a.Password)
+	default:
+		return a.Authenticate(req)
+	}
+	return nil
+}
+
 type Pagination struct {
 	First   string `json:"first"`
 	Last    string `json:"last"`
@@ -188,6 +229,18 @@ type Project struct {
 	Parent      *Project `json:"parent,omitempty"`
 	Tags        []string `json:"tags"`
 	URLPath     string   `json:"url_path"`
+	User        *User    `json:"user,omitempty"`
+
+	// AccessUsers maps access levels, such as "owner" or "commit", to the
+	// users who have them.
+	AccessUsers map[string][]string `json:"access_users,omitempty"`
+}
+
+// User is a Pagure user.
+type User struct {
+	Name     string `json:"name"`
+	Fullname string `json:"fullname"`
+	URLPath  string `json:"url_path"`
 }
 
 type httpError struct {
@@ -207,3 +260,7 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+func (e *httpError) HTTPStatusCode() int {
+	return e.StatusCode
+}
@@ -0,0 +1,320 @@
+package pagure
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+// PullRequestStatus is the status of a pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "Open"
+	PullRequestStatusMerged PullRequestStatus = "Merged"
+	PullRequestStatusClosed PullRequestStatus = "Closed"
+)
+
+// PullRequest is a Pagure pull request.
+type PullRequest struct {
+	ID             int               `json:"id"`
+	UID            string            `json:"uid"`
+	Title          string            `json:"title"`
+	InitialComment string            `json:"initial_comment"`
+	Branch         string            `json:"branch"`
+	BranchFrom     string            `json:"branch_from"`
+	CommitStart    string            `json:"commit_start"`
+	CommitStop     string            `json:"commit_stop"`
+	DateCreated    Timestamp         `json:"date_created"`
+	LastUpdated    Timestamp         `json:"last_updated"`
+	ClosedAt       *Timestamp        `json:"closed_at"`
+	Status         PullRequestStatus `json:"status"`
+	User           User              `json:"user"`
+	Project        *Project          `json:"project"`
+	RepoFrom       *Project          `json:"repo_from"`
+	Comments       []*Comment        `json:"comments"`
+	FullURL        string            `json:"full_url"`
+
+	// ThresholdReached is true if the pull request has reached the minimum
+	// score required to be merged, false if it hasn't, and nil if the project
+	// doesn't require a minimum score.
+	ThresholdReached  *bool  `json:"threshold_reached"`
+	CachedMergeStatus string `json:"cached_merge_status"`
+}
+
+// Comment is a comment on a pull request.
+type Comment struct {
+	ID          int        `json:"id"`
+	Comment     string     `json:"comment"`
+	DateCreated Timestamp  `json:"date_created"`
+	EditedOn    *Timestamp `json:"edited_on"`
+	User        User       `json:"user"`
+
+	// Notification is true for comments Pagure adds itself, for example when
+	// the pull request is rebased or its status changes.
+	Notification bool `json:"notification"`
+}
+
+// Key is a unique key identifying this comment in the context of its pull
+// request.
+func (c *Comment) Key() string {
+	return strconv.Itoa(c.ID)
+}
+
+// Timestamp is a point in time that Pagure encodes as a string containing
+// the number of seconds since the Unix epoch.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "parsing timestamp %q", s)
+	}
+	t.Time = time.Unix(secs, 0).UTC()
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(strconv.FormatInt(t.Unix(), 10))), nil
+}
+
+// CreatePullRequestInput is the input for CreatePullRequest.
+type CreatePullRequestInput struct {
+	Title          string
+	InitialComment string
+	BranchTo       string
+	BranchFrom     string
+
+	// RepoFrom is the fork the pull request is opened from. If nil, the pull
+	// request is opened from a branch of the target project.
+	RepoFrom *Project
+}
+
+// UpdatePullRequestInput is the input for UpdatePullRequest.
+type UpdatePullRequestInput struct {
+	Title          string
+	InitialComment string
+}
+
+// ListPullRequestsArgs defines options to be set on ListPullRequests method
+// calls.
+type ListPullRequestsArgs struct {
+	Cursor *Pagination
+
+	// Status filters pull requests by status. Pagure only returns open pull
+	// requests if it is empty.
+	Status PullRequestStatus
+
+	// Author filters pull requests by the name of the user who opened them.
+	Author string
+}
+
+// listPullRequestsResponse defines a response struct returned from
+// ListPullRequests method calls.
+type listPullRequestsResponse struct {
+	*Pagination `json:"pagination"`
+	Requests    []*PullRequest `json:"requests"`
+}
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+// GetProject retrieves the project with the given URL path, such as
+// "rpms/tmux" or "fork/asn/rpms/tmux".
+func (c *Client) GetProject(ctx context.Context, urlPath string) (*Project, error) {
+	var project Project
+	if err := c.send(ctx, "GET", "api/0/"+urlPath, nil, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetFork retrieves the fork of the given project in the namespace of the
+// given user.
+func (c *Client) GetFork(ctx context.Context, project *Project, username string) (*Project, error) {
+	p := "fork/" + username + "/"
+	if project.Namespace != "" {
+		p += project.Namespace + "/"
+	}
+	return c.GetProject(ctx, p+project.Name)
+}
+
+// ForkProject forks the given project into the namespace of the
+// authenticated user and waits for the fork to be created.
+func (c *Client) ForkProject(ctx context.Context, project *Project) error {
+	form := url.Values{
+		"repo": {project.Name},
+		"wait": {"true"},
+	}
+	if project.Namespace != "" {
+		form.Set("namespace", project.Namespace)
+	}
+	// Forks of forks are identified by the user owning the forked project.
+	if project.Parent != nil && project.User != nil {
+		form.Set("username", project.User.Name)
+	}
+
+	return c.send(ctx, "POST", "api/0/fork", form, &messageResponse{})
+}
+
+// WhoAmI returns the name of the user the API token belongs to.
+func (c *Client) WhoAmI(ctx context.Context) (string, error) {
+	var resp struct {
+		Username string `json:"username"`
+	}
+	if err := c.send(ctx, "POST", "api/0/-/whoami", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Username, nil
+}
+
+// GetPullRequest retrieves the pull request with the given ID of the given
+// project.
+func (c *Client) GetPullRequest(ctx context.Context, project *Project, id int) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "GET", pullRequestPath(project, id), nil, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ListPullRequests returns the pull requests of the given project.
+func (c *Client) ListPullRequests(ctx context.Context, project *Project, args ListPullRequestsArgs) *iterator.Iterator[*PullRequest] {
+	cursor := args.Cursor
+	if cursor == nil {
+		cursor = &Pagination{PerPage: 100, Page: 1}
+	}
+
+	return iterator.New(func() ([]*PullRequest, error) {
+		if cursor == nil {
+			return nil, nil
+		}
+
+		qs := make(url.Values)
+
+		cursor.EncodeTo(qs)
+		if args.Status != "" {
+			qs.Set("status", string(args.Status))
+		}
+		if args.Author != "" {
+			qs.Set("author", args.Author)
+		}
+
+		u := url.URL{Path: "api/0/" + project.URLPath + "/pull-requests", RawQuery: qs.Encode()}
+
+		var resp listPullRequestsResponse
+		if err := c.send(ctx, "GET", u.String(), nil, &resp); err != nil {
+			return nil, err
+		}
+
+		cursor = resp.Pagination
+		if cursor == nil || cursor.Next == "" {
+			cursor = nil
+		} else {
+			cursor.Page++
+		}
+
+		return resp.Requests, nil
+	})
+}
+
+// CreatePullRequest opens a new pull request against the given project.
+func (c *Client) CreatePullRequest(ctx context.Context, project *Project, input CreatePullRequestInput) (*PullRequest, error) {
+	form := url.Values{
+		"title":       {input.Title},
+		"branch_to":   {input.BranchTo},
+		"branch_from": {input.BranchFrom},
+	}
+	if input.InitialComment != "" {
+		form.Set("initial_comment", input.InitialComment)
+	}
+	if fork := input.RepoFrom; fork != nil {
+		form.Set("repo_from", fork.Name)
+		if fork.Namespace != "" {
+			form.Set("repo_from_namespace", fork.Namespace)
+		}
+		if fork.User != nil {
+			form.Set("repo_from_username", fork.User.Name)
+		}
+	}
+
+	var pr PullRequest
+	if err := c.send(ctx, "POST", "api/0/"+project.URLPath+"/pull-request/new", form, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the title and initial comment of the given pull
+// request.
+func (c *Client) UpdatePullRequest(ctx context.Context, project *Project, id int, input UpdatePullRequestInput) (*PullRequest, error) {
+	form := url.Values{
+		"title":           {input.Title},
+		"initial_comment": {input.InitialComment},
+	}
+
+	var pr PullRequest
+	if err := c.send(ctx, "POST", pullRequestPath(project, id), form, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// ClosePullRequest closes the given pull request without merging it.
+func (c *Client) ClosePullRequest(ctx context.Context, project *Project, id int) error {
+	return c.send(ctx, "POST", pullRequestPath(project, id)+"/close", nil, &messageResponse{})
+}
+
+// ReopenPullRequest reopens the given closed pull request.
+func (c *Client) ReopenPullRequest(ctx context.Context, project *Project, id int) error {
+	return c.send(ctx, "POST", pullRequestPath(project, id)+"/reopen", nil, &messageResponse{})
+}
+
+// MergePullRequest merges the given pull request.
+func (c *Client) MergePullRequest(ctx context.Context, project *Project, id int) error {
+	return c.send(ctx, "POST", pullRequestPath(project, id)+"/merge", nil, &messageResponse{})
+}
+
+// CreatePullRequestComment adds a comment to the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, project *Project, id int, comment string) error {
+	form := url.Values{"comment": {comment}}
+	return c.send(ctx, "POST", pullRequestPath(project, id)+"/comment", form, &messageResponse{})
+}
+
+func (c *Client) send(ctx context.Context, method, path string, form url.Values, result any) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, result)
+	return err
+}
+
+func pullRequestPath(project *Project, id int) string {
+	return "api/0/" + project.URLPath + "/pull-request/" + strconv.Itoa(id)
+}
@@ -0,0 +1,136 @@
+package pagure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+// testProject is the project the pull request fixtures were recorded against.
+var testProject = &Project{
+	ID:      11234,
+	Name:    "batch-changes-test",
+	URLPath: "batch-changes-test",
+}
+
+func TestClient_GetPullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequest", *update)
+	defer save()
+
+	pr, err := cli.GetPullRequest(context.Background(), testProject, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, PullRequestStatusOpen, pr.Status)
+	assert.Equal(t, time.Date(2023, 1, 11, 14, 6, 40, 0, time.UTC), pr.DateCreated.Time)
+	assert.Nil(t, pr.ClosedAt)
+
+	testutil.AssertGolden(t, "testdata/golden/GetPullRequest.json", *update, pr)
+}
+
+func TestClient_ListPullRequests(t *testing.T) {
+	cli, save := NewTestClient(t, "ListPullRequests", *update)
+	defer save()
+
+	it := cli.ListPullRequests(context.Background(), testProject, ListPullRequestsArgs{
+		Status: PullRequestStatusOpen,
+	})
+
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Current().ID)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "CreatePullRequest", *update)
+	defer save()
+
+	pr, err := cli.CreatePullRequest(context.Background(), testProject, CreatePullRequestInput{
+		Title:          "Update README",
+		InitialComment: "This changes the README.",
+		BranchTo:       "main",
+		BranchFrom:     "batch-changes/create",
+		RepoFrom: &Project{
+			Name: "batch-changes-test",
+			User: &User{Name: "sg-bot"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, pr.ID)
+	assert.Equal(t, "fork/sg-bot/batch-changes-test", pr.RepoFrom.URLPath)
+}
+
+func TestClient_UpdatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "UpdatePullRequest", *update)
+	defer save()
+
+	pr, err := cli.UpdatePullRequest(context.Background(), testProject, 1, UpdatePullRequestInput{
+		Title:          "Update README again",
+		InitialComment: "This changes the README.",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Update README again", pr.Title)
+}
+
+func TestClient_WhoAmI(t *testing.T) {
+	cli, save := NewTestClient(t, "WhoAmI", *update)
+	defer save()
+
+	username, err := cli.WhoAmI(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "sg-bot", username)
+}
+
+func TestClient_GetFork(t *testing.T) {
+	cli, save := NewTestClient(t, "GetFork", *update)
+	defer save()
+
+	fork, err := cli.GetFork(context.Background(), testProject, "sg-bot")
+	require.NoError(t, err)
+
+	assert.Equal(t, "forks/sg-bot/batch-changes-test", fork.Fullname)
+	require.NotNil(t, fork.Parent)
+	assert.Equal(t, testProject.ID, fork.Parent.ID)
+}
+
+func TestClient_ForkProject(t *testing.T) {
+	cli, save := NewTestClient(t, "ForkProject", *update)
+	defer save()
+
+	require.NoError(t, cli.ForkProject(context.Background(), testProject))
+}
+
+func TestTimestamp(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data string
+		want time.Time
+	}{
+		"string": {data: `"1673446000"`, want: time.Unix(1673446000, 0).UTC()},
+		"number": {data: `1673446000`, want: time.Unix(1673446000, 0).UTC()},
+		"null":   {data: `null`, want: time.Time{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var ts Timestamp
+			require.NoError(t, ts.UnmarshalJSON([]byte(tc.data)))
+			assert.Equal(t, tc.want, ts.Time)
+
+			// Timestamps must survive being stored as changeset metadata.
+			data, err := ts.MarshalJSON()
+			require.NoError(t, err)
+			var roundTripped Timestamp
+			require.NoError(t, roundTripped.UnmarshalJSON(data))
+			assert.Equal(t, ts, roundTripped)
+		})
+	}
+}
@@ -113,12 +113,14 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
+	KindGitea           = "GITEA"
 	KindPerforce        = "PERFORCE"
 	KindPhabricator     = "PHABRICATOR"
 	KindGoPackages      = "GOMODULES"
@@ -139,6 +141,10 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The ServiceID
+	// value is the base URL to the Azure DevOps instance (https://dev.azure.com or the Azure DevOps Server URL).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -161,6 +167,10 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea repositories. The ServiceID
+	// value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = "perforce"
 
@@ -198,6 +208,8 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
@@ -210,6 +222,8 @@ func KindToType(kind string) string {
 		return TypeGitLab
 	case KindGitolite:
 		return TypeGitolite
+	case KindGitea:
+		return TypeGitea
 	case KindPhabricator:
 		return TypePhabricator
 	case KindPerforce:
@@ -241,6 +255,8 @@ func TypeToKind(t string) string {
 	switch t {
 	case TypeAWSCodeCommit:
 		return KindAWSCodeCommit
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeBitbucketServer:
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
@@ -253,6 +269,8 @@ func TypeToKind(t string) string {
 		return KindGitLab
 	case TypeGitolite:
 		return KindGitolite
+	case TypeGitea:
+		return KindGitea
 	case TypePerforce:
 		return KindPerforce
 	case TypePhabricator:
@@ -296,6 +314,8 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
@@ -308,6 +328,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypePerforce:
 		return TypePerforce, true
 	case TypePhabricator:
@@ -339,6 +361,8 @@ func ParseServiceKind(s string) (string, bool) {
 	switch strings.ToUpper(s) {
 	case KindAWSCodeCommit:
 		return KindAWSCodeCommit, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindBitbucketServer:
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
@@ -351,6 +375,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindGitLab, true
 	case KindGitolite:
 		return KindGitolite, true
+	case KindGitea:
+		return KindGitea, true
 	case KindPerforce:
 		return KindPerforce, true
 	case KindPhabricator:
@@ -418,6 +444,8 @@ func getConfigPrototype(kind string) (any, error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		return &schema.AWSCodeCommitConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindBitbucketServer:
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
@@ -430,6 +458,8 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.GitLabConnection{}, nil
 	case KindGitolite:
 		return &schema.GitoliteConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindPerforce:
 		return &schema.PerforceConnection{}, nil
 	case KindPhabricator:
@@ -519,6 +549,10 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.AzureDevOpsConnection:
+		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -602,6 +636,19 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.AzureDevOpsConnection:
+		// Azure DevOps throttles based on resource consumption rather than
+		// request counts, so we default to a conservative 5/s.
+		limit = rate.Limit(18000.0 / 3600.0) // Same as the default in azuredevops.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.GiteaConnection:
+		// Gitea does not rate limit its API by default.
+		limit = rate.Limit(7200.0 / 3600.0) // Same as the default in gitea.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -731,6 +778,10 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -1494,6 +1494,8 @@ type CommitsOptions struct {
 	Reverse   bool // Whether or not commits should be given in reverse order (optional)
 	DateOrder bool // Whether or not commits should be sorted by date (optional)
 
+	FirstParent bool // Whether or not to only follow the first parent of merge commits (optional)
+
 	Path string // only commits modifying the given path are selected (optional)
 
 	Follow bool // follow the history of the path beyond renames (works only for a single path)
@@ -1906,6 +1908,9 @@ func commitLogArgs(initialArgs []string, opt CommitsOptions) (args []string, err
 	if opt.DateOrder {
 		args = append(args, "--date-order")
 	}
+	if opt.FirstParent {
+		args = append(args, "--first-parent")
+	}
 
 	if opt.MessageQuery != "" {
 		args = append(args, "--fixed-strings", "--regexp-ignore-case", "--grep="+opt.MessageQuery)
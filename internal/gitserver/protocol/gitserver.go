@@ -326,6 +326,12 @@ type PushConfig struct {
 	// Passphrase is the passphrase to decrypt the private key. It is required
 	// when passing PrivateKey.
 	Passphrase string
+
+	// PushRef, if set, is the ref on the remote the commit is pushed to
+	// instead of the target ref. Code hosts such as Gerrit create and update
+	// changes through pushes to magic refs like refs/for/<branch>. The target
+	// ref is still updated in the local repository.
+	PushRef string
 }
 
 // CreateCommitFromPatchResponse is the response type returned after creating
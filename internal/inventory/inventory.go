@@ -132,6 +132,23 @@ func GetLanguageByFilename(name string) (language string, safe bool) {
 	return enry.GetLanguageByExtension(name)
 }
 
+// GetLanguage returns the language of the named file with the given content.
+// When the filename alone is not conclusive, the language is detected from
+// the modeline, shebang, or content of the file. For example, files without an
+// extension, and .h files which may be C, C++ or Objective-C. It returns the
+// empty string if the language is unknown.
+func GetLanguage(name string, content []byte) string {
+	if language, safe := GetLanguageByFilename(name); safe {
+		return language
+	}
+	// Like getLang, we only pass a prefix of the file contents for
+	// analysis.
+	if len(content) > fileReadBufferSize {
+		content = content[:fileReadBufferSize]
+	}
+	return enry.GetLanguage(name, content)
+}
+
 func init() {
 	// Treat .tsx and .jsx as TypeScript and JavaScript, respectively, instead of distinct languages
 	// called "TSX" and "JSX". This is more consistent with user expectations.
@@ -141,6 +141,29 @@ func TestGet_readFile(t *testing.T) {
 	}
 }
 
+func TestGetLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "a.go", content: "package a", want: "Go"},
+		{name: "bin/deploy", content: "#!/usr/bin/env python3\nprint('hi')\n", want: "Python"},
+		{name: "configure", content: "#!/bin/sh\necho hi\n", want: "Shell"},
+		{name: "script", content: "# vim: set ft=ruby:\nputs 'hi'\n", want: "Ruby"},
+		{name: "a.h", content: "#include <string>\nnamespace a {\nclass A {\n public:\n  std::string b;\n};\n}\n", want: "C++"},
+		{name: "README", content: "hello", want: ""},
+		{name: "blob", content: "\x00\x01\x02", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := GetLanguage(test.name, []byte(test.content)); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
 type nopReadCloser struct {
 	data   []byte
 	reader *bytes.Reader
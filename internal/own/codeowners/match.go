@@ -0,0 +1,202 @@
+package codeowners
+
+import (
+	"path"
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// Ruleset evaluates the rules of a parsed CODEOWNERS file against file paths.
+//
+// Evaluation follows the semantics described on codeownerspb.Rule:
+//   - Within a section, the last rule that matches a path wins.
+//   - Every section is evaluated independently, so a path can have
+//     one matching rule per section. Rules outside of any section
+//     belong to the default section with an empty name.
+type Ruleset struct {
+	file  *codeownerspb.File
+	rules []*compiledRule
+}
+
+// compiledRule is a rule together with the pre-processed pattern
+// used for matching.
+type compiledRule struct {
+	rule    *codeownerspb.Rule
+	pattern *globPattern
+}
+
+// NewRuleset compiles the rules of the given file. Rules with patterns
+// that cannot be compiled never match any path.
+func NewRuleset(file *codeownerspb.File) *Ruleset {
+	rs := &Ruleset{file: file}
+	for _, rule := range file.GetRule() {
+		rs.rules = append(rs.rules, &compiledRule{
+			rule:    rule,
+			pattern: compile(rule.GetPattern()),
+		})
+	}
+	return rs
+}
+
+// GetFile returns the proto representation of the rules.
+func (rs *Ruleset) GetFile() *codeownerspb.File {
+	return rs.file
+}
+
+// Match returns the rule that applies to the given path in the default
+// section, or nil if there is none.
+func (rs *Ruleset) Match(filePath string) *codeownerspb.Rule {
+	return rs.MatchSections(filePath)[""]
+}
+
+// MatchSections returns the rule that applies to the given path for every
+// section that has a matching rule, keyed by section name.
+func (rs *Ruleset) MatchSections(filePath string) map[string]*codeownerspb.Rule {
+	filePath = strings.TrimPrefix(filePath, "/")
+	matched := map[string]*codeownerspb.Rule{}
+	// Iterate backwards, since the last matching rule in a section wins.
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		r := rs.rules[i]
+		section := r.rule.GetSectionName()
+		if _, ok := matched[section]; ok {
+			continue
+		}
+		if r.pattern.match(filePath) {
+			matched[section] = r.rule
+		}
+	}
+	return matched
+}
+
+// FindOwners returns the owners of the given path across all sections.
+// Owners that appear in multiple sections are only returned once.
+// An empty result means that the path has no owners, either because
+// no rule matches or because the matching rules list no owners.
+func (rs *Ruleset) FindOwners(filePath string) []*codeownerspb.Owner {
+	matched := rs.MatchSections(filePath)
+	// Visit sections in file order to keep the result deterministic.
+	var owners []*codeownerspb.Owner
+	seen := map[string]struct{}{}
+	for _, r := range rs.rules {
+		rule, ok := matched[r.rule.GetSectionName()]
+		if !ok || rule != r.rule {
+			continue
+		}
+		for _, o := range rule.GetOwner() {
+			key := o.GetHandle() + "\x00" + o.GetEmail()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
+
+const (
+	separator      = "/"
+	anySubPath     = "**"
+	anyPathSegment = "*"
+)
+
+// globPattern is a CODEOWNERS pattern split into path segments.
+// Every segment is either `**` (any number of path segments),
+// or a path.Match pattern that needs to match exactly one segment.
+type globPattern struct {
+	segments []string
+}
+
+// compile turns a CODEOWNERS file pattern into a globPattern:
+//   - A pattern starting with `/` is anchored at the repository root,
+//     otherwise it can match at any depth.
+//   - A pattern ending with `/` only matches the contents of a directory.
+//   - A pattern whose last segment is a literal name matches a file
+//     with that name as well as everything within a directory with that name.
+//   - A pattern whose last segment contains a wildcard only matches
+//     direct children, for instance `docs/*` does not match `docs/a/b.md`.
+//
+// A nil globPattern is returned for invalid patterns. It matches nothing.
+func compile(pattern string) *globPattern {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil
+	}
+	anchored := strings.HasPrefix(pattern, separator)
+	directory := strings.HasSuffix(pattern, separator)
+	pattern = strings.Trim(pattern, separator)
+	var segments []string
+	if !anchored {
+		segments = append(segments, anySubPath)
+	}
+	if pattern != "" {
+		for _, s := range strings.Split(pattern, separator) {
+			if s == "" {
+				// Tolerate duplicate separators like `docs//index.md`.
+				continue
+			}
+			// Consecutive `**` are equivalent to a single one.
+			if s == anySubPath && len(segments) > 0 && segments[len(segments)-1] == anySubPath {
+				continue
+			}
+			if s != anySubPath {
+				if _, err := path.Match(s, ""); err != nil {
+					return nil
+				}
+			}
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		// The pattern `/` denotes the repository root.
+		return &globPattern{segments: []string{anySubPath}}
+	}
+	last := segments[len(segments)-1]
+	switch {
+	case directory:
+		// Directory contents, but not a file with the directory name.
+		segments = append(segments, anyPathSegment, anySubPath)
+	case last == anySubPath:
+		// Already matches everything below.
+	case !hasWildcard(last):
+		// A literal name matches both a file and a directory.
+		segments = append(segments, anySubPath)
+	}
+	return &globPattern{segments: segments}
+}
+
+func hasWildcard(segment string) bool {
+	return strings.ContainsAny(segment, "*?[\\")
+}
+
+// match returns true if the given slash-separated path, relative
+// to the repository root, matches the pattern.
+func (p *globPattern) match(filePath string) bool {
+	if p == nil {
+		return false
+	}
+	return matchSegments(p.segments, strings.Split(filePath, separator))
+}
+
+func matchSegments(pattern, filePath []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == anySubPath {
+			// Try every possible number of consumed path segments.
+			for i := 0; i <= len(filePath); i++ {
+				if matchSegments(pattern[1:], filePath[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(filePath) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], filePath[0]); !ok {
+			return false
+		}
+		pattern, filePath = pattern[1:], filePath[1:]
+	}
+	return len(filePath) == 0
+}
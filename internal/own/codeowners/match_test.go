@@ -0,0 +1,177 @@
+package codeowners_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+func TestRulesetMatchPatterns(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		paths   map[string]bool
+	}{
+		{
+			pattern: "filename",
+			paths: map[string]bool{
+				"filename":                 true,
+				"src/filename":             true,
+				"src/filename/nested.go":   true,
+				"src/filename.go":          false,
+				"src/another_filename":     false,
+				"src/filename_and_more.md": false,
+			},
+		},
+		{
+			pattern: "/filename",
+			paths: map[string]bool{
+				"filename":     true,
+				"src/filename": false,
+			},
+		},
+		{
+			pattern: "directory/path/",
+			paths: map[string]bool{
+				"directory/path/file":                   true,
+				"src/directory/path/file":               true,
+				"src/directory/path/another/dir/file":   true,
+				"src/directory/path":                    false,
+				"src/another/directory/file":            false,
+				"src/directory/path_with_suffix/nested": false,
+			},
+		},
+		{
+			pattern: "directory/*",
+			paths: map[string]bool{
+				"directory/file":                 true,
+				"src/foo/bar/directory/file":     true,
+				"src/foo/bar/directory/dir/file": false,
+			},
+		},
+		{
+			pattern: "/src/dir/*",
+			paths: map[string]bool{
+				"src/dir/file":         true,
+				"main/src/dir/file":    false,
+				"src/dir/another/file": false,
+			},
+		},
+		{
+			pattern: "/docs/**/internal/",
+			paths: map[string]bool{
+				"docs/internal/file":           true,
+				"docs/foo/bar/internal/file":   true,
+				"docs/foo/bar/internal/a/file": true,
+				"src/docs/foo/internal/file":   false,
+				"docs/foo/bar/internal":        false,
+			},
+		},
+		{
+			pattern: "docs/*.md",
+			paths: map[string]bool{
+				"src/docs/index.md":      true,
+				"src/docs/index.js":      false,
+				"src/docs/nested/foo.md": false,
+			},
+		},
+		{
+			pattern: "/cmd/**",
+			paths: map[string]bool{
+				"cmd/main.go":         true,
+				"cmd/deeply/nested/x": true,
+				"src/cmd/main.go":     false,
+			},
+		},
+		{
+			pattern: "*",
+			paths: map[string]bool{
+				"README.md":       true,
+				"src/nested/file": true,
+			},
+		},
+		{
+			pattern: "*.js",
+			paths: map[string]bool{
+				"index.js":          true,
+				"client/web/app.js": true,
+				"client/web/app.ts": false,
+			},
+		},
+		{
+			pattern: "/",
+			paths: map[string]bool{
+				"README.md":       true,
+				"src/nested/file": true,
+			},
+		},
+		{
+			pattern: "[invalid",
+			paths: map[string]bool{
+				"[invalid": false,
+			},
+		},
+	} {
+		t.Run(tc.pattern, func(t *testing.T) {
+			rs := codeowners.NewRuleset(&codeownerspb.File{
+				Rule: []*codeownerspb.Rule{{
+					Pattern: tc.pattern,
+					Owner:   []*codeownerspb.Owner{{Handle: "owner"}},
+				}},
+			})
+			for path, want := range tc.paths {
+				got := rs.Match(path) != nil
+				assert.Equal(t, want, got, "pattern %q, path %q", tc.pattern, path)
+			}
+		})
+	}
+}
+
+func TestRulesetLastMatchWins(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader(`
+*           @global-owner
+*.js        @js-owner
+/apps/      @octocat
+/apps/github
+`))
+	require.NoError(t, err)
+	rs := codeowners.NewRuleset(file)
+
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "global-owner"}}, rs.FindOwners("README.md"))
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "js-owner"}}, rs.FindOwners("client/index.js"))
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "octocat"}}, rs.FindOwners("apps/index.js"))
+	assert.Empty(t, rs.FindOwners("apps/github/main.go"))
+	require.NotNil(t, rs.Match("apps/github/main.go"))
+	assert.Equal(t, "/apps/github", rs.Match("apps/github/main.go").Pattern)
+}
+
+func TestRulesetSections(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader(`
+*.go @go-owner
+
+[Documentation]
+docs/ @docs-owner
+*.md  @md-owner
+
+[Database]
+/migrations/ @db-owner @go-owner
+`))
+	require.NoError(t, err)
+	rs := codeowners.NewRuleset(file)
+
+	sections := rs.MatchSections("migrations/docs/README.md")
+	require.Len(t, sections, 2)
+	assert.Equal(t, "*.md", sections["documentation"].Pattern)
+	assert.Equal(t, "/migrations/", sections["database"].Pattern)
+	assert.Nil(t, rs.Match("migrations/docs/README.md"))
+
+	assert.Equal(t, []*codeownerspb.Owner{
+		{Handle: "go-owner"},
+		{Handle: "db-owner"},
+	}, rs.FindOwners("migrations/main.go"))
+}
@@ -0,0 +1,96 @@
+package own
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// Service gives access to code ownership data.
+type Service interface {
+	// OwnersFile returns the rules of the CODEOWNERS file of the given
+	// repository at the given commit. If there is no CODEOWNERS file,
+	// a nil Ruleset is returned without error.
+	OwnersFile(ctx context.Context, repo api.RepoName, commitID api.CommitID) (*codeowners.Ruleset, error)
+
+	// FindOwnersFile is like OwnersFile, but also returns the path of the
+	// CODEOWNERS file that was used. The path is empty if there is none.
+	FindOwnersFile(ctx context.Context, repo api.RepoName, commitID api.CommitID) (string, *codeowners.Ruleset, error)
+
+	// Owners returns the owners of the file at the given path in the given
+	// repository at the given commit, as defined by its CODEOWNERS file.
+	// No owners are returned if there is no CODEOWNERS file.
+	Owners(ctx context.Context, repo api.RepoName, commitID api.CommitID, path string) ([]*codeownerspb.Owner, error)
+}
+
+// NewService returns a Service that reads CODEOWNERS files through
+// the given gitserver client.
+func NewService(gitserverClient gitserver.Client) Service {
+	return &service{gitserverClient: gitserverClient}
+}
+
+type service struct {
+	gitserverClient gitserver.Client
+}
+
+// codeownersLocations are the paths at which a CODEOWNERS file is looked up,
+// in order of precedence. Only the first file found is considered.
+var codeownersLocations = []string{
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// ParseError is returned when the CODEOWNERS file of a repository exists but
+// cannot be parsed.
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parsing %s: %s", e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+func (s *service) OwnersFile(ctx context.Context, repo api.RepoName, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	_, rs, err := s.FindOwnersFile(ctx, repo, commitID)
+	return rs, err
+}
+
+func (s *service) FindOwnersFile(ctx context.Context, repo api.RepoName, commitID api.CommitID) (string, *codeowners.Ruleset, error) {
+	for _, path := range codeownersLocations {
+		content, err := s.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, commitID, path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", nil, errors.Wrapf(err, "reading %s", path)
+		}
+		file, err := codeowners.Parse(bytes.NewReader(content))
+		if err != nil {
+			return "", nil, &ParseError{Path: path, Err: err}
+		}
+		return path, codeowners.NewRuleset(file), nil
+	}
+	return "", nil, nil
+}
+
+func (s *service) Owners(ctx context.Context, repo api.RepoName, commitID api.CommitID, path string) ([]*codeownerspb.Owner, error) {
+	rs, err := s.OwnersFile(ctx, repo, commitID)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return rs.FindOwners(path), nil
+}
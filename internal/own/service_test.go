@@ -0,0 +1,87 @@
+package own
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+type repoFiles map[string]string
+
+func (fs repoFiles) readFile(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, path string) ([]byte, error) {
+	content, ok := fs[path]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	}
+	return []byte(content), nil
+}
+
+func TestOwners(t *testing.T) {
+	for name, tc := range map[string]struct {
+		files repoFiles
+		path  string
+		want  []*codeownerspb.Owner
+	}{
+		"no codeowners file": {
+			files: repoFiles{},
+			path:  "README.md",
+		},
+		"root codeowners file": {
+			files: repoFiles{"CODEOWNERS": "*.md @docs"},
+			path:  "README.md",
+			want:  []*codeownerspb.Owner{{Handle: "docs"}},
+		},
+		".github takes precedence over root": {
+			files: repoFiles{
+				"CODEOWNERS":         "*.md @root",
+				".github/CODEOWNERS": "*.md @github",
+			},
+			path: "README.md",
+			want: []*codeownerspb.Owner{{Handle: "github"}},
+		},
+		"docs codeowners file": {
+			files: repoFiles{"docs/CODEOWNERS": "/src/ owner@example.com"},
+			path:  "src/main.go",
+			want:  []*codeownerspb.Owner{{Email: "owner@example.com"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			git := gitserver.NewMockClient()
+			git.ReadFileFunc.SetDefaultHook(tc.files.readFile)
+
+			got, err := NewService(git).Owners(context.Background(), "github.com/sourcegraph/sourcegraph", "deadbeef", tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFindOwnersFile(t *testing.T) {
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repoFiles{
+		"CODEOWNERS":         "*.md @root",
+		".gitlab/CODEOWNERS": "*.md @gitlab",
+	}.readFile)
+
+	path, rs, err := NewService(git).FindOwnersFile(context.Background(), "github.com/sourcegraph/sourcegraph", "deadbeef")
+	require.NoError(t, err)
+	assert.Equal(t, ".gitlab/CODEOWNERS", path)
+	assert.Equal(t, []*codeownerspb.Owner{{Handle: "gitlab"}}, rs.FindOwners("README.md"))
+
+	// Lines longer than the scanner buffer cannot be parsed.
+	git.ReadFileFunc.SetDefaultHook(repoFiles{"CODEOWNERS": strings.Repeat("a", 1<<17)}.readFile)
+	_, _, err = NewService(git).FindOwnersFile(context.Background(), "github.com/sourcegraph/sourcegraph", "deadbeef")
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "CODEOWNERS", parseErr.Path)
+}
@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// globalBucket is a token bucket whose state is shared by all replicas of our
+// services, so that the rate limit of a code host is enforced across the whole
+// instance rather than per process.
+type globalBucket interface {
+	// reserve takes n tokens from the bucket stored at key, which refills at
+	// limit tokens per second up to burst tokens. It returns how long the
+	// caller has to wait before acting on the reservation. If the wait would
+	// exceed maxWait, no tokens are taken and ok is false. A negative maxWait
+	// means the caller is willing to wait indefinitely.
+	reserve(ctx context.Context, key string, limit rate.Limit, burst, n int, maxWait time.Duration) (wait time.Duration, ok bool, err error)
+
+	// tokens returns the number of tokens currently available in the bucket
+	// stored at key.
+	tokens(key string, limit rate.Limit, burst int) (float64, error)
+}
+
+// redisKeyPrefix is prepended to the URN of a rate limiter to build the key of
+// its token bucket in Redis.
+const redisKeyPrefix = "ratelimit:v1:"
+
+// redisRetryInterval is how long we wait before trying Redis again after a
+// failed request. In the meantime, rate limiters fall back to their local
+// limit.
+const redisRetryInterval = 10 * time.Second
+
+// reserveScript implements a token bucket in Redis. The bucket is stored as a
+// hash with the number of tokens left and the time (in microseconds) tokens
+// were last added. Tokens can go negative: like rate.Limiter, a reservation
+// is handed out immediately and callers wait until the debt is repaid.
+//
+// KEYS[1] is the bucket. ARGV is the rate (tokens per second), the burst, the
+// number of tokens to take, the current time in microseconds and the maximum
+// wait in microseconds (negative for no maximum). It returns the wait in
+// microseconds and 1 if the tokens were taken, 0 otherwise.
+var reserveScript = redis.NewScript(1, `
+local key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+local max_wait = tonumber(ARGV[5])
+
+local state = redis.call('HMGET', key, 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+if now > ts then
+  tokens = math.min(burst, tokens + (now - ts) * rate / 1000000)
+  ts = now
+end
+
+tokens = tokens - n
+local wait = 0
+if tokens < 0 then
+  wait = math.ceil(-tokens * 1000000 / rate)
+end
+if max_wait >= 0 and wait > max_wait then
+  return {wait, 0}
+end
+
+redis.call('HMSET', key, 'tokens', tostring(tokens), 'ts', string.format('%d', ts))
+-- Keep the bucket until it's full again, so that a bucket in debt can't
+-- expire and hand out a full burst early.
+redis.call('EXPIRE', key, math.ceil((burst - tokens) / rate) + 1)
+return {wait, 1}
+`)
+
+// redisBucket is a globalBucket stored in Redis. When Redis cannot be
+// reached, the bucket reports itself as unavailable for redisRetryInterval.
+type redisBucket struct {
+	pool *redis.Pool
+	now  func() time.Time
+
+	mu      sync.Mutex
+	retryAt time.Time
+}
+
+func newRedisBucket(pool *redis.Pool) *redisBucket {
+	return &redisBucket{pool: pool, now: time.Now}
+}
+
+func (b *redisBucket) reserve(_ context.Context, key string, limit rate.Limit, burst, n int, maxWait time.Duration) (time.Duration, bool, error) {
+	if !b.available() {
+		return 0, false, errRedisUnavailable
+	}
+
+	c := b.pool.Get()
+	defer c.Close()
+
+	maxWaitMicros := int64(-1)
+	if maxWait >= 0 {
+		maxWaitMicros = maxWait.Microseconds()
+	}
+
+	res, err := redis.Int64s(reserveScript.Do(c,
+		redisKeyPrefix+key,
+		strconv.FormatFloat(float64(limit), 'f', -1, 64),
+		burst,
+		n,
+		b.now().UnixMicro(),
+		maxWaitMicros,
+	))
+	if err != nil {
+		return 0, false, b.fail(err)
+	}
+	if len(res) != 2 {
+		return 0, false, errors.Errorf("ratelimit: unexpected reply from Redis: %v", res)
+	}
+
+	return time.Duration(res[0]) * time.Microsecond, res[1] == 1, nil
+}
+
+func (b *redisBucket) tokens(key string, limit rate.Limit, burst int) (float64, error) {
+	if !b.available() {
+		return 0, errRedisUnavailable
+	}
+
+	c := b.pool.Get()
+	defer c.Close()
+
+	vals, err := redis.Strings(c.Do("HMGET", redisKeyPrefix+key, "tokens", "ts"))
+	if err != nil {
+		return 0, b.fail(err)
+	}
+	if len(vals) != 2 || vals[0] == "" || vals[1] == "" {
+		// The bucket has not been used yet, or has expired because it was full.
+		return float64(burst), nil
+	}
+
+	tokens, err := strconv.ParseFloat(vals[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	ts, err := strconv.ParseFloat(vals[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	elapsed := float64(b.now().UnixMicro()) - ts
+	if elapsed > 0 {
+		tokens += elapsed * float64(limit) / 1e6
+	}
+	return math.Min(float64(burst), tokens), nil
+}
+
+// available returns false if Redis failed less than redisRetryInterval ago.
+func (b *redisBucket) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.now().Before(b.retryAt)
+}
+
+// fail records that Redis could not be reached and returns err.
+func (b *redisBucket) fail(err error) error {
+	b.mu.Lock()
+	b.retryAt = b.now().Add(redisRetryInterval)
+	b.mu.Unlock()
+	return err
+}
+
+// errRedisUnavailable is returned by redisBucket while Redis is considered
+// unreachable.
+var errRedisUnavailable = errors.New("ratelimit: redis unavailable")
+
+var metricGlobalFallback = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_internal_rate_limit_global_fallback_total",
+	Help: "Number of times our internal rate limiter fell back to local limiting because the shared Redis bucket was unavailable",
+}, []string{"urn"})
@@ -0,0 +1,258 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeBucket struct {
+	wait   time.Duration
+	ok     bool
+	remain float64
+	err    error
+
+	calls   int
+	maxWait time.Duration
+}
+
+func (b *fakeBucket) reserve(_ context.Context, _ string, _ rate.Limit, _, _ int, maxWait time.Duration) (time.Duration, bool, error) {
+	b.calls++
+	b.maxWait = maxWait
+	return b.wait, b.ok, b.err
+}
+
+func (b *fakeBucket) tokens(string, rate.Limit, int) (float64, error) {
+	return b.remain, b.err
+}
+
+func TestInstrumentedLimiter_Global(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("waits for the shared bucket", func(t *testing.T) {
+		b := &fakeBucket{wait: 10 * time.Millisecond, ok: true}
+		l := NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(1, 1))
+		l.global = b
+
+		// The local limiter is never touched, so we can wait for more than its
+		// budget.
+		for i := 0; i < 3; i++ {
+			require.NoError(t, l.Wait(ctx))
+		}
+		assert.Equal(t, 3, b.calls)
+		assert.Equal(t, time.Duration(-1), b.maxWait)
+		assert.Equal(t, float64(1), l.Tokens())
+	})
+
+	t.Run("fails if the wait exceeds the deadline", func(t *testing.T) {
+		b := &fakeBucket{wait: time.Hour, ok: false}
+		l := NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(1, 1))
+		l.global = b
+
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		assert.Error(t, l.Wait(ctx))
+		assert.Greater(t, b.maxWait, time.Duration(0))
+		assert.LessOrEqual(t, b.maxWait, time.Minute)
+	})
+
+	t.Run("fails if n exceeds burst", func(t *testing.T) {
+		b := &fakeBucket{ok: true}
+		l := NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(1, 1))
+		l.global = b
+
+		assert.Error(t, l.WaitN(ctx, 2))
+		assert.Equal(t, 0, b.calls)
+	})
+
+	t.Run("falls back to the local limiter", func(t *testing.T) {
+		b := &fakeBucket{err: errRedisUnavailable}
+		l := NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(1, 1))
+		l.global = b
+
+		require.NoError(t, l.Wait(ctx))
+		assert.Equal(t, 1, b.calls)
+		assert.Less(t, l.Tokens(), float64(1))
+	})
+
+	t.Run("infinite limits are local", func(t *testing.T) {
+		b := &fakeBucket{err: errors.New("should not be called")}
+		l := NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(rate.Inf, 1))
+		l.global = b
+
+		require.NoError(t, l.Wait(ctx))
+		assert.Equal(t, 0, b.calls)
+	})
+}
+
+func TestRegistry_Global(t *testing.T) {
+	b := &fakeBucket{remain: 4.5}
+	r := NewRegistry()
+	r.global = b
+
+	r.getOrSet("extsvc:github:1", NewInstrumentedLimiter("extsvc:github:1", rate.NewLimiter(rate.Inf, 1)))
+	r.getOrSet("extsvc:github:2", NewInstrumentedLimiter("extsvc:github:2", rate.NewLimiter(10, 5)))
+	assert.Equal(t, globalBucket(b), r.Get("extsvc:github:2").global)
+
+	info := r.LimitInfo()
+	assert.Equal(t, LimitInfo{
+		Limit:    0,
+		Burst:    1,
+		Infinite: true,
+	}, info["extsvc:github:1"])
+	assert.Equal(t, LimitInfo{
+		Limit:     10,
+		Burst:     5,
+		Global:    true,
+		Remaining: 4.5,
+	}, info["extsvc:github:2"])
+
+	b.err = errRedisUnavailable
+	info = r.LimitInfo()
+	assert.Equal(t, LimitInfo{
+		Limit:    10,
+		Burst:    5,
+		Global:   true,
+		Fallback: true,
+	}, info["extsvc:github:2"])
+}
+
+func redisTestPool(t *testing.T) *redis.Pool {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle: 3,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	c := pool.Get()
+	_, err := c.Do("PING")
+	c.Close()
+	if err != nil {
+		if os.Getenv("CI") == "" {
+			t.Skip("could not connect to redis", err)
+		}
+		t.Fatal(err)
+	}
+	return pool
+}
+
+func TestRedisBucket(t *testing.T) {
+	pool := redisTestPool(t)
+
+	now := time.Now()
+	b := newRedisBucket(pool)
+	b.now = func() time.Time { return now }
+
+	key := "__test__" + t.Name()
+	c := pool.Get()
+	_, err := c.Do("DEL", redisKeyPrefix+key)
+	c.Close()
+	require.NoError(t, err)
+
+	remaining, err := b.tokens(key, 10, 5)
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), remaining)
+
+	// The burst can be used right away.
+	wait, ok, err := b.reserve(context.Background(), key, 10, 5, 5, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// The next token is available in 1/10th of a second.
+	wait, ok, err = b.reserve(context.Background(), key, 10, 5, 1, -1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, wait)
+
+	// Waiting for more than maxWait doesn't take any tokens.
+	wait, ok, err = b.reserve(context.Background(), key, 10, 5, 1, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 200*time.Millisecond, wait)
+
+	remaining, err = b.tokens(key, 10, 5)
+	require.NoError(t, err)
+	assert.Equal(t, float64(-1), remaining)
+
+	// Tokens are refilled over time, up to the burst.
+	now = now.Add(time.Second)
+	remaining, err = b.tokens(key, 10, 5)
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), remaining)
+}
+
+func TestRedisBucket_ExpiryInDebt(t *testing.T) {
+	pool := redisTestPool(t)
+
+	now := time.Now()
+	b := newRedisBucket(pool)
+	b.now = func() time.Time { return now }
+
+	key := "__test__" + t.Name()
+	c := pool.Get()
+	defer c.Close()
+	_, err := c.Do("DEL", redisKeyPrefix+key)
+	require.NoError(t, err)
+
+	// Take 10 tokens from a bucket of 2 that refills at 1 token per second,
+	// leaving it 8 tokens in debt.
+	wait, ok, err := b.reserve(context.Background(), key, 1, 2, 10, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 8*time.Second, wait)
+
+	// The bucket must outlive its debt: it takes 10 seconds to be full again.
+	ttl, err := redis.Int(c.Do("TTL", redisKeyPrefix+key))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, ttl, 10)
+
+	// Until then, the debt is still being repaid.
+	now = now.Add(9 * time.Second)
+	remaining, err := b.tokens(key, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), remaining)
+
+	// Once the bucket expires, starting over with a full burst doesn't hand
+	// out more tokens than the refill would have.
+	_, err = c.Do("DEL", redisKeyPrefix+key)
+	require.NoError(t, err)
+	now = now.Add(time.Duration(ttl-9) * time.Second)
+	wait, ok, err = b.reserve(context.Background(), key, 1, 2, 2, 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+}
+
+func TestRedisBucket_Unavailable(t *testing.T) {
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	now := time.Now()
+	b := newRedisBucket(pool)
+	b.now = func() time.Time { return now }
+
+	_, _, err := b.reserve(context.Background(), "extsvc:github:1", 10, 5, 1, -1)
+	require.Error(t, err)
+	assert.False(t, b.available())
+
+	_, err = b.tokens("extsvc:github:1", 10, 5)
+	assert.Equal(t, errRedisUnavailable, err)
+
+	now = now.Add(redisRetryInterval)
+	assert.True(t, b.available())
+}
@@ -6,17 +6,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gomodule/redigo/redis"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/time/rate"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // DefaultRegistry is the default global rate limit registry, which holds rate
-// limit mappings for each instance of our services.
-var DefaultRegistry = NewRegistry()
+// limit mappings for each instance of our services. Its rate limiters share
+// their budget across all instances through Redis.
+var DefaultRegistry = NewGlobalRegistry(redispool.Cache)
 
 const defaultBurst = 10
 
@@ -28,12 +31,25 @@ func NewRegistry() *Registry {
 	}
 }
 
+// NewGlobalRegistry creates and returns an empty rate limit registry whose rate
+// limiters enforce their limit across all instances of our services, by
+// sharing a token bucket stored in the given Redis pool. When Redis is
+// unreachable, the rate limiters fall back to limiting locally.
+func NewGlobalRegistry(pool *redis.Pool) *Registry {
+	r := NewRegistry()
+	r.global = newRedisBucket(pool)
+	return r
+}
+
 // Registry manages rate limiters for external services.
 type Registry struct {
 	mu sync.Mutex
 	// rateLimiters contains mappings of external service to its *rate.Limiter. The
 	// key should be the URN of the external service.
 	rateLimiters map[string]*InstrumentedLimiter
+	// global is the shared token bucket used by the rate limiters of this
+	// registry. It is nil if rate limits are only enforced locally.
+	global globalBucket
 }
 
 // Get returns the rate limiter configured for the given URN of an external
@@ -68,6 +84,9 @@ func (r *Registry) getOrSet(urn string, fallback *InstrumentedLimiter) *Instrume
 		}
 		fallback = NewInstrumentedLimiter(urn, rate.NewLimiter(fallbackRateLimit, defaultBurst))
 	}
+	if fallback.global == nil {
+		fallback.global = r.global
+	}
 	r.rateLimiters[urn] = fallback
 	return fallback
 }
@@ -88,16 +107,28 @@ type LimitInfo struct {
 	// Infinite is true if Limit is infinite. This is required since infinity cannot
 	// be marshalled in JSON.
 	Infinite bool
+	// Global is true if the limit is shared by all instances of our services.
+	Global bool
+	// Fallback is true if the limit is global, but the shared budget is
+	// currently unavailable and the limit is enforced locally instead.
+	Fallback bool
+	// Remaining is the number of requests left in the shared budget. It is only
+	// set if Global is true and Fallback is false.
+	Remaining float64
 }
 
 // LimitInfo reports how all the existing rate limiters are configured, keyed by
 // URN.
 func (r *Registry) LimitInfo() map[string]LimitInfo {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	m := make(map[string]LimitInfo, len(r.rateLimiters))
+	limiters := make(map[string]*InstrumentedLimiter, len(r.rateLimiters))
 	for urn, rl := range r.rateLimiters {
+		limiters[urn] = rl
+	}
+	r.mu.Unlock()
+
+	m := make(map[string]LimitInfo, len(limiters))
+	for urn, rl := range limiters {
 		limit := rl.Limit()
 		info := LimitInfo{
 			Burst: rl.Burst(),
@@ -107,6 +138,15 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 			info.Limit = 0
 			info.Infinite = true
 		}
+		if rl.isGlobal() {
+			info.Global = true
+			remaining, err := rl.global.tokens(urn, limit, info.Burst)
+			if err != nil {
+				info.Fallback = true
+			} else {
+				info.Remaining = remaining
+			}
+		}
 		m[urn] = info
 	}
 	return m
@@ -116,6 +156,11 @@ func (r *Registry) LimitInfo() map[string]LimitInfo {
 type InstrumentedLimiter struct {
 	urn string
 	*rate.Limiter
+
+	// global, if set, is the token bucket shared with other instances of our
+	// services. The wrapped *rate.Limiter holds its configuration and is used
+	// when the bucket is unavailable.
+	global globalBucket
 }
 
 // NewInstrumentedLimiter creates new InstrumentedLimiter with given URN and rate.Limiter
@@ -143,7 +188,12 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	}
 
 	start := time.Now()
-	err := i.Limiter.WaitN(ctx, n)
+	var err error
+	if i.isGlobal() {
+		err = i.waitGlobalN(ctx, n)
+	} else {
+		err = i.Limiter.WaitN(ctx, n)
+	}
 	d := time.Since(start)
 	failedLabel := "false"
 	if err != nil {
@@ -154,6 +204,52 @@ func (i *InstrumentedLimiter) WaitN(ctx context.Context, n int) error {
 	return err
 }
 
+// isGlobal returns true if the limit is enforced using the shared token
+// bucket. Infinite and zero limits don't need to be coordinated, so they are
+// always enforced locally.
+func (i *InstrumentedLimiter) isGlobal() bool {
+	limit := i.Limit()
+	return i.global != nil && limit != rate.Inf && limit > 0
+}
+
+// waitGlobalN blocks until the shared token bucket permits n events to happen.
+// If the bucket is unavailable, it falls back to the wrapped *rate.Limiter.
+func (i *InstrumentedLimiter) waitGlobalN(ctx context.Context, n int) error {
+	limit, burst := i.Limit(), i.Burst()
+	if n > burst {
+		return errors.Errorf("rate: Wait(n=%d) exceeds limiter's burst %d", n, burst)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	maxWait := time.Duration(-1)
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = time.Until(deadline)
+	}
+
+	wait, ok, err := i.global.reserve(ctx, i.urn, limit, burst, n, maxWait)
+	if err != nil {
+		metricGlobalFallback.WithLabelValues(i.urn).Inc()
+		return i.Limiter.WaitN(ctx, n)
+	}
+	if !ok {
+		return errors.Errorf("rate: Wait(n=%d) would exceed context deadline", n)
+	}
+	if wait <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SetBurst is calling SetBurstAt(time.Now(), newBurst) method of the wrapped *rate.Limiter.
 func (i *InstrumentedLimiter) SetBurst(newBurst int) {
 	i.Limiter.SetBurstAt(time.Now(), newBurst)
@@ -0,0 +1,205 @@
+package repos
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/goware/urlx"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps
+// connection configured in Sourcegraph via the external services
+// configuration.
+type AzureDevOpsSource struct {
+	svc       *types.ExternalService
+	cli       *azuredevops.Client
+	exclude   excludeFunc
+	serviceID string
+	perPage   int
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external
+// service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:       svc,
+		cli:       cli,
+		exclude:   exclude,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		perPage:   100,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s *AzureDevOpsSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this
+// AzureDevOpsSource's config. Repositories of every project of the configured
+// orgs are listed first, followed by the repositories of the configured
+// projects.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]struct{})
+
+	// sendProject sends all repositories of the given project that have not
+	// been seen yet. If listing the repositories fails, the error is sent
+	// instead and the remaining projects are still listed.
+	sendProject := func(org, project string) {
+		repos, err := s.cli.ListRepositoriesByProject(ctx, org, project)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		for _, r := range repos {
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+
+			if r.IsDisabled || s.excludes(r) {
+				continue
+			}
+
+			repo, err := s.makeRepo(r)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: repo}
+		}
+	}
+
+	for _, org := range s.cli.Config.Orgs {
+		var token string
+		for {
+			projects, next, err := s.cli.ListProjects(ctx, azuredevops.ListProjectsArgs{
+				Org:               org,
+				Top:               s.perPage,
+				ContinuationToken: token,
+			})
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				break
+			}
+
+			for _, p := range projects {
+				sendProject(org, p.Name)
+			}
+
+			if next == "" {
+				break
+			}
+			token = next
+		}
+	}
+
+	for _, p := range s.cli.Config.Projects {
+		org, project, ok := strings.Cut(p, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Azure DevOps project %q, expected org/project", p)}
+			continue
+		}
+		sendProject(org, project)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// excludes returns true if the repository, identified by its
+// "org/project/repository" name, is excluded by the config.
+func (s *AzureDevOpsSource) excludes(r *azuredevops.Repository) bool {
+	org, err := r.Org()
+	if err != nil {
+		return false
+	}
+	return s.exclude(path.Join(org, r.Project.Name, r.Name))
+}
+
+func (s *AzureDevOpsSource) makeRepo(r *azuredevops.Repository) (*types.Repo, error) {
+	urn := s.svc.URN()
+
+	webURL, err := urlx.Parse(r.WebURL)
+	if err != nil {
+		return nil, err
+	}
+	org, err := r.Org()
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps Server may be served from a virtual directory such as
+	// /tfs, which is kept in the repository name.
+	segments := strings.Split(strings.Trim(webURL.Path, "/"), "/")
+	host := path.Join(append([]string{webURL.Host}, segments[:len(segments)-4]...)...)
+	name := reposource.AzureDevOpsRepoName(host, org, r.Project.Name, r.Name)
+
+	return &types.Repo{
+		Name:        name,
+		URI:         string(name),
+		Description: r.Project.Description,
+		Fork:        r.IsFork,
+		Private:     r.IsPrivate(),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.RemoteURL,
+			},
+		},
+		Metadata: r,
+	}, nil
+}
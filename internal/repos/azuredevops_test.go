@@ -0,0 +1,121 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf *schema.AzureDevOpsConnection
+	}{
+		{
+			name: "orgs",
+			conf: &schema.AzureDevOpsConnection{
+				Url:      "https://dev.azure.com",
+				Username: os.Getenv("AZURE_DEVOPS_USERNAME"),
+				Token:    os.Getenv("AZURE_DEVOPS_TOKEN"),
+				Orgs:     []string{"sgtestazure"},
+				Exclude: []*schema.ExcludedAzureDevOpsServerRepo{
+					{Pattern: "^sgtestazure/sgtestazure/src-"},
+				},
+			},
+		},
+		{
+			name: "projects",
+			conf: &schema.AzureDevOpsConnection{
+				Url:      "https://dev.azure.com",
+				Username: os.Getenv("AZURE_DEVOPS_USERNAME"),
+				Token:    os.Getenv("AZURE_DEVOPS_TOKEN"),
+				Projects: []string{"sgtestazure/sg-test-public"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, t.Name())
+			defer save(t)
+
+			svc := &types.ExternalService{
+				Kind:   extsvc.KindAzureDevOps,
+				Config: extsvc.NewUnencryptedConfig(marshalJSON(t, tc.conf)),
+			}
+
+			ctx := context.Background()
+			src, err := NewAzureDevOpsSource(ctx, svc, cf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			src.perPage = 2 // 2 pages for 3 projects
+
+			repos, err := listAll(ctx, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutil.AssertGolden(t, "testdata/sources/AZUREDEVOPS/"+t.Name(), update(t.Name()), repos)
+		})
+	}
+}
+
+func TestAzureDevOpsSource_ListRepos_ProjectError(t *testing.T) {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/org/broken/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/org/ok/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"count": 1,
+			"value": []map[string]any{{
+				"id":        "1",
+				"name":      "repo",
+				"webUrl":    server.URL + "/org/ok/_git/repo",
+				"remoteUrl": server.URL + "/org/ok/_git/repo",
+				"project":   map[string]any{"name": "ok", "visibility": "private"},
+			}},
+		})
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AzureDevOpsConnection{
+			Url:      server.URL,
+			Username: "user",
+			Token:    "secret",
+			Projects: []string{"org/broken", "org/ok"},
+		})),
+	}
+
+	ctx := context.Background()
+	src, err := NewAzureDevOpsSource(ctx, svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A project that can't be listed must not stop the remaining projects
+	// from being synced.
+	repos, err := listAll(ctx, src)
+	if err == nil {
+		t.Fatal("expected an error for the broken project")
+	}
+	if len(repos) != 1 || repos[0].ExternalRepo.ID != "1" {
+		t.Fatalf("expected the repository of the other project, got %v", repos)
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -52,6 +54,10 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		if r, ok := repo.Metadata.(*awscodecommit.Repository); ok {
 			return awsCodeCloneURL(logger, r, t), nil
 		}
+	case *schema.AzureDevOpsConnection:
+		if r, ok := repo.Metadata.(*azuredevops.Repository); ok {
+			return azureDevOpsCloneURL(logger, r, t), nil
+		}
 	case *schema.BitbucketServerConnection:
 		if r, ok := repo.Metadata.(*bitbucketserver.Repo); ok {
 			return bitbucketServerCloneURL(r, t), nil
@@ -72,6 +78,10 @@ func cloneURL(parsed any, logger log.Logger, kind string, repo *types.Repo) (str
 		if r, ok := repo.Metadata.(*gitlab.Project); ok {
 			return gitlabCloneURL(logger, r, t), nil
 		}
+	case *schema.GiteaConnection:
+		if r, ok := repo.Metadata.(*gitea.Repository); ok {
+			return giteaCloneURL(logger, r, t), nil
+		}
 	case *schema.GitoliteConnection:
 		if r, ok := repo.Metadata.(*gitolite.Repo); ok {
 			return r.URL, nil
@@ -128,6 +138,17 @@ func awsCodeCloneURL(logger log.Logger, repo *awscodecommit.Repository, cfg *sch
 	return u.String()
 }
 
+func azureDevOpsCloneURL(logger log.Logger, repo *azuredevops.Repository, cfg *schema.AzureDevOpsConnection) string {
+	u, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Azure DevOps repository Git remote URL.", log.String("url", repo.RemoteURL), log.Error(err))
+		return repo.RemoteURL
+	}
+
+	u.User = url.UserPassword(cfg.Username, cfg.Token)
+	return u.String()
+}
+
 func bitbucketServerCloneURL(repo *bitbucketserver.Repo, cfg *schema.BitbucketServerConnection) string {
 	var cloneURL string
 	for _, l := range repo.Links.Clone {
@@ -179,6 +200,24 @@ func bitbucketCloudCloneURL(logger log.Logger, repo *bitbucketcloud.Repo, cfg *s
 	return u.String()
 }
 
+func giteaCloneURL(logger log.Logger, repo *gitea.Repository, cfg *schema.GiteaConnection) string {
+	if cfg.GitURLType == "ssh" {
+		return repo.SSHURL
+	}
+
+	if cfg.Token == "" {
+		return repo.CloneURL
+	}
+	u, err := url.Parse(repo.CloneURL)
+	if err != nil {
+		logger.Warn("Error adding authentication to Gitea repository Git remote URL.", log.String("url", repo.CloneURL), log.Error(err))
+		return repo.CloneURL
+	}
+
+	u.User = url.UserPassword("oauth2", cfg.Token)
+	return u.String()
+}
+
 func githubCloneURL(logger log.Logger, repo *github.Repository, cfg *schema.GitHubConnection) (string, error) {
 	if cfg.GitURLType == "ssh" {
 		baseURL, err := url.Parse(cfg.Url)
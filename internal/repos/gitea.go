@@ -0,0 +1,163 @@
+package repos
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea connection configured
+// in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc       *types.ExternalService
+	cli       *gitea.Client
+	exclude   excludeFunc
+	serviceID string
+	perPage   int
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:       svc,
+		cli:       cli,
+		exclude:   exclude,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		perPage:   50,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors returned
+// from the subsequent calls. This is going to be expanded as part of issue #44683
+// to actually only return true if the source can serve requests.
+func (s *GiteaSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config. Repositories are yielded at most once, even when matched by more
+// than one of the "orgs", "users" and "repositoryQuery" settings.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	var its []*iterator.Iterator[*gitea.Repository]
+	for _, org := range s.cli.Config.Orgs {
+		its = append(its, s.cli.ListOrgRepos(ctx, org, s.perPage))
+	}
+	for _, user := range s.cli.Config.Users {
+		its = append(its, s.cli.ListUserRepos(ctx, user, s.perPage))
+	}
+	for _, q := range s.cli.Config.RepositoryQuery {
+		switch q {
+		case "none":
+			continue
+		case "all":
+			q = ""
+		}
+		its = append(its, s.cli.SearchRepos(ctx, q, s.perPage))
+	}
+
+	seen := make(map[int64]struct{})
+	for _, it := range its {
+		for it.Next() {
+			r := it.Current()
+			if _, ok := seen[r.ID]; ok {
+				continue
+			}
+			seen[r.ID] = struct{}{}
+
+			if s.excludes(r) {
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+		}
+
+		if err := it.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	return s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10))
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+
+	name := reposource.GiteaRepoName(
+		s.cli.Config.RepositoryPathPattern,
+		s.cli.URL.Hostname(),
+		r.FullName,
+	)
+
+	return &types.Repo{
+		Name:        name,
+		URI:         string(reposource.GiteaRepoName("", s.cli.URL.Hostname(), r.FullName)),
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private || r.Internal,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: r.CloneURL,
+			},
+		},
+		Metadata: r,
+	}
+}
@@ -0,0 +1,60 @@
+package repos
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf *schema.GiteaConnection
+	}{
+		{
+			name: "orgs users and query",
+			conf: &schema.GiteaConnection{
+				Url:             "https://gitea.com",
+				Token:           os.Getenv("GITEA_TOKEN"),
+				Orgs:            []string{"sourcegraph-testing"},
+				Users:           []string{"sgtest"},
+				RepositoryQuery: []string{"tools"},
+				Exclude: []*schema.ExcludedGiteaRepo{
+					{Name: "sourcegraph-testing/src-cli"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cf, save := newClientFactory(t, t.Name())
+			defer save(t)
+
+			svc := &types.ExternalService{
+				Kind:   extsvc.KindGitea,
+				Config: extsvc.NewUnencryptedConfig(marshalJSON(t, tc.conf)),
+			}
+
+			ctx := context.Background()
+			src, err := NewGiteaSource(ctx, svc, cf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			src.perPage = 2 // 2 pages for 3 org repos
+
+			repos, err := listAll(ctx, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutil.AssertGolden(t, "testdata/sources/GITEA/"+t.Name(), update(t.Name()), repos)
+		})
+	}
+}
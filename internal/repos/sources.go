@@ -70,6 +70,10 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewJVMPackagesSource(ctx, svc)
 	case extsvc.KindPagure:
 		return NewPagureSource(ctx, svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(ctx, svc, cf)
 	case extsvc.KindPythonPackages:
@@ -0,0 +1,153 @@
+package sbom
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// See https://cyclonedx.org/docs/1.5/json/ for the specification.
+
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Group      string        `json:"group,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Scope      string        `json:"scope,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// EncodeCycloneDX writes the document as CycloneDX 1.5 JSON.
+func EncodeCycloneDX(w io.Writer, doc *Document) error {
+	rootRef := "repository"
+
+	root := cdxComponent{
+		Type:    "application",
+		BOMRef:  rootRef,
+		Name:    string(doc.Repo),
+		Version: string(doc.Commit),
+	}
+	for _, lang := range doc.Languages {
+		root.Properties = append(root.Properties, cdxProperty{
+			Name:  "sourcegraph:language:" + lang.Name + ":bytes",
+			Value: strconv.FormatUint(lang.TotalBytes, 10),
+		})
+	}
+
+	refs := make([]string, len(doc.Packages))
+	for i, pkg := range doc.Packages {
+		refs[i] = pkg.PURL
+		if refs[i] == "" {
+			refs[i] = pkg.Scheme + ":" + pkg.Name + "@" + pkg.Version
+		}
+	}
+
+	components := make([]cdxComponent, 0, len(doc.Packages))
+	rootDependency := cdxDependency{Ref: rootRef, DependsOn: []string{}}
+	dependencies := []cdxDependency{}
+	for i, pkg := range doc.Packages {
+		group, name := cdxGroupAndName(pkg)
+
+		component := cdxComponent{
+			Type:    "library",
+			BOMRef:  refs[i],
+			Group:   group,
+			Name:    name,
+			Version: pkg.Version,
+			Scope:   "required",
+			PURL:    pkg.PURL,
+		}
+		for _, lockfile := range pkg.Lockfiles {
+			component.Properties = append(component.Properties, cdxProperty{Name: "sourcegraph:lockfile", Value: lockfile})
+		}
+		if pkg.Direct {
+			component.Properties = append(component.Properties, cdxProperty{Name: "sourcegraph:direct", Value: "true"})
+			rootDependency.DependsOn = append(rootDependency.DependsOn, refs[i])
+		}
+		components = append(components, component)
+
+		// Only list the dependencies of packages whose dependencies are known,
+		// as an empty list would claim the package has none.
+		if pkg.DependsOn != nil {
+			dependency := cdxDependency{Ref: refs[i], DependsOn: make([]string, 0, len(pkg.DependsOn))}
+			for _, j := range pkg.DependsOn {
+				dependency.DependsOn = append(dependency.DependsOn, refs[j])
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+
+	bom := cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewSHA1(uuid.NameSpaceURL, []byte(doc.Namespace+"#"+doc.Created.UTC().Format(time.RFC3339))).String(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: doc.Created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: "application", Group: "com.sourcegraph", Name: "sourcegraph", Version: doc.ToolVersion},
+			}},
+			Component: root,
+		},
+		Components:   components,
+		Dependencies: append([]cdxDependency{rootDependency}, dependencies...),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bom)
+}
+
+// cdxGroupAndName splits the namespace of Maven and scoped npm packages into
+// the group of the component.
+func cdxGroupAndName(pkg Package) (group, name string) {
+	switch purlTypes[pkg.Scheme] {
+	case "maven":
+		if group, name, ok := strings.Cut(pkg.Name, ":"); ok {
+			return group, name
+		}
+	case "npm":
+		if strings.HasPrefix(pkg.Name, "@") {
+			if group, name, ok := strings.Cut(pkg.Name, "/"); ok {
+				return group, name
+			}
+		}
+	}
+	return "", pkg.Name
+}
@@ -0,0 +1,124 @@
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// exportPageSize is the number of repositories read from the database at a
+// time during an export.
+const exportPageSize = 100
+
+type exporter struct {
+	svc    *Service
+	store  uploadstore.Store
+	logger log.Logger
+}
+
+// NewExporter returns a background routine that periodically writes the SBOMs
+// of all repositories with indexed lockfiles to the given upload store, in
+// every supported format. Each export is written under a prefix named after
+// the date it started on, and is complete once its manifest.json is written.
+func NewExporter(svc *Service, store uploadstore.Store, interval time.Duration) goroutine.BackgroundRoutine {
+	e := &exporter{
+		svc:    svc,
+		store:  store,
+		logger: svc.logger.Scoped("exporter", "exports the SBOMs of repositories to the upload store"),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		context.Background(),
+		"sbom.exporter", "exports the SBOMs of repositories to the upload store",
+		interval,
+		goroutine.HandlerFunc(e.export),
+	)
+}
+
+// manifestEntry describes the files of a repository in an export.
+type manifestEntry struct {
+	Repo   string            `json:"repo"`
+	Commit string            `json:"commit"`
+	Files  map[Format]string `json:"files"`
+}
+
+func (e *exporter) export(ctx context.Context) error {
+	// We should use an internal actor when doing cross service calls.
+	ctx = actor.WithInternalActor(ctx)
+
+	prefix := e.svc.now().UTC().Format("2006-01-02")
+	manifest := []manifestEntry{}
+
+	var errs error
+	opts := dependencies.ListLockfileIndexesOpts{Limit: exportPageSize}
+	for {
+		indexes, err := e.svc.dependencies.ListLockfileIndexes(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "failed to list lockfile indexes")
+		}
+		if len(indexes) == 0 {
+			break
+		}
+
+		for _, index := range indexes {
+			entry, err := e.exportRepo(ctx, prefix, index)
+			if err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "failed to export SBOM of repo %s", index.RepoName))
+				continue
+			}
+			manifest = append(manifest, entry)
+		}
+
+		opts.After = indexes[len(indexes)-1].RepoID
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Append(errs, err)
+	}
+	if _, err := e.store.Upload(ctx, path.Join(prefix, "manifest.json"), bytes.NewReader(b)); err != nil {
+		return errors.Append(errs, errors.Wrap(err, "failed to upload manifest"))
+	}
+
+	e.logger.Info("exported SBOMs", log.String("prefix", prefix), log.Int("repos", len(manifest)))
+	return errs
+}
+
+func (e *exporter) exportRepo(ctx context.Context, prefix string, index shared.LockfileIndex) (manifestEntry, error) {
+	doc, err := e.svc.Generate(ctx, authz.DefaultSubRepoPermsChecker, index.RepoID, index.RepoName, index.Commit)
+	if err != nil {
+		return manifestEntry{}, err
+	}
+
+	entry := manifestEntry{
+		Repo:   string(index.RepoName),
+		Commit: string(index.Commit),
+		Files:  make(map[Format]string, len(Formats)),
+	}
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		if err := Encode(&buf, doc, format); err != nil {
+			return manifestEntry{}, err
+		}
+
+		key := path.Join(prefix, string(index.RepoName), string(index.Commit)+format.FileExtension())
+		if _, err := e.store.Upload(ctx, key, &buf); err != nil {
+			return manifestEntry{}, err
+		}
+		entry.Files[format] = key
+	}
+
+	return entry, nil
+}
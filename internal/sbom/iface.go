@@ -0,0 +1,21 @@
+package sbom
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+type DependenciesService interface {
+	ListLockfileIndexes(ctx context.Context, opts dependencies.ListLockfileIndexesOpts) ([]shared.LockfileIndex, error)
+	LockfileGraphs(ctx context.Context, repoID api.RepoID, commit api.CommitID) (map[string]*shared.DependencyGraph, error)
+}
+
+type GitserverClient interface {
+	dependencies.GitserverClient
+	ReadDir(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string, recurse bool) ([]fs.FileInfo, error)
+}
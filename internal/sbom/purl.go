@@ -0,0 +1,71 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+// purlTypes maps package schemes to package URL types. See
+// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst.
+var purlTypes = map[string]string{
+	shared.GoPackagesScheme:     "golang",
+	shared.JVMPackagesScheme:    "maven",
+	shared.NpmPackagesScheme:    "npm",
+	shared.PythonPackagesScheme: "pypi",
+	shared.RustPackagesScheme:   "cargo",
+	shared.RubyPackagesScheme:   "gem",
+}
+
+// PURL returns the package URL identifying the given package, or the empty
+// string if the scheme has no package URL type.
+func PURL(scheme, name, version string) string {
+	typ, ok := purlTypes[scheme]
+	if !ok {
+		return ""
+	}
+
+	var namespace string
+	switch typ {
+	case "maven":
+		// Maven packages are named group:artifact.
+		if group, artifact, ok := strings.Cut(name, ":"); ok {
+			namespace, name = group, artifact
+		}
+	case "npm", "golang":
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "pkg:%s/", typ)
+	if namespace != "" {
+		for _, segment := range strings.Split(namespace, "/") {
+			b.WriteString(purlEscape(segment))
+			b.WriteByte('/')
+		}
+	}
+	b.WriteString(purlEscape(name))
+	if version != "" {
+		b.WriteByte('@')
+		b.WriteString(purlEscape(version))
+	}
+	return b.String()
+}
+
+// purlEscape percent-encodes all characters of s except for unreserved ones.
+func purlEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '.', c == '_', c == '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,29 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+func TestPURL(t *testing.T) {
+	for _, tc := range []struct {
+		scheme, name, version string
+		want                  string
+	}{
+		{shared.NpmPackagesScheme, "express", "4.18.2", "pkg:npm/express@4.18.2"},
+		{shared.NpmPackagesScheme, "@babel/core", "7.20.12", "pkg:npm/%40babel/core@7.20.12"},
+		{shared.GoPackagesScheme, "github.com/google/go-cmp", "v0.5.9", "pkg:golang/github.com/google/go-cmp@v0.5.9"},
+		{shared.JVMPackagesScheme, "com.google.guava:guava", "31.1-jre", "pkg:maven/com.google.guava/guava@31.1-jre"},
+		{shared.PythonPackagesScheme, "requests", "2.28.1", "pkg:pypi/requests@2.28.1"},
+		{shared.RustPackagesScheme, "rand", "0.8.5", "pkg:cargo/rand@0.8.5"},
+		{shared.RubyPackagesScheme, "nokogiri", "1.14.0", "pkg:gem/nokogiri@1.14.0"},
+		{shared.GoPackagesScheme, "golang.org/x/net", "v0.0.0-20220722155237-a158d28d115b", "pkg:golang/golang.org/x/net@v0.0.0-20220722155237-a158d28d115b"},
+		{shared.NpmPackagesScheme, "left-pad", "", "pkg:npm/left-pad"},
+		{"unknown", "foo", "1.0.0", ""},
+	} {
+		if have := PURL(tc.scheme, tc.name, tc.version); have != tc.want {
+			t.Errorf("PURL(%q, %q, %q): want %q, have %q", tc.scheme, tc.name, tc.version, tc.want, have)
+		}
+	}
+}
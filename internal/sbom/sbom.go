@@ -0,0 +1,186 @@
+// Package sbom generates software bills of materials (SBOMs) of repositories
+// from the dependency graphs of their lockfiles and their language inventory.
+package sbom
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is a supported SBOM format.
+type Format string
+
+const (
+	// FormatCycloneDX is the JSON encoding of CycloneDX 1.5.
+	FormatCycloneDX Format = "cyclonedx"
+	// FormatSPDX is the JSON encoding of SPDX 2.3.
+	FormatSPDX Format = "spdx"
+)
+
+// Formats are all supported SBOM formats.
+var Formats = []Format{FormatCycloneDX, FormatSPDX}
+
+// ParseFormat returns the format with the given name. The empty string
+// defaults to CycloneDX.
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatCycloneDX:
+		return FormatCycloneDX, nil
+	case FormatSPDX:
+		return FormatSPDX, nil
+	default:
+		return "", errors.Errorf("unsupported SBOM format %q, expected %q or %q", s, FormatCycloneDX, FormatSPDX)
+	}
+}
+
+// ContentType returns the media type of documents in the format.
+func (f Format) ContentType() string {
+	if f == FormatSPDX {
+		return "application/spdx+json"
+	}
+	return "application/vnd.cyclonedx+json; version=1.5"
+}
+
+// FileExtension returns the conventional file extension of documents in the
+// format.
+func (f Format) FileExtension() string {
+	if f == FormatSPDX {
+		return ".spdx.json"
+	}
+	return ".cdx.json"
+}
+
+// Document is the format-independent content of an SBOM of a repository at
+// a given commit.
+type Document struct {
+	Repo    api.RepoName
+	Commit  api.CommitID
+	Created time.Time
+
+	// Namespace is a URI uniquely identifying the document.
+	Namespace string
+
+	// ToolVersion is the version of Sourcegraph that generated the document.
+	ToolVersion string
+
+	// Languages is the language breakdown of the repository, largest first.
+	Languages []inventory.Lang
+
+	// Packages are the packages referenced by any lockfile of the repository,
+	// sorted by scheme, name and version.
+	Packages []Package
+}
+
+// Package is a package the repository depends on.
+type Package struct {
+	Scheme  string
+	Name    string
+	Version string
+	PURL    string
+
+	// Direct is true if the repository depends on the package itself in at
+	// least one of its lockfiles.
+	Direct bool
+
+	// Lockfiles are the paths of the lockfiles referencing the package.
+	Lockfiles []string
+
+	// DependsOn holds the indexes of the packages this package depends on.
+	// It is nil if none of the lockfiles referencing the package record its
+	// dependencies.
+	DependsOn []int
+}
+
+// NewDocument merges the dependency graphs of the lockfiles of a repository,
+// keyed by lockfile path, into a single document.
+func NewDocument(repo api.RepoName, commit api.CommitID, languages []inventory.Lang, graphs map[string]*shared.DependencyGraph) *Document {
+	type key struct{ scheme, name, version string }
+	keyOf := func(pkg shared.PackageDependency) key {
+		return key{pkg.Scheme(), string(pkg.PackageSyntax()), pkg.PackageVersion()}
+	}
+
+	lockfiles := make([]string, 0, len(graphs))
+	for lockfile := range graphs {
+		lockfiles = append(lockfiles, lockfile)
+	}
+	sort.Strings(lockfiles)
+
+	packages := map[key]*Package{}
+	edges := map[key]map[key]struct{}{}
+	for _, lockfile := range lockfiles {
+		graph := graphs[lockfile]
+
+		direct := map[key]struct{}{}
+		for _, pkg := range graph.DirectDependencies() {
+			direct[keyOf(pkg)] = struct{}{}
+		}
+
+		for _, pkg := range graph.Packages() {
+			k := keyOf(pkg)
+			p, ok := packages[k]
+			if !ok {
+				p = &Package{Scheme: k.scheme, Name: k.name, Version: k.version, PURL: PURL(k.scheme, k.name, k.version)}
+				packages[k] = p
+			}
+			p.Lockfiles = append(p.Lockfiles, lockfile)
+			if _, ok := direct[k]; ok {
+				p.Direct = true
+			}
+
+			if graph.Fidelity() != shared.LockfileFidelityGraph {
+				continue
+			}
+			if _, ok := edges[k]; !ok {
+				edges[k] = map[key]struct{}{}
+			}
+			for _, dep := range graph.DependsOn(pkg) {
+				edges[k][keyOf(dep)] = struct{}{}
+			}
+		}
+	}
+
+	keys := make([]key, 0, len(packages))
+	for k := range packages {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].scheme != keys[j].scheme {
+			return keys[i].scheme < keys[j].scheme
+		}
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].version < keys[j].version
+	})
+
+	indexes := make(map[key]int, len(keys))
+	for i, k := range keys {
+		indexes[k] = i
+	}
+
+	doc := &Document{
+		Repo:      repo,
+		Commit:    commit,
+		Languages: languages,
+		Packages:  make([]Package, 0, len(keys)),
+	}
+	for _, k := range keys {
+		p := *packages[k]
+		if deps, ok := edges[k]; ok {
+			p.DependsOn = make([]int, 0, len(deps))
+			for dep := range deps {
+				p.DependsOn = append(p.DependsOn, indexes[dep])
+			}
+			sort.Ints(p.DependsOn)
+		}
+		doc.Packages = append(doc.Packages, p)
+	}
+
+	return doc
+}
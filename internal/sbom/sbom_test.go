@@ -0,0 +1,86 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+)
+
+func TestParseFormat(t *testing.T) {
+	for input, want := range map[string]Format{
+		"":          FormatCycloneDX,
+		"cyclonedx": FormatCycloneDX,
+		"SPDX":      FormatSPDX,
+	} {
+		have, err := ParseFormat(input)
+		if err != nil {
+			t.Fatalf("ParseFormat(%q): unexpected error: %s", input, err)
+		}
+		if have != want {
+			t.Errorf("ParseFormat(%q): want %q, have %q", input, want, have)
+		}
+	}
+
+	if _, err := ParseFormat("swid"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	doc := testDocument()
+
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Encode(&buf, doc, format); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			autogold.Equal(t, autogold.Raw(buf.String()))
+		})
+	}
+}
+
+func testDocument() *Document {
+	pkg := func(scheme, name, version string) shared.PackageDependency {
+		return shared.PackageDependencyLiteral{
+			SchemeValue:         scheme,
+			PackageSyntaxValue:  reposource.PackageName(name),
+			PackageVersionValue: version,
+		}
+	}
+
+	express := pkg(shared.NpmPackagesScheme, "express", "4.18.2")
+	debug := pkg(shared.NpmPackagesScheme, "debug", "2.6.9")
+	ms := pkg(shared.NpmPackagesScheme, "ms", "2.0.0")
+	npm := shared.NewDependencyGraph()
+	npm.AddDirectDependency(express)
+	npm.AddDependency(express, debug)
+	npm.AddDependency(debug, ms)
+
+	// The same package referenced by a flat lockfile is merged with the one
+	// above, and keeps the dependencies recorded by the graph.
+	cmp := pkg(shared.GoPackagesScheme, "github.com/google/go-cmp", "v0.5.9")
+	gosum := shared.NewDependencyGraph()
+	gosum.AddDirectDependency(cmp)
+	gosum.AddDirectDependency(ms)
+
+	languages := []inventory.Lang{
+		{Name: "Go", TotalBytes: 3000},
+		{Name: "TypeScript", TotalBytes: 1000},
+	}
+
+	doc := NewDocument("github.com/sourcegraph/app", "deadbeef01deadbeef02deadbeef03deadbeef04", languages, map[string]*shared.DependencyGraph{
+		"web/package-lock.json": npm,
+		"go.sum":                gosum,
+	})
+	doc.Created = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	doc.Namespace = "https://sourcegraph.example.com/.api/sbom/github.com/sourcegraph/app@deadbeef01deadbeef02deadbeef03deadbeef04"
+	doc.ToolVersion = "5.0.0"
+	return doc
+}
@@ -0,0 +1,101 @@
+package sbom
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/version"
+)
+
+// Service generates SBOMs of repositories.
+type Service struct {
+	dependencies DependenciesService
+	gitserver    GitserverClient
+	logger       log.Logger
+	now          func() time.Time
+}
+
+func NewService(observationCtx *observation.Context, db database.DB, gitserverClient GitserverClient) *Service {
+	return newService(observationCtx.Logger, dependencies.NewService(observationCtx, db), gitserverClient)
+}
+
+func newService(logger log.Logger, dependenciesSvc DependenciesService, gitserverClient GitserverClient) *Service {
+	return &Service{
+		dependencies: dependenciesSvc,
+		gitserver:    gitserverClient,
+		logger:       logger.Scoped("sbom", "generates software bills of materials of repositories"),
+		now:          time.Now,
+	}
+}
+
+// Generate returns the SBOM of the given repository at the given commit. The
+// dependency graphs recorded by the lockfile indexer are used when the commit
+// was indexed, otherwise the lockfiles are read from gitserver.
+func (s *Service) Generate(ctx context.Context, checker authz.SubRepoPermissionChecker, repoID api.RepoID, repo api.RepoName, commit api.CommitID) (*Document, error) {
+	graphs, err := s.dependencies.LockfileGraphs(ctx, repoID, commit)
+	if err != nil {
+		return nil, err
+	}
+	if len(graphs) == 0 {
+		graphs, err = dependencies.ParseLockfiles(ctx, s.gitserver, checker, repo, commit, func(path string, err error) {
+			s.logger.Warn("failed to parse lockfile", log.String("repo", string(repo)), log.String("path", path), log.Error(err))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	languages, err := s.languages(ctx, checker, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := NewDocument(repo, commit, languages, graphs)
+	doc.Created = s.now()
+	doc.Namespace = namespace(repo, commit)
+	doc.ToolVersion = version.Version()
+	return doc, nil
+}
+
+// languages returns the language breakdown of the repository. Languages are
+// detected from file names only, as reading every file of the repository is
+// too expensive to do on each request.
+func (s *Service) languages(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID) ([]inventory.Lang, error) {
+	invCtx := inventory.Context{
+		ReadTree: func(ctx context.Context, path string) ([]fs.FileInfo, error) {
+			return s.gitserver.ReadDir(ctx, checker, repo, commit, path, false)
+		},
+		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			return nil, nil
+		},
+	}
+
+	entries, err := invCtx.ReadTree(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	inv, err := invCtx.Entries(ctx, entries...)
+	if err != nil {
+		return nil, err
+	}
+	return inv.Languages, nil
+}
+
+// namespace returns the URL of the SBOM endpoint of the given repository at
+// the given commit, which uniquely identifies the generated document.
+func namespace(repo api.RepoName, commit api.CommitID) string {
+	return strings.TrimSuffix(conf.ExternalURL(), "/") + "/.api/sbom/" + (&url.URL{Path: string(repo)}).EscapedPath() + "@" + string(commit)
+}
@@ -0,0 +1,142 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// See https://spdx.github.io/spdx-spec/v2.3/ for the specification.
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// EncodeSPDX writes the document as SPDX 2.3 JSON.
+func EncodeSPDX(w io.Writer, doc *Document) error {
+	const (
+		documentID = "SPDXRef-DOCUMENT"
+		rootID     = "SPDXRef-Repository"
+	)
+
+	root := spdxPackage{
+		SPDXID:                rootID,
+		Name:                  string(doc.Repo),
+		VersionInfo:           string(doc.Commit),
+		DownloadLocation:      "NOASSERTION",
+		PrimaryPackagePurpose: "SOURCE",
+	}
+	if len(doc.Languages) > 0 {
+		var total uint64
+		for _, lang := range doc.Languages {
+			total += lang.TotalBytes
+		}
+
+		languages := make([]string, 0, len(doc.Languages))
+		for _, lang := range doc.Languages {
+			share := 0.0
+			if total > 0 {
+				share = float64(lang.TotalBytes) / float64(total) * 100
+			}
+			languages = append(languages, fmt.Sprintf("%s (%.1f%%)", lang.Name, share))
+		}
+		root.Comment = "Languages: " + strings.Join(languages, ", ")
+	}
+
+	packages := []spdxPackage{root}
+	relationships := []spdxRelationship{
+		{SPDXElementID: documentID, RelationshipType: "DESCRIBES", RelatedSPDXElement: rootID},
+	}
+
+	ids := make([]string, len(doc.Packages))
+	for i := range doc.Packages {
+		ids[i] = fmt.Sprintf("SPDXRef-Package-%d", i+1)
+	}
+
+	for i, pkg := range doc.Packages {
+		p := spdxPackage{
+			SPDXID:                ids[i],
+			Name:                  pkg.Name,
+			VersionInfo:           pkg.Version,
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: "LIBRARY",
+			Comment:               "Referenced by " + strings.Join(pkg.Lockfiles, ", "),
+		}
+		if pkg.PURL != "" {
+			p.ExternalRefs = []spdxExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: pkg.PURL},
+			}
+		}
+		packages = append(packages, p)
+
+		if pkg.Direct {
+			relationships = append(relationships, spdxRelationship{SPDXElementID: rootID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: ids[i]})
+		}
+		for _, j := range pkg.DependsOn {
+			relationships = append(relationships, spdxRelationship{SPDXElementID: ids[i], RelationshipType: "DEPENDS_ON", RelatedSPDXElement: ids[j]})
+		}
+	}
+
+	document := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            documentID,
+		Name:              string(doc.Repo) + "@" + string(doc.Commit),
+		DocumentNamespace: doc.Namespace,
+		CreationInfo: spdxCreationInfo{
+			Created:  doc.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: sourcegraph-" + doc.ToolVersion},
+		},
+		Packages:      packages,
+		Relationships: relationships,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(document)
+}
+
+// Encode writes the document in the given format.
+func Encode(w io.Writer, doc *Document, format Format) error {
+	if format == FormatSPDX {
+		return EncodeSPDX(w, doc)
+	}
+	return EncodeCycloneDX(w, doc)
+}
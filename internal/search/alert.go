@@ -123,6 +123,23 @@ func AlertForTimeout(usedTime time.Duration, suggestTime time.Duration, queryStr
 	}
 }
 
+func AlertForExpensiveQuery(estimatedCost, maxCost int, warnings []string) *Alert {
+	var description strings.Builder
+	fmt.Fprintf(&description, "This query has an estimated cost of %d, which is more than the maximum of %d configured by your site admin. Narrow it down with more specific repo: filters, or with after: or before: filters for commit and diff searches.", estimatedCost, maxCost)
+	if len(warnings) > 0 {
+		description.WriteString("\n")
+		for _, w := range warnings {
+			fmt.Fprintf(&description, "\n- %s", w)
+		}
+	}
+
+	return &Alert{
+		PrometheusType: "expensive_query",
+		Title:          "Query too expensive",
+		Description:    description.String(),
+	}
+}
+
 // capFirst capitalizes the first rune in the given string. It can be safely
 // used with UTF-8 strings.
 func capFirst(s string) string {
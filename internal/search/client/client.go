@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/grafana/regexp"
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,14 +11,17 @@ import (
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -54,6 +58,7 @@ func NewSearchClient(logger log.Logger, db database.DB, zoektStreamer zoekt.Stre
 		db:           db,
 		zoekt:        zoektStreamer,
 		searcherURLs: searcherURLs,
+		dependencies: dependencies.NewService(observation.NewContext(logger), db),
 	}
 }
 
@@ -62,6 +67,7 @@ type searchClient struct {
 	db           database.DB
 	zoekt        zoekt.Streamer
 	searcherURLs *endpoint.Map
+	dependencies *dependencies.Service
 }
 
 func (s *searchClient) Plan(
@@ -145,7 +151,36 @@ func (s *searchClient) Execute(
 		return nil, err
 	}
 
-	return planJob.Run(ctx, s.JobClients(), stream)
+	clients := s.JobClients()
+	if maxCost := limits.SearchLimits(conf.Get()).MaxEstimatedCost; maxCost > 0 {
+		if explanation := s.estimateCost(ctx, clients, inputs, planJob, maxCost); explanation != nil {
+			if cost := explanation.Cost.Score(); cost > maxCost {
+				tr.LazyPrintf("rejected query with estimated cost %d", cost)
+				return search.AlertForExpensiveQuery(cost, maxCost, explanation.Warnings), nil
+			}
+		}
+	}
+
+	return planJob.Run(ctx, clients, stream)
+}
+
+// estimateCostTimeout bounds the time spent estimating the cost of a search
+// before running it.
+const estimateCostTimeout = 2 * time.Second
+
+// estimateCost estimates the cost of planJob, up to maxCost. The estimate is
+// best-effort: it returns nil if the cost could not be estimated in time, in
+// which case the search runs anyway.
+func (s *searchClient) estimateCost(ctx context.Context, clients job.RuntimeClients, inputs *search.Inputs, planJob job.Job, maxCost int) *jobutil.Explanation {
+	ctx, cancel := context.WithTimeout(ctx, estimateCostTimeout)
+	defer cancel()
+
+	explanation, err := jobutil.ExplainUpTo(ctx, clients, inputs.Plan, planJob, maxCost)
+	if err != nil {
+		s.logger.Warn("failed to estimate search cost", log.String("query", inputs.OriginalQuery), log.Error(err))
+		return nil
+	}
+	return explanation
 }
 
 func (s *searchClient) JobClients() job.RuntimeClients {
@@ -155,6 +190,7 @@ func (s *searchClient) JobClients() job.RuntimeClients {
 		Zoekt:        s.zoekt,
 		SearcherURLs: s.searcherURLs,
 		Gitserver:    gitserver.NewClient(s.db),
+		Dependencies: s.dependencies,
 	}
 }
 
@@ -33,6 +33,12 @@ type SearchJob struct {
 	IncludeModifiedFiles bool
 	Concurrency          int
 
+	// IncludeDiffFunctions and ExcludeDiffFunctions are regular expressions
+	// for the names of the functions or classes enclosing the hunks of diff
+	// matches. Hunks outside of matching functions are dropped.
+	IncludeDiffFunctions []string
+	ExcludeDiffFunctions []string
+
 	// CodeMonitorSearchWrapper, if set, will wrap the commit search with extra logic specific to code monitors.
 	CodeMonitorSearchWrapper CodeMonitorHook `json:"-"`
 }
@@ -53,6 +59,11 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		return nil, err
 	}
 
+	functionMatcher, err := newFunctionMatcher(j.IncludeDiffFunctions, j.ExcludeDiffFunctions)
+	if err != nil {
+		return nil, err
+	}
+
 	searchRepoRev := func(ctx context.Context, repoRev *search.RepositoryRevisions) error {
 		// Skip the repo if no revisions were resolved for it
 		if len(repoRev.Revs) == 0 {
@@ -69,9 +80,21 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		}
 
 		onMatches := func(in []protocol.CommitMatch) {
-			res := make([]result.Match, 0, len(in))
+			commitMatches := make([]*result.CommitMatch, 0, len(in))
 			for _, protocolMatch := range in {
-				res = append(res, protocolMatchToCommitMatch(repoRev.Repo, j.Diff, protocolMatch))
+				commitMatches = append(commitMatches, protocolMatchToCommitMatch(repoRev.Repo, j.Diff, protocolMatch))
+			}
+			if j.Diff {
+				annotateFunctions(ctx, commitMatches, functionMatcher, func(m *result.CommitMatch) {
+					stream.Send(streaming.SearchEvent{
+						Results: result.Matches{m},
+					})
+				})
+				return
+			}
+			res := make([]result.Match, 0, len(commitMatches))
+			for _, m := range commitMatches {
+				res = append(res, m)
 			}
 			stream.Send(streaming.SearchEvent{
 				Results: res,
@@ -96,7 +119,7 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		return doSearch(args)
 	}
 
-	repos := searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt)
+	repos := searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt, clients.Dependencies)
 	return nil, repos.Paginate(ctx, j.RepoOpts, func(page *searchrepos.Resolved) error {
 		page.MaybeSendStats(stream)
 
@@ -125,6 +148,8 @@ func (j *SearchJob) Fields(v job.Verbosity) (res []log.Field) {
 	case job.VerbosityMax:
 		res = append(res,
 			log.Bool("includeModifiedFiles", j.IncludeModifiedFiles),
+			trace.Strings("includeDiffFunctions", j.IncludeDiffFunctions),
+			trace.Strings("excludeDiffFunctions", j.ExcludeDiffFunctions),
 		)
 		fallthrough
 	case job.VerbosityBasic:
@@ -0,0 +1,208 @@
+package commit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/group"
+)
+
+// enclosingSymbols returns the names of the functions or classes that enclose
+// line ranges of a file. It is a variable so tests can stub the symbols
+// service.
+var enclosingSymbols = symbols.DefaultClient.EnclosingSymbols
+
+// maxConcurrentAnnotations bounds the number of concurrent requests to the
+// symbols service per batch of diff matches.
+const maxConcurrentAnnotations = 8
+
+// annotationTimeout bounds each request to the symbols service, so a slow
+// symbols service delays diff results by at most this long.
+const annotationTimeout = 500 * time.Millisecond
+
+// QueryToDiffFunctions returns the regular expressions of the diff.function:
+// filters in b.
+func QueryToDiffFunctions(b query.Basic) (include, exclude []string) {
+	include, exclude = b.IncludeExcludeValues(query.FieldDiffFunction)
+	if b.IsCaseSensitive() {
+		return include, exclude
+	}
+	ignoreCase := func(patterns []string) []string {
+		for i, p := range patterns {
+			patterns[i] = "(?i:" + p + ")"
+		}
+		return patterns
+	}
+	return ignoreCase(include), ignoreCase(exclude)
+}
+
+// functionMatcher matches the name of the function or class enclosing a diff
+// hunk against the diff.function: filters of a query.
+type functionMatcher struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+func newFunctionMatcher(include, exclude []string) (*functionMatcher, error) {
+	compile := func(patterns []string) ([]*regexp.Regexp, error) {
+		res := make([]*regexp.Regexp, 0, len(patterns))
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, re)
+		}
+		return res, nil
+	}
+
+	var (
+		fm  functionMatcher
+		err error
+	)
+	if fm.include, err = compile(include); err != nil {
+		return nil, err
+	}
+	if fm.exclude, err = compile(exclude); err != nil {
+		return nil, err
+	}
+	return &fm, nil
+}
+
+// Match returns true if function is matched by all include and none of the
+// exclude patterns. The empty function, i.e. a hunk outside of any function,
+// never matches an include pattern.
+func (fm *functionMatcher) Match(function string) bool {
+	for _, re := range fm.include {
+		if function == "" || !re.MatchString(function) {
+			return false
+		}
+	}
+	for _, re := range fm.exclude {
+		if function != "" && re.MatchString(function) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fm *functionMatcher) empty() bool {
+	return len(fm.include) == 0 && len(fm.exclude) == 0
+}
+
+// annotateFunctions annotates the hunks of the diff matches with the function
+// or class that encloses them in the post-image, and calls send with each
+// match, in order, as soon as its hunks are annotated. If fm has filters, it
+// drops hunks outside of matching functions, and matches without any
+// remaining hunks. Annotation is best-effort: hunks of files in unsupported
+// languages, or for which the symbols service fails or is too slow, are not
+// annotated.
+func annotateFunctions(ctx context.Context, matches []*result.CommitMatch, fm *functionMatcher, send func(*result.CommitMatch)) {
+	annotated := make([]sync.WaitGroup, len(matches))
+	for i, m := range matches {
+		annotated[i].Add(len(m.Diff))
+	}
+
+	go func() {
+		g := group.New().WithMaxConcurrency(maxConcurrentAnnotations)
+		for i, m := range matches {
+			for j := range m.Diff {
+				wg, m, file := &annotated[i], m, &m.Diff[j]
+				g.Go(func() {
+					defer wg.Done()
+					annotateFileFunctions(ctx, m, file)
+				})
+			}
+		}
+		g.Wait()
+	}()
+
+	for i, m := range matches {
+		annotated[i].Wait()
+		if filterFunctions(m, fm) {
+			send(m)
+		}
+	}
+}
+
+// filterFunctions drops the hunks of m outside of functions matched by fm. It
+// returns false if m has no remaining hunks and should be dropped.
+func filterFunctions(m *result.CommitMatch, fm *functionMatcher) bool {
+	if m.DiffPreview == nil {
+		return true
+	}
+	diff := m.Diff
+	m.DiffPreview, m.Diff = result.RewriteDiffPreview(m.DiffPreview, diff, func(fileIdx, hunkIdx int) bool {
+		if hunkIdx < 0 {
+			// A change without hunks (e.g. a rename) is never inside
+			// a function.
+			return fm.empty()
+		}
+		return fm.Match(diff[fileIdx].Hunks[hunkIdx].Function)
+	})
+	return len(m.Diff) > 0 || fm.empty()
+}
+
+func annotateFileFunctions(ctx context.Context, m *result.CommitMatch, file *result.DiffFile) {
+	if file.NewName == "/dev/null" || len(file.Hunks) == 0 {
+		return
+	}
+
+	lines := make([]types.LineRange, 0, len(file.Hunks))
+	hunkIdxs := make([]int, 0, len(file.Hunks))
+	for i := range file.Hunks {
+		start, end, ok := file.Hunks[i].ChangedLines()
+		if !ok {
+			continue
+		}
+		// The symbols service uses 0-based line numbers.
+		lines = append(lines, types.LineRange{Start: start - 1, End: end - 1})
+		hunkIdxs = append(hunkIdxs, i)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, annotationTimeout)
+	defer cancel()
+	payload, err := enclosingSymbols(ctx, types.RepoCommitPathLines{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   string(m.Repo.Name),
+			Commit: string(m.Commit.ID),
+			Path:   file.NewName,
+		},
+		Lines: lines,
+	})
+	if err != nil || payload == nil || len(payload.Symbols) != len(lines) {
+		return
+	}
+
+	for i, function := range payload.Symbols {
+		if function == "" {
+			continue
+		}
+		hunk := &file.Hunks[hunkIdxs[i]]
+		hunk.Function = function
+		hunk.Header = hunkHeader(function, hunk.Header)
+	}
+}
+
+// extraHunkMatchesRegexp matches the note gitserver appends to hunk headers
+// when it elides matches from a hunk, e.g. "... +3".
+var extraHunkMatchesRegexp = regexp.MustCompile(`(?:^|\s)(\.\.\. \+\d+)$`)
+
+// hunkHeader returns the header of a hunk enclosed by function, preserving a
+// note about elided matches from the original header.
+func hunkHeader(function, header string) string {
+	if m := extraHunkMatchesRegexp.FindStringSubmatch(header); m != nil {
+		return function + " " + m[1]
+	}
+	return function
+}
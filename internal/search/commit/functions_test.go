@@ -0,0 +1,218 @@
+package commit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestQueryToDiffFunctions(t *testing.T) {
+	parse := func(t *testing.T, input string) query.Basic {
+		t.Helper()
+		plan, err := query.Pipeline(query.Init(input, query.SearchTypeRegex))
+		require.NoError(t, err)
+		return plan[0]
+	}
+
+	t.Run("case insensitive by default", func(t *testing.T) {
+		include, exclude := QueryToDiffFunctions(parse(t, "type:diff diff.function:^Validate -diff.function:Test foo"))
+		require.Equal(t, []string{"(?i:^Validate)"}, include)
+		require.Equal(t, []string{"(?i:Test)"}, exclude)
+	})
+
+	t.Run("case sensitive", func(t *testing.T) {
+		include, exclude := QueryToDiffFunctions(parse(t, "type:diff case:yes diff.function:Validate foo"))
+		require.Equal(t, []string{"Validate"}, include)
+		require.Empty(t, exclude)
+	})
+}
+
+func TestFunctionMatcher(t *testing.T) {
+	fm, err := newFunctionMatcher([]string{"Token"}, []string{"^Test"})
+	require.NoError(t, err)
+
+	require.True(t, fm.Match("ValidateToken"))
+	require.False(t, fm.Match("Auth.validatetoken"), "matching is case sensitive")
+	require.False(t, fm.Match("TestValidateToken"))
+	require.False(t, fm.Match(""))
+
+	fm, err = newFunctionMatcher(nil, []string{"^Test"})
+	require.NoError(t, err)
+	require.True(t, fm.Match(""), "hunks outside of functions are not excluded")
+
+	_, err = newFunctionMatcher([]string{"("}, nil)
+	require.Error(t, err)
+}
+
+func TestAnnotateFunctions(t *testing.T) {
+	const diff = `auth/token.go auth/token.go
+@@ -10,3 +10,3 @@ func ValidateToken(token string) error {
+ 	if token == "" {
+-		return nil
++		return errEmpty
+ 	}
+@@ -30,2 +30,3 @@ func Handle() {
+ 	x := 1
++	y := 2
+ 	return
+old.go /dev/null
+@@ -1,2 +0,0 @@
+-package old
+-var x = 1
+`
+
+	// Stub the symbols service: the first hunk of auth/token.go is inside
+	// ValidateToken, the second one is outside of any function.
+	orig := enclosingSymbols
+	t.Cleanup(func() { enclosingSymbols = orig })
+	var requests []types.RepoCommitPathLines
+	enclosingSymbols = func(ctx context.Context, args types.RepoCommitPathLines) (*types.EnclosingSymbolsPayload, error) {
+		requests = append(requests, args)
+		return &types.EnclosingSymbolsPayload{Symbols: []string{"ValidateToken", ""}}, nil
+	}
+
+	newMatch := func(t *testing.T) *result.CommitMatch {
+		t.Helper()
+		structuredDiff, err := result.ParseDiffString(diff)
+		require.NoError(t, err)
+		return &result.CommitMatch{
+			Repo:        types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"},
+			DiffPreview: &result.MatchedString{Content: diff},
+			Diff:        structuredDiff,
+		}
+	}
+
+	annotate := func(fm *functionMatcher, matches ...*result.CommitMatch) (sent []*result.CommitMatch) {
+		annotateFunctions(context.Background(), matches, fm, func(m *result.CommitMatch) {
+			sent = append(sent, m)
+		})
+		return sent
+	}
+
+	t.Run("annotates hunks", func(t *testing.T) {
+		requests = nil
+		fm, err := newFunctionMatcher(nil, nil)
+		require.NoError(t, err)
+
+		matches := annotate(fm, newMatch(t))
+		require.Len(t, matches, 1)
+
+		// Deleted files are not annotated.
+		require.Len(t, requests, 1)
+		require.Equal(t, "auth/token.go", requests[0].Path)
+		require.Equal(t, []types.LineRange{{Start: 10, End: 10}, {Start: 30, End: 30}}, requests[0].Lines)
+
+		m := matches[0]
+		require.Len(t, m.Diff, 2)
+		require.Equal(t, "ValidateToken", m.Diff[0].Hunks[0].Function)
+		require.Equal(t, "ValidateToken", m.Diff[0].Hunks[0].Header)
+		require.Equal(t, "", m.Diff[0].Hunks[1].Function)
+		require.Equal(t, "func Handle() {", m.Diff[0].Hunks[1].Header)
+		require.Contains(t, m.DiffPreview.Content, "@@ -10,3 +10,3 @@ ValidateToken\n")
+	})
+
+	t.Run("filters hunks", func(t *testing.T) {
+		fm, err := newFunctionMatcher([]string{"Validate"}, nil)
+		require.NoError(t, err)
+
+		matches := annotate(fm, newMatch(t))
+		require.Len(t, matches, 1)
+
+		m := matches[0]
+		require.Len(t, m.Diff, 1)
+		require.Len(t, m.Diff[0].Hunks, 1)
+		require.Equal(t, `auth/token.go auth/token.go
+@@ -10,3 +10,3 @@ ValidateToken
+ 	if token == "" {
+-		return nil
++		return errEmpty
+ 	}
+`, m.DiffPreview.Content)
+	})
+
+	t.Run("drops matches without matching hunks", func(t *testing.T) {
+		fm, err := newFunctionMatcher([]string{"Handle"}, nil)
+		require.NoError(t, err)
+
+		matches := annotate(fm, newMatch(t))
+		require.Empty(t, matches)
+	})
+}
+
+func TestAnnotateFunctionsStreaming(t *testing.T) {
+	newMatch := func(t *testing.T, path string) *result.CommitMatch {
+		t.Helper()
+		diff := path + " " + path + "\n@@ -1,1 +1,1 @@\n-a\n+b\n"
+		structuredDiff, err := result.ParseDiffString(diff)
+		require.NoError(t, err)
+		return &result.CommitMatch{
+			DiffPreview: &result.MatchedString{Content: diff},
+			Diff:        structuredDiff,
+		}
+	}
+
+	orig := enclosingSymbols
+	t.Cleanup(func() { enclosingSymbols = orig })
+
+	t.Run("sends matches before later ones are annotated", func(t *testing.T) {
+		// The symbols service only answers for b.go once the match of a.go
+		// has been sent.
+		firstSent := make(chan struct{})
+		enclosingSymbols = func(ctx context.Context, args types.RepoCommitPathLines) (*types.EnclosingSymbolsPayload, error) {
+			if args.Path == "b.go" {
+				select {
+				case <-firstSent:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return &types.EnclosingSymbolsPayload{Symbols: []string{"F"}}, nil
+		}
+
+		fm, err := newFunctionMatcher(nil, nil)
+		require.NoError(t, err)
+
+		var sent []*result.CommitMatch
+		annotateFunctions(context.Background(), []*result.CommitMatch{newMatch(t, "a.go"), newMatch(t, "b.go")}, fm, func(m *result.CommitMatch) {
+			if len(sent) == 0 {
+				close(firstSent)
+			}
+			sent = append(sent, m)
+		})
+
+		require.Len(t, sent, 2)
+		require.Equal(t, "a.go", sent[0].Diff[0].NewName)
+		require.Equal(t, "F", sent[0].Diff[0].Hunks[0].Function)
+		require.Equal(t, "b.go", sent[1].Diff[0].NewName)
+		require.Equal(t, "F", sent[1].Diff[0].Hunks[0].Function)
+	})
+
+	t.Run("leaves hunks unannotated on timeout", func(t *testing.T) {
+		enclosingSymbols = func(ctx context.Context, args types.RepoCommitPathLines) (*types.EnclosingSymbolsPayload, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		fm, err := newFunctionMatcher(nil, nil)
+		require.NoError(t, err)
+
+		var sent []*result.CommitMatch
+		annotateFunctions(context.Background(), []*result.CommitMatch{newMatch(t, "a.go")}, fm, func(m *result.CommitMatch) {
+			sent = append(sent, m)
+		})
+
+		require.Len(t, sent, 1)
+		require.Equal(t, "", sent[0].Diff[0].Hunks[0].Function)
+	})
+}
+
+func TestHunkHeader(t *testing.T) {
+	require.Equal(t, "ValidateToken", hunkHeader("ValidateToken", "func ValidateToken() {"))
+	require.Equal(t, "ValidateToken ... +3", hunkHeader("ValidateToken", "func ValidateToken() { ... +3"))
+	require.Equal(t, "ValidateToken ... +3", hunkHeader("ValidateToken", "... +3"))
+}
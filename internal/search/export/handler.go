@@ -0,0 +1,210 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// handler runs search export jobs. The results of each page of repositories
+// are written to their own part in the upload store, and the job is
+// checkpointed after each page so that a job that failed or was reset resumes
+// at the page it was interrupted on. Once every page has been searched, the
+// parts are concatenated into the final export.
+type handler struct {
+	db           database.DB
+	store        Store
+	uploadStore  uploadstore.Store
+	searchClient client.SearchClient
+}
+
+var _ workerutil.Handler[*Job] = &handler{}
+
+func (h *handler) Handle(ctx context.Context, logger log.Logger, j *Job) error {
+	ctx = actor.WithActor(ctx, actor.FromUser(j.InitiatorID))
+	ctx = featureflag.WithFlags(ctx, h.db.FeatureFlags())
+
+	if !j.Searched() {
+		if err := h.search(ctx, j); err != nil {
+			return err
+		}
+	}
+
+	if j.ObjectKey != nil {
+		// The export was composed before the job was interrupted.
+		return nil
+	}
+	return h.compose(ctx, logger, j)
+}
+
+func (h *handler) search(ctx context.Context, j *Job) error {
+	settings := j.Settings
+	if settings == nil {
+		settings = &schema.Settings{}
+	}
+	var patternType *string
+	if j.PatternType != "" {
+		patternType = &j.PatternType
+	}
+
+	inputs, err := h.searchClient.Plan(
+		ctx,
+		"V3",
+		patternType,
+		j.Query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	// Results are sent concurrently by the search backends of a page, and
+	// buffered until the page is done.
+	var (
+		mu       sync.Mutex
+		buf      bytes.Buffer
+		w        = newRowWriter(&buf, j.Format)
+		matches  int64
+		writeErr error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, match := range event.Results {
+			n, err := w.WriteMatch(match)
+			if err != nil {
+				writeErr = errors.Append(writeErr, err)
+				continue
+			}
+			matches += int64(n)
+		}
+	})
+
+	onPage := func(next types.MultiCursor) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if writeErr != nil {
+			return writeErr
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		var part string
+		if buf.Len() > 0 {
+			part = j.partKey(j.PagesSearched)
+			if _, err := h.uploadStore.Upload(ctx, part, bytes.NewReader(buf.Bytes())); err != nil {
+				return errors.Wrap(err, "failed to upload results")
+			}
+		}
+		if err := h.store.CheckpointJob(ctx, j.ID, next, part, matches); err != nil {
+			return errors.Wrap(err, "failed to checkpoint job")
+		}
+
+		j.Cursors = next
+		j.PagesSearched++
+		if part != "" {
+			j.Parts = append(j.Parts, part)
+		}
+		j.MatchesWritten += matches
+
+		buf.Reset()
+		matches = 0
+		return nil
+	}
+
+	searchJob, err := jobutil.NewExhaustiveJob(inputs, j.Cursors, onPage)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	_, err = searchJob.Run(ctx, h.searchClient.JobClients(), stream)
+	return err
+}
+
+// compose concatenates the parts of a job into its final export, and deletes
+// the parts.
+func (h *handler) compose(ctx context.Context, logger log.Logger, j *Job) error {
+	prefix, err := header(j.Format)
+	if err != nil {
+		return err
+	}
+
+	key := j.objectKey()
+	r := io.MultiReader(bytes.NewReader(prefix), &partsReader{ctx: ctx, store: h.uploadStore, keys: j.Parts})
+	if _, err := h.uploadStore.Upload(ctx, key, r); err != nil {
+		return errors.Wrap(err, "failed to upload export")
+	}
+	if err := h.store.SetJobObjectKey(ctx, j.ID, key); err != nil {
+		return err
+	}
+
+	for _, part := range j.Parts {
+		if err := h.uploadStore.Delete(ctx, part); err != nil {
+			logger.Warn("failed to delete search export part", log.String("key", part), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// partsReader reads the objects with the given keys one after the other,
+// opening each of them only once the previous one has been read.
+type partsReader struct {
+	ctx     context.Context
+	store   uploadstore.Store
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *partsReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+
+			rc, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to read part %q", r.keys[0])
+			}
+			r.current = rc
+			r.keys = r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			if closeErr := r.current.Close(); closeErr != nil {
+				return n, closeErr
+			}
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
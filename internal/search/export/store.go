@@ -0,0 +1,217 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ErrJobNotFound is returned by Store.GetJob when the job does not exist.
+var ErrJobNotFound = errors.New("search export job not found")
+
+// CreateJobOpts are the options of a new search export job.
+type CreateJobOpts struct {
+	InitiatorID int32
+	Query       string
+	PatternType string
+	Format      Format
+	Settings    *schema.Settings
+}
+
+// Store is responsible for data stored in the search_export_jobs table.
+type Store interface {
+	basestore.ShareableStore
+
+	// CreateJob enqueues a new search export job.
+	CreateJob(ctx context.Context, opts CreateJobOpts) (*Job, error)
+
+	// GetJob returns the search export job with the given ID.
+	GetJob(ctx context.Context, id int) (*Job, error)
+
+	// ListJobs returns the search export jobs created by the given user, most
+	// recent first.
+	ListJobs(ctx context.Context, initiatorID int32) ([]*Job, error)
+
+	// CheckpointJob records that a page of repositories of the job has been
+	// searched. part is the upload store key of its results, or empty if the
+	// page had no matches, and next are the cursors of the next page.
+	CheckpointJob(ctx context.Context, id int, next types.MultiCursor, part string, matches int64) error
+
+	// SetJobObjectKey records the upload store key of the complete export.
+	SetJobObjectKey(ctx context.Context, id int, key string) error
+}
+
+type store struct {
+	*basestore.Store
+}
+
+// NewStore returns a new Store using the given handle.
+func NewStore(other basestore.ShareableStore) Store {
+	return &store{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+// jobColumns are the columns selected for a Job, in the order expected by
+// scanJob. They are also used as the column expressions of the dbworker store.
+var jobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_export_jobs.id"),
+	sqlf.Sprintf("search_export_jobs.state"),
+	sqlf.Sprintf("search_export_jobs.failure_message"),
+	sqlf.Sprintf("search_export_jobs.queued_at"),
+	sqlf.Sprintf("search_export_jobs.started_at"),
+	sqlf.Sprintf("search_export_jobs.finished_at"),
+	sqlf.Sprintf("search_export_jobs.process_after"),
+	sqlf.Sprintf("search_export_jobs.num_resets"),
+	sqlf.Sprintf("search_export_jobs.num_failures"),
+	sqlf.Sprintf("search_export_jobs.last_heartbeat_at"),
+	sqlf.Sprintf("search_export_jobs.worker_hostname"),
+	sqlf.Sprintf("search_export_jobs.cancel"),
+	sqlf.Sprintf("search_export_jobs.initiator_id"),
+	sqlf.Sprintf("search_export_jobs.query"),
+	sqlf.Sprintf("search_export_jobs.pattern_type"),
+	sqlf.Sprintf("search_export_jobs.format"),
+	sqlf.Sprintf("search_export_jobs.settings"),
+	sqlf.Sprintf("search_export_jobs.cursors"),
+	sqlf.Sprintf("search_export_jobs.pages_searched"),
+	sqlf.Sprintf("search_export_jobs.parts"),
+	sqlf.Sprintf("search_export_jobs.matches_written"),
+	sqlf.Sprintf("search_export_jobs.object_key"),
+	sqlf.Sprintf("search_export_jobs.created_at"),
+	sqlf.Sprintf("search_export_jobs.updated_at"),
+}
+
+const createJobFmtstr = `
+INSERT INTO search_export_jobs (initiator_id, query, pattern_type, format, settings)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+func (s *store) CreateJob(ctx context.Context, opts CreateJobOpts) (*Job, error) {
+	settings, err := json.Marshal(opts.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanJob(s.QueryRow(ctx, sqlf.Sprintf(
+		createJobFmtstr,
+		opts.InitiatorID,
+		opts.Query,
+		opts.PatternType,
+		opts.Format,
+		settings,
+		sqlf.Join(jobColumns, ", "),
+	)))
+}
+
+const getJobFmtstr = `
+SELECT %s FROM search_export_jobs WHERE id = %s
+`
+
+func (s *store) GetJob(ctx context.Context, id int) (*Job, error) {
+	job, err := scanJob(s.QueryRow(ctx, sqlf.Sprintf(getJobFmtstr, sqlf.Join(jobColumns, ", "), id)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrJobNotFound
+	}
+	return job, err
+}
+
+const listJobsFmtstr = `
+SELECT %s FROM search_export_jobs WHERE initiator_id = %s ORDER BY id DESC
+`
+
+func (s *store) ListJobs(ctx context.Context, initiatorID int32) ([]*Job, error) {
+	return scanJobs(s.Query(ctx, sqlf.Sprintf(listJobsFmtstr, sqlf.Join(jobColumns, ", "), initiatorID)))
+}
+
+const checkpointJobFmtstr = `
+UPDATE search_export_jobs
+SET
+	cursors = %s,
+	pages_searched = pages_searched + 1,
+	parts = CASE WHEN %s = '' THEN parts ELSE array_append(parts, %s) END,
+	matches_written = matches_written + %s,
+	updated_at = NOW()
+WHERE id = %s
+`
+
+func (s *store) CheckpointJob(ctx context.Context, id int, next types.MultiCursor, part string, matches int64) error {
+	var cursors []byte
+	if next != nil {
+		var err error
+		if cursors, err = json.Marshal(next); err != nil {
+			return err
+		}
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(checkpointJobFmtstr, cursors, part, part, matches, id))
+}
+
+const setJobObjectKeyFmtstr = `
+UPDATE search_export_jobs SET object_key = %s, updated_at = NOW() WHERE id = %s
+`
+
+func (s *store) SetJobObjectKey(ctx context.Context, id int, key string) error {
+	return s.Exec(ctx, sqlf.Sprintf(setJobObjectKeyFmtstr, key, id))
+}
+
+func scanJob(sc dbutil.Scanner) (*Job, error) {
+	var (
+		j               Job
+		lastHeartbeatAt *time.Time
+		settings        []byte
+		cursors         []byte
+	)
+	if err := sc.Scan(
+		&j.ID,
+		&j.State,
+		&j.FailureMessage,
+		&j.QueuedAt,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+		&lastHeartbeatAt,
+		&j.WorkerHostname,
+		&j.Cancel,
+		&j.InitiatorID,
+		&j.Query,
+		&j.PatternType,
+		&j.Format,
+		&settings,
+		&cursors,
+		&j.PagesSearched,
+		pq.Array(&j.Parts),
+		&j.MatchesWritten,
+		&j.ObjectKey,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if lastHeartbeatAt != nil {
+		j.LastHeartbeatAt = *lastHeartbeatAt
+	}
+	if err := json.Unmarshal(settings, &j.Settings); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal settings")
+	}
+	if len(cursors) > 0 {
+		if err := json.Unmarshal(cursors, &j.Cursors); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal cursors")
+		}
+	}
+
+	return &j, nil
+}
+
+var scanJobs = basestore.NewSliceScanner(scanJob)
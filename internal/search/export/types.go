@@ -0,0 +1,117 @@
+// Package export implements search export jobs: background jobs that run a
+// search without result limit or timeout over every repository it matches,
+// and write all of its matches to a file in the upload store.
+package export
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Format is the file format of a search export.
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// ParseFormat returns the format with the given name. An empty name defaults
+// to JSONL.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", string(FormatJSONL):
+		return FormatJSONL, nil
+	case string(FormatCSV):
+		return FormatCSV, nil
+	}
+	return "", errors.Errorf("unsupported search export format %q: must be jsonl or csv", name)
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// FileExtension returns the extension of files in the format, including the
+// leading dot.
+func (f Format) FileExtension() string {
+	return "." + string(f)
+}
+
+// Job is a search export job, as stored in the search_export_jobs table.
+type Job struct {
+	ID              int
+	State           string
+	FailureMessage  *string
+	QueuedAt        time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	ProcessAfter    *time.Time
+	NumResets       int
+	NumFailures     int
+	LastHeartbeatAt time.Time
+	WorkerHostname  string
+	Cancel          bool
+
+	InitiatorID int32
+	Query       string
+	PatternType string // empty for the default pattern type
+	Format      Format
+
+	// Settings are the final settings of the initiator when the job was
+	// created. They are used to plan the search, so that it doesn't change
+	// if the job is resumed after the settings of the initiator changed.
+	Settings *schema.Settings
+
+	// Cursors are the repository cursors of the next page of repositories to
+	// search. They are nil both before the first page and after the last page
+	// has been searched, which can be told apart with PagesSearched.
+	Cursors types.MultiCursor
+
+	// PagesSearched is the number of pages of repositories searched so far.
+	PagesSearched int
+
+	// Parts are the upload store keys of the results of each page with at
+	// least one match, in search order.
+	Parts []string
+
+	// MatchesWritten is the number of rows written to the parts so far.
+	MatchesWritten int64
+
+	// ObjectKey is the upload store key of the export once complete.
+	ObjectKey *string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (j *Job) RecordID() int {
+	return j.ID
+}
+
+// Searched returns true if every page of repositories has been searched.
+func (j *Job) Searched() bool {
+	return j.PagesSearched > 0 && j.Cursors == nil
+}
+
+// partKey returns the upload store key of the results of the given page of
+// the job. Keys are stable so that the part of a page is overwritten if the
+// page is searched again after a failure.
+func (j *Job) partKey(page int) string {
+	return path.Join("search-exports", strconv.Itoa(j.ID), "parts", strconv.Itoa(page)+j.Format.FileExtension())
+}
+
+// objectKey returns the upload store key of the complete export of the job.
+func (j *Job) objectKey() string {
+	return path.Join("search-exports", strconv.Itoa(j.ID), "results"+j.Format.FileExtension())
+}
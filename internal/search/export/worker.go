@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// NewWorker returns a worker that runs the queued search export jobs.
+//
+// Jobs have no maximum runtime: searching every repository of a large
+// instance can take hours. Instead, a job that stops heartbeating is reset by
+// the resetter returned by NewResetter, and resumes from its last checkpoint.
+func NewWorker(ctx context.Context, observationCtx *observation.Context, db database.DB, uploadStore uploadstore.Store, searchClient client.SearchClient, numHandlers int) *workerutil.Worker[*Job] {
+	options := workerutil.WorkerOptions{
+		Name:              "search_export_jobs_worker",
+		NumHandlers:       numHandlers,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationCtx, "search_export_jobs"),
+	}
+
+	h := &handler{
+		db:           db,
+		store:        NewStore(db),
+		uploadStore:  uploadStore,
+		searchClient: searchClient,
+	}
+
+	return dbworker.NewWorker[*Job](ctx, newWorkerStore(observationCtx, db), h, options)
+}
+
+// NewResetter returns a background routine that requeues search export jobs
+// whose worker stopped heartbeating.
+func NewResetter(observationCtx *observation.Context, db database.DB) *dbworker.Resetter[*Job] {
+	options := dbworker.ResetterOptions{
+		Name:     "search_export_jobs_worker_resetter",
+		Interval: time.Minute,
+		Metrics:  *dbworker.NewResetterMetrics(observationCtx, "search_export_jobs"),
+	}
+	return dbworker.NewResetter(observationCtx.Logger, newWorkerStore(observationCtx, db), options)
+}
+
+func newWorkerStore(observationCtx *observation.Context, db database.DB) dbworkerstore.Store[*Job] {
+	observationCtx = observation.ContextWithLogger(observationCtx.Logger.Scoped("searchExportJobs.dbworker.Store", ""), observationCtx)
+
+	return dbworkerstore.New(observationCtx, db.Handle(), dbworkerstore.Options[*Job]{
+		Name:              "search_export_jobs_worker_store",
+		TableName:         "search_export_jobs",
+		ColumnExpressions: jobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(scanJob),
+		StalledMaxAge:     time.Minute,
+		RetryAfter:        time.Minute,
+		MaxNumRetries:     5,
+		// Resets are expected when the worker restarts during a long export,
+		// and are cheap since jobs resume from their last checkpoint.
+		MaxNumResets:      10,
+		OrderByExpression: sqlf.Sprintf("search_export_jobs.id"),
+	})
+}
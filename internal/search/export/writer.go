@@ -0,0 +1,159 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Row is a single match of an export. File matches are exported as one row
+// per matched range, so that a match of every occurrence of a call is
+// exported as one row per call site. Path matches and other results without
+// ranges are exported as a single row.
+type Row struct {
+	Repository string `json:"repository"`
+	Revision   string `json:"revision,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Path       string `json:"path,omitempty"`
+
+	// Line and Column are the 1-based position of the start of the match,
+	// or zero if the row is not a content match.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// Preview is the content of the line of the match.
+	Preview string `json:"preview,omitempty"`
+
+	// Owner is set when owners are selected with select:file.owners.
+	Owner string `json:"owner,omitempty"`
+}
+
+// csvHeader is the header row of CSV exports. It is written once at the top
+// of a complete export, and not to the parts of each page.
+var csvHeader = []string{"repository", "revision", "commit", "path", "line", "column", "preview", "owner"}
+
+func (r *Row) csvRecord() []string {
+	itoa := func(i int) string {
+		if i == 0 {
+			return ""
+		}
+		return strconv.Itoa(i)
+	}
+	return []string{r.Repository, r.Revision, r.Commit, r.Path, itoa(r.Line), itoa(r.Column), r.Preview, r.Owner}
+}
+
+// rows returns the rows of the given match.
+func rows(match result.Match) []Row {
+	repo := match.RepoName()
+
+	switch m := match.(type) {
+	case *result.FileMatch:
+		base := Row{
+			Repository: string(repo.Name),
+			Commit:     string(m.CommitID),
+			Path:       m.Path,
+		}
+		if m.InputRev != nil {
+			base.Revision = *m.InputRev
+		}
+
+		var rows []Row
+		for _, lm := range m.ChunkMatches.AsLineMatches() {
+			for _, offsetAndLength := range lm.OffsetAndLengths {
+				row := base
+				row.Line = int(lm.LineNumber) + 1
+				row.Column = int(offsetAndLength[0]) + 1
+				row.Preview = lm.Preview
+				rows = append(rows, row)
+			}
+		}
+		if len(rows) == 0 {
+			rows = append(rows, base)
+		}
+		return rows
+
+	case *result.OwnerMatch:
+		owner := m.Handle
+		if owner == "" {
+			owner = m.Email
+		}
+		return []Row{{Repository: string(repo.Name), Commit: string(m.CommitID), Owner: owner}}
+
+	default:
+		return []Row{{Repository: string(repo.Name)}}
+	}
+}
+
+// rowWriter writes the rows of matches in an export format.
+type rowWriter interface {
+	// WriteMatch writes the rows of the given match and returns how many
+	// rows were written.
+	WriteMatch(match result.Match) (int, error)
+
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+}
+
+func newRowWriter(w io.Writer, format Format) rowWriter {
+	if format == FormatCSV {
+		return &csvRowWriter{w: csv.NewWriter(w)}
+	}
+	return &jsonlRowWriter{enc: json.NewEncoder(w)}
+}
+
+type jsonlRowWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlRowWriter) WriteMatch(match result.Match) (int, error) {
+	rows := rows(match)
+	for i := range rows {
+		if err := w.enc.Encode(&rows[i]); err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (w *jsonlRowWriter) Flush() error {
+	return nil
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvRowWriter) WriteMatch(match result.Match) (int, error) {
+	rows := rows(match)
+	for i := range rows {
+		if err := w.w.Write(rows[i].csvRecord()); err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (w *csvRowWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// header returns the bytes written at the top of a complete export in the
+// given format.
+func header(format Format) ([]byte, error) {
+	if format != FormatCSV {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRowWriter(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	rev := "main"
+
+	matches := []result.Match{
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: api.CommitID("deadbeef"), InputRev: &rev, Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "\tfmt.Println(\"a\", \"b\")",
+				ContentStart: result.Location{Offset: 10, Line: 4, Column: 0},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 11, Line: 4, Column: 1},
+					End:   result.Location{Offset: 22, Line: 4, Column: 12},
+				}, {
+					Start: result.Location{Offset: 23, Line: 4, Column: 13},
+					End:   result.Location{Offset: 26, Line: 4, Column: 16},
+				}},
+			}},
+		},
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: api.CommitID("deadbeef"), Path: "README.md"},
+		},
+		&result.OwnerMatch{Repo: repo, CommitID: api.CommitID("deadbeef"), Email: "alice@example.com"},
+		&result.RepoMatch{Name: repo.Name, ID: repo.ID},
+	}
+
+	t.Run("jsonl", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := newRowWriter(&buf, FormatJSONL)
+
+		n := 0
+		for _, m := range matches {
+			written, err := w.WriteMatch(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			n += written
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 5 {
+			t.Errorf("expected 5 rows, got %d", n)
+		}
+		want := `{"repository":"github.com/sourcegraph/sourcegraph","revision":"main","commit":"deadbeef","path":"main.go","line":5,"column":2,"preview":"\tfmt.Println(\"a\", \"b\")"}
+{"repository":"github.com/sourcegraph/sourcegraph","revision":"main","commit":"deadbeef","path":"main.go","line":5,"column":14,"preview":"\tfmt.Println(\"a\", \"b\")"}
+{"repository":"github.com/sourcegraph/sourcegraph","commit":"deadbeef","path":"README.md"}
+{"repository":"github.com/sourcegraph/sourcegraph","commit":"deadbeef","owner":"alice@example.com"}
+{"repository":"github.com/sourcegraph/sourcegraph"}
+`
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("unexpected output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		prefix, err := header(FormatCSV)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := bytes.NewBuffer(prefix)
+		w := newRowWriter(buf, FormatCSV)
+
+		for _, m := range matches {
+			if _, err := w.WriteMatch(m); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `repository,revision,commit,path,line,column,preview,owner
+github.com/sourcegraph/sourcegraph,main,deadbeef,main.go,5,2,"	fmt.Println(""a"", ""b"")",
+github.com/sourcegraph/sourcegraph,main,deadbeef,main.go,5,14,"	fmt.Println(""a"", ""b"")",
+github.com/sourcegraph/sourcegraph,,deadbeef,README.md,,,,
+github.com/sourcegraph/sourcegraph,,deadbeef,,,,,alice@example.com
+github.com/sourcegraph/sourcegraph,,,,,,,
+`
+		if diff := cmp.Diff(want, buf.String()); diff != "" {
+			t.Errorf("unexpected output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("jsonl header", func(t *testing.T) {
+		prefix, err := header(FormatJSONL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(prefix) != 0 {
+			t.Errorf("expected no header, got %q", prefix)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package jobutil
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// exhaustiveRepoPageSize is the number of repositories searched at a time
+	// by an exhaustive search. Smaller pages make for more frequent
+	// checkpoints.
+	exhaustiveRepoPageSize = 100
+
+	// exhaustiveFileMatchLimit is the file match limit of the searches over
+	// each page of repositories. It is large enough to be effectively
+	// unbounded.
+	exhaustiveFileMatchLimit = 1_000_000
+)
+
+// NewExhaustiveJob creates a job that returns every file and path match of
+// the query planned in inputs, without a result limit or timeout. It pages
+// through the repositories matching the query starting at the given cursors,
+// and calls onPage with the cursors of the next page once all the results of
+// a page have been sent. A nil next cursor means the search is done. The
+// cursors passed to onPage can be used to resume the search after a failure.
+//
+// Only a subset of queries is supported: the query must be a single basic
+// query whose pattern is not an expression, and must search file contents or
+// paths.
+func NewExhaustiveJob(inputs *search.Inputs, cursors types.MultiCursor, onPage func(next types.MultiCursor) error) (job.Job, error) {
+	if len(inputs.Plan) != 1 {
+		return nil, errors.New("exhaustive search does not support queries with or/and expressions over filters")
+	}
+	b := inputs.Plan[0]
+
+	if _, ok := b.Pattern.(query.Operator); ok {
+		return nil, errors.New("exhaustive search does not support queries with or/and expressions")
+	}
+	if inputs.PatternType == query.SearchTypeStructural {
+		return nil, errors.New("exhaustive search does not support structural search")
+	}
+	if len(b.FileContainsContent()) > 0 {
+		return nil, errors.New("exhaustive search does not support file:contains.content()")
+	}
+	if b.IsEmptyPattern() && !b.Exists(query.FieldFile) && !b.Exists(query.FieldLang) {
+		return nil, errors.New("exhaustive search requires a pattern, or a file: or lang: filter")
+	}
+
+	resultTypes := computeResultTypes(b, inputs.PatternType)
+	if !resultTypes.Has(result.TypeFile | result.TypePath) {
+		return nil, errors.Errorf("exhaustive search only supports file and path results, got type %s", resultTypes)
+	}
+	// Repository matches are dropped: an exhaustive search is only interested
+	// in the contents of repositories.
+	resultTypes = resultTypes.Without(result.TypeRepo)
+
+	selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
+
+	repoOptions := toRepoOptions(b, inputs.UserSettings)
+	repoOptions.Limit = exhaustiveRepoPageSize
+	repoOptions.Cursors = cursors
+
+	builder := &jobBuilder{
+		query:          b,
+		resultTypes:    resultTypes,
+		repoOptions:    repoOptions,
+		features:       inputs.Features,
+		fileMatchLimit: exhaustiveFileMatchLimit,
+		selector:       selector,
+	}
+
+	zoektJob, err := builder.newZoektSearch(search.TextRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	patternInfo := toTextPatternInfo(b, resultTypes, inputs.Features, inputs.Protocol)
+	patternInfo.FileMatchLimit = exhaustiveFileMatchLimit
+	searcherJob := &searcher.TextSearchJob{
+		PatternInfo:     patternInfo,
+		Indexed:         false,
+		UseFullDeadline: true,
+		Features:        *inputs.Features,
+		PathRegexps:     getPathRegexpsFromTextPatternInfo(patternInfo),
+	}
+
+	var basicJob job.Job = &repoPagerJob{
+		child:            &reposPartialJob{NewParallelJob(zoektJob, searcherJob)},
+		repoOpts:         repoOptions,
+		containsRefGlobs: query.ContainsRefGlobs(b.ToParseTree()),
+		onPage:           onPage,
+	}
+
+	return wrapWithPostFilters(b, inputs, basicJob), nil
+}
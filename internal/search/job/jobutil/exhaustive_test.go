@@ -0,0 +1,75 @@
+package jobutil
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewExhaustiveJob(t *testing.T) {
+	newInputs := func(t *testing.T, q string, searchType query.SearchType) *search.Inputs {
+		plan, err := query.Pipeline(query.Init(q, searchType))
+		require.NoError(t, err)
+
+		return &search.Inputs{
+			Plan:         plan,
+			UserSettings: &schema.Settings{},
+			PatternType:  searchType,
+			Protocol:     search.Streaming,
+			Features:     &search.Features{},
+		}
+	}
+
+	t.Run("job tree", func(t *testing.T) {
+		inputs := newInputs(t, `repo:sourcegraph foo count:10 timeout:1s`, query.SearchTypeLiteral)
+		j, err := NewExhaustiveJob(inputs, nil, nil)
+		require.NoError(t, err)
+
+		autogold.Want("exhaustive job tree", `
+(REPOPAGER
+  (repoOpts.repoFilters.0 . sourcegraph)(repoOpts.limit . 100)
+  (PARTIALREPOS
+    (PARALLEL
+      (ZOEKTREPOSUBSETTEXTSEARCH
+        (query . substr:"foo")
+        (type . text))
+      (SEARCHERTEXTSEARCH
+        (indexed . false)))))`).Equal(t, "\n"+printer.SexpPretty(j))
+	})
+
+	t.Run("resumes from cursors", func(t *testing.T) {
+		inputs := newInputs(t, `foo`, query.SearchTypeLiteral)
+		cursors := types.MultiCursor{{Column: "stars", Value: "10", Direction: "next"}}
+		j, err := NewExhaustiveJob(inputs, cursors, nil)
+		require.NoError(t, err)
+
+		pager := j.(*repoPagerJob)
+		require.Equal(t, []*types.Cursor(cursors), pager.repoOpts.Cursors)
+		require.Equal(t, exhaustiveRepoPageSize, pager.repoOpts.Limit)
+	})
+
+	for _, tc := range []struct {
+		query      string
+		searchType query.SearchType
+	}{
+		{query: `foo or bar`, searchType: query.SearchTypeLiteral},
+		{query: `(repo:a foo) or (repo:b bar)`, searchType: query.SearchTypeLiteral},
+		{query: `foo(:[args])`, searchType: query.SearchTypeStructural},
+		{query: `file:contains.content(foo) bar`, searchType: query.SearchTypeLiteral},
+		{query: `repo:sourcegraph`, searchType: query.SearchTypeLiteral},
+		{query: `foo type:symbol`, searchType: query.SearchTypeLiteral},
+		{query: `foo type:diff`, searchType: query.SearchTypeLiteral},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := NewExhaustiveJob(newInputs(t, tc.query, tc.searchType), nil, nil)
+			require.Error(t, err)
+		})
+	}
+}
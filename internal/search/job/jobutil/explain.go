@@ -0,0 +1,451 @@
+package jobutil
+
+import (
+	"context"
+	"fmt"
+	"regexp/syntax"
+	"unicode/utf8"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/limits"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/structural"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// The relative cost of searching a repository revision with each backend.
+// Zoekt answers from an in-memory index, searcher has to fetch and scan an
+// archive of the revision, and commit and diff searches walk the history of
+// the revision on gitserver.
+const (
+	indexedRepoCost       = 1
+	searcherRevisionCost  = 10
+	gitserverRevisionCost = 100
+)
+
+// minIndexableLiteral is the length of the shortest literal Zoekt can look up
+// in its trigram index.
+const minIndexableLiteral = 3
+
+// Cost is the estimated cost of running a job.
+type Cost struct {
+	// Repos is the number of repositories the job searches.
+	Repos int
+
+	// IndexedRepos is the number of repositories searched by Zoekt.
+	IndexedRepos int
+
+	// SearcherRevisions is the number of repository revisions searched by
+	// searcher, because they are not indexed.
+	SearcherRevisions int
+
+	// GitserverRevisions is the number of repository revisions whose history
+	// is searched on gitserver by commit and diff searches.
+	GitserverRevisions int
+
+	// FanOut is the list of predicates that are evaluated by searching each
+	// repository, or each repository with results.
+	FanOut []string
+
+	// PredicateRepos is the number of repositories the predicates in FanOut
+	// are evaluated on.
+	PredicateRepos int
+}
+
+// Score combines the estimates of c into a single number, weighted by how
+// expensive each backend is. It is compared against the maxEstimatedCost
+// search limit.
+func (c Cost) Score() int {
+	return c.IndexedRepos*indexedRepoCost +
+		(c.SearcherRevisions+c.PredicateRepos)*searcherRevisionCost +
+		c.GitserverRevisions*gitserverRevisionCost
+}
+
+// add returns the cost of running the jobs of c and other. Repos is the
+// maximum of both, since the children of a job usually search the same
+// repositories.
+func (c Cost) add(other Cost) Cost {
+	if other.Repos > c.Repos {
+		c.Repos = other.Repos
+	}
+	c.IndexedRepos += other.IndexedRepos
+	c.SearcherRevisions += other.SearcherRevisions
+	c.GitserverRevisions += other.GitserverRevisions
+	c.PredicateRepos += other.PredicateRepos
+	for _, p := range other.FanOut {
+		if !contains(c.FanOut, p) {
+			c.FanOut = append(c.FanOut, p)
+		}
+	}
+	return c
+}
+
+func (c Cost) fields() []otlog.Field {
+	res := []otlog.Field{
+		otlog.Int("score", c.Score()),
+		otlog.Int("repos", c.Repos),
+	}
+	if c.IndexedRepos > 0 {
+		res = append(res, otlog.Int("indexedRepos", c.IndexedRepos))
+	}
+	if c.SearcherRevisions > 0 {
+		res = append(res, otlog.Int("searcherRevisions", c.SearcherRevisions))
+	}
+	if c.GitserverRevisions > 0 {
+		res = append(res, otlog.Int("gitserverRevisions", c.GitserverRevisions))
+	}
+	if len(c.FanOut) > 0 {
+		res = append(res, trace.Strings("fanOut", c.FanOut))
+	}
+	if c.PredicateRepos > 0 {
+		res = append(res, otlog.Int("predicateRepos", c.PredicateRepos))
+	}
+	return res
+}
+
+// Explanation is the estimated cost of a job tree.
+type Explanation struct {
+	// Job is the job tree with each job annotated with its estimated cost.
+	// It can be printed with any of the printers of the printer package.
+	Job job.Describer
+
+	// Cost is the estimated cost of the whole job tree.
+	Cost Cost
+
+	// Warnings describe what makes the job tree expensive to run.
+	Warnings []string
+}
+
+// Explain estimates the cost of running j, the job tree of plan, without
+// running it. Repositories are counted in the database and looked up in the
+// Zoekt index, but revisions are not resolved and no repository is searched.
+func Explain(ctx context.Context, clients job.RuntimeClients, plan query.Plan, j job.Describer) (*Explanation, error) {
+	return explain(ctx, clients, plan, j, 0)
+}
+
+// ExplainUpTo is like Explain, but stops estimating as soon as the cost of
+// the job tree exceeds maxScore, so the repositories of the remaining jobs
+// are not counted. The cost it returns is then a lower bound, and the job
+// tree is incomplete.
+func ExplainUpTo(ctx context.Context, clients job.RuntimeClients, plan query.Plan, j job.Describer, maxScore int) (*Explanation, error) {
+	return explain(ctx, clients, plan, j, maxScore)
+}
+
+func explain(ctx context.Context, clients job.RuntimeClients, plan query.Plan, j job.Describer, maxScore int) (*Explanation, error) {
+	e := &explainer{
+		ctx:       ctx,
+		maxScore:  maxScore,
+		resolver:  searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt, clients.Dependencies),
+		estimates: map[string]searchrepos.Estimate{},
+		charged:   map[string]struct{}{},
+	}
+	for _, b := range plan {
+		if b.Pattern != nil {
+			e.checkPatterns([]query.Node{b.Pattern})
+		}
+	}
+
+	root, err := e.explain(j, nil)
+	if err != nil {
+		return nil, err
+	}
+	root.warnings = e.warnings
+
+	return &Explanation{
+		Job:      root,
+		Cost:     root.cost,
+		Warnings: e.warnings,
+	}, nil
+}
+
+type explainer struct {
+	ctx       context.Context
+	maxScore  int
+	resolver  *searchrepos.Resolver
+	estimates map[string]searchrepos.Estimate
+	charged   map[string]struct{}
+	warnings  []string
+}
+
+// pagerScope is the split of the repositories of a RepoPagerJob between the
+// indexed and unindexed jobs it runs.
+type pagerScope struct {
+	indexed     int
+	unindexed   int
+	revsPerRepo int
+}
+
+func (e *explainer) explain(j job.Describer, scope *pagerScope) (*explainedJob, error) {
+	var cost Cost
+
+	switch v := j.(type) {
+	case *repoPagerJob:
+		est, err := e.estimate(v.repoOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		scope = &pagerScope{indexed: est.Indexed, unindexed: est.Repos - est.Indexed, revsPerRepo: est.RevsPerRepo}
+		if v.containsRefGlobs || v.repoOpts.UseIndex == query.No {
+			scope.indexed, scope.unindexed = 0, est.Repos
+		}
+		if v.repoOpts.UseIndex == query.Only {
+			scope.unindexed = 0
+		}
+		cost = e.predicateCost(v.repoOpts, est)
+
+	case *zoekt.RepoSubsetTextSearchJob, *zoekt.SymbolSearchJob:
+		if scope != nil {
+			cost = Cost{Repos: scope.indexed, IndexedRepos: scope.indexed}
+		}
+
+	case *searcher.TextSearchJob:
+		if scope != nil && v.Indexed {
+			// Searcher only checks the files Zoekt finds, see toNearJob.
+			cost = Cost{Repos: scope.indexed, IndexedRepos: scope.indexed}
+		} else if scope != nil {
+			cost = Cost{Repos: scope.unindexed, SearcherRevisions: scope.unindexed * scope.revsPerRepo}
+		}
+
+	case *searcher.SymbolSearchJob:
+		if scope != nil {
+			cost = Cost{Repos: scope.unindexed, SearcherRevisions: scope.unindexed * scope.revsPerRepo}
+		}
+
+	case *zoekt.GlobalTextSearchJob:
+		est, err := e.estimate(v.RepoOpts)
+		if err != nil {
+			return nil, err
+		}
+		cost = Cost{Repos: est.Indexed, IndexedRepos: est.Indexed}
+
+	case *zoekt.GlobalSymbolSearchJob:
+		est, err := e.estimate(v.RepoOpts)
+		if err != nil {
+			return nil, err
+		}
+		cost = Cost{Repos: est.Indexed, IndexedRepos: est.Indexed}
+
+	case *structural.SearchJob:
+		est, err := e.estimate(v.RepoOpts)
+		if err != nil {
+			return nil, err
+		}
+		cost = e.predicateCost(v.RepoOpts, est)
+		cost.SearcherRevisions = est.Repos * est.RevsPerRepo
+
+	case *commit.SearchJob:
+		est, err := e.estimate(v.RepoOpts)
+		if err != nil {
+			return nil, err
+		}
+		cost = e.predicateCost(v.RepoOpts, est)
+		cost.GitserverRevisions = est.Repos * est.RevsPerRepo
+		e.checkCommitRepos(v, est.Repos)
+
+	case *RepoSearchJob:
+		est, err := e.estimate(v.RepoOpts)
+		if err != nil {
+			return nil, err
+		}
+		cost = e.predicateCost(v.RepoOpts, est)
+
+	case *fileHasOwnersJob:
+		cost = Cost{FanOut: []string{"file:has.owner"}}
+	}
+
+	children := j.Children()
+	explained := &explainedJob{Describer: j, children: make([]job.Describer, 0, len(children))}
+	for _, child := range children {
+		if e.maxScore > 0 && cost.Score() > e.maxScore {
+			break
+		}
+		c, err := e.explain(child, scope)
+		if err != nil {
+			return nil, err
+		}
+		cost = cost.add(c.cost)
+		explained.children = append(explained.children, c)
+	}
+	explained.cost = cost
+
+	return explained, nil
+}
+
+// estimate returns the estimate of the repository revisions of opts. Jobs of
+// the same query usually share their repository options, so estimates are
+// cached.
+func (e *explainer) estimate(opts search.RepoOptions) (searchrepos.Estimate, error) {
+	key := opts.String()
+	if est, ok := e.estimates[key]; ok {
+		return est, nil
+	}
+
+	est, err := e.resolver.Estimate(e.ctx, opts)
+	if err != nil {
+		return searchrepos.Estimate{}, err
+	}
+	if est.RevsPerRepo == 0 {
+		e.warn("rev: contains a ref glob, which is expanded in every repository; each repository is estimated at a single revision")
+		est.RevsPerRepo = 1
+	}
+	for _, p := range est.FanOut {
+		e.warn(fmt.Sprintf("%s is evaluated by searching each of the %d repositories matched by the repository filters", p, est.Repos))
+	}
+
+	e.estimates[key] = est
+	return est, nil
+}
+
+// predicateCost returns the cost of evaluating the fan-out predicates of
+// est. Jobs that share their repository options share the evaluation of the
+// predicates, so it is only charged to the first of them.
+func (e *explainer) predicateCost(opts search.RepoOptions, est searchrepos.Estimate) Cost {
+	cost := Cost{Repos: est.Repos, FanOut: est.FanOut}
+
+	key := opts.String()
+	if _, ok := e.charged[key]; !ok {
+		e.charged[key] = struct{}{}
+		cost.PredicateRepos = est.Repos * len(est.FanOut)
+	}
+	return cost
+}
+
+// checkCommitRepos warns if a commit or diff search searches more
+// repositories than the commitDiffMaxRepos search limits.
+func (e *explainer) checkCommitRepos(j *commit.SearchJob, repos int) {
+	searchLimits := limits.SearchLimits(conf.Get())
+
+	max, limit := searchLimits.CommitDiffMaxRepos, "commitDiffMaxRepos"
+	if hasTimeFilter(j.Query) {
+		max, limit = searchLimits.CommitDiffWithTimeFilterMaxRepos, "commitDiffWithTimeFilterMaxRepos"
+	}
+	if repos > max {
+		e.warn(fmt.Sprintf("%s searches the history of %d repositories on gitserver, more than the %s search limit of %d", j.Name(), repos, limit, max))
+	}
+}
+
+// hasTimeFilter returns whether a commit search query is bounded by an
+// after: or before: filter.
+func hasTimeFilter(n gitprotocol.Node) bool {
+	switch v := n.(type) {
+	case *gitprotocol.CommitAfter, *gitprotocol.CommitBefore:
+		return true
+	case *gitprotocol.Operator:
+		if v.Kind != gitprotocol.And {
+			return false
+		}
+		for _, operand := range v.Operands {
+			if hasTimeFilter(operand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPatterns warns about patterns that cannot be looked up in the trigram
+// index of Zoekt, and so are matched against the content of every file.
+func (e *explainer) checkPatterns(nodes []query.Node) {
+	query.VisitPattern(nodes, func(value string, negated bool, annotation query.Annotation) {
+		if negated || annotation.Labels.IsSet(query.Structural) {
+			return
+		}
+
+		n := utf8.RuneCountInString(value)
+		if annotation.Labels.IsSet(query.Regexp) {
+			re, err := syntax.Parse(value, syntax.Perl)
+			if err != nil {
+				return
+			}
+			n = requiredLiteralLen(re.Simplify())
+		}
+		if n < minIndexableLiteral {
+			e.warn(fmt.Sprintf("pattern %q contains no literal of at least %d characters, so it cannot use the search index and is matched against the content of every file", value, minIndexableLiteral))
+		}
+	})
+}
+
+// requiredLiteralLen returns the length of the longest literal every match
+// of re contains.
+func requiredLiteralLen(re *syntax.Regexp) int {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiteralLen(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return 0
+		}
+		return requiredLiteralLen(re.Sub[0])
+	case syntax.OpConcat:
+		n := 0
+		for _, sub := range re.Sub {
+			if l := requiredLiteralLen(sub); l > n {
+				n = l
+			}
+		}
+		return n
+	case syntax.OpAlternate:
+		n := -1
+		for _, sub := range re.Sub {
+			if l := requiredLiteralLen(sub); n < 0 || l < n {
+				n = l
+			}
+		}
+		if n < 0 {
+			return 0
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+func (e *explainer) warn(msg string) {
+	if !contains(e.warnings, msg) {
+		e.warnings = append(e.warnings, msg)
+	}
+}
+
+// explainedJob is a job annotated with its estimated cost.
+type explainedJob struct {
+	job.Describer
+	cost     Cost
+	children []job.Describer
+
+	// warnings is only set on the root of the job tree.
+	warnings []string
+}
+
+func (j *explainedJob) Children() []job.Describer { return j.children }
+
+func (j *explainedJob) Fields(v job.Verbosity) []otlog.Field {
+	fields := j.Describer.Fields(v)
+	res := make([]otlog.Field, 0, len(fields)+2)
+	res = append(res, fields...)
+	res = append(res, trace.Scoped("cost", j.cost.fields()...))
+	if len(j.warnings) > 0 {
+		res = append(res, trace.Strings("warnings", j.warnings))
+	}
+	return res
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// listOnlyStreamer is a zoekt.Streamer that only supports listing the
+// minimal repo list of the given repos.
+type listOnlyStreamer struct {
+	zoekt.Streamer
+	indexed []uint32
+}
+
+func (s *listOnlyStreamer) List(context.Context, zoektquery.Q, *zoekt.ListOptions) (*zoekt.RepoList, error) {
+	minimal := make(map[uint32]*zoekt.MinimalRepoListEntry, len(s.indexed))
+	for _, id := range s.indexed {
+		minimal[id] = &zoekt.MinimalRepoListEntry{}
+	}
+	return &zoekt.RepoList{Minimal: minimal}, nil
+}
+
+func TestExplain(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchLimits: &schema.SearchLimits{CommitDiffMaxRepos: 2},
+	}})
+	defer conf.Mock(nil)
+
+	// Four repositories, of which the first two are indexed.
+	repos := database.NewMockRepoStore()
+	repos.CountFunc.SetDefaultReturn(4, nil)
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	clients := job.RuntimeClients{
+		Logger:    logtest.Scoped(t),
+		DB:        db,
+		Zoekt:     &listOnlyStreamer{indexed: []uint32{1, 2}},
+		Gitserver: gitserver.NewMockClient(),
+	}
+
+	explain := func(t *testing.T, q string, searchType query.SearchType) *Explanation {
+		plan, err := query.Pipeline(query.Init(q, searchType))
+		require.NoError(t, err)
+
+		inputs := &search.Inputs{
+			Plan:         plan,
+			UserSettings: &schema.Settings{},
+			PatternType:  searchType,
+			Protocol:     search.Streaming,
+			Features:     &search.Features{},
+		}
+		j, err := NewPlanJob(inputs, plan)
+		require.NoError(t, err)
+
+		explanation, err := Explain(context.Background(), clients, plan, j)
+		require.NoError(t, err)
+		return explanation
+	}
+
+	t.Run("text search", func(t *testing.T) {
+		e := explain(t, `repo:foo bar`, query.SearchTypeLiteral)
+
+		require.Equal(t, Cost{Repos: 4, IndexedRepos: 2, SearcherRevisions: 2}, e.Cost)
+		require.Equal(t, 22, e.Cost.Score())
+		require.Empty(t, e.Warnings)
+	})
+
+	t.Run("annotated job tree", func(t *testing.T) {
+		e := explain(t, `repo:foo@a:b bar`, query.SearchTypeLiteral)
+
+		autogold.Want("explained job tree", `
+(LOG
+  (cost.score . 42)(cost.repos . 4)(cost.indexedRepos . 2)(cost.searcherRevisions . 4)
+  (ALERT
+    (cost.score . 42)(cost.repos . 4)(cost.indexedRepos . 2)(cost.searcherRevisions . 4)
+    (TIMEOUT
+      (cost.score . 42)(cost.repos . 4)(cost.indexedRepos . 2)(cost.searcherRevisions . 4)
+      (LIMIT
+        (cost.score . 42)(cost.repos . 4)(cost.indexedRepos . 2)(cost.searcherRevisions . 4)
+        (PARALLEL
+          (cost.score . 42)(cost.repos . 4)(cost.indexedRepos . 2)(cost.searcherRevisions . 4)
+          (REPOPAGER
+            (cost.score . 2)(cost.repos . 4)(cost.indexedRepos . 2)
+            (PARTIALREPOS
+              (cost.score . 2)(cost.repos . 2)(cost.indexedRepos . 2)
+              (ZOEKTREPOSUBSETTEXTSEARCH
+                (cost.score . 2)(cost.repos . 2)(cost.indexedRepos . 2))))
+          (REPOSCOMPUTEEXCLUDED
+            (cost.score . 0)(cost.repos . 0))
+          (PARALLEL
+            (cost.score . 40)(cost.repos . 4)(cost.searcherRevisions . 4)
+            (REPOPAGER
+              (cost.score . 40)(cost.repos . 4)(cost.searcherRevisions . 4)
+              (PARTIALREPOS
+                (cost.score . 40)(cost.repos . 2)(cost.searcherRevisions . 4)
+                (SEARCHERTEXTSEARCH
+                  (cost.score . 40)(cost.repos . 2)(cost.searcherRevisions . 4))))
+            (REPOSEARCH
+              (cost.score . 0)(cost.repos . 4))))))))`).Equal(t, "\n"+printer.SexpFormat(e.Job, job.VerbosityNone, "\n", "  "))
+	})
+
+	t.Run("near search", func(t *testing.T) {
+		e := explain(t, `repo:foo foo NEAR/3 bar`, query.SearchTypeLiteral)
+
+		// NEAR/n is evaluated by searcher, which reads indexed repositories
+		// from zoekt and the rest from gitserver.
+		require.Equal(t, Cost{Repos: 4, IndexedRepos: 2, SearcherRevisions: 2}, e.Cost)
+		require.Empty(t, e.Warnings)
+	})
+
+	t.Run("diff search", func(t *testing.T) {
+		e := explain(t, `repo:.* type:diff foo`, query.SearchTypeLiteral)
+
+		require.Equal(t, Cost{Repos: 4, GitserverRevisions: 4}, e.Cost)
+		require.Equal(t, []string{
+			"DiffSearchJob searches the history of 4 repositories on gitserver, more than the commitDiffMaxRepos search limit of 2",
+		}, e.Warnings)
+	})
+
+	t.Run("diff search with time filter", func(t *testing.T) {
+		e := explain(t, `repo:.* type:diff after:"1 week ago" foo`, query.SearchTypeLiteral)
+
+		require.Equal(t, Cost{Repos: 4, GitserverRevisions: 4}, e.Cost)
+		require.Empty(t, e.Warnings)
+	})
+
+	t.Run("fan-out predicates", func(t *testing.T) {
+		e := explain(t, `repo:contains.file(path:go.mod) type:diff after:"1 week ago" foo`, query.SearchTypeLiteral)
+
+		require.Equal(t, Cost{Repos: 4, GitserverRevisions: 4, FanOut: []string{"repo:contains.path"}, PredicateRepos: 4}, e.Cost)
+		require.Equal(t, 440, e.Cost.Score())
+		require.Equal(t, []string{
+			"repo:contains.path is evaluated by searching each of the 4 repositories matched by the repository filters",
+		}, e.Warnings)
+	})
+
+	t.Run("up to max score", func(t *testing.T) {
+		q := `(repo:foo bar) or (repo:baz bar)`
+		plan, err := query.Pipeline(query.Init(q, query.SearchTypeLiteral))
+		require.NoError(t, err)
+		j, err := NewPlanJob(&search.Inputs{
+			Plan:         plan,
+			UserSettings: &schema.Settings{},
+			PatternType:  query.SearchTypeLiteral,
+			Protocol:     search.Streaming,
+			Features:     &search.Features{},
+		}, plan)
+		require.NoError(t, err)
+
+		counted := len(repos.CountFunc.History())
+		e := explain(t, q, query.SearchTypeLiteral)
+		fullCounts := len(repos.CountFunc.History()) - counted
+
+		counted = len(repos.CountFunc.History())
+		upTo, err := ExplainUpTo(context.Background(), clients, plan, j, 10)
+		require.NoError(t, err)
+		upToCounts := len(repos.CountFunc.History()) - counted
+
+		// The estimate stops once the first query exceeds the maximum score,
+		// so the repositories of the second query are not counted.
+		require.Greater(t, upTo.Cost.Score(), 10)
+		require.Less(t, upTo.Cost.Score(), e.Cost.Score())
+		require.Less(t, upToCounts, fullCounts)
+	})
+
+	t.Run("unbounded patterns", func(t *testing.T) {
+		for _, tc := range []struct {
+			query      string
+			searchType query.SearchType
+			warns      bool
+		}{
+			{query: `foo.*bar`, searchType: query.SearchTypeRegex, warns: false},
+			{query: `(foo|barbaz)\d+`, searchType: query.SearchTypeRegex, warns: false},
+			{query: `.*`, searchType: query.SearchTypeRegex, warns: true},
+			{query: `a.b.c`, searchType: query.SearchTypeRegex, warns: true},
+			{query: `(foo|b)`, searchType: query.SearchTypeRegex, warns: true},
+			{query: `(foo)?`, searchType: query.SearchTypeRegex, warns: true},
+			{query: `ab`, searchType: query.SearchTypeLiteral, warns: true},
+			{query: `abc`, searchType: query.SearchTypeLiteral, warns: false},
+			{query: `-content:ab abc`, searchType: query.SearchTypeLiteral, warns: false},
+		} {
+			t.Run(tc.query, func(t *testing.T) {
+				e := explain(t, tc.query, tc.searchType)
+				require.Equal(t, tc.warns, len(e.Warnings) > 0, "warnings: %v", e.Warnings)
+			})
+		}
+	})
+}
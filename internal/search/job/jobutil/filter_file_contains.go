@@ -106,6 +106,10 @@ func (j *fileContainsFilterJob) filterEvent(ctx context.Context, searcherURLs *e
 			if cm != nil {
 				filtered = append(filtered, cm)
 			}
+		case *result.OwnerMatch:
+			// Owners are resolved from the file matches after this filter
+			// has run, so there is nothing left to filter.
+			filtered = append(filtered, v)
 		default:
 			// Filter out any results that are not FileMatch or CommitMatch
 		}
@@ -0,0 +1,200 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/internal/own/codeowners/proto"
+)
+
+// NewFileHasOwnersJob creates a filter job to post-filter file results for
+// the file:has.owner() predicate. Files are kept if they are owned by every
+// included owner and by none of the excluded owners. Results that are not
+// file results are dropped.
+func NewFileHasOwnersJob(child job.Job, includeOwners, excludeOwners []string) job.Job {
+	return &fileHasOwnersJob{
+		child:         child,
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+	}
+}
+
+type fileHasOwnersJob struct {
+	child job.Job
+
+	includeOwners []string
+	excludeOwners []string
+}
+
+func (s *fileHasOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := newRulesetCache(own.NewService(clients.Gitserver))
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		filtered := event.Results[:0]
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := rules.owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+			if err != nil {
+				mu.Lock()
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			if s.matches(owners) {
+				filtered = append(filtered, fm)
+			}
+		}
+		event.Results = filtered
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *fileHasOwnersJob) matches(owners []*codeownerspb.Owner) bool {
+	for _, want := range s.includeOwners {
+		if !containsOwner(owners, want) {
+			return false
+		}
+	}
+	for _, unwanted := range s.excludeOwners {
+		if containsOwner(owners, unwanted) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsOwner returns true if the given handle (with or without leading
+// `@`) or email is one of the given owners. The comparison is case-insensitive.
+func containsOwner(owners []*codeownerspb.Owner, owner string) bool {
+	handle := strings.TrimPrefix(owner, "@")
+	for _, o := range owners {
+		if o.GetHandle() != "" && strings.EqualFold(o.GetHandle(), handle) {
+			return true
+		}
+		if o.GetEmail() != "" && strings.EqualFold(o.GetEmail(), owner) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fileHasOwnersJob) Name() string {
+	return "FileHasOwnersFilterJob"
+}
+
+func (s *fileHasOwnersJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", s.includeOwners),
+			trace.Strings("excludeOwners", s.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (s *fileHasOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *fileHasOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
+
+// rulesetCache memoizes the CODEOWNERS rules of every repository and commit
+// seen during a single search, so that the CODEOWNERS file is read at most
+// once per commit. Concurrent lookups of the same commit share one read, and
+// lookups of other commits aren't blocked by it.
+type rulesetCache struct {
+	ownService own.Service
+	group      singleflight.Group
+
+	mu    sync.Mutex
+	rules map[rulesetCacheKey]*codeowners.Ruleset
+}
+
+type rulesetCacheKey struct {
+	repo     api.RepoName
+	commitID api.CommitID
+}
+
+func newRulesetCache(ownService own.Service) *rulesetCache {
+	return &rulesetCache{
+		ownService: ownService,
+		rules:      map[rulesetCacheKey]*codeowners.Ruleset{},
+	}
+}
+
+// owners returns the owners of the given file. A file in a repository
+// without a CODEOWNERS file has no owners.
+func (c *rulesetCache) owners(ctx context.Context, repo api.RepoName, commitID api.CommitID, path string) ([]*codeownerspb.Owner, error) {
+	rs, err := c.ruleset(ctx, repo, commitID)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, nil
+	}
+	return rs.FindOwners(path), nil
+}
+
+func (c *rulesetCache) ruleset(ctx context.Context, repo api.RepoName, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	key := rulesetCacheKey{repo: repo, commitID: commitID}
+
+	c.mu.Lock()
+	rs, ok := c.rules[key]
+	c.mu.Unlock()
+	if ok {
+		return rs, nil
+	}
+
+	v, err, _ := c.group.Do(string(repo)+"@"+string(commitID), func() (any, error) {
+		rs, err := c.ownService.OwnersFile(ctx, repo, commitID)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.rules[key] = rs
+		c.mu.Unlock()
+		return rs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*codeowners.Ruleset), nil
+}
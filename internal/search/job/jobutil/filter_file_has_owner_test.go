@@ -0,0 +1,193 @@
+package jobutil
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testCodeowners = `
+*            @everyone
+/payments/   @team-payments
+/billing/    @team-payments billing@example.com
+/docs/
+`
+
+func ownersTestClients() job.RuntimeClients {
+	gs := gitserver.NewMockClient()
+	gs.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, path string) ([]byte, error) {
+		if repo == "github.com/sourcegraph/owned" && path == "CODEOWNERS" {
+			return []byte(testCodeowners), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	})
+	return job.RuntimeClients{Gitserver: gs}
+}
+
+func runWithFileMatches(t *testing.T, j func(job.Job) job.Job, paths ...string) result.Matches {
+	t.Helper()
+
+	var input result.Matches
+	for _, path := range paths {
+		input = append(input, &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/owned"},
+			CommitID: "deadbeef",
+			Path:     path,
+		}})
+	}
+	input = append(input, &result.FileMatch{File: result.File{
+		Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/unowned"},
+		CommitID: "deadbeef",
+		Path:     "payments/main.go",
+	}})
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: input})
+		return nil, nil
+	})
+
+	var got result.Matches
+	stream := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		got = append(got, ev.Results...)
+	})
+	alert, err := j(childJob).Run(context.Background(), ownersTestClients(), stream)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	return got
+}
+
+func TestFileHasOwnersJob(t *testing.T) {
+	paths := func(matches result.Matches) (res []string) {
+		for _, m := range matches {
+			fm := m.(*result.FileMatch)
+			res = append(res, string(fm.Repo.Name)+"/"+fm.Path)
+		}
+		return res
+	}
+
+	cases := []struct {
+		name    string
+		include []string
+		exclude []string
+		want    []string
+	}{{
+		name:    "include handle",
+		include: []string{"@team-payments"},
+		want: []string{
+			"github.com/sourcegraph/owned/payments/main.go",
+			"github.com/sourcegraph/owned/billing/main.go",
+		},
+	}, {
+		name:    "include handle without at sign and different case",
+		include: []string{"Team-Payments"},
+		want: []string{
+			"github.com/sourcegraph/owned/payments/main.go",
+			"github.com/sourcegraph/owned/billing/main.go",
+		},
+	}, {
+		name:    "include email",
+		include: []string{"billing@example.com"},
+		want:    []string{"github.com/sourcegraph/owned/billing/main.go"},
+	}, {
+		name:    "exclude handle",
+		exclude: []string{"@team-payments"},
+		want: []string{
+			"github.com/sourcegraph/owned/README.md",
+			"github.com/sourcegraph/owned/docs/index.md",
+			"github.com/sourcegraph/unowned/payments/main.go",
+		},
+	}, {
+		name:    "include and exclude",
+		include: []string{"@team-payments"},
+		exclude: []string{"billing@example.com"},
+		want:    []string{"github.com/sourcegraph/owned/payments/main.go"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := runWithFileMatches(t, func(child job.Job) job.Job {
+				return NewFileHasOwnersJob(child, tc.include, tc.exclude)
+			}, "README.md", "payments/main.go", "billing/main.go", "docs/index.md")
+			require.Equal(t, tc.want, paths(got))
+		})
+	}
+}
+
+func TestSelectOwnersJob(t *testing.T) {
+	got := runWithFileMatches(t, NewSelectOwnersJob, "README.md", "payments/main.go", "billing/main.go", "payments/other.go", "docs/index.md")
+
+	var owners []string
+	for _, m := range got {
+		owners = append(owners, m.(*result.OwnerMatch).Identifier())
+	}
+	require.Equal(t, []string{"@everyone", "@team-payments", "billing@example.com"}, owners)
+}
+
+type blockingOwnService struct {
+	own.Service
+	unblock chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *blockingOwnService) OwnersFile(ctx context.Context, repo api.RepoName, _ api.CommitID) (*codeowners.Ruleset, error) {
+	s.calls.Add(1)
+	if repo == "github.com/sourcegraph/slow" {
+		<-s.unblock
+	}
+	return nil, nil
+}
+
+func TestRulesetCacheConcurrentReads(t *testing.T) {
+	svc := &blockingOwnService{unblock: make(chan struct{})}
+	cache := newRulesetCache(svc)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.owners(ctx, "github.com/sourcegraph/slow", "deadbeef", "main.go")
+			require.NoError(t, err)
+		}()
+	}
+
+	// A slow read of one repository must not block lookups in another.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := cache.owners(ctx, "github.com/sourcegraph/fast", "deadbeef", "main.go")
+		require.NoError(t, err)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lookup was blocked by a slow read of another repository")
+	}
+
+	close(svc.unblock)
+	wg.Wait()
+
+	// Concurrent and later lookups of the same commit share one read.
+	_, err := cache.owners(ctx, "github.com/sourcegraph/slow", "deadbeef", "other.go")
+	require.NoError(t, err)
+	require.LessOrEqual(t, svc.calls.Load(), int32(4))
+}
@@ -149,14 +149,18 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 			diff := resultTypes.Has(result.TypeDiff)
 			repoOptionsCopy := repoOptions
 			repoOptionsCopy.OnlyCloned = true
-			addJob(&commit.SearchJob{
+			commitJob := &commit.SearchJob{
 				Query:                commit.QueryToGitQuery(originalQuery, diff),
 				RepoOpts:             repoOptionsCopy,
 				Diff:                 diff,
 				Limit:                int(fileMatchLimit),
 				IncludeModifiedFiles: authz.SubRepoEnabled(authz.DefaultSubRepoPermsChecker),
 				Concurrency:          4,
-			})
+			}
+			if diff {
+				commitJob.IncludeDiffFunctions, commitJob.ExcludeDiffFunctions = commit.QueryToDiffFunctions(originalQuery)
+			}
+			addJob(commitJob)
 		}
 
 		addJob(&searchrepos.ComputeExcludedJob{
@@ -183,25 +187,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
-	{ // Apply selectors
-		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
-			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
-			basicJob = NewSelectJob(sp, basicJob)
-		}
-	}
-
-	{ // Apply subrepo permissions checks
-		checker := authz.DefaultSubRepoPermsChecker
-		if authz.SubRepoEnabled(checker) {
-			basicJob = NewFilterJob(basicJob)
-		}
-	}
-
-	{ // Apply search result sanitization post-filter if enabled
-		if len(inputs.SanitizeSearchPatterns) > 0 {
-			basicJob = NewSanitizeJob(inputs.SanitizeSearchPatterns, basicJob)
-		}
-	}
+	basicJob = wrapWithPostFilters(b, inputs, basicJob)
 
 	{ // Apply limit
 		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
@@ -232,6 +218,57 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	return basicJob, nil
 }
 
+// wrapWithPostFilters wraps basicJob with the post-filters that apply to the
+// results of a basic query: file:has.owner(), select:, sub-repo permissions
+// and search result sanitization.
+func wrapWithPostFilters(b query.Basic, inputs *search.Inputs, basicJob job.Job) job.Job {
+	var selectOwners bool
+	{ // Apply file:has.owner() post-filter
+		includeOwners, excludeOwners := b.FileHasOwner()
+		if len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = NewFileHasOwnersJob(basicJob, includeOwners, excludeOwners)
+		}
+	}
+
+	{ // Apply selectors
+		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
+			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
+			if isSelectOwners(sp) {
+				selectOwners = true
+			} else {
+				basicJob = NewSelectJob(sp, basicJob)
+			}
+		}
+	}
+
+	{ // Apply subrepo permissions checks
+		checker := authz.DefaultSubRepoPermsChecker
+		if authz.SubRepoEnabled(checker) {
+			basicJob = NewFilterJob(basicJob)
+		}
+	}
+
+	{ // Apply search result sanitization post-filter if enabled
+		if len(inputs.SanitizeSearchPatterns) > 0 {
+			basicJob = NewSanitizeJob(inputs.SanitizeSearchPatterns, basicJob)
+		}
+	}
+
+	// Owners are resolved last, so that they are only derived from files
+	// that passed sub-repo permissions and sanitization.
+	if selectOwners {
+		basicJob = NewSelectOwnersJob(basicJob)
+	}
+
+	return basicJob
+}
+
+// isSelectOwners returns true if the select path is `select:file.owners`,
+// which needs to resolve owners at runtime rather than via Match.Select.
+func isSelectOwners(sp filter.SelectPath) bool {
+	return len(sp) == 2 && sp[0] == filter.File && sp[1] == "owners"
+}
+
 // orderRacingJobs ensures that searcher and repo search jobs only ever run
 // sequentially after a Zoekt search has returned all its results.
 func orderRacingJobs(j job.Job) job.Job {
@@ -290,7 +327,7 @@ func orderRacingJobs(j job.Job) job.Job {
 func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	maxResults := f.MaxResults(searchInputs.DefaultLimit())
 	resultTypes := computeResultTypes(f.ToBasic(), searchInputs.PatternType)
-	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Protocol)
+	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, searchInputs.Features, searchInputs.Protocol)
 
 	// searcher to use full deadline if timeout: set or we are streaming.
 	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming
@@ -563,13 +600,17 @@ func count(b query.Basic, p search.Protocol) int {
 // text search. An atomic query is a Basic query where the Pattern is either
 // nil, or comprises only one Pattern node (hence, an atom, and not an
 // expression). See TextPatternInfo for the values it computes and populates.
-func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protocol) *search.TextPatternInfo {
+func toTextPatternInfo(b query.Basic, resultTypes result.Types, feat *search.Features, p search.Protocol) *search.TextPatternInfo {
 	// Handle file: and -file: filters.
 	filesInclude, filesExclude := b.IncludeExcludeValues(query.FieldFile)
-	// Handle lang: and -lang: filters.
+	// Handle lang: and -lang: filters. Structural search relies on the
+	// file extension, so it always uses the path based filters.
 	langInclude, langExclude := b.IncludeExcludeValues(query.FieldLang)
-	filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
-	filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	contentBasedLangFilters := feat != nil && feat.ContentBasedLangFilters && !b.IsStructural() && len(langInclude)+len(langExclude) > 0
+	if !contentBasedLangFilters {
+		filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
+		filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	}
 	selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
 	count := count(b, p)
 
@@ -604,6 +645,8 @@ func toTextPatternInfo(b query.Basic, resultTypes result.Types, p search.Protoco
 		PatternMatchesPath:           resultTypes.Has(result.TypePath),
 		PatternMatchesContent:        resultTypes.Has(result.TypeFile),
 		Languages:                    langInclude,
+		ExcludeLanguages:             langExclude,
+		ContentBasedLangFilters:      contentBasedLangFilters,
 		PathPatternsAreCaseSensitive: b.IsCaseSensitive(),
 		CombyRule:                    b.FindValue(query.FieldCombyRule),
 		Index:                        b.Index(),
@@ -678,6 +721,7 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 
 	visibility := b.Visibility()
 	searchContextSpec := b.FindValue(query.FieldContext)
+	onlyCodeowners, noCodeowners := b.RepoHasCodeowners()
 
 	return search.RepoOptions{
 		RepoFilters:         repoFilters,
@@ -695,6 +739,10 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 		CommitAfter:         b.RepoContainsCommitAfter(),
 		UseIndex:            b.Index(),
 		HasKVPs:             b.RepoHasKVPs(),
+		DependsOn:           b.RepoDependsOn(),
+		HasLanguages:        b.RepoHasLanguages(),
+		OnlyCodeowners:      onlyCodeowners,
+		NoCodeowners:        noCodeowners,
 	}
 }
 
@@ -847,9 +895,22 @@ func jobMode(b query.Basic, repoOptions search.RepoOptions, resultTypes result.T
 	// and search over resolved repos, and return results from either job).
 	runZoektOverRepos = !repoUniverseSearch || onSourcegraphDotCom
 
+	if isNear(b.Pattern) {
+		// Zoekt cannot check the distance between the matches of a
+		// NEAR/n query, so searcher searches both indexed and unindexed
+		// repositories. See toNearJob.
+		repoUniverseSearch, runZoektOverRepos = false, false
+	}
+
 	return repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos
 }
 
+// isNear returns whether pattern is a Near operator.
+func isNear(pattern query.Node) bool {
+	operator, ok := pattern.(query.Operator)
+	return ok && operator.Kind == query.Near
+}
+
 // toAndJob creates a new job from a basic query whose pattern is an And operator at the root.
 func toAndJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	// Invariant: this function is only reachable from callers that
@@ -893,6 +954,42 @@ func toOrJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	return NewOrJob(operands...), nil
 }
 
+// toNearJob creates a new job from a basic query whose pattern is a Near
+// operator at the root. Searcher checks the distance between the matches of
+// the two patterns: it searches unindexed revisions itself, and asks Zoekt for
+// the files of indexed revisions in which both patterns match.
+func toNearJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
+	// Invariant: the parser guarantees that a Near operator relates two
+	// patterns.
+	near := b.Pattern.(query.Operator)
+	left, right := near.Operands[0].(query.Pattern), near.Operands[1].(query.Pattern)
+
+	f := query.Flat{Parameters: b.Parameters, Pattern: &left}
+	resultTypes := computeResultTypes(f.ToBasic(), inputs.PatternType)
+	patternInfo := toTextPatternInfo(f.ToBasic(), resultTypes, inputs.Features, inputs.Protocol)
+	patternInfo.PatternMatchesContent = true
+	patternInfo.PatternMatchesPath = false
+	patternInfo.NearPattern = b.MapPattern(right).PatternString()
+	patternInfo.NearDistance = near.Distance
+
+	useFullDeadline := b.GetTimeout() != nil || b.Count() != nil || inputs.Protocol == search.Streaming
+
+	newSearcherJob := func(indexed bool) job.Job {
+		return &searcher.TextSearchJob{
+			PatternInfo:     patternInfo,
+			Indexed:         indexed,
+			UseFullDeadline: useFullDeadline,
+			Features:        *inputs.Features,
+		}
+	}
+
+	return &repoPagerJob{
+		child:            &reposPartialJob{NewParallelJob(newSearcherJob(true), newSearcherJob(false))},
+		repoOpts:         toRepoOptions(b, inputs.UserSettings),
+		containsRefGlobs: query.ContainsRefGlobs(b.ToParseTree()),
+	}, nil
+}
+
 func toPatternExpressionJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 	switch term := b.Pattern.(type) {
 	case query.Operator:
@@ -905,6 +1002,8 @@ func toPatternExpressionJob(inputs *search.Inputs, b query.Basic) (job.Job, erro
 			return toAndJob(inputs, b)
 		case query.Or:
 			return toOrJob(inputs, b)
+		case query.Near:
+			return toNearJob(inputs, b)
 		}
 	case query.Pattern:
 		return NewFlatJob(inputs, query.Flat{Parameters: b.Parameters, Pattern: &term})
@@ -963,6 +1062,22 @@ func isGlobal(op search.RepoOptions) bool {
 		return false
 	}
 
+	// Lockfile dependency graphs are stored in the database.
+	if len(op.DependsOn) > 0 {
+		return false
+	}
+
+	// Zoekt does not know about CODEOWNERS files, so we depend on the
+	// database to handle this filter.
+	if op.OnlyCodeowners || op.NoCodeowners {
+		return false
+	}
+
+	// Repository inventories are stored in the database.
+	if len(op.HasLanguages) > 0 {
+		return false
+	}
+
 	// If a search context is specified, we do not know ahead of time whether
 	// the repos in the context are indexed and we need to go through the repo
 	// resolution process.
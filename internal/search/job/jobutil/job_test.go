@@ -985,7 +985,7 @@ func TestToTextPatternInfo(t *testing.T) {
 		b := plan[0]
 		mode := search.Batch
 		resultTypes := computeResultTypes(b, query.SearchTypeLiteral)
-		p := toTextPatternInfo(b, resultTypes, mode)
+		p := toTextPatternInfo(b, resultTypes, &search.Features{}, mode)
 		v, _ := json.Marshal(p)
 		return string(v)
 	}
@@ -997,6 +997,33 @@ func TestToTextPatternInfo(t *testing.T) {
 	}
 }
 
+func TestToTextPatternInfo_contentBasedLangFilters(t *testing.T) {
+	plan, err := query.Pipeline(query.Init(`foo file:\.x$ lang:go -lang:c`, query.SearchTypeLiteral))
+	require.NoError(t, err)
+	b := plan[0]
+	resultTypes := computeResultTypes(b, query.SearchTypeLiteral)
+
+	p := toTextPatternInfo(b, resultTypes, &search.Features{ContentBasedLangFilters: true}, search.Streaming)
+	require.True(t, p.ContentBasedLangFilters)
+	require.Equal(t, []string{`\.x$`}, p.IncludePatterns)
+	require.Empty(t, p.ExcludePattern)
+	require.Equal(t, []string{"go"}, p.Languages)
+	require.Equal(t, []string{"c"}, p.ExcludeLanguages)
+
+	// Backends which cannot detect languages get the lang filters as path
+	// patterns.
+	include, exclude := p.PathPatterns()
+	require.Equal(t, []string{`\.x$`, `\.go$`}, include)
+	require.Equal(t, `(?:\.c$)|(?:\.cats$)|(?:\.h$)|(?:\.idc$)`, exclude)
+
+	// Structural search relies on file extensions.
+	plan, err = query.Pipeline(query.Init(`foo lang:go`, query.SearchTypeStructural))
+	require.NoError(t, err)
+	p = toTextPatternInfo(plan[0], result.TypeStructural, &search.Features{ContentBasedLangFilters: true}, search.Streaming)
+	require.False(t, p.ContentBasedLangFilters)
+	require.Equal(t, []string{`\.go$`}, p.IncludePatterns)
+}
+
 func overrideSearchType(input string, searchType query.SearchType) query.SearchType {
 	q, err := query.Parse(input, query.SearchTypeLiteral)
 	q = query.LowercaseFieldNames(q)
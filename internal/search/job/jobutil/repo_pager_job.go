@@ -12,12 +12,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 type repoPagerJob struct {
 	repoOpts         search.RepoOptions
 	containsRefGlobs bool                          // whether to include repositories with refs
 	child            job.PartialJob[resolvedRepos] // child job tree that need populating a repos field to run
+
+	// onPage, if set, is called with the cursors of the next page once the
+	// child job has run over a page of repos. next is nil after the last page.
+	onPage func(next types.MultiCursor) error
 }
 
 // resolvedRepos is the set of information to complete the partial
@@ -61,7 +66,17 @@ func setRepos(j job.Job, indexed *zoekt.IndexedRepoRevs, unindexed []*search.Rep
 			return &cp
 		case *searcher.TextSearchJob:
 			cp := *v
-			cp.Repos = unindexed
+			if v.Indexed {
+				// Searcher asks Zoekt to search these repos, see toNearJob.
+				cp.Repos = nil
+				if indexed != nil {
+					for _, repoRevs := range indexed.RepoRevs {
+						cp.Repos = append(cp.Repos, repoRevs)
+					}
+				}
+			} else {
+				cp.Repos = unindexed
+			}
 			return &cp
 		case *zoekt.SymbolSearchJob:
 			cp := *v
@@ -83,7 +98,7 @@ func (p *repoPagerJob) Run(ctx context.Context, clients job.RuntimeClients, stre
 
 	var maxAlerter search.MaxAlerter
 
-	repoResolver := repos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt)
+	repoResolver := repos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt, clients.Dependencies)
 	pager := func(page *repos.Resolved) error {
 		page.MaybeSendStats(stream)
 
@@ -103,7 +118,10 @@ func (p *repoPagerJob) Run(ctx context.Context, clients job.RuntimeClients, stre
 		job := p.child.Resolve(resolvedRepos{indexed, unindexed})
 		alert, err := job.Run(ctx, clients, stream)
 		maxAlerter.Add(alert)
-		return err
+		if err != nil || p.onPage == nil {
+			return err
+		}
+		return p.onPage(page.Next)
 	}
 
 	return maxAlerter.Alert, repoResolver.Paginate(ctx, p.repoOpts, pager)
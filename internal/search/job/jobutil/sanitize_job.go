@@ -69,6 +69,12 @@ func (j *sanitizeJob) sanitizeEvent(event streaming.SearchEvent) streaming.Searc
 			}
 		case *result.RepoMatch:
 			sanitized = append(sanitized, v)
+		case *result.OwnerMatch:
+			// Owners are resolved from sanitized file matches, so only
+			// the owner itself needs to be checked.
+			if !j.matchesAnySanitizePattern(v.Identifier()) {
+				sanitized = append(sanitized, v)
+			}
 		default:
 			// default to dropping this result
 		}
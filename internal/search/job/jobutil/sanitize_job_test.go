@@ -147,6 +147,15 @@ func TestSanitizeJob(t *testing.T) {
 				Results: r(&result.RepoMatch{Name: "weird al greatest hits"}),
 			},
 		},
+		{
+			name: "sanitize owner match",
+			inputEvent: streaming.SearchEvent{
+				Results: r(&result.OwnerMatch{Handle: "omitmeABCDE"}, &result.OwnerMatch{Email: "alice@example.com"}),
+			},
+			outputEvent: streaming.SearchEvent{
+				Results: r(&result.OwnerMatch{Email: "alice@example.com"}),
+			},
+		},
 	}
 
 	for _, tc := range tests {
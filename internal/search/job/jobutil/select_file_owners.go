@@ -0,0 +1,96 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSelectOwnersJob creates a job that transforms streamed file results
+// into one result per distinct owner of the files, as resolved from
+// CODEOWNERS. This implements `select:file.owners`.
+func NewSelectOwnersJob(child job.Job) job.Job {
+	return &selectOwnersJob{child: child}
+}
+
+type selectOwnersJob struct {
+	child job.Job
+}
+
+func (s *selectOwnersJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, s)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+		seen = map[string]struct{}{}
+	)
+
+	rules := newRulesetCache(own.NewService(clients.Gitserver))
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var selected result.Matches
+		for _, m := range event.Results {
+			fm, ok := m.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := rules.owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+			mu.Lock()
+			if err != nil {
+				errs = errors.Append(errs, err)
+				mu.Unlock()
+				continue
+			}
+			for _, o := range owners {
+				om := &result.OwnerMatch{
+					Handle:   o.GetHandle(),
+					Email:    o.GetEmail(),
+					Repo:     fm.Repo,
+					CommitID: fm.CommitID,
+				}
+				key := strings.ToLower(om.Identifier())
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				selected = append(selected, om)
+			}
+			mu.Unlock()
+		}
+		event.Results = selected
+		stream.Send(event)
+	})
+
+	alert, err = s.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func (s *selectOwnersJob) Name() string {
+	return "SelectOwnersJob"
+}
+
+func (s *selectOwnersJob) Fields(job.Verbosity) []otlog.Field { return nil }
+
+func (s *selectOwnersJob) Children() []job.Describer {
+	return []job.Describer{s.child}
+}
+
+func (s *selectOwnersJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *s
+	cp.child = job.Map(s.child, fn)
+	return &cp
+}
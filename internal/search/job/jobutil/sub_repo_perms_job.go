@@ -117,6 +117,10 @@ func applySubRepoFiltering(ctx context.Context, checker authz.SubRepoPermissionC
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// Owners are resolved from the file matches that passed this
+			// filter, so they are only derived from readable files.
+			filtered = append(filtered, m)
 		}
 
 	}
@@ -13,7 +13,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -214,3 +219,57 @@ func TestApplySubRepoFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestSubRepoFilteringSelectOwners(t *testing.T) {
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoFunc.SetDefaultReturn(true, nil)
+	checker.PermissionsFunc.SetDefaultHook(func(_ context.Context, _ int32, rc authz.RepoContent) (authz.Perms, error) {
+		if strings.HasPrefix(rc.Path, "payments/") || strings.HasPrefix(rc.Path, "billing/") {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+	orig := authz.DefaultSubRepoPermsChecker
+	authz.DefaultSubRepoPermsChecker = checker
+	t.Cleanup(func() { authz.DefaultSubRepoPermsChecker = orig })
+
+	plan, err := query.Pipeline(query.Init("select:file.owners foo", query.SearchTypeLiteral))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		var matches result.Matches
+		for _, path := range []string{"README.md", "payments/main.go", "billing/main.go"} {
+			matches = append(matches, &result.FileMatch{File: result.File{
+				Repo:     types.MinimalRepo{Name: "github.com/sourcegraph/owned"},
+				CommitID: "deadbeef",
+				Path:     path,
+			}})
+		}
+		s.Send(streaming.SearchEvent{Results: matches})
+		return nil, nil
+	})
+
+	var owners []string
+	stream := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		for _, m := range ev.Results {
+			owners = append(owners, m.(*result.OwnerMatch).Identifier())
+		}
+	})
+
+	clients := ownersTestClients()
+	clients.Logger = logtest.Scoped(t)
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	_, err = wrapWithPostFilters(plan[0], &search.Inputs{}, childJob).Run(ctx, clients, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Owners of unreadable files are not returned.
+	if diff := cmp.Diff([]string{"@everyone"}, owners); diff != "" {
+		t.Fatal(diff)
+	}
+}
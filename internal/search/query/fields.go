@@ -27,6 +27,9 @@ const (
 	FieldCommitter = "committer"
 	FieldMessage   = "message"
 
+	// For diff search only:
+	FieldDiffFunction = "diff.function"
+
 	// Temporary experimental fields:
 	FieldIndex     = "index"
 	FieldCount     = "count" // Searches that specify `count:` will fetch at least that number of results, or the full result set
@@ -64,6 +67,7 @@ var allFields = map[string]struct{}{
 	FieldMessage:            empty,
 	"m":                     empty,
 	"msg":                   empty,
+	FieldDiffFunction:       empty,
 	FieldIndex:              empty,
 	FieldCount:              empty,
 	FieldTimeout:            empty,
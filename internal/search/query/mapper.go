@@ -30,6 +30,9 @@ func (*BaseMapper) MapNodes(mapper Mapper, nodes []Node) []Node {
 			}
 		case Operator:
 			if result := mapper.MapOperator(mapper, v.Kind, v.Operands); result != nil {
+				if v.Kind == Near {
+					result = withDistance(result, v.Distance)
+				}
 				mapped = append(mapped, result...)
 			}
 		}
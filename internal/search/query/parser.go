@@ -17,7 +17,7 @@ Parser implements a parser for the following grammar:
 OrTerm     → AndTerm { OR AndTerm }
 AndTerm    → Term { AND Term }
 Term       → (OrTerm) | Parameters
-Parameters → Parameter { " " Parameter }
+Parameters → Parameter { " " Parameter | NEAR/n Parameter }
 */
 
 type Node interface {
@@ -57,6 +57,7 @@ const (
 	Or OperatorKind = iota
 	And
 	Concat
+	Near
 )
 
 // Operator is a nonterminal node of kind Kind with child nodes Operands.
@@ -64,6 +65,10 @@ type Operator struct {
 	Kind       OperatorKind
 	Operands   []Node
 	Annotation Annotation
+
+	// Distance is the maximum number of lines between the two operands
+	// of a Near operator. It is unused for other kinds.
+	Distance int `json:",omitempty"`
 }
 
 func (node Pattern) String() string {
@@ -99,6 +104,8 @@ func (node Operator) String() string {
 		kind = "and"
 	case Concat:
 		kind = "concat"
+	case Near:
+		kind = fmt.Sprintf("near/%d", node.Distance)
 	}
 
 	return fmt.Sprintf("(%s %s)", kind, strings.Join(result, " "))
@@ -116,6 +123,7 @@ const (
 	DQUOTE keyword = "\""
 	SLASH  keyword = "/"
 	NOT    keyword = "not"
+	NEAR   keyword = "near/"
 )
 
 func isSpace(buf []byte) bool {
@@ -233,6 +241,31 @@ func (p *parser) matchKeyword(keyword keyword) bool {
 	return strings.EqualFold(v, string(keyword))
 }
 
+// matchNear is like matchKeyword for the NEAR/n keyword, where n is a
+// non-negative number of lines. It returns the number n and the length of the
+// keyword, and does not advance the position.
+func (p *parser) matchNear() (distance, advance int, ok bool) {
+	if p.pos == 0 || !isSpace(p.buf[p.pos-1:p.pos]) {
+		return 0, 0, false
+	}
+	if !p.match(NEAR) {
+		return 0, 0, false
+	}
+	start := p.pos + len(string(NEAR))
+	end := start
+	for end < len(p.buf) && '0' <= p.buf[end] && p.buf[end] <= '9' {
+		end++
+	}
+	if end == start || end >= len(p.buf) || !isSpace(p.buf[end:end+1]) {
+		return 0, 0, false
+	}
+	distance, err := strconv.Atoi(string(p.buf[start:end]))
+	if err != nil {
+		return 0, 0, false
+	}
+	return distance, end - p.pos, true
+}
+
 // matchUnaryKeyword is like match but expects the keyword to be followed by whitespace.
 func (p *parser) matchUnaryKeyword(keyword keyword) bool {
 	if p.pos != 0 && !(isSpace(p.buf[p.pos-1:p.pos]) || p.buf[p.pos-1] == '(') {
@@ -336,7 +369,8 @@ func ScanBalancedPattern(buf []byte) (scanned string, count int, ok bool) {
 		}
 		if lookahead("and ") ||
 			lookahead("or ") ||
-			lookahead("not ") {
+			lookahead("not ") ||
+			lookahead(string(NEAR)) {
 			// This "pattern" contains a recognized keyword, reject it.
 			return false
 		}
@@ -601,7 +635,8 @@ func ScanField(buf []byte) (string, bool, int) {
 	success := false
 	for len(buf) > 0 {
 		r = next()
-		if strings.ContainsRune(allowed, r) {
+		// Fields like `diff.function:` are namespaced with a '.'.
+		if strings.ContainsRune(allowed, r) || (r == '.' && len(result) > 0 && result[len(result)-1] != '-') {
 			result = append(result, r)
 			continue
 		}
@@ -955,6 +990,12 @@ loop:
 		case p.matchKeyword(AND), p.matchKeyword(OR):
 			// Caller advances.
 			break loop
+		case p.isNear():
+			near, err := p.parseNear(nodes, label)
+			if err != nil {
+				return nil, err
+			}
+			nodes[len(nodes)-1] = near
 		case p.matchUnaryKeyword(NOT):
 			start := p.pos
 			_ = p.expect(NOT)
@@ -996,6 +1037,62 @@ loop:
 	return partitionParameters(nodes), nil
 }
 
+func (p *parser) isNear() bool {
+	_, _, ok := p.matchNear()
+	return ok
+}
+
+// parseNear parses the NEAR/n keyword at the current position and the pattern
+// following it. It returns a Near operator over that pattern and the last of
+// the already parsed nodes, which must be a pattern.
+func (p *parser) parseNear(nodes []Node, label labels) (Operator, error) {
+	distance, advance, _ := p.matchNear()
+	keyword := string(p.buf[p.pos : p.pos+advance])
+	p.pos += advance
+
+	var left Pattern
+	if len(nodes) > 0 {
+		left, _ = nodes[len(nodes)-1].(Pattern)
+	}
+	if left.Value == "" || left.Negated {
+		return Operator{}, errors.Errorf("expected a search pattern before %s", keyword)
+	}
+
+	if err := p.skipSpaces(); err != nil {
+		return Operator{}, err
+	}
+	if p.done() || p.matchUnaryKeyword(NOT) || p.isNear() {
+		return Operator{}, errors.Errorf("expected a search pattern after %s", keyword)
+	}
+	if field, _, _ := ScanField(p.buf[p.pos:]); field != "" {
+		return Operator{}, errors.Errorf("expected a search pattern after %s, but found the field %s:", keyword, field)
+	}
+	if p.match(LPAREN) {
+		if _, _, ok := ScanBalancedPattern(p.buf[p.pos:]); !ok {
+			return Operator{}, errors.Errorf("expected a search pattern after %s. NEAR/n is not supported for expressions", keyword)
+		}
+	}
+	right := p.ParsePattern(label)
+	if right.Value == "" {
+		return Operator{}, errors.Errorf("expected a search pattern after %s", keyword)
+	}
+
+	return Operator{Kind: Near, Operands: []Node{left, right}, Distance: distance}, nil
+}
+
+// withDistance sets the distance of the Near operators among nodes. Use it
+// to restore the distance of Near operators rebuilt with NewOperator, which
+// only knows about operator kinds.
+func withDistance(nodes []Node, distance int) []Node {
+	for i, node := range nodes {
+		if operator, ok := node.(Operator); ok && operator.Kind == Near {
+			operator.Distance = distance
+			nodes[i] = operator
+		}
+	}
+	return nodes
+}
+
 // reduce takes lists of left and right nodes and reduces them if possible. For example,
 // (and a (b and c))       => (and a b c)
 // (((a and b) or c) or d) => (or (and a b) c d)
@@ -289,6 +289,9 @@ func TestScanField(t *testing.T) {
 	autogold.Want("-repo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("-repo"))
 	autogold.Want("--repo:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("--repo:"))
 	autogold.Want(":foo", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test(":foo"))
+	autogold.Want("diff.function:foo", `{"Field":"diff.function","Negated":false,"Advance":14}`).Equal(t, test("diff.function:foo"))
+	autogold.Want("-diff.function:foo", `{"Field":"diff.function","Negated":true,"Advance":15}`).Equal(t, test("-diff.function:foo"))
+	autogold.Want("fmt.Println:", `{"Field":"","Negated":false,"Advance":0}`).Equal(t, test("fmt.Println:"))
 }
 
 func parseAndOrGrammar(in string) ([]Node, error) {
@@ -786,3 +789,27 @@ func TestParseStandard(t *testing.T) {
 		autogold.Equal(t, autogold.Raw(test("(sancerre and /pouilly-fume/)")))
 	})
 }
+
+func TestParseNear(t *testing.T) {
+	test := func(input string) string {
+		result, err := Parse(input, SearchTypeStandard)
+		if err != nil {
+			return fmt.Sprintf("ERROR: %s", err.Error())
+		}
+		return toString(result)
+	}
+
+	autogold.Want("near", `(near/3 "foo" "bar")`).Equal(t, test("foo NEAR/3 bar"))
+	autogold.Want("lowercase near", `(near/10 "foo" "bar")`).Equal(t, test("foo near/10 bar"))
+	autogold.Want("near with parameters", `(and "repo:foo" "file:bar" (near/0 "foo" "baz"))`).Equal(t, test("repo:foo foo NEAR/0 baz file:bar"))
+	autogold.Want("near regexp", `(near/3 "foo bar" "baz")`).Equal(t, test("/foo bar/ NEAR/3 baz"))
+	autogold.Want("near balanced parens", `(near/3 "foo" "bar()")`).Equal(t, test("foo NEAR/3 bar()"))
+	autogold.Want("near in group", `(or (near/3 "foo" "bar") "baz")`).Equal(t, test("(foo NEAR/3 bar) or baz"))
+	autogold.Want("no distance", `(concat "foo" "NEAR/" "bar")`).Equal(t, test("foo NEAR/ bar"))
+	autogold.Want("leading near", `(concat "NEAR/3" "bar")`).Equal(t, test("NEAR/3 bar"))
+	autogold.Want("trailing near", `(concat "foo" "NEAR/3")`).Equal(t, test("foo NEAR/3"))
+	autogold.Want("chained near", "ERROR: expected a search pattern before NEAR/2").Equal(t, test("foo NEAR/3 bar NEAR/2 baz"))
+	autogold.Want("negated operand", "ERROR: expected a search pattern before NEAR/3").Equal(t, test("not foo NEAR/3 bar"))
+	autogold.Want("parameter operand", "ERROR: expected a search pattern after NEAR/3, but found the field repo:").Equal(t, test("foo NEAR/3 repo:bar"))
+	autogold.Want("expression operand", "ERROR: expected a search pattern after NEAR/3. NEAR/n is not supported for expressions").Equal(t, test("foo NEAR/3 (bar or baz)"))
+}
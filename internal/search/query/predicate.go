@@ -2,8 +2,11 @@ package query
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-enry/go-enry/v2"
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 
@@ -38,10 +41,17 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.tag":               func() Predicate { return &RepoHasTagPredicate{} },
 		"has":                   func() Predicate { return &RepoHasKVPPredicate{} },
 		"has.key":               func() Predicate { return &RepoHasKeyPredicate{} },
+		"has.codeowners":        func() Predicate { return &RepoHasCodeownersPredicate{} },
+		"has.language":          func() Predicate { return &RepoHasLanguagePredicate{} },
+		"depends.on":            func() Predicate { return &RepoDependsOnPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+	},
+	FieldRev: {
+		"at.time": func() Predicate { return &RevAtTimePredicate{} },
 	},
 }
 
@@ -318,6 +328,131 @@ func (p *RepoHasKeyPredicate) Unmarshal(params string, negated bool) (err error)
 func (p *RepoHasKeyPredicate) Field() string { return FieldRepo }
 func (p *RepoHasKeyPredicate) Name() string  { return "has.key" }
 
+/* repo:has.codeowners() */
+
+type RepoHasCodeownersPredicate struct {
+	Negated bool
+}
+
+func (p *RepoHasCodeownersPredicate) Unmarshal(params string, negated bool) error {
+	if params != "" {
+		return errors.Errorf("repo:%s does not accept arguments", p.Name())
+	}
+	p.Negated = negated
+	return nil
+}
+
+func (p *RepoHasCodeownersPredicate) Field() string { return FieldRepo }
+func (p *RepoHasCodeownersPredicate) Name() string  { return "has.codeowners" }
+
+/* repo:has.language(language, min_percent) */
+
+// RepoHasLanguagePredicate represents the `repo:has.language()` predicate,
+// which filters to repos with at least MinPercent of their code written in
+// Language. For example, `repo:has.language(java, 60)`.
+type RepoHasLanguagePredicate struct {
+	Language   string
+	MinPercent float64
+	Negated    bool
+}
+
+func (p *RepoHasLanguagePredicate) Unmarshal(params string, negated bool) error {
+	alias, minPercent, hasMinPercent := strings.Cut(params, ",")
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return errors.Errorf("repo:%s argument must be of the form language or language, min_percent", p.Name())
+	}
+
+	language, ok := enry.GetLanguageByAlias(alias)
+	if !ok {
+		return errors.Errorf("unknown language %q in repo:%s", alias, p.Name())
+	}
+
+	if hasMinPercent {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(minPercent), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return errors.Errorf("repo:%s min_percent must be a number between 0 and 100, got %q", p.Name(), strings.TrimSpace(minPercent))
+		}
+		p.MinPercent = percent
+	}
+
+	p.Language = language
+	p.Negated = negated
+	return nil
+}
+
+func (p *RepoHasLanguagePredicate) Field() string { return FieldRepo }
+func (p *RepoHasLanguagePredicate) Name() string  { return "has.language" }
+
+/* repo:depends.on(ecosystem:name@constraints) */
+
+// DependsOnEcosystems are the package ecosystems supported by the
+// repo:depends.on() predicate.
+var DependsOnEcosystems = []string{"cargo", "gem", "go", "maven", "npm", "pypi"}
+
+// RepoDependsOnPredicate represents the `repo:depends.on()` predicate, which
+// filters to repos whose lockfiles reference a package, optionally only
+// versions of it matching all of the given constraints. For example,
+// `repo:depends.on(npm:lodash@<4.17.21)`.
+type RepoDependsOnPredicate struct {
+	Ecosystem   string
+	Package     string
+	Constraints []VersionConstraint
+	Negated     bool
+}
+
+func (p *RepoDependsOnPredicate) Unmarshal(params string, negated bool) error {
+	ecosystem, pkg, ok := strings.Cut(strings.TrimSpace(params), ":")
+	if !ok || ecosystem == "" || pkg == "" {
+		return errors.Errorf("repo:%s argument must be of the form ecosystem:name or ecosystem:name@version", p.Name())
+	}
+
+	supported := false
+	for _, e := range DependsOnEcosystems {
+		supported = supported || e == ecosystem
+	}
+	if !supported {
+		return errors.Errorf("unsupported repo:%s ecosystem %q, expected one of %s", p.Name(), ecosystem, strings.Join(DependsOnEcosystems, ", "))
+	}
+
+	// The version starts at the first @ that doesn't start the name, so that
+	// scoped npm packages like @types/node are supported.
+	name, version := pkg, ""
+	if i := strings.Index(pkg[1:], "@"); i >= 0 {
+		name, version = pkg[:i+1], pkg[i+2:]
+		if version == "" {
+			return errors.Errorf("empty version in repo:%s argument", p.Name())
+		}
+	}
+
+	if version != "" {
+		constraints, err := ParseVersionConstraints(version)
+		if err != nil {
+			return err
+		}
+		p.Constraints = constraints
+	}
+
+	p.Ecosystem = ecosystem
+	p.Package = name
+	p.Negated = negated
+	return nil
+}
+
+// MatchesVersion returns true if the given version satisfies all of the
+// predicate's version constraints.
+func (p *RepoDependsOnPredicate) MatchesVersion(version string) bool {
+	for _, c := range p.Constraints {
+		if !c.Matches(version) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *RepoDependsOnPredicate) Field() string { return FieldRepo }
+func (p *RepoDependsOnPredicate) Name() string  { return "depends.on" }
+
 /* file:contains.content(pattern) */
 
 type FileContainsContentPredicate struct {
@@ -341,3 +476,86 @@ func (f *FileContainsContentPredicate) Unmarshal(params string, negated bool) er
 
 func (f FileContainsContentPredicate) Field() string { return FieldFile }
 func (f FileContainsContentPredicate) Name() string  { return "contains.content" }
+
+/* file:has.owner(owner) */
+
+// FileHasOwnerPredicate represents the `file:has.owner()` predicate, which
+// filters to files owned by the given handle or email as defined by the
+// CODEOWNERS file of the repository.
+type FileHasOwnerPredicate struct {
+	Owner   string
+	Negated bool
+}
+
+func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
+	owner := strings.TrimSpace(params)
+	if owner == "" || owner == "@" {
+		return errors.Errorf("file:has.owner argument should not be empty")
+	}
+	if strings.ContainsAny(owner, " \t\n") {
+		return errors.Errorf("file:has.owner argument should be a single handle or email, got %q", owner)
+	}
+	f.Owner = owner
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* rev:at.time(time[, rev]) */
+
+// RevAtTimePredicate represents the `rev:at.time()` predicate, which searches
+// the commit that was at the tip of a revision at the given time. The
+// revision defaults to the default branch.
+type RevAtTimePredicate struct {
+	Time time.Time
+	Rev  string
+}
+
+func (f *RevAtTimePredicate) Unmarshal(params string, negated bool) error {
+	if negated {
+		return &NegatedPredicateError{f.Field() + ":" + f.Name()}
+	}
+	return f.unmarshal(params, time.Now)
+}
+
+func (f *RevAtTimePredicate) unmarshal(params string, now func() time.Time) error {
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return errors.New("rev:at.time argument should not be empty")
+	}
+
+	// Some date formats contain commas themselves, so the text after the
+	// last comma is only a revision if it is a single word.
+	date, rev := params, ""
+	if i := strings.LastIndexByte(params, ','); i >= 0 {
+		if candidate := strings.TrimSpace(params[i+1:]); candidate != "" && !strings.ContainsAny(candidate, " \t\n") {
+			date, rev = strings.TrimSpace(params[:i]), candidate
+		}
+	}
+	t, err := ParseGitDate(date, now)
+	if err != nil {
+		return errors.Errorf("rev:at.time argument %q is not a valid date", date)
+	}
+	if strings.Contains(rev, ":") || strings.HasPrefix(rev, "*") {
+		return errors.Errorf("rev:at.time revision should be a single branch, tag or commit, got %q", rev)
+	}
+
+	f.Time = t.UTC()
+	f.Rev = rev
+	return nil
+}
+
+func (f RevAtTimePredicate) Field() string { return FieldRev }
+func (f RevAtTimePredicate) Name() string  { return "at.time" }
+
+// String returns the predicate in its query syntax, with the time in an
+// absolute format.
+func (f RevAtTimePredicate) String() string {
+	args := f.Time.Format(time.RFC3339)
+	if f.Rev != "" {
+		args += ", " + f.Rev
+	}
+	return f.Name() + "(" + args + ")"
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestRepoContainsFilePredicate(t *testing.T) {
@@ -170,3 +171,291 @@ func TestRepoHasKVPPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasOwnerPredicate
+		}
+
+		valid := []test{
+			{`handle`, `@team-payments`, false, &FileHasOwnerPredicate{Owner: "@team-payments"}},
+			{`nested handle`, `@octo-org/octocats`, false, &FileHasOwnerPredicate{Owner: "@octo-org/octocats"}},
+			{`email`, `owner@example.com`, false, &FileHasOwnerPredicate{Owner: "owner@example.com"}},
+			{`negated`, `@team-payments`, true, &FileHasOwnerPredicate{Owner: "@team-payments", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`only at sign`, `@`, false, nil},
+			{`multiple owners`, `@a @b`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestRepoHasCodeownersPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		p := &RepoHasCodeownersPredicate{}
+		if err := p.Unmarshal("", true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if want := (&RepoHasCodeownersPredicate{Negated: true}); !reflect.DeepEqual(want, p) {
+			t.Fatalf("expected %#v, got %#v", want, p)
+		}
+
+		if err := (&RepoHasCodeownersPredicate{}).Unmarshal("CODEOWNERS", false); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		for query, want := range map[string][2]bool{
+			`repo:has.codeowners()`:  {true, false},
+			`-repo:has.codeowners()`: {false, true},
+			`repo:foo`:               {false, false},
+		} {
+			plan, err := Pipeline(InitLiteral(query))
+			if err != nil {
+				t.Fatal(err)
+			}
+			only, no := plan[0].RepoHasCodeowners()
+			if got := [2]bool{only, no}; got != want {
+				t.Errorf("%s: got %v, want %v", query, got, want)
+			}
+		}
+	})
+}
+
+func TestRepoHasLanguagePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *RepoHasLanguagePredicate
+		}
+
+		valid := []test{
+			{`language only`, `Java`, false, &RepoHasLanguagePredicate{Language: "Java"}},
+			{`alias`, `golang`, false, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`min percent`, `java, 60`, false, &RepoHasLanguagePredicate{Language: "Java", MinPercent: 60}},
+			{`fractional min percent`, `kotlin,12.5`, false, &RepoHasLanguagePredicate{Language: "Kotlin", MinPercent: 12.5}},
+			{`negated`, `java, 60`, true, &RepoHasLanguagePredicate{Language: "Java", MinPercent: 60, Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				if err := p.Unmarshal(tc.params, tc.negated); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`unknown language`, `notalanguage`, false, nil},
+			{`empty min percent`, `java,`, false, nil},
+			{`invalid min percent`, `java, most`, false, nil},
+			{`min percent out of range`, `java, 101`, false, nil},
+			{`negative min percent`, `java, -1`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasLanguagePredicate{}
+				if err := p.Unmarshal(tc.params, tc.negated); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		plan, err := Pipeline(InitLiteral(`repo:has.language(java, 60) -repo:has.language(kotlin) foo`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []RepoLanguageFilter{
+			{Language: "Java", MinPercent: 60},
+			{Language: "Kotlin", Negated: true},
+		}
+		if have := plan[0].RepoHasLanguages(); !reflect.DeepEqual(want, have) {
+			t.Errorf("expected %#v, got %#v", want, have)
+		}
+	})
+}
+
+func TestRepoDependsOnPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *RepoDependsOnPredicate
+		}
+
+		valid := []test{
+			{`name only`, `npm:lodash`, false, &RepoDependsOnPredicate{Ecosystem: "npm", Package: "lodash"}},
+			{`exact version`, `cargo:serde@1.0.152`, false, &RepoDependsOnPredicate{Ecosystem: "cargo", Package: "serde", Constraints: []VersionConstraint{{Op: "=", Version: "1.0.152"}}}},
+			{`range`, `npm:lodash@<4.17.21`, false, &RepoDependsOnPredicate{Ecosystem: "npm", Package: "lodash", Constraints: []VersionConstraint{{Op: "<", Version: "4.17.21"}}}},
+			{`scoped npm package`, `npm:@types/node@>=18`, false, &RepoDependsOnPredicate{Ecosystem: "npm", Package: "@types/node", Constraints: []VersionConstraint{{Op: ">=", Version: "18"}}}},
+			{`maven`, `maven:org.apache.logging.log4j:log4j-core@>=2.0.0, <2.17.1`, false, &RepoDependsOnPredicate{Ecosystem: "maven", Package: "org.apache.logging.log4j:log4j-core", Constraints: []VersionConstraint{{Op: ">=", Version: "2.0.0"}, {Op: "<", Version: "2.17.1"}}}},
+			{`negated`, `go:golang.org/x/net`, true, &RepoDependsOnPredicate{Ecosystem: "go", Package: "golang.org/x/net", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoDependsOnPredicate{}
+				if err := p.Unmarshal(tc.params, tc.negated); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`no ecosystem`, `lodash`, false, nil},
+			{`unknown ecosystem`, `hex:phoenix`, false, nil},
+			{`empty version`, `npm:lodash@`, false, nil},
+			{`empty constraint`, `npm:lodash@<`, false, nil},
+			{`empty constraint in list`, `npm:lodash@>1,`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoDependsOnPredicate{}
+				if err := p.Unmarshal(tc.params, tc.negated); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("MatchesVersion", func(t *testing.T) {
+		for _, tc := range []struct {
+			params  string
+			version string
+			want    bool
+		}{
+			{`npm:lodash`, "4.17.21", true},
+			{`npm:lodash@<4.17.21`, "4.17.20", true},
+			{`npm:lodash@<4.17.21`, "4.17.21", false},
+			{`npm:lodash@<4.17.21`, "4.17.21-rc.1", true},
+			{`npm:lodash@4.17.21`, "4.17.21", true},
+			{`npm:lodash@!=4.17.21`, "4.17.21", false},
+			{`go:golang.org/x/net@<v0.7.0`, "v0.0.0-20220722155237-a158d28d115b", true},
+			{`maven:com.google.guava:guava@>=30,<32`, "31.1-jre", true},
+			{`maven:com.google.guava:guava@>=30,<32`, "29.0-jre", false},
+			{`pypi:certifi@<2022.12.7`, "2022.9.24", true},
+			{`pypi:urllib3@>1.26`, "1.26.13", true},
+		} {
+			p := &RepoDependsOnPredicate{}
+			if err := p.Unmarshal(tc.params, false); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if have := p.MatchesVersion(tc.version); have != tc.want {
+				t.Errorf("%s matching %s: want %v, have %v", tc.params, tc.version, tc.want, have)
+			}
+		}
+	})
+
+	t.Run("Parameters", func(t *testing.T) {
+		plan, err := Pipeline(InitLiteral(`repo:depends.on(npm:lodash@<4.17.21) -repo:depends.on(cargo:openssl) foo`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []RepoDependsOnPredicate{
+			{Ecosystem: "npm", Package: "lodash", Constraints: []VersionConstraint{{Op: "<", Version: "4.17.21"}}},
+			{Ecosystem: "cargo", Package: "openssl", Negated: true},
+		}
+		if have := plan[0].RepoDependsOn(); !reflect.DeepEqual(want, have) {
+			t.Errorf("expected %#v, got %#v", want, have)
+		}
+	})
+}
+
+func TestRevAtTimePredicate(t *testing.T) {
+	now := func() time.Time { return time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC) }
+
+	valid := []struct {
+		params   string
+		expected *RevAtTimePredicate
+	}{
+		{`2023-01-01`, &RevAtTimePredicate{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		{`2023-01-01, main`, &RevAtTimePredicate{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Rev: "main"}},
+		{`2023-01-01T10:30:00+02:00,release/1.0`, &RevAtTimePredicate{Time: time.Date(2023, 1, 1, 8, 30, 0, 0, time.UTC), Rev: "release/1.0"}},
+		{`Thu, 02 Jan 2020 15:04:05 -0700`, &RevAtTimePredicate{Time: time.Date(2020, 1, 2, 22, 4, 5, 0, time.UTC)}},
+		{`Thu, 02 Jan 2020 15:04:05 -0700, main`, &RevAtTimePredicate{Time: time.Date(2020, 1, 2, 22, 4, 5, 0, time.UTC), Rev: "main"}},
+		{`2 days ago, main`, &RevAtTimePredicate{Time: time.Date(2023, 3, 13, 0, 0, 0, 0, time.UTC), Rev: "main"}},
+	}
+	for _, tc := range valid {
+		t.Run(tc.params, func(t *testing.T) {
+			p := &RevAtTimePredicate{}
+			if err := p.unmarshal(tc.params, now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expected, p) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, p)
+			}
+		})
+	}
+
+	for _, params := range []string{``, `not a date`, `2023-01-01, *refs/heads/*`, `2023-01-01, refs:main`} {
+		t.Run(params, func(t *testing.T) {
+			p := &RevAtTimePredicate{}
+			if err := p.unmarshal(params, now); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+
+	t.Run("negated", func(t *testing.T) {
+		p := &RevAtTimePredicate{}
+		if err := p.Unmarshal(`2023-01-01`, true); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		p := RevAtTimePredicate{Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), Rev: "main"}
+		if have, want := p.String(), "at.time(2023-01-01T00:00:00Z, main)"; have != want {
+			t.Fatalf("expected %q, got %q", want, have)
+		}
+	})
+}
@@ -44,6 +44,8 @@ func stringHumanPattern(nodes []Node) string {
 				separator = " OR "
 			case And:
 				separator = " AND "
+			case Near:
+				separator = fmt.Sprintf(" NEAR/%d ", n.Distance)
 			}
 			result = append(result, "("+strings.Join(nested, separator)+")")
 		}
@@ -113,6 +115,8 @@ func StringHuman(nodes []Node) string {
 					v = append(v, "("+strings.Join(s, " OR ")+")")
 				} else if term.Kind == And {
 					v = append(v, "("+strings.Join(s, " AND ")+")")
+				} else if term.Kind == Near {
+					v = append(v, "("+strings.Join(s, fmt.Sprintf(" NEAR/%d ", term.Distance))+")")
 				}
 			}
 		}
@@ -157,6 +161,14 @@ func nodeToJSON(node Node) any {
 			}{
 				Or: jsons,
 			}
+		case Near:
+			return struct {
+				Near     []any `json:"near"`
+				Distance int   `json:"distance"`
+			}{
+				Near:     jsons,
+				Distance: n.Distance,
+			}
 		case Concat:
 			// Concat should already be processed at this point, or
 			// the original query expresses something that is not
@@ -383,7 +383,7 @@ func Hoist(nodes []Node) ([]Node, error) {
 	}
 
 	expression, ok := nodes[0].(Operator)
-	if !ok || expression.Kind == Concat {
+	if !ok || expression.Kind == Concat || expression.Kind == Near {
 		return nil, errors.Errorf("heuristic requires top-level and- or or-expression")
 	}
 
@@ -557,7 +557,7 @@ func substituteOrForRegexp(nodes []Node) []Node {
 					newNode = NewOperator(append(newNode, rest...), Or)
 				}
 			} else {
-				newNode = append(newNode, NewOperator(substituteOrForRegexp(v.Operands), v.Kind)...)
+				newNode = append(newNode, withDistance(NewOperator(substituteOrForRegexp(v.Operands), v.Kind), v.Distance)...)
 			}
 		case Parameter, Pattern:
 			newNode = append(newNode, node)
@@ -703,7 +703,7 @@ func substituteConcat(callback func([]Pattern) []Node) func([]Node) []Node {
 						newNode = append(newNode, callback(ps)...)
 					}
 				} else {
-					newNode = append(newNode, NewOperator(substituteNodes(v.Operands), v.Kind)...)
+					newNode = append(newNode, withDistance(NewOperator(substituteNodes(v.Operands), v.Kind), v.Distance)...)
 				}
 			}
 		}
@@ -393,6 +393,19 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+// FileHasOwner returns the owners specified by the file:has.owner()
+// predicate, split by whether they are included or excluded.
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
@@ -453,6 +466,45 @@ func (p Parameters) RepoHasKVPs() (res []RepoKVPFilter) {
 	return res
 }
 
+// RepoHasCodeowners returns whether the query only matches repositories with a
+// valid CODEOWNERS file (only) or only repositories without one (no).
+func (p Parameters) RepoHasCodeowners() (only, no bool) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasCodeownersPredicate) {
+		if pred.Negated {
+			no = true
+		} else {
+			only = true
+		}
+	})
+	return only, no
+}
+
+// RepoDependsOn returns the repo:depends.on() predicates of the query.
+func (p Parameters) RepoDependsOn() (res []RepoDependsOnPredicate) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoDependsOnPredicate) {
+		res = append(res, *pred)
+	})
+	return res
+}
+
+// RepoLanguageFilter is the argument of a repo:has.language() predicate.
+type RepoLanguageFilter struct {
+	Language   string
+	MinPercent float64
+	Negated    bool
+}
+
+func (p Parameters) RepoHasLanguages() (res []RepoLanguageFilter) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasLanguagePredicate) {
+		res = append(res, RepoLanguageFilter{
+			Language:   pred.Language,
+			MinPercent: pred.MinPercent,
+			Negated:    pred.Negated,
+		})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -270,7 +270,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldAuthor,
 		FieldCommitter,
-		FieldMessage:
+		FieldMessage,
+		FieldDiffFunction:
 		return satisfies(isValidRegexp)
 	case
 		FieldIndex,
@@ -352,6 +353,24 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// Queries containing diff parameters without type:diff are not valid.
+func validateDiffParameters(nodes []Node) error {
+	var seenDiffParam string
+	var typeDiffExists bool
+	VisitParameter(nodes, func(field, value string, _ bool, _ Annotation) {
+		if field == FieldDiffFunction {
+			seenDiffParam = field
+		}
+		if field == FieldType && value == "diff" {
+			typeDiffExists = true
+		}
+	})
+	if seenDiffParam != "" && !typeDiffExists {
+		return errors.Errorf(`your query contains the field '%s', which requires type:diff in the query`, seenDiffParam)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -390,6 +409,68 @@ func validateRefGlobs(nodes []Node) error {
 	return nil
 }
 
+// validateNear validates that NEAR/n only relates two patterns of a content
+// search, and is not combined with other search patterns.
+func validateNear(nodes []Node) error {
+	var seenNear, structural bool
+	VisitOperator(nodes, func(kind OperatorKind, operands []Node) {
+		if kind == Near {
+			seenNear = true
+			structural = structural || Exists(operands, func(node Node) bool {
+				p, ok := node.(Pattern)
+				return ok && p.Annotation.Labels.IsSet(Structural)
+			})
+		}
+	})
+	if !seenNear {
+		return nil
+	}
+
+	if structural {
+		return errors.New("NEAR/n is not supported for structural search")
+	}
+	var typeErr error
+	VisitField(nodes, FieldType, func(value string, _ bool, _ Annotation) {
+		if value != "file" {
+			typeErr = errors.Errorf("NEAR/n only applies to searching file contents and is not supported for type:%s", value)
+		}
+	})
+	if typeErr != nil {
+		return typeErr
+	}
+	var fileContains bool
+	VisitField(nodes, FieldFile, func(value string, _ bool, annotation Annotation) {
+		if annotation.Labels.IsSet(IsPredicate) {
+			name, _ := ParseAsPredicate(value)
+			_, isContent := DefaultPredicateRegistry.Get(FieldFile, name).(*FileContainsContentPredicate)
+			fileContains = fileContains || isContent
+		}
+	})
+	if fileContains {
+		return errors.New("NEAR/n is not supported together with file:contains.content()")
+	}
+	combined := Exists(nodes, func(node Node) bool {
+		operator, ok := node.(Operator)
+		if !ok || operator.Kind == Near {
+			return false
+		}
+		patterns := 0
+		for _, operand := range operator.Operands {
+			if containsPattern(operand) {
+				patterns++
+			}
+		}
+		return patterns > 1 && Exists(operator.Operands, func(node Node) bool {
+			op, ok := node.(Operator)
+			return ok && op.Kind == Near
+		})
+	})
+	if combined {
+		return errors.New(`NEAR/n cannot be combined with other search patterns. Use a regular expression for patterns that contain spaces, as in /foo bar/ NEAR/3 baz`)
+	}
+	return nil
+}
+
 // validatePredicates validates predicate parameters with respect to their validation logic.
 func validatePredicate(field, value string, negated bool) error {
 	name, params := ParseAsPredicate(value)                // guaranteed to succeed
@@ -492,8 +573,10 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateDiffParameters,
 		validateTypeStructural,
 		validateRefGlobs,
+		validateNear,
 	)
 }
 
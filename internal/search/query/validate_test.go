@@ -83,6 +83,10 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "repo:foo type:commit diff.function:ValidateToken",
+			want:  `your query contains the field 'diff.function', which requires type:diff in the query`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
@@ -109,6 +113,28 @@ func TestValidation(t *testing.T) {
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents and is not currently supported for diff searches",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input: "type:diff foo NEAR/3 bar",
+			want:  "NEAR/n only applies to searching file contents and is not supported for type:diff",
+		},
+		{
+			input: "foo NEAR/3 bar or baz",
+			want:  "NEAR/n cannot be combined with other search patterns. Use a regular expression for patterns that contain spaces, as in /foo bar/ NEAR/3 baz",
+		},
+		{
+			input:      "foo bar NEAR/3 baz",
+			want:       "NEAR/n cannot be combined with other search patterns. Use a regular expression for patterns that contain spaces, as in /foo bar/ NEAR/3 baz",
+			searchType: SearchTypeStandard,
+		},
+		{
+			input: "file:contains.content(baz) foo NEAR/3 bar",
+			want:  "NEAR/n is not supported together with file:contains.content()",
+		},
+		{
+			input:      "foo(...) NEAR/3 bar",
+			want:       "NEAR/n is not supported for structural search",
+			searchType: SearchTypeStructural,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// VersionConstraint constrains the version of a package, e.g. "<4.17.21".
+type VersionConstraint struct {
+	// Op is one of "=", "!=", "<", "<=", ">" or ">=".
+	Op      string
+	Version string
+}
+
+// versionConstraintOps are checked in order, so that longer operators are
+// matched before their prefixes.
+var versionConstraintOps = []string{"<=", ">=", "!=", "<", ">", "="}
+
+// ParseVersionConstraints parses a comma-separated list of version
+// constraints, e.g. ">=1.2.0,<1.3.0". A version without an operator must
+// match exactly.
+func ParseVersionConstraints(s string) ([]VersionConstraint, error) {
+	var constraints []VersionConstraint
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		c := VersionConstraint{Op: "="}
+		for _, op := range versionConstraintOps {
+			if strings.HasPrefix(part, op) {
+				c.Op = op
+				part = strings.TrimSpace(strings.TrimPrefix(part, op))
+				break
+			}
+		}
+		if part == "" {
+			return nil, errors.Errorf("missing version in constraint %q", s)
+		}
+
+		c.Version = part
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// Matches returns true if the given version satisfies the constraint.
+func (c VersionConstraint) Matches(version string) bool {
+	cmp := compareVersions(version, c.Version)
+	switch c.Op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+func (c VersionConstraint) String() string {
+	if c.Op == "=" {
+		return c.Version
+	}
+	return c.Op + c.Version
+}
+
+// compareVersions compares two versions, returning -1, 0 or 1. Versions are
+// compared as semantic versions when both parse as such, which is the case
+// for most ecosystems. Otherwise, e.g. for Maven versions like "31.1-jre" or
+// Python versions like "2022.12.7.post1", they are compared segment by
+// segment, numerically where both segments are numbers.
+func compareVersions(a, b string) int {
+	if va, err := semver.NewVersion(a); err == nil {
+		if vb, err := semver.NewVersion(b); err == nil {
+			return va.Compare(vb)
+		}
+	}
+
+	sa, sb := versionSegments(a), versionSegments(b)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		var x, y string
+		if i < len(sa) {
+			x = sa[i]
+		}
+		if i < len(sb) {
+			y = sb[i]
+		}
+		if cmp := compareVersionSegments(x, y); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+func versionSegments(version string) []string {
+	version = strings.TrimPrefix(version, "v")
+	return strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_' || r == '+'
+	})
+}
+
+func compareVersionSegments(x, y string) int {
+	nx, errX := strconv.Atoi(x)
+	ny, errY := strconv.Atoi(y)
+	switch {
+	case x == y:
+		return 0
+	case x == "":
+		// A missing segment is equivalent to a zero and sorts before any
+		// qualifier, e.g. 1.0 < 1.0.1 and 1.0 < 1.0-jre.
+		if errY == nil && ny == 0 {
+			return 0
+		}
+		return -1
+	case y == "":
+		if errX == nil && nx == 0 {
+			return 0
+		}
+		return 1
+	case errX == nil && errY == nil:
+		switch {
+		case nx < ny:
+			return -1
+		case nx > ny:
+			return 1
+		default:
+			return 0
+		}
+	case errX == nil:
+		// Numeric segments sort after qualifiers.
+		return 1
+	case errY == nil:
+		return -1
+	default:
+		return strings.Compare(x, y)
+	}
+}
@@ -3,13 +3,16 @@ package search
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 // RevisionSpecifier represents either a revspec or a ref glob. At most one
-// field is set. The default branch is represented by all fields being empty.
+// of RevSpec, RefGlob and ExcludeRefGlob is set. The default branch is
+// represented by all fields being empty.
 type RevisionSpecifier struct {
 	// RevSpec is a revision range specifier suitable for passing to git. See
 	// the manpage gitrevisions(7).
@@ -22,9 +25,16 @@ type RevisionSpecifier struct {
 	// ExcludeRefGlob is a glob for references to exclude. See the
 	// documentation for "--exclude" in git-log.
 	ExcludeRefGlob string
+
+	// AtTime, if set, selects the commit that was at the tip of RevSpec (or
+	// of the default branch if RevSpec is empty) at the given time, in UTC.
+	AtTime time.Time
 }
 
 func (r1 RevisionSpecifier) String() string {
+	if !r1.AtTime.IsZero() {
+		return query.RevAtTimePredicate{Time: r1.AtTime, Rev: r1.RevSpec}.String()
+	}
 	if r1.ExcludeRefGlob != "" {
 		return "*!" + r1.ExcludeRefGlob
 	}
@@ -46,7 +56,10 @@ func (r1 RevisionSpecifier) Less(r2 RevisionSpecifier) bool {
 	if r1.RefGlob != r2.RefGlob {
 		return r1.RefGlob < r2.RefGlob
 	}
-	return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	if r1.ExcludeRefGlob != r2.ExcludeRefGlob {
+		return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	}
+	return r1.AtTime.Before(r2.AtTime)
 }
 
 // RepositoryRevisions specifies a repository and 0 or more revspecs and ref
@@ -79,7 +92,9 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 // where repo is a repository regex and revs is a ':'-separated list of revspecs
 // and/or ref globs. A ref glob is a revspec prefixed with '*' (which is not a
 // valid revspec or ref itself; see `man git-check-ref-format`). The '@' and revs
-// may be omitted to refer to the default branch.
+// may be omitted to refer to the default branch. A revspec of the form
+// 'at.time(date[, rev])' refers to the commit at the tip of rev (or the
+// default branch) at the given date.
 //
 // For example:
 //
@@ -90,6 +105,8 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 //   - 'foo@*bar' refers to the 'foo' repo and all refs matching the glob 'bar/*',
 //     because git interprets the ref glob 'bar' as being 'bar/*' (see `man git-log`
 //     section on the --glob flag)
+//   - 'foo@at.time(2023-01-01, main)' refers to the 'foo' repo at the last
+//     commit on the 'main' branch before 2023-01-01.
 func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpecifier) {
 	i := strings.Index(repoAndOptionalRev, "@")
 	if i == -1 {
@@ -103,7 +120,7 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 
 	repo := repoAndOptionalRev[:i]
 	var revs []RevisionSpecifier
-	for _, part := range strings.Split(repoAndOptionalRev[i+1:], ":") {
+	for _, part := range splitRevs(repoAndOptionalRev[i+1:]) {
 		if part == "" {
 			continue
 		}
@@ -115,7 +132,35 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 	return repo, revs
 }
 
+// splitRevs splits a ':'-separated list of revs, ignoring the separators
+// within the parentheses of an at.time() rev, as its date may contain colons.
+func splitRevs(revs string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range revs {
+		switch {
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ':' && depth == 0:
+			parts = append(parts, revs[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, revs[start:])
+}
+
 func parseRev(spec string) RevisionSpecifier {
+	if strings.HasPrefix(spec, "at.time(") && strings.HasSuffix(spec, ")") {
+		var p query.RevAtTimePredicate
+		if err := p.Unmarshal(spec[len("at.time("):len(spec)-1], false); err == nil {
+			return RevisionSpecifier{RevSpec: p.Rev, AtTime: p.Time}
+		}
+	}
 	if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
 	} else if strings.HasPrefix(spec, "*") {
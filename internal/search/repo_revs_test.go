@@ -3,6 +3,7 @@ package search
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestParseRepositoryRevisions(t *testing.T) {
@@ -30,6 +31,18 @@ func TestParseRepositoryRevisions(t *testing.T) {
 				{RefGlob: "glob3"},
 			},
 		},
+		"repo@at.time(2023-01-01, main)": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{RevSpec: "main", AtTime: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}},
+		},
+		"repo@at.time(2023-01-01T10:30:00Z):rev2": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{AtTime: time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)}, {RevSpec: "rev2"}},
+		},
+		"repo@at.time(yesterday-ish)": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{RevSpec: "at.time(yesterday-ish)"}},
+		},
 	}
 	for input, want := range tests {
 		t.Run(input, func(t *testing.T) {
@@ -21,7 +21,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -70,22 +72,24 @@ func (r *Resolved) String() string {
 	return fmt.Sprintf("Resolved{RepoRevs=%d, MissingRepoRevs=%d BackendsMissing=%d}", len(r.RepoRevs), len(r.MissingRepoRevs), r.BackendsMissing)
 }
 
-func NewResolver(logger log.Logger, db database.DB, gitserverClient gitserver.Client, searcher *endpoint.Map, zoekt zoekt.Streamer) *Resolver {
+func NewResolver(logger log.Logger, db database.DB, gitserverClient gitserver.Client, searcher *endpoint.Map, zoekt zoekt.Streamer, dependenciesSvc *dependencies.Service) *Resolver {
 	return &Resolver{
-		logger:    logger,
-		db:        db,
-		gitserver: gitserverClient,
-		zoekt:     zoekt,
-		searcher:  searcher,
+		logger:       logger,
+		db:           db,
+		gitserver:    gitserverClient,
+		zoekt:        zoekt,
+		searcher:     searcher,
+		dependencies: dependenciesSvc,
 	}
 }
 
 type Resolver struct {
-	logger    log.Logger
-	db        database.DB
-	gitserver gitserver.Client
-	zoekt     zoekt.Streamer
-	searcher  *endpoint.Map
+	logger       log.Logger
+	db           database.DB
+	gitserver    gitserver.Client
+	zoekt        zoekt.Streamer
+	searcher     *endpoint.Map
+	dependencies *dependencies.Service
 }
 
 func (r *Resolver) Paginate(ctx context.Context, opts search.RepoOptions, handle func(*Resolved) error) (err error) {
@@ -134,7 +138,6 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		tr.Finish()
 	}()
 
-	excludePatterns := op.MinusRepoFilters
 	includePatterns, includePatternRevs, errs := findPatternRevs(op.RepoFilters)
 	if errs != nil {
 		return Resolved{}, errs
@@ -150,52 +153,19 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		return Resolved{}, errs
 	}
 
-	kvpFilters := make([]database.RepoKVPFilter, 0, len(op.HasKVPs))
-	for _, filter := range op.HasKVPs {
-		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
-			Key:     filter.Key,
-			Value:   filter.Value,
-			Negated: filter.Negated,
-			KeyOnly: filter.KeyOnly,
-		})
-	}
-
-	options := database.ReposListOptions{
-		IncludePatterns:       includePatterns,
-		ExcludePattern:        query.UnionRegExps(excludePatterns),
-		DescriptionPatterns:   op.DescriptionPatterns,
-		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
-		KVPFilters:            kvpFilters,
-		Cursors:               op.Cursors,
-		// List N+1 repos so we can see if there are repos omitted due to our repo limit.
-		LimitOffset:  &database.LimitOffset{Limit: limit + 1},
-		NoForks:      op.NoForks,
-		OnlyForks:    op.OnlyForks,
-		NoArchived:   op.NoArchived,
-		OnlyArchived: op.OnlyArchived,
-		NoPrivate:    op.Visibility == query.Public,
-		OnlyPrivate:  op.Visibility == query.Private,
-		OnlyCloned:   op.OnlyCloned,
-		OrderBy: database.RepoListOrderBy{
-			{
-				Field:      database.RepoListStars,
-				Descending: true,
-				Nulls:      "LAST",
-			},
-			{
-				Field:      database.RepoListID,
-				Descending: true,
-			},
-		},
+	options, ok, errs := r.listOptions(ctx, op, searchContext, includePatterns)
+	if errs != nil {
+		return Resolved{}, errs
 	}
-
-	// Filter by search context repository revisions only if this search context doesn't have
-	// a query, which replaces the context:foo term at query parsing time.
-	if searchContext.Query == "" {
-		options.SearchContextID = searchContext.ID
-		options.UserID = searchContext.NamespaceUserID
-		options.OrgID = searchContext.NamespaceOrgID
+	if !ok {
+		// No repository depends on all of the given packages.
+		if len(op.Cursors) == 0 {
+			return Resolved{}, ErrNoResolvedRepos
+		}
+		return Resolved{}, nil
 	}
+	// List N+1 repos so we can see if there are repos omitted due to our repo limit.
+	options.LimitOffset = &database.LimitOffset{Limit: limit + 1}
 
 	tr.LazyPrintf("Repos.ListMinimalRepos - start")
 	repos, errs := r.db.Repos().ListMinimalRepos(ctx, options)
@@ -292,6 +262,207 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 	}, err
 }
 
+// listOptions returns the options to list the repositories matched by op,
+// without a limit. ok is false if op cannot match any repository.
+func (r *Resolver) listOptions(ctx context.Context, op search.RepoOptions, searchContext *types.SearchContext, includePatterns []string) (_ database.ReposListOptions, ok bool, _ error) {
+	kvpFilters := make([]database.RepoKVPFilter, 0, len(op.HasKVPs))
+	for _, filter := range op.HasKVPs {
+		kvpFilters = append(kvpFilters, database.RepoKVPFilter{
+			Key:     filter.Key,
+			Value:   filter.Value,
+			Negated: filter.Negated,
+			KeyOnly: filter.KeyOnly,
+		})
+	}
+
+	languageFilters := make([]database.RepoLanguageFilter, 0, len(op.HasLanguages))
+	for _, filter := range op.HasLanguages {
+		languageFilters = append(languageFilters, database.RepoLanguageFilter{
+			Language:   filter.Language,
+			MinPercent: filter.MinPercent,
+			Negated:    filter.Negated,
+		})
+	}
+
+	options := database.ReposListOptions{
+		IncludePatterns:       includePatterns,
+		ExcludePattern:        query.UnionRegExps(op.MinusRepoFilters),
+		DescriptionPatterns:   op.DescriptionPatterns,
+		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
+		KVPFilters:            kvpFilters,
+		Cursors:               op.Cursors,
+		NoForks:               op.NoForks,
+		OnlyForks:             op.OnlyForks,
+		NoArchived:            op.NoArchived,
+		OnlyArchived:          op.OnlyArchived,
+		NoPrivate:             op.Visibility == query.Public,
+		OnlyPrivate:           op.Visibility == query.Private,
+		OnlyCloned:            op.OnlyCloned,
+		OnlyCodeowners:        op.OnlyCodeowners,
+		NoCodeowners:          op.NoCodeowners,
+		LanguageFilters:       languageFilters,
+		OrderBy: database.RepoListOrderBy{
+			{
+				Field:      database.RepoListStars,
+				Descending: true,
+				Nulls:      "LAST",
+			},
+			{
+				Field:      database.RepoListID,
+				Descending: true,
+			},
+		},
+	}
+
+	if len(op.DependsOn) > 0 {
+		includeIDs, excludeIDs, err := r.resolveDependsOn(ctx, op.DependsOn)
+		if err != nil {
+			return options, false, errors.Wrap(err, "resolve repo:depends.on")
+		}
+		if includeIDs != nil && len(includeIDs) == 0 {
+			return options, false, nil
+		}
+		options.IDs = includeIDs
+		options.ExcludeIDs = excludeIDs
+	}
+
+	// Filter by search context repository revisions only if this search context doesn't have
+	// a query, which replaces the context:foo term at query parsing time.
+	if searchContext.Query == "" {
+		options.SearchContextID = searchContext.ID
+		options.UserID = searchContext.NamespaceUserID
+		options.OrgID = searchContext.NamespaceOrgID
+	}
+
+	return options, true, nil
+}
+
+// estimateSampleSize is the number of repositories Estimate looks up in the
+// Zoekt index to estimate how many of the resolved repositories are indexed.
+// Estimates run before every search when maxEstimatedCost is set, so the
+// sample is kept small.
+const estimateSampleSize = 1000
+
+// Estimate is an estimate of the repository revisions a set of RepoOptions
+// resolves to.
+type Estimate struct {
+	// Repos is the number of repositories matched by the repository filters,
+	// before evaluating the predicates in FanOut.
+	Repos int
+
+	// Indexed is the estimated number of Repos that are indexed by Zoekt.
+	Indexed int
+
+	// RevsPerRepo is the number of revisions searched in each repository.
+	// It is zero if the revisions include ref globs, which are expanded per
+	// repository.
+	RevsPerRepo int
+
+	// FanOut is the list of repository predicates that are evaluated by
+	// searching each of Repos.
+	FanOut []string
+}
+
+// Estimate returns an estimate of the repository revisions op resolves to.
+// Unlike Resolve, it does not resolve revisions or evaluate predicates that
+// need to search repositories, so it is cheap enough to run before deciding
+// whether to run a search at all.
+func (r *Resolver) Estimate(ctx context.Context, op search.RepoOptions) (_ Estimate, err error) {
+	tr, ctx := trace.New(ctx, "searchrepos.Estimate", op.String())
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	includePatterns, includePatternRevs, err := findPatternRevs(op.RepoFilters)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	est := Estimate{
+		RevsPerRepo: revsPerRepo(includePatternRevs),
+		FanOut:      fanOutPredicates(op),
+	}
+
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, r.db, op.SearchContextSpec)
+	if err != nil {
+		return Estimate{}, err
+	}
+	options, ok, err := r.listOptions(ctx, op, searchContext, includePatterns)
+	if err != nil || !ok {
+		return est, err
+	}
+
+	est.Repos, err = r.db.Repos().Count(ctx, options)
+	if err != nil || est.Repos == 0 || r.zoekt == nil || op.UseIndex == query.No {
+		return est, err
+	}
+
+	// Extrapolate the indexed ratio of the most starred repositories, which
+	// are the ones searched first.
+	options.LimitOffset = &database.LimitOffset{Limit: estimateSampleSize}
+	sample, err := r.db.Repos().ListMinimalRepos(ctx, options)
+	if err != nil || len(sample) == 0 {
+		return est, err
+	}
+	ids := make([]uint32, 0, len(sample))
+	for _, repo := range sample {
+		ids = append(ids, uint32(repo.ID))
+	}
+	q := zoektquery.NewSingleBranchesRepos("HEAD", ids...)
+	list, err := r.zoekt.List(ctx, q, &zoekt.ListOptions{Minimal: true})
+	if err != nil {
+		// Search falls back to unindexed search when Zoekt is unavailable.
+		r.logger.Warn("failed to list indexed repositories", log.Error(err))
+		return est, nil
+	}
+	indexed := 0
+	for _, repo := range sample {
+		if _, ok := list.Minimal[uint32(repo.ID)]; ok { //nolint:staticcheck // See https://github.com/sourcegraph/sourcegraph/issues/45814
+			indexed++
+		}
+	}
+	est.Indexed = est.Repos * indexed / len(sample)
+
+	return est, nil
+}
+
+// revsPerRepo returns the largest number of revisions a repository is
+// searched at, or zero if any of the revisions is a ref glob.
+func revsPerRepo(patternRevs []patternRevspec) int {
+	n := 1
+	for _, p := range patternRevs {
+		for _, rev := range p.revs {
+			if rev.RefGlob != "" || rev.ExcludeRefGlob != "" {
+				return 0
+			}
+		}
+		if len(p.revs) > n {
+			n = len(p.revs)
+		}
+	}
+	return n
+}
+
+// fanOutPredicates returns the repository predicates of op that are
+// evaluated by searching each repository.
+func fanOutPredicates(op search.RepoOptions) (res []string) {
+	for _, arg := range op.HasFileContent {
+		switch {
+		case arg.Path != "" && arg.Content != "":
+			res = append(res, "repo:contains.file")
+		case arg.Path != "":
+			res = append(res, "repo:contains.path")
+		default:
+			res = append(res, "repo:contains.content")
+		}
+	}
+	if op.CommitAfter != nil {
+		res = append(res, "repo:contains.commit.after")
+	}
+	return res
+}
+
 // associateReposWithRevs re-associates revisions with the repositories fetched from the db
 func (r *Resolver) associateReposWithRevs(
 	repos []types.MinimalRepo,
@@ -411,6 +582,16 @@ func (r *Resolver) normalizeRepoRefs(
 	var globs []gitdomain.RefGlob
 	for _, rev := range revSpecs {
 		switch {
+		case !rev.AtTime.IsZero():
+			commitID, err := r.resolveRevAtTime(ctx, repo, rev)
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+					return nil, err
+				}
+				reportMissing(RepoRevSpecs{Repo: repo, Revs: []search.RevisionSpecifier{rev}})
+				continue
+			}
+			revs = append(revs, string(commitID))
 		case rev.RefGlob != "":
 			globs = append(globs, gitdomain.RefGlob{Include: rev.RefGlob})
 		case rev.ExcludeRefGlob != "":
@@ -460,6 +641,82 @@ func (r *Resolver) normalizeRepoRefs(
 
 }
 
+// resolveRevAtTime returns the commit that was at the tip of the revision of
+// the given at.time() revision specifier at its time. Only the first parents
+// are followed so that commits merged after that time are not selected, even
+// if they were committed before it.
+func (r *Resolver) resolveRevAtTime(ctx context.Context, repo types.MinimalRepo, rev search.RevisionSpecifier) (api.CommitID, error) {
+	revSpec := rev.RevSpec
+	if revSpec == "" {
+		revSpec = "HEAD"
+	}
+
+	commits, err := r.gitserver.Commits(ctx, authz.DefaultSubRepoPermsChecker, repo.Name, gitserver.CommitsOptions{
+		Range:            revSpec,
+		Before:           rev.AtTime.Format(time.RFC3339),
+		N:                1,
+		FirstParent:      true,
+		NoEnsureRevision: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(commits) == 0 {
+		return "", &gitdomain.RevisionNotFoundError{Repo: repo.Name, Spec: rev.String()}
+	}
+	return commits[0].ID, nil
+}
+
+// resolveDependsOn returns the IDs of the repositories matching all of the
+// given repo:depends.on() predicates, and the IDs of the repositories excluded
+// by the negated ones. includeIDs is nil if there are no non-negated
+// predicates.
+func (r *Resolver) resolveDependsOn(ctx context.Context, predicates []query.RepoDependsOnPredicate) (includeIDs, excludeIDs []api.RepoID, err error) {
+	var include map[api.RepoID]struct{}
+	exclude := map[api.RepoID]struct{}{}
+	for _, predicate := range predicates {
+		dependents, err := r.dependencies.LockfileDependents(ctx, predicate.Ecosystem, reposource.PackageName(predicate.Package))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		matching := map[api.RepoID]struct{}{}
+		for _, dependent := range dependents {
+			if predicate.MatchesVersion(dependent.Version) {
+				matching[dependent.RepoID] = struct{}{}
+			}
+		}
+
+		switch {
+		case predicate.Negated:
+			for id := range matching {
+				exclude[id] = struct{}{}
+			}
+		case include == nil:
+			include = matching
+		default:
+			for id := range include {
+				if _, ok := matching[id]; !ok {
+					delete(include, id)
+				}
+			}
+		}
+	}
+
+	if include != nil {
+		includeIDs = make([]api.RepoID, 0, len(include))
+		for id := range include {
+			if _, ok := exclude[id]; !ok {
+				includeIDs = append(includeIDs, id)
+			}
+		}
+	}
+	for id := range exclude {
+		excludeIDs = append(excludeIDs, id)
+	}
+	return includeIDs, excludeIDs, nil
+}
+
 // filterHasCommitAfter filters the revisions on each of a set of RepositoryRevisions to ensure that
 // any repo-level filters (e.g. `repo:contains.commit.after()`) apply to this repo/rev combo.
 func (r *Resolver) filterHasCommitAfter(
@@ -1008,6 +1265,8 @@ func (r *RepoRevSpecs) RevSpecs() []string {
 		switch {
 		case rev.RefGlob != "":
 		case rev.ExcludeRefGlob != "":
+		case !rev.AtTime.IsZero():
+			res = append(res, rev.String())
 		default:
 			res = append(res, rev.RevSpec)
 		}
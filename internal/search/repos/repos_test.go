@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/grafana/regexp"
 	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/log/logtest"
@@ -140,7 +141,7 @@ func TestRevisionValidation(t *testing.T) {
 			db.ReposFunc.SetDefaultReturn(repos)
 
 			op := search.RepoOptions{RepoFilters: tt.repoFilters}
-			repositoryResolver := NewResolver(logtest.Scoped(t), db, nil, nil, nil)
+			repositoryResolver := NewResolver(logtest.Scoped(t), db, nil, nil, nil, nil)
 			repositoryResolver.gitserver = mockGitserver
 			resolved, err := repositoryResolver.Resolve(context.Background(), op)
 			if !errors.Is(err, tt.wantErr) {
@@ -158,6 +159,85 @@ func TestRevisionValidation(t *testing.T) {
 	}
 }
 
+func TestRevAtTime(t *testing.T) {
+	mockGitserver := gitserver.NewMockClient()
+	mockGitserver.CommitsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, opt gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		if !opt.FirstParent || opt.N != 1 {
+			t.Errorf("unexpected commits options %+v", opt)
+		}
+		switch {
+		case opt.Range == "main" && opt.Before == "2023-01-01T00:00:00Z":
+			return []*gitdomain.Commit{{ID: "deadbeef"}}, nil
+		case opt.Range == "HEAD" && opt.Before == "2023-01-01T00:00:00Z":
+			return []*gitdomain.Commit{{ID: "cafebabe"}}, nil
+		case opt.Range == "bad_commit":
+			return nil, &gitdomain.BadCommitError{}
+		}
+		return nil, nil
+	})
+
+	tests := []struct {
+		repoFilter               string
+		wantRepoRevs             []*search.RepositoryRevisions
+		wantMissingRepoRevisions []RepoRevSpecs
+		wantErr                  error
+	}{
+		{
+			repoFilter: "repoFoo@at.time(2023-01-01, main)",
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"deadbeef"},
+			}},
+			wantMissingRepoRevisions: []RepoRevSpecs{},
+		},
+		{
+			repoFilter: "repoFoo@at.time(2023-01-01)",
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"cafebabe"},
+			}},
+			wantMissingRepoRevisions: []RepoRevSpecs{},
+		},
+		{
+			repoFilter:   "repoFoo@at.time(1999-01-01, main)",
+			wantRepoRevs: []*search.RepositoryRevisions{},
+			wantMissingRepoRevisions: []RepoRevSpecs{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []search.RevisionSpecifier{{RevSpec: "main", AtTime: time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			}},
+			wantErr: &MissingRepoRevsError{},
+		},
+		{
+			repoFilter: "repoFoo@at.time(2023-01-01, bad_commit)",
+			wantErr:    &gitdomain.BadCommitError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoFilter, func(t *testing.T) {
+			repos := database.NewMockRepoStore()
+			repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{{Name: "repoFoo"}}, nil)
+			db := database.NewMockDB()
+			db.ReposFunc.SetDefaultReturn(repos)
+
+			op := search.RepoOptions{RepoFilters: []string{tt.repoFilter}}
+			repositoryResolver := NewResolver(logtest.Scoped(t), db, nil, nil, nil, nil)
+			repositoryResolver.gitserver = mockGitserver
+			resolved, err := repositoryResolver.Resolve(context.Background(), op)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got: %v, expected: %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.wantRepoRevs, resolved.RepoRevs); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.wantMissingRepoRevisions, resolved.MissingRepoRevs); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 // TestSearchRevspecs tests a repository name against a list of
 // repository specs with optional revspecs, and determines whether
 // we get the expected error, list of matching rev specs, or list
@@ -309,7 +389,7 @@ func TestResolverPaginate(t *testing.T) {
 		return "", nil
 	})
 
-	resolver := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil)
+	resolver := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil, nil)
 	all, err := resolver.Resolve(ctx, search.RepoOptions{})
 	if err != nil {
 		t.Fatal(err)
@@ -409,7 +489,7 @@ func TestResolverPaginate(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			r := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil)
+			r := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil, nil)
 
 			var pages []Resolved
 			err := r.Paginate(ctx, tc.opts, func(page *Resolved) error {
@@ -482,7 +562,7 @@ func TestResolveRepositoriesWithUserSearchContext(t *testing.T) {
 	op := search.RepoOptions{
 		SearchContextSpec: "@" + wantName,
 	}
-	repositoryResolver := NewResolver(logtest.Scoped(t), db, nil, nil, nil)
+	repositoryResolver := NewResolver(logtest.Scoped(t), db, nil, nil, nil, nil)
 	resolved, err := repositoryResolver.Resolve(context.Background(), op)
 	if err != nil {
 		t.Fatal(err)
@@ -553,7 +633,7 @@ func TestResolveRepositoriesWithSearchContext(t *testing.T) {
 	op := search.RepoOptions{
 		SearchContextSpec: "searchcontext",
 	}
-	repositoryResolver := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil)
+	repositoryResolver := NewResolver(logtest.Scoped(t), db, gsClient, nil, nil, nil)
 	resolved, err := repositoryResolver.Resolve(context.Background(), op)
 	if err != nil {
 		t.Fatal(err)
@@ -704,7 +784,7 @@ func TestRepoHasFileContent(t *testing.T) {
 				Minimal: tc.matchingRepos,
 			}, nil)
 
-			res := NewResolver(logtest.Scoped(t), db, gitserver.NewMockClient(), endpoint.Static("test"), mockZoekt)
+			res := NewResolver(logtest.Scoped(t), db, gitserver.NewMockClient(), endpoint.Static("test"), mockZoekt, nil)
 			resolved, err := res.Resolve(context.Background(), search.RepoOptions{
 				RepoFilters:    []string{".*"},
 				HasFileContent: tc.filters,
@@ -807,7 +887,7 @@ func TestRepoHasCommitAfter(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			res := NewResolver(logtest.Scoped(t), db, nil, endpoint.Static("test"), nil)
+			res := NewResolver(logtest.Scoped(t), db, nil, endpoint.Static("test"), nil, nil)
 			res.gitserver = mockGitserver
 			resolved, err := res.Resolve(context.Background(), search.RepoOptions{
 				RepoFilters: []string{tc.nameFilter},
@@ -818,3 +898,76 @@ func TestRepoHasCommitAfter(t *testing.T) {
 		})
 	}
 }
+
+func TestEstimate(t *testing.T) {
+	repoA := types.MinimalRepo{ID: 1, Name: "example.com/1"}
+	repoB := types.MinimalRepo{ID: 2, Name: "example.com/2"}
+	repoC := types.MinimalRepo{ID: 3, Name: "example.com/3"}
+	repoD := types.MinimalRepo{ID: 4, Name: "example.com/4"}
+
+	repos := database.NewMockRepoStore()
+	repos.CountFunc.SetDefaultReturn(4, nil)
+	repos.ListMinimalReposFunc.SetDefaultReturn([]types.MinimalRepo{repoA, repoB, repoC, repoD}, nil)
+
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	cases := []struct {
+		name     string
+		op       search.RepoOptions
+		expected Estimate
+	}{{
+		name:     "all repos",
+		op:       search.RepoOptions{RepoFilters: []string{".*"}},
+		expected: Estimate{Repos: 4, Indexed: 2, RevsPerRepo: 1},
+	}, {
+		name:     "revisions",
+		op:       search.RepoOptions{RepoFilters: []string{".*@main:dev:v1"}},
+		expected: Estimate{Repos: 4, Indexed: 2, RevsPerRepo: 3},
+	}, {
+		name:     "ref glob",
+		op:       search.RepoOptions{RepoFilters: []string{".*@*refs/heads/*"}},
+		expected: Estimate{Repos: 4, Indexed: 2},
+	}, {
+		name:     "index:no",
+		op:       search.RepoOptions{RepoFilters: []string{".*"}, UseIndex: query.No},
+		expected: Estimate{Repos: 4, RevsPerRepo: 1},
+	}, {
+		name: "predicates",
+		op: search.RepoOptions{
+			RepoFilters:    []string{".*"},
+			HasFileContent: []query.RepoHasFileContentArgs{{Path: "go.mod"}, {Content: "TODO"}},
+			CommitAfter:    &query.RepoHasCommitAfterArgs{TimeRef: "1 week ago"},
+		},
+		expected: Estimate{
+			Repos:       4,
+			Indexed:     2,
+			RevsPerRepo: 1,
+			FanOut:      []string{"repo:contains.path", "repo:contains.content", "repo:contains.commit.after"},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Only repos A and B are indexed
+			mockZoekt := NewMockStreamer()
+			mockZoekt.ListFunc.SetDefaultReturn(&zoekt.RepoList{
+				Minimal: map[uint32]*zoekt.MinimalRepoListEntry{
+					uint32(repoA.ID): {},
+					uint32(repoB.ID): {},
+				},
+			}, nil)
+
+			res := NewResolver(logtest.Scoped(t), db, gitserver.NewMockClient(), endpoint.Static("test"), mockZoekt, nil)
+			est, err := res.Estimate(context.Background(), tc.op)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, est)
+
+			// Only the sampled repositories are looked up in the index.
+			for _, call := range mockZoekt.ListFunc.History() {
+				want := zoektquery.NewSingleBranchesRepos("HEAD", 1, 2, 3, 4)
+				require.Equal(t, want.String(), call.Arg1.String())
+			}
+		})
+	}
+}
@@ -244,6 +244,10 @@ type Hunk struct {
 	OldCount, NewCount int
 	Header             string
 	Lines              []string
+
+	// Function is the qualified name of the function or class that encloses
+	// the lines changed by the hunk in the post-image, if known.
+	Function string
 }
 
 type PathStatus int
@@ -253,3 +257,161 @@ const (
 	Added
 	Deleted
 )
+
+// ChangedLines returns the inclusive range of 1-based line numbers in the
+// post-image that the hunk changes. For hunks that only remove lines, it is
+// the line following the removal. ok is false if the hunk has no post-image,
+// e.g. because the file was deleted.
+func (h *Hunk) ChangedLines() (start, end int, ok bool) {
+	if h.NewCount == 0 {
+		return 0, 0, false
+	}
+	last := h.NewStart + h.NewCount - 1
+
+	line := h.NewStart
+	for _, l := range h.Lines {
+		if len(l) == 0 {
+			continue
+		}
+		changed := line
+		switch l[0] {
+		case ' ':
+			line++
+			continue
+		case '+':
+			line++
+		case '-':
+			if changed > last {
+				changed = last
+			}
+		}
+		if !ok || changed < start {
+			start = changed
+		}
+		if !ok || changed > end {
+			end = changed
+		}
+		ok = true
+	}
+	return start, end, ok
+}
+
+// RewriteDiffPreview rewrites a diff preview and its parsed diff, as returned by
+// ParseDiffString. Only the hunks for which keep returns true are retained,
+// and files without any retained hunk are dropped. For files without hunks
+// (e.g. renames), keep is called with a hunkIdx of -1. Hunk header lines are
+// rewritten to reflect the Header of each hunk in diff. Matched ranges are
+// adjusted to the rewritten content, and ranges in dropped lines are removed.
+func RewriteDiffPreview(preview *MatchedString, diff []DiffFile, keep func(fileIdx, hunkIdx int) bool) (*MatchedString, []DiffFile) {
+	newDiff := make([]DiffFile, 0, len(diff))
+	keepFiles := make([]bool, len(diff))
+	keepHunks := make([][]bool, len(diff))
+	for i, file := range diff {
+		keepHunks[i] = make([]bool, len(file.Hunks))
+		keepFiles[i] = len(file.Hunks) == 0 && keep(i, -1)
+		newFile := DiffFile{OrigName: file.OrigName, NewName: file.NewName}
+		for j, hunk := range file.Hunks {
+			if keep(i, j) {
+				keepHunks[i][j] = true
+				keepFiles[i] = true
+				newFile.Hunks = append(newFile.Hunks, hunk)
+			}
+		}
+		if keepFiles[i] {
+			newDiff = append(newDiff, newFile)
+		}
+	}
+
+	var (
+		buf strings.Builder
+		// newLines maps the lines of the preview to lines of the rewritten
+		// preview, or -1 for dropped lines.
+		newLines      []int
+		oldLineStarts []int
+		newLineStarts []int
+		lineCount     int
+	)
+
+	emit := func(line string) {
+		newLines = append(newLines, lineCount)
+		newLineStarts = append(newLineStarts, buf.Len())
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+		lineCount++
+	}
+	drop := func() {
+		newLines = append(newLines, -1)
+		newLineStarts = append(newLineStarts, -1)
+	}
+
+	// This mirrors the state machine of ParseDiffString.
+	fileIdx, hunkIdx := -1, -1
+	inHunk := false
+	offset := 0
+	for _, line := range strings.Split(preview.Content, "\n") {
+		oldLineStarts = append(oldLineStarts, offset)
+		offset += len(line) + len("\n")
+
+		switch {
+		case len(line) == 0:
+			drop()
+		case !inHunk || (line[0] != '-' && line[0] != '+' && line[0] != ' ' && line[0] != '@'):
+			// A file line.
+			fileIdx++
+			hunkIdx = -1
+			inHunk = true
+			if fileIdx >= len(diff) || !keepFiles[fileIdx] {
+				drop()
+				continue
+			}
+			emit(line)
+		case line[0] == '@':
+			hunkIdx++
+			if fileIdx >= len(diff) || hunkIdx >= len(keepHunks[fileIdx]) || !keepHunks[fileIdx][hunkIdx] {
+				drop()
+				continue
+			}
+			h := diff[fileIdx].Hunks[hunkIdx]
+			header := fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldCount, h.NewStart, h.NewCount)
+			if h.Header != "" {
+				header += " " + h.Header
+			}
+			emit(header)
+		default:
+			if fileIdx >= len(diff) || hunkIdx < 0 || hunkIdx >= len(keepHunks[fileIdx]) || !keepHunks[fileIdx][hunkIdx] {
+				drop()
+				continue
+			}
+			emit(line)
+		}
+	}
+
+	mapLocation := func(l Location) (Location, bool) {
+		if l.Line < 0 || l.Line >= len(newLines) || newLines[l.Line] < 0 {
+			return Location{}, false
+		}
+		return Location{
+			Offset: newLineStarts[l.Line] + (l.Offset - oldLineStarts[l.Line]),
+			Line:   newLines[l.Line],
+			Column: l.Column,
+		}, true
+	}
+
+	var ranges Ranges
+	for _, r := range preview.MatchedRanges {
+		start, ok := mapLocation(r.Start)
+		if !ok {
+			continue
+		}
+		end, ok := mapLocation(r.End)
+		if !ok {
+			continue
+		}
+		ranges = append(ranges, Range{Start: start, End: end})
+	}
+
+	return &MatchedString{
+		Content:       buf.String(),
+		MatchedRanges: ranges,
+	}, newDiff
+}
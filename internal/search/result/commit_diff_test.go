@@ -62,3 +62,66 @@ func TestCommitDiffMatch(t *testing.T) {
 		"client/web/src/enterprise/codeintel/badge/components/IndexerSummary.module.scss").
 		Equal(t, commitDiff.Path())
 }
+
+func TestHunkChangedLines(t *testing.T) {
+	res, _ := ParseDiffString(input)
+
+	type lines struct {
+		Start, End int
+		Ok         bool
+	}
+	changedLines := func(h Hunk) lines {
+		start, end, ok := h.ChangedLines()
+		return lines{start, end, ok}
+	}
+
+	require.Equal(t, lines{1, 6, true}, changedLines(res[0].Hunks[0]))
+	require.Equal(t, lines{58, 58, true}, changedLines(res[1].Hunks[0]))
+	require.Equal(t, lines{2, 4, true}, changedLines(res[2].Hunks[0]))
+
+	removal := Hunk{OldStart: 3, OldCount: 3, NewStart: 3, NewCount: 2, Lines: []string{" a", "-b", " c"}}
+	require.Equal(t, lines{4, 4, true}, changedLines(removal))
+
+	deletion := Hunk{OldStart: 1, OldCount: 1, NewStart: 0, NewCount: 0, Lines: []string{"-a"}}
+	require.Equal(t, lines{0, 0, false}, changedLines(deletion))
+}
+
+func TestRewriteDiffPreview(t *testing.T) {
+	res, _ := ParseDiffString(input)
+	res[1].Hunks[1].Header = "IndexerSummary"
+
+	// Highlight "Enabled" in the second hunk of the second file, and
+	// "display" in the first and last file.
+	preview := &MatchedString{
+		Content: input,
+		MatchedRanges: Ranges{
+			{Start: Location{Offset: 260, Line: 7, Column: 5}, End: Location{Offset: 267, Line: 7, Column: 12}},
+			{Start: Location{Offset: 1097, Line: 19, Column: 29}, End: Location{Offset: 1104, Line: 19, Column: 36}},
+			{Start: Location{Offset: 1704, Line: 30, Column: 5}, End: Location{Offset: 1711, Line: 30, Column: 12}},
+		},
+	}
+
+	newPreview, newDiff := RewriteDiffPreview(preview, res, func(fileIdx, hunkIdx int) bool {
+		return fileIdx == 1 && hunkIdx == 1
+	})
+
+	autogold.Want("rewritten preview", `client/web/src/enterprise/codeintel/badge/components/IndexerSummary.tsx client/web/src/enterprise/codeintel/badge/components/IndexerSummary.tsx
+@@ -61,3 +61,3 @@ IndexerSummary
+                     {summary.uploads.length + summary.indexes.length > 0 ? (
+-                        <Badge variant="success" className={className}>
++                        <Badge variant="success" small={true} className={className}>
+                             Enabled
+`).Equal(t, newPreview.Content)
+
+	require.Len(t, newDiff, 1)
+	require.Len(t, newDiff[0].Hunks, 1)
+	require.Len(t, newPreview.MatchedRanges, 1)
+	r := newPreview.MatchedRanges[0]
+	require.Equal(t, "Enabled", newPreview.Content[r.Start.Offset:r.End.Offset])
+	require.Equal(t, 5, r.Start.Line)
+
+	// Keeping everything with unchanged headers is the identity.
+	res, _ = ParseDiffString(input)
+	same, _ := RewriteDiffPreview(preview, res, func(int, int) bool { return true })
+	require.Equal(t, preview, same)
+}
@@ -49,6 +49,11 @@ type FileMatch struct {
 
 	LimitHit bool
 
+	// Language is the language of the file as detected by the search backend
+	// from its path and content. It is empty if the backend did not detect
+	// it, in which case the language can be guessed from the file extension.
+	Language string `json:",omitempty"`
+
 	// Debug is optionally set with a debug message explaining the result.
 	//
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
@@ -125,6 +130,9 @@ func (fm *FileMatch) AppendMatches(src *FileMatch) {
 	fm.ChunkMatches = append(fm.ChunkMatches, src.ChunkMatches...)
 	fm.Symbols = append(fm.Symbols, src.Symbols...)
 	fm.LimitHit = fm.LimitHit || src.LimitHit
+	if fm.Language == "" {
+		fm.Language = src.Language
+	}
 }
 
 // Limit will mutate fm such that it only has limit results. limit is a number
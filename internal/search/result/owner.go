@@ -0,0 +1,62 @@
+package result
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch represents an owner of matched files, as defined by the
+// CODEOWNERS file of the repository the files were found in. Owner
+// matches are produced by `select:file.owners`.
+type OwnerMatch struct {
+	// Handle is the handle of the owner without the leading `@`.
+	// Either Handle or Email is set.
+	Handle string
+	Email  string
+
+	// Repo and CommitID identify where the owner was first found.
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+}
+
+func (o *OwnerMatch) RepoName() types.MinimalRepo {
+	return o.Repo
+}
+
+func (o *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (o *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (o *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return o
+	}
+	return nil
+}
+
+// Identifier returns the handle of the owner prefixed with `@` or its email.
+func (o *OwnerMatch) Identifier() string {
+	if o.Handle != "" {
+		return "@" + o.Handle
+	}
+	return o.Email
+}
+
+func (o *OwnerMatch) Key() Key {
+	// Owners are distinct across repositories, so the key intentionally
+	// does not contain the repository.
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    strings.ToLower(o.Identifier()),
+	}
+}
+
+func (o *OwnerMatch) searchResultMarker() {}
@@ -63,6 +63,8 @@ func Search(
 			ExcludePattern:               p.ExcludePattern,
 			IncludePatterns:              p.IncludePatterns,
 			Languages:                    p.Languages,
+			ExcludeLanguages:             p.ExcludeLanguages,
+			ContentBasedLangFilters:      p.ContentBasedLangFilters,
 			CombyRule:                    p.CombyRule,
 			Select:                       p.Select.Root(),
 			Limit:                        int(p.FileMatchLimit),
@@ -74,6 +76,8 @@ func Search(
 			IsNegated:                    p.IsNegated,
 			PatternMatchesContent:        p.PatternMatchesContent,
 			PatternMatchesPath:           p.PatternMatchesPath,
+			NearPattern:                  p.NearPattern,
+			NearDistance:                 p.NearDistance,
 		},
 		Indexed:      indexed,
 		FetchTimeout: fetchTimeout.String(),
@@ -269,6 +269,7 @@ func convertMatches(repo types.MinimalRepo, commit api.CommitID, rev *string, se
 			ChunkMatches: chunkMatches,
 			PathMatches:  pathMatches,
 			LimitHit:     fm.LimitHit,
+			Language:     fm.Language,
 		})
 	}
 	return matches
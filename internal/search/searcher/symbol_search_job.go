@@ -119,14 +119,15 @@ func searchInRepo(ctx context.Context, db database.DB, repoRevs *search.Reposito
 	}
 	span.SetTag("commit", string(commitID))
 
+	includePatterns, excludePattern := patternInfo.PathPatterns()
 	symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
 		Repo:            repoRevs.Repo.Name,
 		CommitID:        commitID,
 		Query:           patternInfo.Pattern,
 		IsCaseSensitive: patternInfo.IsCaseSensitive,
 		IsRegExp:        patternInfo.IsRegExp,
-		IncludePatterns: patternInfo.IncludePatterns,
-		ExcludePattern:  patternInfo.ExcludePattern,
+		IncludePatterns: includePatterns,
+		ExcludePattern:  excludePattern,
 		// Ask for limit + 1 so we can detect whether there are more results than the limit.
 		First: limit + 1,
 	})
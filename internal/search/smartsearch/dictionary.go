@@ -0,0 +1,309 @@
+package smartsearch
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/zoekt"
+	zoektquery "github.com/sourcegraph/zoekt/query"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// dictionary is a set of known words (identifiers and search terms) with a
+// frequency for each. It is used to suggest spelling corrections for query
+// terms.
+type dictionary struct {
+	words map[string]int
+}
+
+func newDictionary() *dictionary {
+	return &dictionary{words: map[string]int{}}
+}
+
+func (d *dictionary) add(word string, count int) {
+	d.words[word] += count
+}
+
+// correct returns the word in the dictionary closest to term, if there is one
+// within a small edit distance of term. Comparison is case-insensitive, and
+// the returned word has the casing it has in the dictionary. Short terms are
+// never corrected, and ties are broken by frequency.
+func (d *dictionary) correct(term string) (string, bool) {
+	maxDist := maxEditDistance(term)
+	if maxDist == 0 {
+		return "", false
+	}
+
+	lowerTerm := strings.ToLower(term)
+	best, bestDist, bestCount := "", maxDist+1, 0
+	for word, count := range d.words {
+		lowerWord := strings.ToLower(word)
+		if lowerWord == lowerTerm {
+			// The term is a known word, so there is nothing to correct.
+			return "", false
+		}
+		if abs(len(lowerWord)-len(lowerTerm)) > maxDist {
+			continue
+		}
+		dist := editDistance(lowerTerm, lowerWord)
+		if dist < bestDist || (dist == bestDist && (count > bestCount || (count == bestCount && word < best))) {
+			best, bestDist, bestCount = word, dist, count
+		}
+	}
+	return best, best != ""
+}
+
+// maxEditDistance returns the maximum edit distance we tolerate for a
+// correction of term. The longer the term, the more typos we allow.
+func maxEditDistance(term string) int {
+	switch n := len(term); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions and transpositions of
+// adjacent bytes needed to turn a into b.
+func editDistance(a, b string) int {
+	// d[i][j] is the distance between a[:i] and b[:j].
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				if t := d[i-2][j-2] + 1; t < d[i][j] {
+					d[i][j] = t
+				}
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// splitIdentifier splits a camelCase, PascalCase or snake_case identifier into
+// its parts, e.g., getHTTPResponse_code -> [get HTTP Response code].
+func splitIdentifier(s string) []string {
+	var parts []string
+	runes := []rune(s)
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			parts = append(parts, string(runes[start:end]))
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			// Split before an upper case letter that follows a lower
+			// case letter or a digit (getUser), or that starts a new
+			// word after an acronym (HTTPResponse).
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush(i)
+				start = i
+			}
+		}
+	}
+	flush(len(runes))
+	return parts
+}
+
+// correctableTerms returns the literal, non-negated pattern values in b that
+// are candidates for spelling correction.
+func correctableTerms(b query.Basic) []string {
+	var terms []string
+	query.VisitPattern(b.ToParseTree(), func(value string, negated bool, annotation query.Annotation) {
+		if negated || annotation.Labels.IsSet(query.Regexp) || annotation.Labels.IsSet(query.Quoted) {
+			return
+		}
+		if !identifierRegexp.MatchString(value) || maxEditDistance(value) == 0 {
+			return
+		}
+		terms = append(terms, value)
+	})
+	return terms
+}
+
+// symbolFragments returns the substrings of term we look up in the symbol
+// index to find candidate corrections. A misspelled identifier typically
+// still contains some of the parts of the intended identifier verbatim. For
+// terms that consist of a single part, we use both halves of the term, since
+// a single typo leaves at least one of them intact.
+func symbolFragments(term string) []string {
+	var fragments []string
+	parts := splitIdentifier(term)
+	if len(parts) == 1 {
+		half := len(term) / 2
+		parts = []string{term[:half], term[half:]}
+	}
+	for _, p := range parts {
+		if len(p) >= 3 {
+			fragments = append(fragments, p)
+		}
+	}
+	return fragments
+}
+
+const (
+	// maxSymbolCandidates bounds the number of symbols we consider per
+	// lookup in the symbol index.
+	maxSymbolCandidates = 500
+	// symbolLookupTimeout bounds the time we spend looking up candidates in
+	// the symbol index.
+	symbolLookupTimeout = 2 * time.Second
+)
+
+// addSymbols adds the names of indexed symbols that share a fragment with any
+// of terms to the dictionary. Symbols are looked up across the whole index.
+// This does not leak symbols of repositories that the user cannot access:
+// corrected queries are only suggested if they find results when run with
+// the permissions of the user.
+func (d *dictionary) addSymbols(ctx context.Context, searcher zoekt.Searcher, terms []string) error {
+	var ors []zoektquery.Q
+	for _, term := range terms {
+		for _, fragment := range symbolFragments(term) {
+			ors = append(ors, &zoektquery.Symbol{Expr: &zoektquery.Substring{Pattern: fragment, Content: true}})
+		}
+	}
+	if len(ors) == 0 {
+		return nil
+	}
+
+	resp, err := searcher.Search(ctx, zoektquery.Simplify(zoektquery.NewOr(ors...)), &zoekt.SearchOptions{
+		MaxWallTime:        symbolLookupTimeout,
+		ShardMaxMatchCount: maxSymbolCandidates,
+		TotalMaxMatchCount: maxSymbolCandidates,
+		MaxDocDisplayCount: maxSymbolCandidates,
+		ChunkMatches:       true,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, file := range resp.Files {
+		for _, l := range file.LineMatches {
+			for _, m := range l.LineFragments {
+				if m.SymbolInfo != nil {
+					d.add(m.SymbolInfo.Sym, 1)
+				}
+			}
+		}
+		for _, cm := range file.ChunkMatches {
+			for _, si := range cm.SymbolInfo {
+				if si != nil {
+					d.add(si.Sym, 1)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// maxPopularTerms bounds the number of search terms we remember.
+const maxPopularTerms = 10000
+
+// termCounter counts how often search terms were searched for successfully
+// (i.e., found results). It is safe for concurrent use.
+type termCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// popularTerms are the terms of smart searches that found results on this
+// instance.
+var popularTerms = &termCounter{counts: map[string]int{}}
+
+func (c *termCounter) record(terms []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, term := range terms {
+		if _, ok := c.counts[term]; !ok && len(c.counts) >= maxPopularTerms {
+			c.decay()
+		}
+		c.counts[term]++
+	}
+}
+
+// decay halves all counts and forgets terms whose count drops to zero, which
+// makes room for new terms while keeping the frequently searched ones.
+func (c *termCounter) decay() {
+	for term, count := range c.counts {
+		if count <= 1 {
+			delete(c.counts, term)
+		} else {
+			c.counts[term] = count / 2
+		}
+	}
+}
+
+func (c *termCounter) addTo(d *dictionary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for term, count := range c.counts {
+		d.add(term, count)
+	}
+}
+
+// newSpellingDictionary builds a dictionary for correcting the terms of
+// plan from popular search terms and, if searcher is non-nil, the identifiers
+// of indexed symbols.
+func newSpellingDictionary(ctx context.Context, searcher zoekt.Searcher, plan query.Plan) (*dictionary, error) {
+	var terms []string
+	for _, b := range plan {
+		terms = append(terms, correctableTerms(b)...)
+	}
+	d := newDictionary()
+	if len(terms) == 0 {
+		return d, nil
+	}
+	popularTerms.addTo(d)
+	if searcher == nil {
+		return d, nil
+	}
+	return d, d.addSymbols(ctx, searcher, terms)
+}
+
+// identifierRegexp matches values that look like identifiers.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
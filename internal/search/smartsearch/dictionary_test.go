@@ -0,0 +1,131 @@
+package smartsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"receive", "receive", 0},
+		{"recieve", "receive", 1}, // transposition
+		{"recive", "receive", 1},  // deletion
+		{"getusrname", "getusername", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tc := range cases {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	cases := map[string][]string{
+		"getUserName":          {"get", "User", "Name"},
+		"GetUserName":          {"Get", "User", "Name"},
+		"get_user_name":        {"get", "user", "name"},
+		"getHTTPResponse_code": {"get", "HTTP", "Response", "code"},
+		"parse2Int":            {"parse2", "Int"},
+		"username":             {"username"},
+	}
+	for input, want := range cases {
+		if diff := cmp.Diff(want, splitIdentifier(input)); diff != "" {
+			t.Errorf("splitIdentifier(%q) mismatch (-want +got):\n%s", input, diff)
+		}
+	}
+}
+
+func TestDictionary_correct(t *testing.T) {
+	d := newDictionary()
+	d.add("getUserName", 1)
+	d.add("getUserNames", 5)
+	d.add("NewServer", 1)
+	d.add("foo", 1)
+
+	cases := []struct {
+		term   string
+		want   string
+		wantOk bool
+	}{
+		{term: "getUsrName", want: "getUserName", wantOk: true},
+		{term: "getUserNamse", want: "getUserNames", wantOk: true},
+		{term: "newservr", want: "NewServer", wantOk: true},
+		{term: "getusername", wantOk: false}, // known word
+		{term: "fooBar", wantOk: false},      // too far from any word
+		{term: "fo", wantOk: false},          // too short
+	}
+	for _, tc := range cases {
+		got, ok := d.correct(tc.term)
+		if got != tc.want || ok != tc.wantOk {
+			t.Errorf("correct(%q) = (%q, %t), want (%q, %t)", tc.term, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
+
+func TestTermCounter(t *testing.T) {
+	c := &termCounter{counts: map[string]int{}}
+	for i := 0; i < maxPopularTerms; i++ {
+		c.record([]string{"frequent"})
+	}
+	for i := 0; i < maxPopularTerms; i++ {
+		c.record([]string{string(rune('a'+i%26)) + string(rune(i))})
+	}
+	if len(c.counts) > maxPopularTerms {
+		t.Fatalf("expected at most %d terms, got %d", maxPopularTerms, len(c.counts))
+	}
+	if c.counts["frequent"] == 0 {
+		t.Fatal("expected frequent term to be retained")
+	}
+}
+
+func TestNewSpellingDictionary(t *testing.T) {
+	searcher := &backend.FakeSearcher{Result: &zoekt.SearchResult{
+		Files: []zoekt.FileMatch{{
+			FileName: "user.go",
+			ChunkMatches: []zoekt.ChunkMatch{{
+				Ranges:     []zoekt.Range{{}},
+				SymbolInfo: []*zoekt.Symbol{{Sym: "getUserName"}},
+			}},
+			LineMatches: []zoekt.LineMatch{{
+				LineFragments: []zoekt.LineFragmentMatch{{SymbolInfo: &zoekt.Symbol{Sym: "setUserName"}}},
+			}},
+		}},
+	}}
+
+	plan, err := query.Pipeline(query.Init("getUsrName", query.SearchTypeStandard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := newSpellingDictionary(context.Background(), searcher, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := d.correct("getUsrName"); got != "getUserName" {
+		t.Fatalf("expected correction getUserName, got %q", got)
+	}
+}
+
+func TestSymbolFragments(t *testing.T) {
+	cases := map[string][]string{
+		"getUsrName": {"get", "Usr", "Name"},
+		"recieve":    {"rec", "ieve"},
+		"ab_cdef":    {"cdef"},
+	}
+	for input, want := range cases {
+		if diff := cmp.Diff(want, symbolFragments(input)); diff != "" {
+			t.Errorf("symbolFragments(%q) mismatch (-want +got):\n%s", input, diff)
+		}
+	}
+}
@@ -54,6 +54,10 @@ var rulesWiden = []rule{
 		description: "AND patterns together",
 		transform:   []transform{unorderedPatterns},
 	},
+	{
+		description: "expand identifier variants",
+		transform:   []transform{identifierPatterns},
+	},
 }
 
 // unquotePatterns is a rule that unquotes all patterns in the input query (it
@@ -285,6 +289,119 @@ func mapConcat(q []query.Node) ([]query.Node, bool) {
 	return mapped, changed
 }
 
+// identifierPatterns converts literal patterns that are camelCase or
+// snake_case identifiers into regular expression patterns that match any
+// variant of the identifier, e.g., getUserName -> /get_?user_?name/ matches
+// getUserName, GetUserName, and get_user_name. It relies on search being case
+// insensitive and does not apply to case sensitive queries.
+func identifierPatterns(b query.Basic) *query.Basic {
+	if b.Parameters.IsCaseSensitive() {
+		return nil
+	}
+
+	rawParseTree, err := query.Parse(query.StringHuman(b.ToParseTree()), query.SearchTypeStandard)
+	if err != nil {
+		return nil
+	}
+
+	changed := false
+	newParseTree := query.MapPattern(rawParseTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+		if annotation.Labels.IsSet(query.Regexp) || annotation.Labels.IsSet(query.Quoted) || !identifierRegexp.MatchString(value) {
+			return query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+		}
+
+		parts := splitIdentifier(value)
+		if len(parts) < 2 {
+			return query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+		}
+
+		for i, p := range parts {
+			parts[i] = regexp.QuoteMeta(strings.ToLower(p))
+		}
+
+		changed = true
+		annotation.Labels.Unset(query.Literal)
+		annotation.Labels.Set(query.Regexp)
+		return query.Pattern{
+			Value:      strings.Join(parts, "_?"),
+			Negated:    negated,
+			Annotation: annotation,
+		}
+	})
+
+	if !changed {
+		return nil
+	}
+
+	newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+	if err != nil {
+		return nil
+	}
+
+	newBasic, err := query.ToBasicQuery(newNodes)
+	if err != nil {
+		return nil
+	}
+
+	return &newBasic
+}
+
+// correctSpelling returns a rule that replaces literal patterns that are
+// likely misspellings of a word in the dictionary d with that word.
+func correctSpelling(d *dictionary) transform {
+	return func(b query.Basic) *query.Basic {
+		rawParseTree, err := query.Parse(query.StringHuman(b.ToParseTree()), query.SearchTypeStandard)
+		if err != nil {
+			return nil
+		}
+
+		changed := false
+		newParseTree := query.MapPattern(rawParseTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+			if negated || annotation.Labels.IsSet(query.Regexp) || annotation.Labels.IsSet(query.Quoted) || !identifierRegexp.MatchString(value) {
+				return query.Pattern{
+					Value:      value,
+					Negated:    negated,
+					Annotation: annotation,
+				}
+			}
+
+			if corrected, ok := d.correct(value); ok {
+				changed = true
+				value = corrected
+			}
+			return query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			}
+		})
+
+		if !changed {
+			return nil
+		}
+
+		newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+		if err != nil {
+			return nil
+		}
+
+		newBasic, err := query.ToBasicQuery(newNodes)
+		if err != nil {
+			return nil
+		}
+
+		return &newBasic
+	}
+}
+
 var symbolTypes = map[string]string{
 	"function":       "function",
 	"func":           "function",
@@ -165,3 +165,48 @@ func Test_patternsToCodeHostFilters(t *testing.T) {
 		})
 	}
 }
+
+func Test_identifierPatterns(t *testing.T) {
+	rule := []transform{identifierPatterns}
+	test := func(input string) string {
+		return apply(input, rule)
+	}
+
+	cases := []string{
+		`getUserName`,
+		`context:global parse get_user_name`,
+		`HTTPResponseCode`,
+		`case:yes getUserName`,
+		`username`,
+	}
+
+	for _, c := range cases {
+		t.Run("identifier patterns", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
+
+func Test_correctSpelling(t *testing.T) {
+	d := newDictionary()
+	d.add("getUserName", 1)
+	d.add("receive", 1)
+	rule := []transform{correctSpelling(d)}
+	test := func(input string) string {
+		return apply(input, rule)
+	}
+
+	cases := []string{
+		`getUsrName`,
+		`context:global recieve -file:test`,
+		`"recieve"`,
+		`getUserName`,
+		`nothing like it`,
+	}
+
+	for _, c := range cases {
+		t.Run("correct spelling", func(t *testing.T) {
+			autogold.Equal(t, autogold.Raw(test(c)))
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/opentracing/opentracing-go/log"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/sourcegraph/log"
+	"github.com/sourcegraph/zoekt"
 
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	alertobserver "github.com/sourcegraph/sourcegraph/internal/search/alert"
@@ -56,6 +58,7 @@ func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *Feel
 		initialJob:      initialJob,
 		generators:      generators,
 		newGeneratedJob: newGeneratedJob,
+		plan:            plan,
 	}
 }
 
@@ -67,6 +70,10 @@ type FeelingLuckySearchJob struct {
 	initialJob      job.Job
 	generators      []next
 	newGeneratedJob func(*autoQuery) job.Job
+
+	// plan is the original query. Rules that depend on runtime data, like
+	// spelling correction, create generators for it when the job runs.
+	plan query.Plan
 }
 
 // Do not run autogenerated queries if RESULT_THRESHOLD results exist on the original query.
@@ -88,6 +95,11 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 	maxAlerter.Add(alert)
 
 	originalResultSetSize := stream.Count()
+	if originalResultSetSize > 0 {
+		for _, b := range f.plan {
+			popularTerms.record(correctableTerms(b))
+		}
+	}
 	if originalResultSetSize >= RESULT_THRESHOLD {
 		return alert, err
 	}
@@ -108,7 +120,7 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 	}
 	generated := &alertobserver.ErrLuckyQueries{Type: luckyAlertType, ProposedQueries: []*search.QueryDescription{}}
 	var autoQ *autoQuery
-	for _, next := range f.generators {
+	for _, next := range append(f.generators, f.spellingGenerators(ctx, clients)...) {
 		for next != nil {
 			autoQ, next = next()
 			j := f.newGeneratedJob(autoQ)
@@ -147,11 +159,41 @@ func (f *FeelingLuckySearchJob) Run(ctx context.Context, clients job.RuntimeClie
 	return maxAlerter.Alert, errs
 }
 
+// spellingGenerators returns generators for queries in which misspelled
+// patterns are corrected. The dictionary of known words is built from
+// popular search terms and indexed symbols, and so depends on runtime
+// clients.
+func (f *FeelingLuckySearchJob) spellingGenerators(ctx context.Context, clients job.RuntimeClients) []next {
+	if len(f.plan) == 0 {
+		return nil
+	}
+
+	var searcher zoekt.Searcher
+	if clients.Zoekt != nil {
+		searcher = clients.Zoekt
+	}
+	d, err := newSpellingDictionary(ctx, searcher, f.plan)
+	if err != nil && clients.Logger != nil {
+		// We still suggest corrections from popular search terms.
+		clients.Logger.Warn("failed to look up symbols for spelling correction", log.Error(err))
+	}
+
+	rules := []rule{{
+		description: "correct spelling",
+		transform:   []transform{correctSpelling(d)},
+	}}
+	generators := make([]next, 0, len(f.plan))
+	for _, b := range f.plan {
+		generators = append(generators, NewGenerator(b, nil, rules))
+	}
+	return generators
+}
+
 func (f *FeelingLuckySearchJob) Name() string {
 	return "FeelingLuckySearchJob"
 }
 
-func (f *FeelingLuckySearchJob) Fields(job.Verbosity) []log.Field { return nil }
+func (f *FeelingLuckySearchJob) Fields(job.Verbosity) []otlog.Field { return nil }
 
 func (f *FeelingLuckySearchJob) Children() []job.Describer {
 	return []job.Describer{f.initialJob}
@@ -200,7 +242,7 @@ func (g *generatedSearchJob) Name() string {
 
 func (g *generatedSearchJob) Children() []job.Describer { return []job.Describer{g.Child} }
 
-func (g *generatedSearchJob) Fields(job.Verbosity) []log.Field { return nil }
+func (g *generatedSearchJob) Fields(job.Verbosity) []otlog.Field { return nil }
 
 func (g *generatedSearchJob) MapChildren(fn job.MapFunc) job.Job {
 	cp := *g
@@ -93,17 +93,19 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		}
 	}
 
-	addLangFilter := func(fileMatchPath string, lineMatchCount int32, limitHit bool) {
-		if ext := path.Ext(fileMatchPath); ext != "" {
-			rawLanguage, _ := inventory.GetLanguageByFilename(fileMatchPath)
-			language := strings.ToLower(rawLanguage)
-			if language != "" {
-				if strings.Contains(language, " ") {
-					language = strconv.Quote(language)
-				}
-				value := fmt.Sprintf(`lang:%s`, language)
-				s.filters.Add(value, rawLanguage, lineMatchCount, limitHit, "lang")
+	// rawLanguage is the language detected by the search backend, if any.
+	// Otherwise we guess the language from the file extension.
+	addLangFilter := func(fileMatchPath, rawLanguage string, lineMatchCount int32, limitHit bool) {
+		if ext := path.Ext(fileMatchPath); rawLanguage == "" && ext != "" {
+			rawLanguage, _ = inventory.GetLanguageByFilename(fileMatchPath)
+		}
+		language := strings.ToLower(rawLanguage)
+		if language != "" {
+			if strings.Contains(language, " ") {
+				language = strconv.Quote(language)
 			}
+			value := fmt.Sprintf(`lang:%s`, language)
+			s.filters.Add(value, rawLanguage, lineMatchCount, limitHit, "lang")
 		}
 	}
 
@@ -125,7 +127,7 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			}
 			lines := int32(v.ResultCount())
 			addRepoFilter(v.Repo.Name, v.Repo.ID, rev, lines)
-			addLangFilter(v.Path, lines, v.LimitHit)
+			addLangFilter(v.Path, v.Language, lines, v.LimitHit)
 			addFileFilter(v.Path, lines, v.LimitHit)
 		case *result.RepoMatch:
 			// It should be fine to leave this blank since revision specifiers
@@ -136,6 +138,9 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			// We leave "rev" empty, instead of using "CommitMatch.Commit.ID". This way we
 			// get 1 filter per repo instead of 1 filter per sha in the side-bar.
 			addRepoFilter(v.Repo.Name, v.Repo.ID, "", int32(v.ResultCount()))
+		case *result.OwnerMatch:
+			// Owners are deduplicated across repositories, so they don't
+			// contribute to repository filters.
 		}
 	}
 }
@@ -69,6 +69,45 @@ func TestSearchFiltersUpdate(t *testing.T) {
 			wantFilterKind:  "repo",
 			wantFilterCount: 2,
 		},
+		{
+			name: "FileMatch, lang: filter from extension",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.FileMatch{
+							File: result.File{
+								Repo: repo,
+								Path: "main.go",
+							},
+							ChunkMatches: result.ChunkMatches{{Ranges: make(result.Ranges, 2)}},
+						},
+					},
+				},
+			},
+			wantFilterName:  "lang:go",
+			wantFilterKind:  "lang",
+			wantFilterCount: 2,
+		},
+		{
+			name: "FileMatch, lang: filter from detected language",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.FileMatch{
+							File: result.File{
+								Repo: repo,
+								Path: "bin/deploy",
+							},
+							ChunkMatches: result.ChunkMatches{{Ranges: make(result.Ranges, 1)}},
+							Language:     "Python",
+						},
+					},
+				},
+			},
+			wantFilterName:  "lang:python",
+			wantFilterKind:  "lang",
+			wantFilterCount: 1,
+		},
 	}
 
 	for _, c := range cases {
@@ -216,6 +216,20 @@ type TextPatternInfo struct {
 	PatternMatchesPath    bool
 
 	Languages []string
+
+	// ExcludeLanguages is the languages passed via negated lang filters.
+	ExcludeLanguages []string `json:",omitempty"`
+
+	// ContentBasedLangFilters is true if the lang filters are not part of
+	// IncludePatterns and ExcludePattern. Instead, the language of each file
+	// is detected from its path and content and matched against Languages
+	// and ExcludeLanguages.
+	ContentBasedLangFilters bool `json:",omitempty"`
+
+	// NearPattern is set for a NEAR/n query. Files only match if Pattern
+	// and NearPattern match within NearDistance lines of each other.
+	NearPattern  string `json:",omitempty"`
+	NearDistance int    `json:",omitempty"`
 }
 
 func (p *TextPatternInfo) Fields() []otlog.Field {
@@ -266,9 +280,41 @@ func (p *TextPatternInfo) Fields() []otlog.Field {
 	if len(p.Languages) > 0 {
 		add(trace.Strings("languages", p.Languages))
 	}
+	if len(p.ExcludeLanguages) > 0 {
+		add(trace.Strings("excludeLanguages", p.ExcludeLanguages))
+	}
+	if p.ContentBasedLangFilters {
+		add(otlog.Bool("contentBasedLangFilters", p.ContentBasedLangFilters))
+	}
+	if p.NearPattern != "" {
+		add(otlog.String("nearPattern", p.NearPattern))
+		add(otlog.Int("nearDistance", p.NearDistance))
+	}
 	return res
 }
 
+// PathPatterns returns IncludePatterns and ExcludePattern. If
+// ContentBasedLangFilters is true, the lang filters are added to them as path
+// patterns. It is used by backends which cannot detect the language of a file
+// from its content.
+func (p *TextPatternInfo) PathPatterns() (include []string, exclude string) {
+	if !p.ContentBasedLangFilters {
+		return p.IncludePatterns, p.ExcludePattern
+	}
+	include = append([]string{}, p.IncludePatterns...)
+	for _, lang := range p.Languages {
+		include = append(include, query.LangToFileRegexp(lang))
+	}
+	var excludes []string
+	if p.ExcludePattern != "" {
+		excludes = append(excludes, p.ExcludePattern)
+	}
+	for _, lang := range p.ExcludeLanguages {
+		excludes = append(excludes, query.LangToFileRegexp(lang))
+	}
+	return include, query.UnionRegExps(excludes)
+}
+
 func (p *TextPatternInfo) String() string {
 	args := []string{fmt.Sprintf("%q", p.Pattern)}
 	if p.IsRegExp {
@@ -299,6 +345,15 @@ func (p *TextPatternInfo) String() string {
 	for _, lang := range p.Languages {
 		args = append(args, fmt.Sprintf("lang:%s", lang))
 	}
+	for _, lang := range p.ExcludeLanguages {
+		args = append(args, fmt.Sprintf("-lang:%s", lang))
+	}
+	if p.ContentBasedLangFilters {
+		args = append(args, "langfromcontent")
+	}
+	if p.NearPattern != "" {
+		args = append(args, fmt.Sprintf("near/%d:%q", p.NearDistance, p.NearPattern))
+	}
 
 	path := "f"
 	if p.PathPatternsAreCaseSensitive {
@@ -382,6 +437,14 @@ type RepoOptions struct {
 	HasFileContent []query.RepoHasFileContentArgs
 	HasKVPs        []query.RepoKVPFilter
 
+	// DependsOn restricts the search to repositories whose lockfiles do (or,
+	// if negated, don't) reference the given packages.
+	DependsOn []query.RepoDependsOnPredicate
+
+	// HasLanguages restricts the search to repositories with (or, if
+	// negated, without) a minimum share of code in the given languages.
+	HasLanguages []query.RepoLanguageFilter
+
 	// ForkSet indicates whether `fork:` was set explicitly in the query,
 	// or whether the values were set from defaults.
 	ForkSet   bool
@@ -390,6 +453,11 @@ type RepoOptions struct {
 
 	OnlyCloned bool
 
+	// OnlyCodeowners and NoCodeowners restrict the search to repositories
+	// with or without a valid CODEOWNERS file.
+	OnlyCodeowners bool
+	NoCodeowners   bool
+
 	// ArchivedSet indicates whether `archived:` was set explicitly in the query,
 	// or whether the values were set from defaults.
 	ArchivedSet  bool
@@ -464,6 +532,35 @@ func (op *RepoOptions) Tags() []otlog.Field {
 			add(trace.Scoped(fmt.Sprintf("hasKVPs[%d]", i), nondefault...))
 		}
 	}
+	if len(op.DependsOn) > 0 {
+		for i, arg := range op.DependsOn {
+			nondefault := []otlog.Field{
+				otlog.String("ecosystem", arg.Ecosystem),
+				otlog.String("package", arg.Package),
+			}
+			for j, c := range arg.Constraints {
+				nondefault = append(nondefault, otlog.String(fmt.Sprintf("constraints[%d]", j), c.String()))
+			}
+			if arg.Negated {
+				nondefault = append(nondefault, otlog.Bool("negated", arg.Negated))
+			}
+			add(trace.Scoped(fmt.Sprintf("dependsOn[%d]", i), nondefault...))
+		}
+	}
+	if len(op.HasLanguages) > 0 {
+		for i, arg := range op.HasLanguages {
+			nondefault := []otlog.Field{
+				otlog.String("language", arg.Language),
+			}
+			if arg.MinPercent != 0 {
+				nondefault = append(nondefault, otlog.Float64("minPercent", arg.MinPercent))
+			}
+			if arg.Negated {
+				nondefault = append(nondefault, otlog.Bool("negated", arg.Negated))
+			}
+			add(trace.Scoped(fmt.Sprintf("hasLanguages[%d]", i), nondefault...))
+		}
+	}
 	if op.ForkSet {
 		add(otlog.Bool("forkSet", op.ForkSet))
 	}
@@ -476,6 +573,12 @@ func (op *RepoOptions) Tags() []otlog.Field {
 	if op.OnlyCloned {
 		add(otlog.Bool("onlyCloned", op.OnlyCloned))
 	}
+	if op.OnlyCodeowners {
+		add(otlog.Bool("onlyCodeowners", op.OnlyCodeowners))
+	}
+	if op.NoCodeowners {
+		add(otlog.Bool("noCodeowners", op.NoCodeowners))
+	}
 	if op.ArchivedSet {
 		add(otlog.Bool("archivedSet", op.ArchivedSet))
 	}
@@ -541,6 +644,30 @@ func (op *RepoOptions) String() string {
 		}
 	}
 
+	if len(op.DependsOn) > 0 {
+		for i, arg := range op.DependsOn {
+			fmt.Fprintf(&b, "DependsOn[%d].package: %s:%s\n", i, arg.Ecosystem, arg.Package)
+			for j, c := range arg.Constraints {
+				fmt.Fprintf(&b, "DependsOn[%d].constraints[%d]: %s\n", i, j, c)
+			}
+			if arg.Negated {
+				fmt.Fprintf(&b, "DependsOn[%d].negated: %t\n", i, arg.Negated)
+			}
+		}
+	}
+
+	if len(op.HasLanguages) > 0 {
+		for i, arg := range op.HasLanguages {
+			fmt.Fprintf(&b, "HasLanguages[%d].language: %s\n", i, arg.Language)
+			if arg.MinPercent != 0 {
+				fmt.Fprintf(&b, "HasLanguages[%d].minPercent: %g\n", i, arg.MinPercent)
+			}
+			if arg.Negated {
+				fmt.Fprintf(&b, "HasLanguages[%d].negated: %t\n", i, arg.Negated)
+			}
+		}
+	}
+
 	if op.CaseSensitiveRepoFilters {
 		fmt.Fprintf(&b, "CaseSensitiveRepoFilters: %t\n", op.CaseSensitiveRepoFilters)
 	}
@@ -556,6 +683,12 @@ func (op *RepoOptions) String() string {
 	if op.OnlyCloned {
 		fmt.Fprintf(&b, "OnlyCloned: %t\n", op.OnlyCloned)
 	}
+	if op.OnlyCodeowners {
+		fmt.Fprintf(&b, "OnlyCodeowners: %t\n", op.OnlyCodeowners)
+	}
+	if op.NoCodeowners {
+		fmt.Fprintf(&b, "NoCodeowners: %t\n", op.NoCodeowners)
+	}
 	if op.ArchivedSet {
 		fmt.Fprintf(&b, "ArchivedSet: %t\n", op.ArchivedSet)
 	}
@@ -401,6 +401,7 @@ func sendMatches(event *zoekt.SearchResult, pathRegexps []*regexp.Regexp, getRep
 				ChunkMatches: hms,
 				Symbols:      symbols,
 				PathMatches:  pathMatches,
+				Language:     file.Language,
 				File: result.File{
 					InputRev: &inputRev,
 					CommitID: api.CommitID(file.Version),
@@ -32,10 +32,15 @@ func QueryToZoektQuery(b query.Basic, resultTypes result.Types, feat *search.Fea
 
 	// Handle file: and -file: filters.
 	filesInclude, filesExclude := b.IncludeExcludeValues(query.FieldFile)
-	// Handle lang: and -lang: filters.
+	// Handle lang: and -lang: filters. Zoekt detects the language of each
+	// file from its path and content when indexing, so with content based
+	// lang filters we match that instead of the file extension.
 	langInclude, langExclude := b.IncludeExcludeValues(query.FieldLang)
-	filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
-	filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	contentBasedLangFilters := len(langInclude)+len(langExclude) > 0 && feat.ContentBasedLangFilters
+	if !contentBasedLangFilters {
+		filesInclude = append(filesInclude, mapSlice(langInclude, query.LangToFileRegexp)...)
+		filesExclude = append(filesExclude, mapSlice(langExclude, query.LangToFileRegexp)...)
+	}
 
 	var and []zoekt.Q
 	if q != nil {
@@ -68,26 +73,31 @@ func QueryToZoektQuery(b query.Basic, resultTypes result.Types, feat *search.Fea
 		and = append(and, zoekt.NewAnd(repoHasFilters...))
 	}
 
-	// Languages are already partially expressed with IncludePatterns, but Zoekt creates
-	// more precise language metadata based on file contents analyzed by go-enry, so it's
-	// useful to pass lang: queries down.
-	//
-	// Currently, negated lang queries create filename-based ExcludePatterns that cannot be
-	// corrected by the more precise language metadata. If this is a problem, indexed search
-	// queries should have a special query converter that produces *only* Language predicates
-	// instead of filepatterns.
-	if len(langInclude) > 0 && feat.ContentBasedLangFilters {
-		or := &zoekt.Or{}
+	// Like file: filters, a file must be in every included language and in
+	// none of the excluded languages.
+	if contentBasedLangFilters {
 		for _, lang := range langInclude {
-			lang, _ = enry.GetLanguageByAlias(lang) // Invariant: lang is valid.
-			or.Children = append(or.Children, &zoekt.Language{Language: lang})
+			and = append(and, languageQuery(lang))
+		}
+		if len(langExclude) > 0 {
+			excluded := make([]zoekt.Q, 0, len(langExclude))
+			for _, lang := range langExclude {
+				excluded = append(excluded, languageQuery(lang))
+			}
+			and = append(and, &zoekt.Not{Child: zoekt.NewOr(excluded...)})
 		}
-		and = append(and, or)
 	}
 
 	return zoekt.Simplify(zoekt.NewAnd(and...)), nil
 }
 
+// languageQuery returns a query matching the files zoekt detected to be in
+// the language with the given alias.
+func languageQuery(alias string) zoekt.Q {
+	lang, _ := enry.GetLanguageByAlias(alias) // Invariant: lang is valid.
+	return &zoekt.Language{Language: lang}
+}
+
 func QueryForFileContentArgs(opt query.RepoHasFileContentArgs, caseSensitive bool) zoekt.Q {
 	var children []zoekt.Q
 	if opt.Path != "" {
@@ -132,6 +142,10 @@ func toZoektPattern(
 				return &zoekt.Or{Children: children}, nil
 			case query.And:
 				return &zoekt.And{Children: children}, nil
+			case query.Near:
+				// Zoekt cannot check the distance between matches, so
+				// NEAR/n finds the candidate files of searcher.
+				return &zoekt.And{Children: children}, nil
 			default:
 				// unreachable
 				return nil, errors.Errorf("broken invariant: don't know what to do with node %T in toZoektPattern", node)
@@ -88,13 +88,22 @@ func TestQueryToZoektQuery(t *testing.T) {
 			Query:   `file:"\\.go(?m:$)" file:"\\.go(?m:$)"`,
 		},
 		{
-			Name:    "language gets passed as both file include and lang: predicate",
+			Name:    "content based language is passed as lang: predicate",
 			Type:    search.TextRequest,
 			Pattern: `file:\.go$ lang:go`,
 			Features: search.Features{
 				ContentBasedLangFilters: true,
 			},
-			Query: `file:"\\.go(?m:$)" file:"\\.go(?m:$)" lang:Go`,
+			Query: `file:"\\.go(?m:$)" lang:Go`,
+		},
+		{
+			Name:    "content based language exclude",
+			Type:    search.TextRequest,
+			Pattern: `foo -lang:c -lang:objective-c`,
+			Features: search.Features{
+				ContentBasedLangFilters: true,
+			},
+			Query: `foo case:no -(lang:C or lang:Objective-C)`,
 		},
 	}
 	for _, tt := range cases {
@@ -235,6 +235,45 @@ func (c *Client) LocalCodeIntel(ctx context.Context, args types.RepoCommitPath)
 	return result, nil
 }
 
+// EnclosingSymbols returns the names of the innermost functions or classes
+// enclosing the given line ranges of a file. A nil result means that the
+// language of the file is not supported.
+func (c *Client) EnclosingSymbols(ctx context.Context, args types.RepoCommitPathLines) (result *types.EnclosingSymbolsPayload, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "squirrel.Client.EnclosingSymbols") //nolint:staticcheck // OT is deprecated
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+		}
+		span.Finish()
+	}()
+	span.SetTag("Repo", args.Repo)
+	span.SetTag("CommitID", args.Commit)
+
+	resp, err := c.httpPost(ctx, "enclosingSymbols", api.RepoName(args.Repo), args)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return nil, errors.Errorf(
+			"Squirrel.EnclosingSymbols http status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding response body")
+	}
+
+	return result, nil
+}
+
 func (c *Client) SymbolInfo(ctx context.Context, args types.RepoCommitPathPoint) (result *types.SymbolInfo, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "squirrel.Client.SymbolInfo") //nolint:staticcheck // OT is deprecated
 	defer func() {
@@ -200,3 +200,23 @@ func (s SymbolInfo) String() string {
 	}
 	return fmt.Sprintf("SymbolInfo{Definition: %s %s, Hover: %q}", s.Definition.RepoCommitPath, rnge, hover)
 }
+
+// RepoCommitPathLines is a file and the line ranges in it for which to find
+// the enclosing symbols.
+type RepoCommitPathLines struct {
+	RepoCommitPath
+	Lines []LineRange `json:"lines"`
+}
+
+// LineRange is an inclusive range of 0-based line numbers.
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type EnclosingSymbolsPayload struct {
+	// Symbols are the qualified names (e.g. "Server.Handle") of the innermost
+	// function or class enclosing each of the requested line ranges, in order.
+	// The name is empty if a range is not inside a function or class.
+	Symbols []string `json:"symbols"`
+}
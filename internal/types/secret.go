@@ -85,6 +85,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		}
 	case *schema.PagureConnection:
 		es.redactString(c.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
@@ -187,6 +191,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 	case *schema.PagureConnection:
 		o := oldCfg.(*schema.PagureConnection)
 		es.unredactString(c.Token, o.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		es.unredactString(c.Token, o.Token, "token")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.NpmPackagesConnection:
 		o := oldCfg.(*schema.NpmPackagesConnection)
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
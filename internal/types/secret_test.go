@@ -85,6 +85,16 @@ func TestExternalService_RedactedConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: "bar"},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: RedactedSecret},
+		},
+		{
+			kind: extsvc.KindGitea,
+			in:   schema.GiteaConnection{Url: "https://gitea.com", Token: "bar"},
+			out:  schema.GiteaConnection{Url: "https://gitea.com", Token: RedactedSecret},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			in:   schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
@@ -265,6 +275,18 @@ func TestExternalService_UnredactConfig(t *testing.T) {
 			in:   schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: RedactedSecret},
 			out:  schema.PagureConnection{Url: "https://src.fedoraproject.org", Token: "bar"},
 		},
+		{
+			kind: extsvc.KindAzureDevOps,
+			old:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "foo", Token: "bar"},
+			in:   schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "baz", Token: RedactedSecret},
+			out:  schema.AzureDevOpsConnection{Url: "https://dev.azure.com", Username: "baz", Token: "bar"},
+		},
+		{
+			kind: extsvc.KindGitea,
+			old:  schema.GiteaConnection{Url: "https://gitea.com", Token: "bar"},
+			in:   schema.GiteaConnection{Url: "https://gitea.com", Token: RedactedSecret},
+			out:  schema.GiteaConnection{Url: "https://gitea.com", Token: "bar"},
+		},
 		{
 			kind: extsvc.KindJVMPackages,
 			old:  schema.JVMPackagesConnection{Maven: &schema.Maven{Credentials: "foobar", Dependencies: []string{"baz"}}},
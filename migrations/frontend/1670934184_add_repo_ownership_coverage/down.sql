@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_ownership_coverage_directories;
+DROP TABLE IF EXISTS repo_ownership_coverage;
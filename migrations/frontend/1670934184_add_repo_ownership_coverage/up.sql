@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS repo_ownership_coverage (
+    repo_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    commit_id text NOT NULL,
+    codeowners_path text,
+    total_files integer NOT NULL DEFAULT 0,
+    owned_files integer NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN repo_ownership_coverage.codeowners_path IS 'Path of the CODEOWNERS file used to compute the coverage. NULL if the repository has no valid CODEOWNERS file.';
+
+CREATE TABLE IF NOT EXISTS repo_ownership_coverage_directories (
+    repo_id integer NOT NULL REFERENCES repo_ownership_coverage(repo_id) ON DELETE CASCADE,
+    directory text NOT NULL,
+    total_files integer NOT NULL DEFAULT 0,
+    owned_files integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (repo_id, directory)
+);
+
+COMMENT ON COLUMN repo_ownership_coverage_directories.directory IS 'Top-level directory of the repository. Files at the root of the repository are counted in the empty directory.';
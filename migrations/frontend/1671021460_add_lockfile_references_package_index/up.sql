@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS codeintel_lockfile_references_package ON codeintel_lockfile_references (package_scheme, package_name);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS codeintel_lockfile_references_resolution;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS codeintel_lockfile_references_resolution ON codeintel_lockfile_references (resolution_repository_id, resolution_commit_bytea, resolution_lockfile);
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS search_export_jobs (
+    id SERIAL PRIMARY KEY,
+    state text DEFAULT 'queued'::text,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT ''::text,
+    cancel boolean NOT NULL DEFAULT false,
+    initiator_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query text NOT NULL,
+    pattern_type text NOT NULL DEFAULT ''::text,
+    format text NOT NULL,
+    settings jsonb NOT NULL,
+    cursors jsonb,
+    pages_searched integer NOT NULL DEFAULT 0,
+    parts text[] NOT NULL DEFAULT '{}'::text[],
+    matches_written bigint NOT NULL DEFAULT 0,
+    object_key text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS search_export_jobs_state_idx ON search_export_jobs (state);
+CREATE INDEX IF NOT EXISTS search_export_jobs_initiator_id_idx ON search_export_jobs (initiator_id);
+
+COMMENT ON COLUMN search_export_jobs.settings IS 'Final settings of the initiator when the job was created, used to plan the search.';
+COMMENT ON COLUMN search_export_jobs.cursors IS 'Repository cursors of the next page to search. NULL before the first page and after the last page.';
+COMMENT ON COLUMN search_export_jobs.parts IS 'Upload store keys of the results of each searched page with at least one match, in search order.';
+COMMENT ON COLUMN search_export_jobs.object_key IS 'Upload store key of the complete export.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_inventory_languages;
+DROP TABLE IF EXISTS repo_inventory;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS repo_inventory (
+    repo_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    commit_id text NOT NULL,
+    total_bytes bigint NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN repo_inventory.total_bytes IS 'Total number of bytes of code in all detected languages.';
+
+CREATE TABLE IF NOT EXISTS repo_inventory_languages (
+    repo_id integer NOT NULL REFERENCES repo_inventory(repo_id) ON DELETE CASCADE,
+    language text NOT NULL,
+    total_bytes bigint NOT NULL DEFAULT 0,
+    total_lines bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (repo_id, language)
+);